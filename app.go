@@ -2,12 +2,22 @@ package main
 
 import (
 	"acca-games/database"
+	"acca-games/games/backup"
+	"acca-games/games/battery"
 	"acca-games/games/cat_chaser"
 	"acca-games/games/count_comparison"
 	"acca-games/games/nback"
 	"acca-games/games/number_pressing"
+	"acca-games/games/position_memory"
+	"acca-games/games/progress"
+	"acca-games/games/recommendation"
+	"acca-games/games/road"
 	"acca-games/games/rps"
+	"acca-games/games/scheduling"
 	"acca-games/games/shape_rotation"
+	"acca-games/games/stroop"
+	"acca-games/games/tower"
+	"acca-games/games/weight"
 	"acca-games/types"
 	"context"
 	"database/sql"
@@ -19,6 +29,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // getApplicationSupportDirectory returns the appropriate application support/data directory
@@ -41,9 +53,14 @@ func getApplicationSupportDirectory(appName string) (string, error) {
 		}
 		dir = filepath.Join(homeDir, "Library", "Application Support", appName)
 	case "linux":
-		dir, err = os.UserCacheDir()
-		if err != nil {
-			return "", fmt.Errorf("failed to get user cache directory: %w", err)
+		// The XDG data directory, not the cache directory, which cleaners are free to wipe.
+		dir = os.Getenv("XDG_DATA_HOME")
+		if dir == "" || !filepath.IsAbs(dir) {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to get user home directory: %w", err)
+			}
+			dir = filepath.Join(homeDir, ".local", "share")
 		}
 		dir = filepath.Join(dir, appName)
 	default:
@@ -71,6 +88,18 @@ func (a *App) GetSessionResults(gameCode string, sessionID int64) (string, error
 		data, err = database.GetCountComparisonResultsForSession(a.db, sessionID)
 	case types.GameCodeCatChaser:
 		data, err = database.GetCatChaserResultsBySessionID(a.db, sessionID)
+	case types.GameCodeTower:
+		data, err = database.GetTowerResultsForSession(a.db, sessionID)
+	case types.GameCodeWeight:
+		data, err = database.GetWeightResultsForSession(a.db, sessionID)
+	case types.GameCodeRoad:
+		data, err = database.GetRoadResultsForSession(a.db, sessionID)
+	case types.GameCodePositionMemory:
+		data, err = database.GetPositionMemoryResultsForSession(a.db, sessionID)
+	case types.GameCodeStroop:
+		data, err = database.GetStroopResultsForSession(a.db, sessionID)
+	case types.GameCodeScheduling:
+		data, err = database.GetSchedulingResultsForSession(a.db, sessionID)
 	default:
 		return "", fmt.Errorf("unknown game code: %s", gameCode)
 	}
@@ -87,6 +116,14 @@ func (a *App) GetSessionResults(gameCode string, sessionID int64) (string, error
 	return string(jsonData), nil
 }
 
+// staleSessionAge is how long a session may stay in progress before the startup sweep
+// marks it abandoned. A session with a saved state can be resumed instead, so the sweep
+// leaves it alone until its state has gone unsaved for resumableSessionAge.
+const (
+	staleSessionAge     = 24 * time.Hour
+	resumableSessionAge = 30 * 24 * time.Hour
+)
+
 // App struct
 type App struct {
 	ctx                      context.Context
@@ -96,6 +133,18 @@ type App struct {
 	numberPressingService    *number_pressing.Service
 	countComparisonService *count_comparison.Service
 	catChaserService       *cat_chaser.Service
+	towerService           *tower.Service
+	weightService          *weight.Service
+	roadService            *road.Service
+	positionMemoryService  *position_memory.Service
+	stroopService          *stroop.Service
+	schedulingService      *scheduling.Service
+	batteryService         *battery.Service
+	recommendationService  *recommendation.Service
+	progressService        *progress.Service
+	shapeRotationSaver     *shape_rotation.Saver
+	dbRecovery             *types.DatabaseRecovery // Set when a corrupt database was replaced at startup
+	backupService          *backup.Service
 }
 
 func init() {
@@ -127,10 +176,27 @@ func (a *App) startup(ctx context.Context) {
 		log.Fatalf("failed to create database directory: %v", err)
 	}
 
-	db, err := database.NewDatabase(dbPath)
+	// Older versions kept the database in the cache directory on Linux.
+	if runtime.GOOS == "linux" {
+		if cacheDir, err := os.UserCacheDir(); err == nil {
+			oldPath := filepath.Join(cacheDir, "acca-games", "acca_games.db")
+			moved, err := database.MoveDatabase(oldPath, dbPath)
+			if err != nil {
+				log.Printf("failed to move database from %s: %v", oldPath, err)
+			} else if moved {
+				log.Printf("Moved database from %s to %s", oldPath, dbPath)
+			}
+		}
+	}
+
+	db, recovery, err := database.OpenOrRecover(dbPath, database.DefaultOptions())
 	if err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
+	if recovery != nil {
+		log.Printf("Database failed its integrity check (%s); moved it to %s and started a new one", recovery.Problem, recovery.MovedTo)
+	}
+	a.dbRecovery = recovery
 	// --- End Database Initialization ---
 
 	a.db = db
@@ -139,14 +205,85 @@ func (a *App) startup(ctx context.Context) {
 	a.numberPressingService = number_pressing.NewService(a.db)
 	a.countComparisonService = count_comparison.NewService(a.db)
 	a.catChaserService = cat_chaser.NewService(a.db)
+	a.towerService = tower.NewService(a.db)
+	a.weightService = weight.NewService(a.db)
+	a.roadService = road.NewService(a.db)
+	a.positionMemoryService = position_memory.NewService(a.db)
+	a.stroopService = stroop.NewService(a.db)
+	a.schedulingService = scheduling.NewService(a.db)
+	a.shapeRotationSaver = shape_rotation.NewSaver(a.db, func(sessionID int64) {
+		a.completeIfDone(sessionID, a.endShapeRotationSession)
+	}, func(err error) {
+		log.Printf("Error saving shape rotation result: %v", err)
+	})
+	a.backupService = backup.NewService(a.db, filepath.Join(supportDir, "backups"), backup.DefaultKeep, a.clearGames)
+	a.backupService.Start(backup.DefaultInterval, func(err error) {
+		log.Printf("Scheduled backup failed: %v", err)
+	})
+	a.batteryService = battery.NewService(a.db, a.batteryGames())
+	a.recommendationService = recommendation.NewService(a.db)
+	a.progressService = progress.NewService(a.db, func(r types.PersonalRecord) {
+		wailsruntime.EventsEmit(a.ctx, progress.RecordBrokenEvent, r)
+	})
+
+	// Sessions left in progress by a crash or a closed window, and that cannot be resumed
+	// or have not been for a long time, are not coming back.
+	now := time.Now()
+	abandoned, err := database.AbandonStaleSessions(a.db, now.Add(-staleSessionAge), now.Add(-resumableSessionAge))
+	if err != nil {
+		log.Printf("failed to abandon stale sessions: %v", err)
+	} else if abandoned > 0 {
+		log.Printf("Marked %d stale sessions as abandoned", abandoned)
+	}
 }
 
 func (a *App) shutdown(ctx context.Context) {
+	if a.backupService != nil {
+		a.backupService.Stop()
+	}
+	if a.shapeRotationSaver != nil {
+		a.shapeRotationSaver.Wait()
+	}
 	if a.db != nil {
 		a.db.Close()
 	}
 }
 
+// GetDatabaseRecovery reports the corrupt database moved aside at startup, or nil if the database was healthy.
+func (a *App) GetDatabaseRecovery() *types.DatabaseRecovery {
+	return a.dbRecovery
+}
+
+// clearGames takes every game out of play, after a restore replaced the sessions they belong to.
+func (a *App) clearGames() {
+	a.nbackService.ClearGames()
+	a.rpsService.ClearGames()
+	a.countComparisonService.ClearGames()
+	a.catChaserService.ClearGames()
+	a.towerService.ClearGames()
+	a.weightService.ClearGames()
+	a.roadService.ClearGames()
+	a.positionMemoryService.ClearGames()
+	a.stroopService.ClearGames()
+	a.schedulingService.ClearGames()
+}
+
+// CreateBackup backs up the database now, deleting the oldest backups beyond the number kept.
+func (a *App) CreateBackup() (*types.BackupInfo, error) {
+	return a.backupService.Backup()
+}
+
+// ListBackups returns the backups of the database, newest first.
+func (a *App) ListBackups() ([]types.BackupInfo, error) {
+	return a.backupService.List()
+}
+
+// RestoreBackup replaces the database with the named backup and returns the backup made of the database it replaced.
+func (a *App) RestoreBackup(name string) (*types.BackupInfo, error) {
+	a.shapeRotationSaver.Wait()
+	return a.backupService.Restore(name)
+}
+
 // GetShapeGroups returns the available shape groups.
 func (a *App) GetShapeGroups() map[string][]string {
 	return nback.GetShapeGroups()
@@ -158,8 +295,12 @@ func (a *App) StartNBackGame(settings types.NBackSettings) (*nback.NBackGameStat
 }
 
 // SubmitNBackAnswer checks the user's answer for a given trial, saves it to the DB, and returns the result.
-func (a *App) SubmitNBackAnswer(playerChoice string, responseTimeMs int, questionNum int) (*types.NBackResult, error) {
-	return a.nbackService.SubmitAnswer(playerChoice, responseTimeMs, questionNum)
+func (a *App) SubmitNBackAnswer(sessionID int64, playerChoice string, responseTimeMs int, questionNum int) (*types.NBackResult, error) {
+	result, err := a.nbackService.SubmitAnswer(sessionID, playerChoice, responseTimeMs, questionNum)
+	if err == nil {
+		a.completeIfDone(sessionID, a.nbackService.EndGame)
+	}
+	return result, err
 }
 
 // StartRpsGame starts a new Rock-Paper-Scissors game with the given settings.
@@ -168,8 +309,12 @@ func (a *App) StartRpsGame(settings types.RpsSettings) (*rps.GameState, error) {
 }
 
 // SubmitRpsAnswer checks the user's answer for a given trial, saves it to the DB, and returns the result.
-func (a *App) SubmitRpsAnswer(playerChoice string, responseTimeMs int, questionNum int) (*types.RpsResult, error) {
-	return a.rpsService.SubmitAnswer(playerChoice, responseTimeMs, questionNum)
+func (a *App) SubmitRpsAnswer(sessionID int64, playerChoice string, responseTimeMs int, questionNum int) (*types.RpsResult, error) {
+	result, err := a.rpsService.SubmitAnswer(sessionID, playerChoice, responseTimeMs, questionNum)
+	if err == nil {
+		a.completeIfDone(sessionID, a.rpsService.EndGame)
+	}
+	return result, err
 }
 
 // StartCountComparisonGame starts a new Count Comparison game.
@@ -177,14 +322,25 @@ func (a *App) StartCountComparisonGame(settings types.CountComparisonSettings) (
 	return a.countComparisonService.StartGame(settings)
 }
 
-// GetNextCountComparisonProblem returns the next problem for the current game.
-func (a *App) GetNextCountComparisonProblem() *types.CountComparisonProblem {
-	return a.countComparisonService.NextProblem()
+// GetNextCountComparisonProblem returns the next problem of a Count Comparison session,
+// completing the session once it has run out of problems.
+func (a *App) GetNextCountComparisonProblem(sessionID int64) *types.CountComparisonProblem {
+	problem, over := a.countComparisonService.NextProblem(sessionID)
+	if over {
+		if _, err := a.endGame(sessionID, a.countComparisonService.EndGame); err != nil {
+			log.Printf("failed to complete session %d: %v", sessionID, err)
+		}
+	}
+	return problem
 }
 
 // SubmitCountComparisonAnswer handles the player's submission and saves the result.
-func (a *App) SubmitCountComparisonAnswer(submission types.CountComparisonSubmission) error {
-	return a.countComparisonService.SubmitAnswer(submission)
+func (a *App) SubmitCountComparisonAnswer(sessionID int64, submission types.CountComparisonSubmission) error {
+	if err := a.countComparisonService.SubmitAnswer(sessionID, submission); err != nil {
+		return err
+	}
+	a.completeIfDone(sessionID, a.countComparisonService.EndGame)
+	return nil
 }
 var validGameCodes = map[string]bool{
 	types.GameCodeShapeRotation:     true,
@@ -193,11 +349,17 @@ var validGameCodes = map[string]bool{
 	types.GameCodeNumberPressing:    true,
 	types.GameCodeCountComparison: true,
 	types.GameCodeCatChaser:       true,
+	types.GameCodeTower:           true,
+	types.GameCodeWeight:          true,
+	types.GameCodeRoad:            true,
+	types.GameCodePositionMemory:  true,
+	types.GameCodeStroop:          true,
+	types.GameCodeScheduling:      true,
 }
 
-// GetPaginatedNBackSessionsWithResults fetches paginated N-Back sessions with their results.
-func (a *App) GetPaginatedNBackSessionsWithResults(page int, limit int) (*types.PaginatedNBackSessions, error) {
-	return database.GetPaginatedNBackSessionsWithResults(a.db, page, limit)
+// GetPaginatedNBackSessionsWithResults fetches paginated N-Back sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedNBackSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedNBackSessions, error) {
+	return database.GetPaginatedNBackSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetNBackSessionStats fetches aggregated statistics for a given N-Back session ID.
@@ -205,9 +367,9 @@ func (a *App) GetNBackSessionStats(sessionID int64) (*types.NBackSessionStats, e
 	return database.GetNBackSessionStats(a.db, sessionID)
 }
 
-// GetPaginatedRpsSessionsWithResults fetches paginated RPS sessions with their results.
-func (a *App) GetPaginatedRpsSessionsWithResults(page int, limit int) (*types.PaginatedRpsSessions, error) {
-	return database.GetPaginatedRpsSessionsWithResults(a.db, page, limit)
+// GetPaginatedRpsSessionsWithResults fetches paginated RPS sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedRpsSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedRpsSessions, error) {
+	return database.GetPaginatedRpsSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetRpsSessionStats fetches aggregated statistics for a given RPS session ID.
@@ -222,12 +384,20 @@ func (a *App) StartNumberPressingGame(setup types.NumberPressingSetup) (*types.N
 
 // SubmitNumberPressingResultR1 saves a result for Round 1.
 func (a *App) SubmitNumberPressingResultR1(result types.NumberPressingResultR1) error {
-	return a.numberPressingService.SubmitResultR1(result)
+	if err := a.numberPressingService.SubmitResultR1(result); err != nil {
+		return err
+	}
+	a.completeIfDone(result.SessionID, a.numberPressingService.EndGame)
+	return nil
 }
 
 // SubmitNumberPressingResultR2 saves a result for Round 2.
 func (a *App) SubmitNumberPressingResultR2(result types.NumberPressingResultR2) error {
-	return a.numberPressingService.SubmitResultR2(result)
+	if err := a.numberPressingService.SubmitResultR2(result); err != nil {
+		return err
+	}
+	a.completeIfDone(result.SessionID, a.numberPressingService.EndGame)
+	return nil
 }
 
 // CalculateCorrectClicksR2 calculates the correct click sequence for a Round 2 problem.
@@ -235,9 +405,9 @@ func (a *App) CalculateCorrectClicksR2(problem types.NumberPressingProblemR2) []
 	return number_pressing.CalculateCorrectClicksR2(problem)
 }
 
-// GetPaginatedNumberPressingSessionsWithResults fetches paginated Number Pressing sessions with their results.
-func (a *App) GetPaginatedNumberPressingSessionsWithResults(page int, limit int) (*types.PaginatedNumberPressingSessions, error) {
-	return database.GetPaginatedNumberPressingSessionsWithResults(a.db, page, limit)
+// GetPaginatedNumberPressingSessionsWithResults fetches paginated Number Pressing sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedNumberPressingSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedNumberPressingSessions, error) {
+	return database.GetPaginatedNumberPressingSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetNumberPressingSessionStats fetches aggregated statistics for a given Number Pressing session ID.
@@ -257,29 +427,13 @@ func (a *App) SaveShapeRotationSession(settings types.ShapeRotationSettings) (in
 
 // SubmitShapeRotationAnswerAsync verifies and saves a result in the background.
 func (a *App) SubmitShapeRotationAnswerAsync(sessionID int64, problem shape_rotation.ShapeRotationProblemWithFinalShape, userSolution []string, solveTime int, clickCount int) error {
-	go func() {
-		isCorrect := shape_rotation.VerifySolution(problem, userSolution)
-
-		result := types.ShapeRotationResult{
-			SessionID:    sessionID,
-			ProblemID:    problem.ID,
-			UserSolution: userSolution,
-			IsCorrect:    isCorrect,
-			SolveTime:    solveTime,
-			ClickCount:   clickCount,
-		}
-
-		err := database.SaveShapeRotationResult(a.db, result)
-		if err != nil {
-			log.Printf("Error saving shape rotation result: %v", err)
-		}
-	}()
+	a.shapeRotationSaver.SubmitAsync(sessionID, problem, userSolution, solveTime, clickCount)
 	return nil
 }
 
-// GetPaginatedShapeRotationSessionsWithResults fetches paginated Shape Rotation sessions with their results.
-func (a *App) GetPaginatedShapeRotationSessionsWithResults(page int, limit int) (*types.PaginatedShapeRotationSessions, error) {
-	return database.GetPaginatedShapeRotationSessionsWithResults(a.db, page, limit)
+// GetPaginatedShapeRotationSessionsWithResults fetches paginated Shape Rotation sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedShapeRotationSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedShapeRotationSessions, error) {
+	return database.GetPaginatedShapeRotationSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetShapeRotationSessionStats fetches aggregated statistics for a given Shape Rotation session ID.
@@ -293,13 +447,17 @@ func (a *App) StartCatChaserGame(settings types.CatChaserSettings) (*cat_chaser.
 }
 
 // SubmitCatChaserAnswer submits an answer for the Cat Chaser game.
-func (a *App) SubmitCatChaserAnswer(round int, targetColor string, playerChoice string, confidence int, responseTimeMs int) (*types.CatChaserResult, error) {
-	return a.catChaserService.SubmitAnswer(round, targetColor, playerChoice, confidence, responseTimeMs)
+func (a *App) SubmitCatChaserAnswer(sessionID int64, round int, targetColor string, playerChoice string, confidence int, responseTimeMs int) (*types.CatChaserResult, error) {
+	result, err := a.catChaserService.SubmitAnswer(sessionID, round, targetColor, playerChoice, confidence, responseTimeMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.catChaserService.EndGame)
+	}
+	return result, err
 }
 
-// GetPaginatedCountComparisonSessionsWithResults fetches paginated Count Comparison sessions with their results.
-func (a *App) GetPaginatedCountComparisonSessionsWithResults(page int, limit int) (*types.PaginatedCountComparisonSessions, error) {
-	return database.GetPaginatedCountComparisonSessionsWithResults(a.db, page, limit)
+// GetPaginatedCountComparisonSessionsWithResults fetches paginated Count Comparison sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedCountComparisonSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedCountComparisonSessions, error) {
+	return database.GetPaginatedCountComparisonSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetCountComparisonSessionStats fetches aggregated statistics for a given Count Comparison session ID.
@@ -307,12 +465,696 @@ func (a *App) GetCountComparisonSessionStats(sessionID int64) (*types.CountCompa
 	return database.GetCountComparisonSessionStats(a.db, sessionID)
 }
 
-// GetPaginatedCatChaserSessionsWithResults fetches paginated Cat Chaser sessions with their results.
-func (a *App) GetPaginatedCatChaserSessionsWithResults(page int, limit int) (*types.PaginatedCatChaserSessions, error) {
-	return database.GetPaginatedCatChaserSessionsWithResults(a.db, page, limit)
+// GetCountComparisonPsychometrics fits accuracy against count ratio for a given Count Comparison session ID.
+func (a *App) GetCountComparisonPsychometrics(sessionID int64) (*types.CountComparisonPsychometrics, error) {
+	return database.GetCountComparisonPsychometrics(a.db, sessionID)
+}
+
+// ImportCountComparisonWordList imports a word list for Count Comparison from JSON or CSV content.
+func (a *App) ImportCountComparisonWordList(name string, language string, format string, content string) (*types.WordList, error) {
+	return a.countComparisonService.ImportWordList(name, language, format, content)
+}
+
+// GetCountComparisonWordLists returns the available word lists, optionally filtered by language.
+func (a *App) GetCountComparisonWordLists(language string) ([]types.WordListSummary, error) {
+	return a.countComparisonService.GetWordLists(language)
+}
+
+// DeleteCountComparisonWordList deletes an imported word list.
+func (a *App) DeleteCountComparisonWordList(id int64) error {
+	return a.countComparisonService.DeleteWordList(id)
+}
+
+// GetCountComparisonWordListStats fetches Count Comparison statistics split by word list.
+func (a *App) GetCountComparisonWordListStats() ([]types.WordListStat, error) {
+	return database.GetCountComparisonWordListStats(a.db)
+}
+
+// GetCountComparisonThresholdTrend fetches the discrimination threshold of every Count Comparison session.
+func (a *App) GetCountComparisonThresholdTrend() ([]types.CountComparisonThresholdPoint, error) {
+	return database.GetCountComparisonThresholdTrend(a.db)
+}
+
+// GetPaginatedCatChaserSessionsWithResults fetches paginated Cat Chaser sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedCatChaserSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedCatChaserSessions, error) {
+	return database.GetPaginatedCatChaserSessionsWithResults(a.db, page, limit, tags...)
 }
 
 // GetCatChaserSessionStats fetches aggregated statistics for a given Cat Chaser session ID.
 func (a *App) GetCatChaserSessionStats(sessionID int64) (*types.CatChaserSessionStats, error) {
 	return database.GetCatChaserSessionStats(a.db, sessionID)
 }
+
+// GetCatChaserCalibration fetches confidence calibration statistics for a given Cat Chaser session ID.
+func (a *App) GetCatChaserCalibration(sessionID int64) (*types.CatChaserCalibration, error) {
+	return database.GetCatChaserCalibration(a.db, sessionID)
+}
+
+// GetCatChaserCalibrationTrend fetches the confidence calibration of every Cat Chaser session.
+func (a *App) GetCatChaserCalibrationTrend() ([]types.CatChaserCalibrationTrendPoint, error) {
+	return database.GetCatChaserCalibrationTrend(a.db)
+}
+
+// StartTowerGame starts a new Tower game.
+func (a *App) StartTowerGame(settings types.TowerSettings) (*tower.TowerGameState, error) {
+	return a.towerService.StartGame(settings)
+}
+
+// SubmitTowerMove checks and applies a move in the Tower game, saving the result once the problem ends.
+func (a *App) SubmitTowerMove(sessionID int64, problemNum int, from int, to int, elapsedMs int) (*types.TowerMoveResult, error) {
+	return a.towerService.SubmitMove(sessionID, problemNum, from, to, elapsedMs)
+}
+
+// FinishTowerProblem ends a Tower problem that was given up or timed out, and saves its result.
+func (a *App) FinishTowerProblem(sessionID int64, problemNum int, elapsedMs int) (*types.TowerResult, error) {
+	result, err := a.towerService.FinishProblem(sessionID, problemNum, elapsedMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.towerService.EndGame)
+	}
+	return result, err
+}
+
+// GetPaginatedTowerSessionsWithResults fetches paginated Tower sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedTowerSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedTowerSessions, error) {
+	return database.GetPaginatedTowerSessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetTowerSessionStats fetches aggregated statistics for a given Tower session ID.
+func (a *App) GetTowerSessionStats(sessionID int64) (*types.TowerSessionStats, error) {
+	return database.GetTowerSessionStats(a.db, sessionID)
+}
+
+// StartWeightGame starts a new Weight Comparison game.
+func (a *App) StartWeightGame(settings types.WeightSettings) (*weight.WeightGameState, error) {
+	return a.weightService.StartGame(settings)
+}
+
+// WeighObjects puts two objects on the scale in the Weight Comparison game and returns the outcome.
+func (a *App) WeighObjects(sessionID int64, problemNum int, left string, right string) (*types.WeightWeighing, error) {
+	return a.weightService.Weigh(sessionID, problemNum, left, right)
+}
+
+// SubmitWeightRanking checks the player's ranking for a Weight Comparison problem and saves the result.
+func (a *App) SubmitWeightRanking(sessionID int64, problemNum int, ranking []string, responseTimeMs int) (*types.WeightResult, error) {
+	result, err := a.weightService.SubmitRanking(sessionID, problemNum, ranking, responseTimeMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.weightService.EndGame)
+	}
+	return result, err
+}
+
+// GetPaginatedWeightSessionsWithResults fetches paginated Weight Comparison sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedWeightSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedWeightSessions, error) {
+	return database.GetPaginatedWeightSessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetWeightSessionStats fetches aggregated statistics for a given Weight Comparison session ID.
+func (a *App) GetWeightSessionStats(sessionID int64) (*types.WeightSessionStats, error) {
+	return database.GetWeightSessionStats(a.db, sessionID)
+}
+
+// StartRoadGame starts a new Road game.
+func (a *App) StartRoadGame(settings types.RoadSettings) (*road.RoadGameState, error) {
+	return a.roadService.StartGame(settings)
+}
+
+// SubmitRoadLayout traces the road the player laid for a Road problem and saves the result.
+func (a *App) SubmitRoadLayout(sessionID int64, problemNum int, layout []types.RoadTile, timeTakenMs int) (*types.RoadResult, error) {
+	result, err := a.roadService.SubmitLayout(sessionID, problemNum, layout, timeTakenMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.roadService.EndGame)
+	}
+	return result, err
+}
+
+// GetPaginatedRoadSessionsWithResults fetches paginated Road sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedRoadSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedRoadSessions, error) {
+	return database.GetPaginatedRoadSessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetRoadSessionStats fetches aggregated statistics for a given Road session ID.
+func (a *App) GetRoadSessionStats(sessionID int64) (*types.RoadSessionStats, error) {
+	return database.GetRoadSessionStats(a.db, sessionID)
+}
+
+// StartPositionMemoryGame starts a new Position Memory game.
+func (a *App) StartPositionMemoryGame(settings types.PositionMemorySettings) (*position_memory.PositionMemoryGameState, error) {
+	return a.positionMemoryService.StartGame(settings)
+}
+
+// SubmitPositionMemorySequence checks the blocks tapped for a Position Memory sequence, saves the result and returns the next sequence.
+func (a *App) SubmitPositionMemorySequence(sessionID int64, sequenceNum int, response []int, responseTimeMs int) (*types.PositionMemorySubmitResult, error) {
+	result, err := a.positionMemoryService.SubmitSequence(sessionID, sequenceNum, response, responseTimeMs)
+	if err == nil && result.Finished {
+		// The game decides when it is over, rather than after a set number of sequences.
+		if _, err := a.endGame(sessionID, a.positionMemoryService.EndGame); err != nil {
+			log.Printf("failed to complete session %d: %v", sessionID, err)
+		}
+	}
+	return result, err
+}
+
+// GetPaginatedPositionMemorySessionsWithResults fetches paginated Position Memory sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedPositionMemorySessionsWithResults(page int, limit int, tags []string) (*types.PaginatedPositionMemorySessions, error) {
+	return database.GetPaginatedPositionMemorySessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetPositionMemorySessionStats fetches aggregated statistics for a given Position Memory session ID.
+func (a *App) GetPositionMemorySessionStats(sessionID int64) (*types.PositionMemorySessionStats, error) {
+	return database.GetPositionMemorySessionStats(a.db, sessionID)
+}
+
+// GetPositionMemorySpanHistory fetches the span of every Position Memory session and the best span in each mode.
+func (a *App) GetPositionMemorySpanHistory() (*types.PositionMemorySpanHistory, error) {
+	return database.GetPositionMemorySpanHistory(a.db)
+}
+
+// StartStroopGame starts a new Stroop game.
+func (a *App) StartStroopGame(settings types.StroopSettings) (*stroop.StroopGameState, error) {
+	return a.stroopService.StartGame(settings)
+}
+
+// SubmitStroopAnswer scores the key pressed for a Stroop trial and saves the result.
+func (a *App) SubmitStroopAnswer(sessionID int64, trialNum int, responseKey string, responseTimeMs int) (*types.StroopResult, error) {
+	result, err := a.stroopService.SubmitAnswer(sessionID, trialNum, responseKey, responseTimeMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.stroopService.EndGame)
+	}
+	return result, err
+}
+
+// GetPaginatedStroopSessionsWithResults fetches paginated Stroop sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedStroopSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedStroopSessions, error) {
+	return database.GetPaginatedStroopSessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetStroopSessionStats fetches aggregated statistics, including the interference cost, for a given Stroop session ID.
+func (a *App) GetStroopSessionStats(sessionID int64) (*types.StroopSessionStats, error) {
+	return database.GetStroopSessionStats(a.db, sessionID)
+}
+
+// GetStroopInterferenceTrend fetches the interference cost of every Stroop session, oldest first.
+func (a *App) GetStroopInterferenceTrend() ([]types.StroopInterferencePoint, error) {
+	return database.GetStroopInterferenceTrend(a.db)
+}
+
+// StartSchedulingGame starts a new Scheduling game.
+func (a *App) StartSchedulingGame(settings types.SchedulingSettings) (*scheduling.SchedulingGameState, error) {
+	return a.schedulingService.StartGame(settings)
+}
+
+// SubmitSchedulingPlacement checks the player's placement for a Scheduling problem and saves the result.
+func (a *App) SubmitSchedulingPlacement(sessionID int64, problemNum int, placement []types.SchedulingSlot, responseTimeMs int) (*types.SchedulingResult, error) {
+	result, err := a.schedulingService.SubmitPlacement(sessionID, problemNum, placement, responseTimeMs)
+	if err == nil {
+		a.completeIfDone(sessionID, a.schedulingService.EndGame)
+	}
+	return result, err
+}
+
+// GetPaginatedSchedulingSessionsWithResults fetches paginated Scheduling sessions with their results, keeping only sessions with all the given tags.
+func (a *App) GetPaginatedSchedulingSessionsWithResults(page int, limit int, tags []string) (*types.PaginatedSchedulingSessions, error) {
+	return database.GetPaginatedSchedulingSessionsWithResults(a.db, page, limit, tags...)
+}
+
+// GetSchedulingSessionStats fetches aggregated statistics for a given Scheduling session ID.
+func (a *App) GetSchedulingSessionStats(sessionID int64) (*types.SchedulingSessionStats, error) {
+	return database.GetSchedulingSessionStats(a.db, sessionID)
+}
+
+// ShapeRotationGameState is the state of a started Shape Rotation game. The game has no
+// service, so the app saves the session and deals the problems itself.
+type ShapeRotationGameState struct {
+	ID          int64                                               `json:"id"`
+	Settings    types.ShapeRotationSettings                         `json:"settings"`
+	Problems    []shape_rotation.ShapeRotationProblemWithFinalShape `json:"problems"`
+	NextProblem int                                                 `json:"nextProblem"` // Position of the next unanswered problem
+}
+
+// StartShapeRotationGame deals the problems of a Shape Rotation game and saves its session, keeping the problems so it can be resumed.
+func (a *App) StartShapeRotationGame(settings types.ShapeRotationSettings) (*ShapeRotationGameState, error) {
+	problems, err := shape_rotation.GetProblems(settings.Round, settings.NumProblems)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, err := database.SaveShapeRotationSession(a.db, settings)
+	if err != nil {
+		return nil, err
+	}
+	state := &ShapeRotationGameState{ID: sessionID, Settings: settings, Problems: problems, NextProblem: 1}
+	if err := database.SaveSessionState(a.db, sessionID, state, 1); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ResumeShapeRotationSession restores a Shape Rotation session left in progress, pointing at its next unanswered problem.
+func (a *App) ResumeShapeRotationSession(sessionID int64) (*ShapeRotationGameState, error) {
+	var state ShapeRotationGameState
+	next, err := database.LoadSessionState(a.db, sessionID, &state)
+	if err != nil {
+		return nil, err
+	}
+	state.NextProblem = next
+	return &state, nil
+}
+
+// batteryGames returns how to start and abandon each game as a step of a battery.
+func (a *App) batteryGames() map[string]battery.Game {
+	return map[string]battery.Game{
+		types.GameCodeNBack: {
+			Start: battery.NewStarter(a.nbackService.StartGame,
+				func(s *nback.NBackGameState) int64 { return s.ID }, func(s *types.NBackSettings) { s.IsRealMode = true }),
+			Abandon: a.nbackService.AbandonGame,
+		},
+		types.GameCodeRPS: {
+			Start: battery.NewStarter(a.rpsService.StartGame,
+				func(s *rps.GameState) int64 { return s.ID }, func(s *types.RpsSettings) { s.IsRealMode = true }),
+			Abandon: a.rpsService.AbandonGame,
+		},
+		types.GameCodeShapeRotation: {
+			Start: battery.NewStarter(a.StartShapeRotationGame,
+				func(s *ShapeRotationGameState) int64 { return s.ID }, func(s *types.ShapeRotationSettings) { s.IsRealMode = true }),
+			Abandon: a.AbandonShapeRotationGame,
+		},
+		types.GameCodeNumberPressing: {
+			Start: battery.NewStarter(a.numberPressingService.StartGame,
+				func(s *types.NumberPressingGameState) int64 { return s.ID }, func(s *types.NumberPressingSetup) { s.IsRealMode = true }),
+			Abandon: a.numberPressingService.AbandonGame,
+		},
+		types.GameCodeCountComparison: {
+			Start: battery.NewStarter(a.countComparisonService.StartGame,
+				func(id int64) int64 { return id }, func(s *types.CountComparisonSettings) { s.IsRealMode = true }),
+			Abandon: a.countComparisonService.AbandonGame,
+		},
+		types.GameCodeCatChaser: {
+			Start: battery.NewStarter(a.catChaserService.StartGame,
+				func(s *cat_chaser.CatChaserGameState) int64 { return s.ID }, func(s *types.CatChaserSettings) { s.IsRealMode = true }),
+			Abandon: a.catChaserService.AbandonGame,
+		},
+		types.GameCodeTower: {
+			Start: battery.NewStarter(a.towerService.StartGame,
+				func(s *tower.TowerGameState) int64 { return s.ID }, func(s *types.TowerSettings) { s.IsRealMode = true }),
+			Abandon: a.towerService.AbandonGame,
+		},
+		types.GameCodeWeight: {
+			Start: battery.NewStarter(a.weightService.StartGame,
+				func(s *weight.WeightGameState) int64 { return s.ID }, func(s *types.WeightSettings) { s.IsRealMode = true }),
+			Abandon: a.weightService.AbandonGame,
+		},
+		types.GameCodeRoad: {
+			Start: battery.NewStarter(a.roadService.StartGame,
+				func(s *road.RoadGameState) int64 { return s.ID }, func(s *types.RoadSettings) { s.IsRealMode = true }),
+			Abandon: a.roadService.AbandonGame,
+		},
+		types.GameCodePositionMemory: {
+			Start: battery.NewStarter(a.positionMemoryService.StartGame,
+				func(s *position_memory.PositionMemoryGameState) int64 { return s.ID }, func(s *types.PositionMemorySettings) { s.IsRealMode = true }),
+			Abandon: a.positionMemoryService.AbandonGame,
+		},
+		types.GameCodeStroop: {
+			Start: battery.NewStarter(a.stroopService.StartGame,
+				func(s *stroop.StroopGameState) int64 { return s.ID }, func(s *types.StroopSettings) { s.IsRealMode = true }),
+			Abandon: a.stroopService.AbandonGame,
+		},
+		types.GameCodeScheduling: {
+			Start: battery.NewStarter(a.schedulingService.StartGame,
+				func(s *scheduling.SchedulingGameState) int64 { return s.ID }, func(s *types.SchedulingSettings) { s.IsRealMode = true }),
+			Abandon: a.schedulingService.AbandonGame,
+		},
+	}
+}
+
+// GetDefaultBatterySteps returns the games and settings of the default battery.
+func (a *App) GetDefaultBatterySteps() []types.BatteryStep {
+	return battery.DefaultSteps()
+}
+
+// StartBattery creates a battery with the given steps, or the default steps if none are given.
+func (a *App) StartBattery(steps []types.BatteryStep) (*types.BatterySession, error) {
+	return a.batteryService.StartBattery(steps)
+}
+
+// StartBatteryStep starts the game of a battery's current step in real mode.
+func (a *App) StartBatteryStep(batteryID int64) (*types.BatteryStepStart, error) {
+	return a.batteryService.StartStep(batteryID)
+}
+
+// CompleteBatteryStep marks the current step of a battery as finished and moves on to the next one.
+func (a *App) CompleteBatteryStep(batteryID int64, sessionID int64) (*types.BatterySession, error) {
+	return a.batteryService.CompleteStep(batteryID, sessionID)
+}
+
+// ResumeBattery returns the most recent unfinished battery, or nil if there is none.
+func (a *App) ResumeBattery() (*types.BatterySession, error) {
+	return a.batteryService.ResumeBattery()
+}
+
+// GetBatterySession fetches a battery and the progress of each of its steps.
+func (a *App) GetBatterySession(batteryID int64) (*types.BatterySession, error) {
+	return database.GetBatterySession(a.db, batteryID)
+}
+
+// GetBatteryReport fetches the composite report of a battery.
+func (a *App) GetBatteryReport(batteryID int64) (*types.BatteryReport, error) {
+	return database.GetBatteryReport(a.db, batteryID)
+}
+
+// GetScoreMetrics returns the metric each game is scored on, which a norm table gives the distribution of.
+func (a *App) GetScoreMetrics() []types.ScoreMetric {
+	return database.GetScoreMetrics()
+}
+
+// ImportNormTable replaces the stored norms with a norm table given as JSON content.
+func (a *App) ImportNormTable(content string) (*types.NormTable, error) {
+	var table types.NormTable
+	if err := json.Unmarshal([]byte(content), &table); err != nil {
+		return nil, fmt.Errorf("invalid norm table: %w", err)
+	}
+	if err := database.ImportNormTable(a.db, table); err != nil {
+		return nil, err
+	}
+	return database.GetNormTable(a.db)
+}
+
+// GetNormTable returns the imported norm table.
+func (a *App) GetNormTable() (*types.NormTable, error) {
+	return database.GetNormTable(a.db)
+}
+
+// GetNormalizedScores scores every session of a game against the player's history and the norm table.
+func (a *App) GetNormalizedScores(gameCode string) ([]types.NormalizedScore, error) {
+	return database.GetNormalizedScores(a.db, gameCode)
+}
+
+// GetNormalizedSessionScore scores one session against the player's earlier sessions and the norm table.
+func (a *App) GetNormalizedSessionScore(sessionID int64) (*types.NormalizedScore, error) {
+	return database.GetNormalizedSessionScore(a.db, sessionID)
+}
+
+// GetCompetencyProfile reports the player's standing in memory, attention, spatial and reasoning.
+func (a *App) GetCompetencyProfile() (*types.CompetencyProfile, error) {
+	return database.GetCompetencyProfile(a.db)
+}
+
+// GetDailyPlan returns today's practice plan, ranked by the player's weakest games, rounds and conditions.
+func (a *App) GetDailyPlan() (*types.DailyPlan, error) {
+	return a.recommendationService.DailyPlan()
+}
+
+// CompleteSession updates the personal records with a finished session, firing a personalRecordBroken event for each record broken.
+func (a *App) CompleteSession(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.progressService.CompleteSession(sessionID)
+}
+
+// GetPersonalRecords returns the best results per game, round and mode.
+func (a *App) GetPersonalRecords() ([]types.PersonalRecord, error) {
+	return database.GetPersonalRecords(a.db)
+}
+
+// CreateGoal sets a new accuracy, response time or sessions-per-week goal for a game.
+func (a *App) CreateGoal(goal types.Goal) (*types.Goal, error) {
+	return a.progressService.CreateGoal(goal)
+}
+
+// DeleteGoal removes a goal.
+func (a *App) DeleteGoal(goalID int64) error {
+	return database.DeleteGoal(a.db, goalID)
+}
+
+// GetGoalProgress evaluates every goal over the last seven days.
+func (a *App) GetGoalProgress() ([]types.GoalProgress, error) {
+	return a.progressService.GoalProgress()
+}
+
+// GetPracticeStreak returns the current and longest daily practice streaks.
+func (a *App) GetPracticeStreak() (*types.PracticeStreak, error) {
+	return a.progressService.Streak()
+}
+
+// DeleteSession moves a session to the trash, hiding it from history, stats and records.
+func (a *App) DeleteSession(sessionID int64) error {
+	return database.DeleteSession(a.db, sessionID)
+}
+
+// RestoreSession takes a session back out of the trash.
+func (a *App) RestoreSession(sessionID int64) error {
+	return database.RestoreSession(a.db, sessionID)
+}
+
+// PurgeSession permanently removes a session in the trash along with its results.
+func (a *App) PurgeSession(sessionID int64) error {
+	return database.PurgeSession(a.db, sessionID)
+}
+
+// PurgeDeletedSessions empties the trash and returns the number of sessions removed.
+func (a *App) PurgeDeletedSessions() (int, error) {
+	return database.PurgeDeletedSessions(a.db)
+}
+
+// GetDeletedSessions returns the sessions in the trash, most recently deleted first.
+func (a *App) GetDeletedSessions() ([]types.DeletedSession, error) {
+	return database.GetDeletedSessions(a.db)
+}
+
+// SetSessionNotes replaces the free-text notes of a session.
+func (a *App) SetSessionNotes(sessionID int64, notes string) error {
+	return database.SetSessionNotes(a.db, sessionID, notes)
+}
+
+// SetSessionTags replaces the tags of a session, such as "tired" or "new strategy".
+func (a *App) SetSessionTags(sessionID int64, tags []string) error {
+	return database.SetSessionTags(a.db, sessionID, tags)
+}
+
+// GetAllTags returns every tag in use, for filtering the history pages.
+func (a *App) GetAllTags() ([]string, error) {
+	return database.GetAllTags(a.db)
+}
+
+// endGame completes a session through its game's service and updates the personal records.
+// A session already completed when its last trial was answered has nothing left to end.
+func (a *App) endGame(sessionID int64, end func(int64) error) ([]types.PersonalRecord, error) {
+	status, err := database.GetSessionStatus(a.db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if status == types.SessionStatusCompleted {
+		return []types.PersonalRecord{}, nil
+	}
+	if err := end(sessionID); err != nil {
+		return nil, err
+	}
+	return a.progressService.CompleteSession(sessionID)
+}
+
+// completeIfDone ends a session once every trial it expects has been answered, so that it
+// is completed even if the player never reaches the end screen. Failures are only logged,
+// as the answer itself was saved.
+func (a *App) completeIfDone(sessionID int64, end func(int64) error) {
+	done, err := database.AllTrialsAnswered(a.db, sessionID)
+	if err == nil && done {
+		_, err = a.endGame(sessionID, end)
+	}
+	if err != nil {
+		log.Printf("failed to complete session %d: %v", sessionID, err)
+	}
+}
+
+// EndNBackGame marks a finished N-Back session as completed and returns the records it set.
+func (a *App) EndNBackGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.nbackService.EndGame)
+}
+
+// AbandonNBackGame marks a N-Back session the player left unfinished as abandoned.
+func (a *App) AbandonNBackGame(sessionID int64) error {
+	return a.nbackService.AbandonGame(sessionID)
+}
+
+// EndRpsGame marks a finished RPS session as completed and returns the records it set.
+func (a *App) EndRpsGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.rpsService.EndGame)
+}
+
+// AbandonRpsGame marks a RPS session the player left unfinished as abandoned.
+func (a *App) AbandonRpsGame(sessionID int64) error {
+	return a.rpsService.AbandonGame(sessionID)
+}
+
+// EndCountComparisonGame marks a finished Count Comparison session as completed and returns the records it set.
+func (a *App) EndCountComparisonGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.countComparisonService.EndGame)
+}
+
+// AbandonCountComparisonGame marks a Count Comparison session the player left unfinished as abandoned.
+func (a *App) AbandonCountComparisonGame(sessionID int64) error {
+	return a.countComparisonService.AbandonGame(sessionID)
+}
+
+// EndNumberPressingGame marks a finished Number Pressing session as completed and returns the records it set.
+func (a *App) EndNumberPressingGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.numberPressingService.EndGame)
+}
+
+// AbandonNumberPressingGame marks a Number Pressing session the player left unfinished as abandoned.
+func (a *App) AbandonNumberPressingGame(sessionID int64) error {
+	return a.numberPressingService.AbandonGame(sessionID)
+}
+
+// EndCatChaserGame marks a finished Cat Chaser session as completed and returns the records it set.
+func (a *App) EndCatChaserGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.catChaserService.EndGame)
+}
+
+// AbandonCatChaserGame marks a Cat Chaser session the player left unfinished as abandoned.
+func (a *App) AbandonCatChaserGame(sessionID int64) error {
+	return a.catChaserService.AbandonGame(sessionID)
+}
+
+// EndTowerGame marks a finished Tower session as completed and returns the records it set.
+func (a *App) EndTowerGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.towerService.EndGame)
+}
+
+// AbandonTowerGame marks a Tower session the player left unfinished as abandoned.
+func (a *App) AbandonTowerGame(sessionID int64) error {
+	return a.towerService.AbandonGame(sessionID)
+}
+
+// EndWeightGame marks a finished Weight Comparison session as completed and returns the records it set.
+func (a *App) EndWeightGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.weightService.EndGame)
+}
+
+// AbandonWeightGame marks a Weight Comparison session the player left unfinished as abandoned.
+func (a *App) AbandonWeightGame(sessionID int64) error {
+	return a.weightService.AbandonGame(sessionID)
+}
+
+// EndRoadGame marks a finished Road session as completed and returns the records it set.
+func (a *App) EndRoadGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.roadService.EndGame)
+}
+
+// AbandonRoadGame marks a Road session the player left unfinished as abandoned.
+func (a *App) AbandonRoadGame(sessionID int64) error {
+	return a.roadService.AbandonGame(sessionID)
+}
+
+// EndPositionMemoryGame marks a finished Position Memory session as completed and returns the records it set.
+func (a *App) EndPositionMemoryGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.positionMemoryService.EndGame)
+}
+
+// AbandonPositionMemoryGame marks a Position Memory session the player left unfinished as abandoned.
+func (a *App) AbandonPositionMemoryGame(sessionID int64) error {
+	return a.positionMemoryService.AbandonGame(sessionID)
+}
+
+// EndStroopGame marks a finished Stroop session as completed and returns the records it set.
+func (a *App) EndStroopGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.stroopService.EndGame)
+}
+
+// AbandonStroopGame marks a Stroop session the player left unfinished as abandoned.
+func (a *App) AbandonStroopGame(sessionID int64) error {
+	return a.stroopService.AbandonGame(sessionID)
+}
+
+// EndSchedulingGame marks a finished Scheduling session as completed and returns the records it set.
+func (a *App) EndSchedulingGame(sessionID int64) ([]types.PersonalRecord, error) {
+	return a.endGame(sessionID, a.schedulingService.EndGame)
+}
+
+// AbandonSchedulingGame marks a Scheduling session the player left unfinished as abandoned.
+func (a *App) AbandonSchedulingGame(sessionID int64) error {
+	return a.schedulingService.AbandonGame(sessionID)
+}
+
+// EndShapeRotationGame marks a finished Shape Rotation session as completed and returns the records it set.
+func (a *App) EndShapeRotationGame(sessionID int64) ([]types.PersonalRecord, error) {
+	// Records must count the last answers, which may still be saving.
+	a.shapeRotationSaver.WaitSession(sessionID)
+	return a.endGame(sessionID, a.endShapeRotationSession)
+}
+
+// endShapeRotationSession marks a Shape Rotation session as completed. Its answers are
+// saved by the saver rather than a service, so there is no game to take out of play.
+func (a *App) endShapeRotationSession(sessionID int64) error {
+	return database.EndGameSession(a.db, sessionID)
+}
+
+// AbandonShapeRotationGame marks a Shape Rotation session the player left unfinished as abandoned.
+func (a *App) AbandonShapeRotationGame(sessionID int64) error {
+	return database.AbandonGameSession(a.db, sessionID)
+}
+
+// GetExcludeIncompleteSessions reports whether stats and trends leave out sessions that were not completed.
+func (a *App) GetExcludeIncompleteSessions() (bool, error) {
+	return database.GetExcludeIncompleteSessions(a.db)
+}
+
+// SetExcludeIncompleteSessions sets whether stats and trends leave out sessions that were not completed.
+func (a *App) SetExcludeIncompleteSessions(exclude bool) error {
+	return database.SetExcludeIncompleteSessions(a.db, exclude)
+}
+
+// GetResumableSessions returns the sessions left in progress that can be resumed, newest first.
+func (a *App) GetResumableSessions() ([]types.GameSession, error) {
+	return database.GetResumableSessions(a.db)
+}
+
+// ResumeNBackSession restores an N-Back session left in progress, pointing at its next unanswered trial.
+func (a *App) ResumeNBackSession(sessionID int64) (*nback.NBackGameState, error) {
+	return a.nbackService.ResumeSession(sessionID)
+}
+
+// ResumeRpsSession restores a Rock-Paper-Scissors session left in progress, pointing at its next unanswered question.
+func (a *App) ResumeRpsSession(sessionID int64) (*rps.GameState, error) {
+	return a.rpsService.ResumeSession(sessionID)
+}
+
+// ResumeCountComparisonSession restores a Count Comparison session left in progress and returns its next unanswered problem.
+func (a *App) ResumeCountComparisonSession(sessionID int64) (*types.CountComparisonProblem, error) {
+	return a.countComparisonService.ResumeSession(sessionID)
+}
+
+// ResumeNumberPressingSession restores a Number Pressing session left in progress, pointing at its next unanswered problem.
+func (a *App) ResumeNumberPressingSession(sessionID int64) (*types.NumberPressingGameState, error) {
+	return a.numberPressingService.ResumeSession(sessionID)
+}
+
+// ResumeCatChaserSession restores a Cat Chaser session left in progress, pointing at its first round with a target left to answer.
+func (a *App) ResumeCatChaserSession(sessionID int64) (*cat_chaser.CatChaserGameState, error) {
+	return a.catChaserService.ResumeSession(sessionID)
+}
+
+// ResumeTowerSession restores a Tower session left in progress, pointing at its first unfinished problem.
+func (a *App) ResumeTowerSession(sessionID int64) (*tower.TowerGameState, error) {
+	return a.towerService.ResumeSession(sessionID)
+}
+
+// ResumeWeightSession restores a Weight Comparison session left in progress, pointing at its first unanswered problem.
+func (a *App) ResumeWeightSession(sessionID int64) (*weight.WeightGameState, error) {
+	return a.weightService.ResumeSession(sessionID)
+}
+
+// ResumeRoadSession restores a Road session left in progress, pointing at its first unfinished problem.
+func (a *App) ResumeRoadSession(sessionID int64) (*road.RoadGameState, error) {
+	return a.roadService.ResumeSession(sessionID)
+}
+
+// ResumePositionMemorySession restores a Position Memory session left in progress with the sequence to answer next.
+func (a *App) ResumePositionMemorySession(sessionID int64) (*position_memory.PositionMemoryGameState, error) {
+	return a.positionMemoryService.ResumeSession(sessionID)
+}
+
+// ResumeStroopSession restores a Stroop session left in progress, pointing at its first unanswered trial.
+func (a *App) ResumeStroopSession(sessionID int64) (*stroop.StroopGameState, error) {
+	return a.stroopService.ResumeSession(sessionID)
+}
+
+// ResumeSchedulingSession restores a Scheduling session left in progress, pointing at its first unfinished problem.
+func (a *App) ResumeSchedulingSession(sessionID int64) (*scheduling.SchedulingGameState, error) {
+	return a.schedulingService.ResumeSession(sessionID)
+}
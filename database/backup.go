@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// fileSuffixes are the suffixes of the files that make up a database: the WAL and
+// shared memory files, then the database itself. Moving the main file last means a move
+// cut short is finished by trying again.
+var fileSuffixes = []string{"-wal", "-shm", ""}
+
+// BackupDatabase copies the database to path with SQLite's online backup API, which
+// gives a consistent copy while the app keeps using the database. The copy is written
+// next to path first and renamed into place once complete, and uses a rollback journal so
+// that it is a single self-contained file.
+func BackupDatabase(db *sql.DB, path string) error {
+	tmpPath := path + ".tmp"
+	os.Remove(tmpPath)
+	if err := copyInto(tmpPath, db); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to move backup into place: %w", err)
+	}
+	return nil
+}
+
+// copyInto copies the database src into a new file at path.
+func copyInto(path string, src *sql.DB) error {
+	dest, err := sql.Open("sqlite3", path+"?_journal_mode=DELETE")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer dest.Close()
+	return backup(dest, src)
+}
+
+// ValidateBackup checks that the file at path is an intact database of this app with a
+// schema version it can read, and returns that version. Older versions are accepted, as
+// opening them brings them up to date.
+func ValidateBackup(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("backup not found: %w", err)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+
+	if err := CheckIntegrity(db); err != nil {
+		return 0, err
+	}
+	version, err := schemaVersion(db)
+	if err != nil {
+		return 0, err
+	}
+	if version < 1 {
+		return 0, fmt.Errorf("backup has no schema version, so it was not made by this app")
+	}
+	if version > SchemaVersion {
+		return 0, fmt.Errorf("backup has schema version %d, newer than the %d this version of the app can read", version, SchemaVersion)
+	}
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'game_sessions'").Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to read tables of backup: %w", err)
+	}
+	if tables == 0 {
+		return 0, fmt.Errorf("backup has no game sessions table")
+	}
+	return version, nil
+}
+
+// RestoreDatabase replaces the contents of db with the backup at path, once the backup
+// has passed ValidateBackup. The copy is made with the online backup API into the open
+// database, so connections already handed out keep working and see the restored data.
+// The schema is then brought up to date, as the backup may be from an older version.
+func RestoreDatabase(db *sql.DB, path string) error {
+	if _, err := ValidateBackup(path); err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+	src, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+
+	if err := backup(db, src); err != nil {
+		return err
+	}
+	if err := initSchema(db); err != nil {
+		return fmt.Errorf("failed to update restored database: %w", err)
+	}
+	return nil
+}
+
+// backup copies every page of src into dest in one step.
+func backup(dest, src *sql.DB) error {
+	return withSQLiteConn(dest, func(destConn *sqlite3.SQLiteConn) error {
+		return withSQLiteConn(src, func(srcConn *sqlite3.SQLiteConn) error {
+			b, err := destConn.Backup("main", srcConn, "main")
+			if err != nil {
+				return fmt.Errorf("failed to start backup: %w", err)
+			}
+			if _, err := b.Step(-1); err != nil {
+				b.Close()
+				return fmt.Errorf("failed to copy database: %w", err)
+			}
+			if err := b.Finish(); err != nil {
+				return fmt.Errorf("failed to finish backup: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+// withSQLiteConn runs fn with a driver connection taken from the pool.
+func withSQLiteConn(db *sql.DB, fn func(*sqlite3.SQLiteConn) error) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		return fn(c)
+	})
+}
+
+// MoveDatabase moves the database at oldPath, with its WAL and shared memory files, to
+// newPath, and reports whether it did. Nothing is moved if there is no database at
+// oldPath, or if one is already at newPath, so it is safe to call on every start.
+func MoveDatabase(oldPath, newPath string) (bool, error) {
+	if _, err := os.Stat(newPath); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+	if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	for _, suffix := range fileSuffixes {
+		if err := moveFile(oldPath+suffix, newPath+suffix); err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("failed to move %s: %w", oldPath+suffix, err)
+		}
+	}
+	return true, nil
+}
+
+// moveFile renames a file, copying it instead when the rename fails, as it does across
+// file systems.
+func moveFile(oldPath, newPath string) error {
+	err := os.Rename(oldPath, newPath)
+	if err == nil || os.IsNotExist(err) {
+		return err
+	}
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) {
+		return err
+	}
+
+	in, err := os.Open(oldPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(newPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(newPath)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+	in.Close()
+	return os.Remove(oldPath)
+}
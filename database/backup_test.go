@@ -0,0 +1,154 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"acca-games/types"
+)
+
+func countSessions(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM game_sessions").Scan(&n); err != nil {
+		t.Fatalf("Failed to count sessions: %v", err)
+	}
+	return n
+}
+
+func TestBackupAndRestoreDatabase(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewDatabase(filepath.Join(dir, "games.db"))
+	if err != nil {
+		t.Fatalf("NewDatabase failed: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := CreateGameSession(db, types.GameCodeTower, types.TowerSettings{}); err != nil {
+		t.Fatalf("CreateGameSession failed: %v", err)
+	}
+	backupPath := filepath.Join(dir, "backup.db")
+	if err := BackupDatabase(db, backupPath); err != nil {
+		t.Fatalf("BackupDatabase failed: %v", err)
+	}
+	if _, err := os.Stat(backupPath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected no temporary file to be left behind")
+	}
+	if _, err := os.Stat(backupPath + "-wal"); !os.IsNotExist(err) {
+		t.Error("Expected the backup to be a single file")
+	}
+	version, err := ValidateBackup(backupPath)
+	if err != nil {
+		t.Fatalf("ValidateBackup failed: %v", err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+
+	if _, err := CreateGameSession(db, types.GameCodeRoad, types.RoadSettings{}); err != nil {
+		t.Fatalf("CreateGameSession failed: %v", err)
+	}
+	if err := RestoreDatabase(db, backupPath); err != nil {
+		t.Fatalf("RestoreDatabase failed: %v", err)
+	}
+	if n := countSessions(t, db); n != 1 {
+		t.Errorf("Expected the restored database to have 1 session, got %d", n)
+	}
+	if _, err := CreateGameSession(db, types.GameCodeRoad, types.RoadSettings{}); err != nil {
+		t.Errorf("Expected the restored database to take writes, got %v", err)
+	}
+}
+
+func TestValidateBackup_Rejects(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := ValidateBackup(filepath.Join(dir, "missing.db")); err == nil {
+		t.Error("Expected a missing backup to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing.db")); !os.IsNotExist(err) {
+		t.Error("Expected validating a missing backup not to create it")
+	}
+
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database at all, not even close"), 0644); err != nil {
+		t.Fatalf("Failed to write garbage: %v", err)
+	}
+	if _, err := ValidateBackup(garbage); err == nil {
+		t.Error("Expected a file that is not a database to be rejected")
+	}
+
+	versions := map[string]int{"unversioned.db": 0, "newer.db": SchemaVersion + 1}
+	for name, version := range versions {
+		path := filepath.Join(dir, name)
+		other, err := sql.Open("sqlite3", path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", name, err)
+		}
+		if _, err := other.Exec(schemaSQL); err != nil {
+			t.Fatalf("Failed to create schema in %s: %v", name, err)
+		}
+		if _, err := other.Exec(fmt.Sprintf("PRAGMA user_version = %d", version)); err != nil {
+			t.Fatalf("Failed to set version of %s: %v", name, err)
+		}
+		other.Close()
+		if _, err := ValidateBackup(path); err == nil {
+			t.Errorf("Expected %s with schema version %d to be rejected", name, version)
+		}
+	}
+
+	db, err := NewDatabase(filepath.Join(dir, "games.db"))
+	if err != nil {
+		t.Fatalf("NewDatabase failed: %v", err)
+	}
+	defer db.Close()
+	if err := RestoreDatabase(db, filepath.Join(dir, "newer.db")); err == nil {
+		t.Error("Expected restoring a backup from a newer version to fail")
+	}
+}
+
+func TestMoveDatabase(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	oldPath, newPath := filepath.Join(oldDir, "games.db"), filepath.Join(newDir, "games.db")
+
+	moved, err := MoveDatabase(oldPath, newPath)
+	if err != nil || moved {
+		t.Fatalf("Expected nothing to move, got %v, %v", moved, err)
+	}
+
+	db, err := NewDatabase(oldPath)
+	if err != nil {
+		t.Fatalf("NewDatabase failed: %v", err)
+	}
+	if _, err := CreateGameSession(db, types.GameCodeTower, types.TowerSettings{}); err != nil {
+		t.Fatalf("CreateGameSession failed: %v", err)
+	}
+	db.Close()
+
+	moved, err = MoveDatabase(oldPath, newPath)
+	if err != nil || !moved {
+		t.Fatalf("Expected the database to move, got %v, %v", moved, err)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Error("Expected the old database to be gone")
+	}
+	db, err = NewDatabase(newPath)
+	if err != nil {
+		t.Fatalf("NewDatabase failed: %v", err)
+	}
+	if n := countSessions(t, db); n != 1 {
+		t.Errorf("Expected the moved database to keep its session, got %d", n)
+	}
+	db.Close()
+
+	// A database already at the new path is never overwritten.
+	if err := os.WriteFile(oldPath, []byte("stale"), 0644); err != nil {
+		t.Fatalf("Failed to write old file: %v", err)
+	}
+	moved, err = MoveDatabase(oldPath, newPath)
+	if err != nil || moved {
+		t.Errorf("Expected nothing to move over an existing database, got %v, %v", moved, err)
+	}
+}
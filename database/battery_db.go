@@ -0,0 +1,156 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// CreateBatterySession creates a new battery with the given steps and returns its ID.
+func CreateBatterySession(db *sql.DB, steps []types.BatteryStep) (int64, error) {
+	stepsJSON, err := json.Marshal(steps)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal steps: %w", err)
+	}
+
+	res, err := db.Exec("INSERT INTO battery_sessions (status, steps) VALUES (?, ?)", types.BatteryStatusInProgress, string(stepsJSON))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert battery session: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// LinkBatteryStep marks a game session as played for one step of a battery.
+func LinkBatteryStep(db *sql.DB, batteryID int64, step int, sessionID int64) error {
+	_, err := db.Exec("UPDATE game_sessions SET battery_session_id = ?, battery_step = ? WHERE id = ?", batteryID, step, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to link session %d to battery %d: %w", sessionID, batteryID, err)
+	}
+	return nil
+}
+
+// AdvanceBatterySession moves a battery on to its next step, completing it after the last.
+func AdvanceBatterySession(db *sql.DB, batteryID int64, numSteps int) error {
+	_, err := db.Exec(`
+		UPDATE battery_sessions
+		SET current_step = current_step + 1,
+		    status = CASE WHEN current_step + 1 >= ? THEN ? ELSE status END,
+		    completed_at = CASE WHEN current_step + 1 >= ? THEN datetime('now','localtime') ELSE completed_at END
+		WHERE id = ?`,
+		numSteps, types.BatteryStatusCompleted, numSteps, batteryID)
+	if err != nil {
+		return fmt.Errorf("failed to advance battery %d: %w", batteryID, err)
+	}
+	return nil
+}
+
+// GetBatterySession retrieves a battery and the progress of each of its steps.
+func GetBatterySession(db *sql.DB, batteryID int64) (*types.BatterySession, error) {
+	var b types.BatterySession
+	var stepsJSON string
+	err := db.QueryRow(`
+		SELECT id, started_at, completed_at, status, current_step, steps
+		FROM battery_sessions WHERE id = ?`, batteryID).
+		Scan(&b.ID, &b.StartedAt, &b.CompletedAt, &b.Status, &b.CurrentStep, &stepsJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("battery %d not found", batteryID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get battery %d: %w", batteryID, err)
+	}
+
+	var steps []types.BatteryStep
+	if err := json.Unmarshal([]byte(stepsJSON), &steps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal steps: %w", err)
+	}
+
+	// A step may have been started more than once; the latest session counts.
+	rows, err := db.Query(`
+		SELECT battery_step, MAX(id) FROM game_sessions
+		WHERE battery_session_id = ?
+		GROUP BY battery_step`, batteryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get battery game sessions: %w", err)
+	}
+	defer rows.Close()
+	sessions := make(map[int]int64)
+	for rows.Next() {
+		var step int
+		var sessionID int64
+		if err := rows.Scan(&step, &sessionID); err != nil {
+			return nil, fmt.Errorf("failed to scan battery game session: %w", err)
+		}
+		sessions[step] = sessionID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	b.Steps = make([]types.BatteryStepProgress, len(steps))
+	for i, s := range steps {
+		p := types.BatteryStepProgress{Step: i, GameCode: s.GameCode, Settings: s.Settings, SessionID: sessions[i], Status: types.BatteryStepPending}
+		switch {
+		case i < b.CurrentStep:
+			p.Status = types.BatteryStepCompleted
+		case p.SessionID != 0:
+			p.Status = types.BatteryStepStarted
+		}
+		b.Steps[i] = p
+	}
+	return &b, nil
+}
+
+// GetUnfinishedBatterySession returns the most recent battery still in progress, or nil.
+func GetUnfinishedBatterySession(db *sql.DB) (*types.BatterySession, error) {
+	var id int64
+	err := db.QueryRow(`
+		SELECT id FROM battery_sessions
+		WHERE status = ?
+		ORDER BY id DESC LIMIT 1`, types.BatteryStatusInProgress).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfinished battery: %w", err)
+	}
+	return GetBatterySession(db, id)
+}
+
+// GetBatteryReport scores every finished step of a battery with the game's own session
+// stats and averages the scores into a composite.
+func GetBatteryReport(db *sql.DB, batteryID int64) (*types.BatteryReport, error) {
+	b, err := GetBatterySession(db, batteryID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.BatteryReport{
+		BatteryID:   b.ID,
+		Status:      b.Status,
+		StartedAt:   b.StartedAt,
+		CompletedAt: b.CompletedAt,
+		Steps:       []types.BatteryStepReport{},
+	}
+	for _, p := range b.Steps {
+		if p.Status != types.BatteryStepCompleted {
+			continue
+		}
+		summary, err := summarizeSession(db, p.GameCode, p.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score step %d: %w", p.Step, err)
+		}
+		report.Steps = append(report.Steps, types.BatteryStepReport{
+			Step:      p.Step,
+			GameCode:  p.GameCode,
+			SessionID: p.SessionID,
+			Score:     summary.Accuracy,
+			Stats:     summary.Stats,
+		})
+		report.CompositeScore += summary.Accuracy
+	}
+	if len(report.Steps) > 0 {
+		report.CompositeScore /= float64(len(report.Steps))
+	}
+	return report, nil
+}
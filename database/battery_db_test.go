@@ -0,0 +1,93 @@
+package database
+
+import (
+	"encoding/json"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestBatterySessionProgressAndReport(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	steps := []types.BatteryStep{
+		{GameCode: types.GameCodeTower, Settings: json.RawMessage(`{"numProblems":2}`)},
+		{GameCode: types.GameCodeTower, Settings: json.RawMessage(`{"numProblems":1}`)},
+	}
+	batteryID, err := CreateBatterySession(db, steps)
+	if err != nil {
+		t.Fatalf("CreateBatterySession failed: %v", err)
+	}
+
+	unfinished, err := GetUnfinishedBatterySession(db)
+	if err != nil {
+		t.Fatalf("GetUnfinishedBatterySession failed: %v", err)
+	}
+	if unfinished == nil || unfinished.ID != batteryID {
+		t.Fatalf("Expected battery %d to be unfinished, got %+v", batteryID, unfinished)
+	}
+	if unfinished.Steps[0].Status != types.BatteryStepPending || string(unfinished.Steps[1].Settings) != `{"numProblems":1}` {
+		t.Errorf("Unexpected steps of a new battery: %+v", unfinished.Steps)
+	}
+
+	// The first step is started twice; only the latest session counts.
+	abandoned, err := CreateGameSession(db, types.GameCodeTower, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	sessionID, err := CreateGameSession(db, types.GameCodeTower, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	for _, id := range []int64{abandoned, sessionID} {
+		if err := LinkBatteryStep(db, batteryID, 0, id); err != nil {
+			t.Fatalf("LinkBatteryStep failed: %v", err)
+		}
+	}
+	for i, solved := range []bool{true, false} {
+		r := types.TowerResult{SessionID: sessionID, ProblemNum: i + 1, Discs: 3,
+			Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{}, {}, {3, 2, 1}},
+			Moves: []types.TowerMove{}, OptimalMoves: 7, MoveLimit: 14, IsSolved: solved, TimeTakenMs: 1000}
+		if err := SaveTowerResult(db, r); err != nil {
+			t.Fatalf("SaveTowerResult failed: %v", err)
+		}
+	}
+
+	b, err := GetBatterySession(db, batteryID)
+	if err != nil {
+		t.Fatalf("GetBatterySession failed: %v", err)
+	}
+	if b.Steps[0].Status != types.BatteryStepStarted || b.Steps[0].SessionID != sessionID {
+		t.Errorf("Expected step 0 started with session %d, got %+v", sessionID, b.Steps[0])
+	}
+
+	if err := AdvanceBatterySession(db, batteryID, len(steps)); err != nil {
+		t.Fatalf("AdvanceBatterySession failed: %v", err)
+	}
+	report, err := GetBatteryReport(db, batteryID)
+	if err != nil {
+		t.Fatalf("GetBatteryReport failed: %v", err)
+	}
+	if report.Status != types.BatteryStatusInProgress || len(report.Steps) != 1 || report.CompositeScore != 50 {
+		t.Errorf("Unexpected report after one step: %+v", report)
+	}
+
+	if err := AdvanceBatterySession(db, batteryID, len(steps)); err != nil {
+		t.Fatalf("AdvanceBatterySession failed: %v", err)
+	}
+	b, err = GetBatterySession(db, batteryID)
+	if err != nil {
+		t.Fatalf("GetBatterySession failed: %v", err)
+	}
+	if b.Status != types.BatteryStatusCompleted || b.CurrentStep != 2 || b.CompletedAt.IsZero() {
+		t.Errorf("Expected a completed battery, got %+v", b)
+	}
+	unfinished, err = GetUnfinishedBatterySession(db)
+	if err != nil || unfinished != nil {
+		t.Errorf("Expected no unfinished battery, got %+v, %v", unfinished, err)
+	}
+}
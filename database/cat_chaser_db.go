@@ -4,6 +4,7 @@ import (
 	"acca-games/types"
 	"database/sql"
 	"fmt"
+	"math"
 )
 
 // SaveCatChaserResult saves a single result of the Cat Chaser game.
@@ -11,8 +12,8 @@ func SaveCatChaserResult(db *sql.DB, result types.CatChaserResult) error {
 	stmt, err := db.Prepare(`
 		INSERT INTO cat_chaser_results (
 			session_id, round, target_color, player_choice, confidence, 
-			correct_choice, is_correct, score, response_time_ms
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			correct_choice, is_correct, score, response_time_ms, frame_schedule
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -29,6 +30,7 @@ func SaveCatChaserResult(db *sql.DB, result types.CatChaserResult) error {
 		result.IsCorrect,
 		result.Score,
 		result.ResponseTimeMs,
+		result.FrameSchedule,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to execute statement: %w", err)
@@ -41,7 +43,7 @@ func SaveCatChaserResult(db *sql.DB, result types.CatChaserResult) error {
 func GetCatChaserResultsBySessionID(db *sql.DB, sessionID int64) ([]types.CatChaserResult, error) {
 	rows, err := db.Query(`
 		SELECT id, session_id, round, target_color, player_choice, confidence, 
-		       correct_choice, is_correct, score, response_time_ms
+		       correct_choice, is_correct, score, response_time_ms, frame_schedule
 		FROM cat_chaser_results
 		WHERE session_id = ?
 		ORDER BY round ASC, target_color DESC
@@ -66,6 +68,7 @@ func GetCatChaserResultsBySessionID(db *sql.DB, sessionID int64) ([]types.CatCha
 			&r.IsCorrect,
 			&r.Score,
 			&r.ResponseTimeMs,
+			&r.FrameSchedule,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -77,12 +80,13 @@ func GetCatChaserResultsBySessionID(db *sql.DB, sessionID int64) ([]types.CatCha
 }
 
 // GetPaginatedCatChaserSessionsWithResults fetches sessions with pagination and includes results for each session.
-func GetPaginatedCatChaserSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedCatChaserSessions, error) {
+func GetPaginatedCatChaserSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedCatChaserSessions, error) {
 	offset := (page - 1) * limit
 
 	// 1. Get total count
+	where, args := historyFilter(types.GameCodeCatChaser, tags)
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeCatChaser).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count: %w", err)
 	}
@@ -91,10 +95,10 @@ func GetPaginatedCatChaserSessionsWithResults(db *sql.DB, page int, limit int) (
 	rows, err := db.Query(`
 		SELECT id, game_code, play_datetime, settings
 		FROM game_sessions
-		WHERE game_code = ?
+		WHERE `+where+`
 		ORDER BY play_datetime DESC
 		LIMIT ? OFFSET ?
-	`, types.GameCodeCatChaser, limit, offset)
+	`, pageArgs(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sessions: %w", err)
 	}
@@ -109,6 +113,9 @@ func GetPaginatedCatChaserSessionsWithResults(db *sql.DB, page int, limit int) (
 			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
 		s.Settings = settingsStr
+		if err := loadSessionDetails(db, &s.GameSession); err != nil {
+			return nil, err
+		}
 
 		// 3. Get results for each session
 		results, err := GetCatChaserResultsBySessionID(db, s.ID)
@@ -206,3 +213,140 @@ func GetCatChaserSessionStats(db *sql.DB, sessionID int64) (*types.CatChaserSess
 
 	return stats, nil
 }
+
+// confidenceProbability maps a 1-4 confidence rating to the probability of being right
+// it stands for: 1 is a coin flip (50%) and 4 is certainty (100%).
+func confidenceProbability(confidence int) float64 {
+	return 0.5 + float64(confidence-1)/6
+}
+
+// GetCatChaserCalibration calculates how well confidence ratings matched accuracy in a session.
+func GetCatChaserCalibration(db *sql.DB, sessionID int64) (*types.CatChaserCalibration, error) {
+	results, err := GetCatChaserResultsBySessionID(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return calculateCatChaserCalibration(sessionID, results), nil
+}
+
+// GetCatChaserCalibrationTrend returns the calibration of every Cat Chaser session, oldest first.
+func GetCatChaserCalibrationTrend(db *sql.DB) ([]types.CatChaserCalibrationTrendPoint, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.play_datetime FROM game_sessions s
+		WHERE s.game_code = ? AND `+counted+`
+		ORDER BY s.play_datetime ASC, s.id ASC`, types.GameCodeCatChaser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var points []types.CatChaserCalibrationTrendPoint
+	for rows.Next() {
+		var p types.CatChaserCalibrationTrendPoint
+		if err := rows.Scan(&p.SessionID, &p.PlayDatetime); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	trend := make([]types.CatChaserCalibrationTrendPoint, 0, len(points))
+	for _, p := range points {
+		results, err := GetCatChaserResultsBySessionID(db, p.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		c := calculateCatChaserCalibration(p.SessionID, results)
+		if c.TotalAnswered == 0 {
+			continue
+		}
+		p.TotalAnswered = c.TotalAnswered
+		p.Accuracy = c.Accuracy
+		p.BrierScore = c.BrierScore
+		p.CalibrationError = c.CalibrationError
+		p.OverconfidenceIndex = c.OverconfidenceIndex
+		trend = append(trend, p)
+	}
+
+	return trend, nil
+}
+
+// calculateCatChaserCalibration computes accuracy per confidence level, the Brier score
+// and the over/underconfidence index. Timeouts carry no confidence and are skipped.
+func calculateCatChaserCalibration(sessionID int64, results []types.CatChaserResult) *types.CatChaserCalibration {
+	c := &types.CatChaserCalibration{
+		SessionID: sessionID,
+		Levels:    []types.ConfidenceLevelStat{},
+	}
+
+	levels := make(map[int]*types.ConfidenceLevelStat)
+	var totalCorrect int
+	var sumProbability, sumSquaredError, sumConfidence float64
+
+	for _, r := range results {
+		if r.PlayerChoice == "TIMEOUT" || r.Confidence < 1 || r.Confidence > 4 {
+			continue
+		}
+
+		p := confidenceProbability(r.Confidence)
+		outcome := 0.0
+		if r.IsCorrect {
+			outcome = 1.0
+			totalCorrect++
+		}
+
+		c.TotalAnswered++
+		sumProbability += p
+		sumConfidence += float64(r.Confidence)
+		sumSquaredError += (p - outcome) * (p - outcome)
+
+		if _, ok := levels[r.Confidence]; !ok {
+			levels[r.Confidence] = &types.ConfidenceLevelStat{Confidence: r.Confidence, StatedProbability: p}
+		}
+		ls := levels[r.Confidence]
+		ls.TotalQuestions++
+		if r.IsCorrect {
+			ls.TotalCorrect++
+		}
+	}
+
+	if c.TotalAnswered == 0 {
+		return c
+	}
+
+	n := float64(c.TotalAnswered)
+	accuracy := float64(totalCorrect) / n
+	c.Accuracy = accuracy * 100
+	c.MeanConfidence = sumConfidence / n
+	c.BrierScore = roundTo(sumSquaredError/n, 4)
+	c.OverconfidenceIndex = roundTo(sumProbability/n-accuracy, 4)
+
+	calibration := 0.0
+	for confidence := 1; confidence <= 4; confidence++ {
+		ls, ok := levels[confidence]
+		if !ok {
+			continue
+		}
+		levelAccuracy := float64(ls.TotalCorrect) / float64(ls.TotalQuestions)
+		ls.Accuracy = levelAccuracy * 100
+		gap := ls.StatedProbability - levelAccuracy
+		calibration += float64(ls.TotalQuestions) * gap * gap
+		c.Levels = append(c.Levels, *ls)
+	}
+	c.CalibrationError = roundTo(calibration/n, 4)
+
+	return c
+}
+
+func roundTo(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"testing"
+
+	"acca-games/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCatChaserCalibration(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeCatChaser, types.CatChaserSettings{NumTrials: 3})
+	assert.NoError(t, err)
+
+	answers := []types.CatChaserResult{
+		{Confidence: 4, IsCorrect: true},
+		{Confidence: 4, IsCorrect: false},
+		{Confidence: 1, IsCorrect: true},
+		{Confidence: 1, IsCorrect: false},
+		{PlayerChoice: "TIMEOUT", Confidence: 0, IsCorrect: false},
+	}
+	for i, a := range answers {
+		a.SessionID = sessionID
+		a.Round = i/2 + 1
+		a.TargetColor = []string{"RED", "BLUE"}[i%2]
+		if a.PlayerChoice == "" {
+			a.PlayerChoice = string(types.StatusCaught)
+		}
+		assert.NoError(t, SaveCatChaserResult(db, a))
+	}
+
+	c, err := GetCatChaserCalibration(db, sessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, c.TotalAnswered, "Timeouts should be skipped")
+	assert.InEpsilon(t, 50.0, c.Accuracy, 0.01)
+	assert.InEpsilon(t, 2.5, c.MeanConfidence, 0.01)
+
+	// Stated probabilities are 1.0, 1.0, 0.5, 0.5 against outcomes 1, 0, 1, 0.
+	assert.InDelta(t, (0+1+0.25+0.25)/4.0, c.BrierScore, 0.0001)
+	assert.InDelta(t, 0.75-0.5, c.OverconfidenceIndex, 0.0001)
+	// Level 4 is 50 points overconfident, level 1 is spot on.
+	assert.InDelta(t, (2*0.25+2*0)/4.0, c.CalibrationError, 0.0001)
+
+	assert.Len(t, c.Levels, 2)
+	assert.Equal(t, 1, c.Levels[0].Confidence)
+	assert.Equal(t, 0.5, c.Levels[0].StatedProbability)
+	assert.Equal(t, 4, c.Levels[1].Confidence)
+	assert.InEpsilon(t, 50.0, c.Levels[1].Accuracy, 0.01)
+}
+
+func TestGetCatChaserCalibrationTrend(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, correct := range []bool{false, true} {
+		sessionID, err := CreateGameSession(db, types.GameCodeCatChaser, types.CatChaserSettings{NumTrials: 1})
+		assert.NoError(t, err)
+		for _, color := range []string{"RED", "BLUE"} {
+			assert.NoError(t, SaveCatChaserResult(db, types.CatChaserResult{
+				SessionID: sessionID, Round: 1, TargetColor: color, PlayerChoice: "CAUGHT", Confidence: 4, IsCorrect: correct,
+			}))
+		}
+	}
+	// A session without answers is left out.
+	_, err := CreateGameSession(db, types.GameCodeCatChaser, types.CatChaserSettings{NumTrials: 1})
+	assert.NoError(t, err)
+
+	trend, err := GetCatChaserCalibrationTrend(db)
+	assert.NoError(t, err)
+	assert.Len(t, trend, 2)
+	assert.InDelta(t, 1.0, trend[0].BrierScore, 0.0001)
+	assert.InDelta(t, 1.0, trend[0].OverconfidenceIndex, 0.0001)
+	assert.InDelta(t, 0.0, trend[1].BrierScore, 0.0001)
+	assert.InDelta(t, 0.0, trend[1].OverconfidenceIndex, 0.0001)
+}
@@ -13,8 +13,9 @@ func SaveCountComparisonResult(db *sql.DB, result types.CountComparisonResult) e
 	_, err := db.Exec(`
 		INSERT INTO count_comparison_results (
 			session_id, problem_number, is_correct, response_time_ms, player_choice,
-			correct_choice, left_word, right_word, left_word_count, right_word_count, applied_traps
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			correct_choice, left_word, right_word, left_word_count, right_word_count, applied_traps, ratio,
+			word_list_id, word_list_name, layout
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		result.SessionID,
 		result.ProblemNumber,
 		result.IsCorrect,
@@ -26,6 +27,10 @@ func SaveCountComparisonResult(db *sql.DB, result types.CountComparisonResult) e
 		result.LeftWordCount,
 		result.RightWordCount,
 		result.AppliedTraps, // Already a JSON string
+		result.Ratio,
+		result.WordListID,
+		result.WordListName,
+		result.Layout, // Already a JSON string
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert count comparison result: %w", err)
@@ -38,7 +43,8 @@ func GetCountComparisonResultsForSession(db *sql.DB, sessionID int64) ([]types.C
 	rows, err := db.Query(`
 		SELECT
 			id, session_id, problem_number, is_correct, response_time_ms, player_choice,
-			correct_choice, left_word, right_word, left_word_count, right_word_count, applied_traps
+			correct_choice, left_word, right_word, left_word_count, right_word_count, applied_traps, ratio,
+			word_list_id, word_list_name, layout
 		FROM count_comparison_results WHERE session_id = ? ORDER BY problem_number ASC`, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query count comparison results: %w", err)
@@ -52,7 +58,8 @@ func GetCountComparisonResultsForSession(db *sql.DB, sessionID int64) ([]types.C
 
 		if err := rows.Scan(
 			&result.ID, &result.SessionID, &result.ProblemNumber, &result.IsCorrect, &result.ResponseTimeMs, &result.PlayerChoice,
-			&result.CorrectChoice, &result.LeftWord, &result.RightWord, &result.LeftWordCount, &result.RightWordCount, &appliedTrapsJSON,
+			&result.CorrectChoice, &result.LeftWord, &result.RightWord, &result.LeftWordCount, &result.RightWordCount, &appliedTrapsJSON, &result.Ratio,
+			&result.WordListID, &result.WordListName, &result.Layout,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan count comparison result: %w", err)
 		}
@@ -69,12 +76,13 @@ func GetCountComparisonResultsForSession(db *sql.DB, sessionID int64) ([]types.C
 }
 
 // GetPaginatedCountComparisonSessionsWithResults fetches paginated sessions with their results.
-func GetPaginatedCountComparisonSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedCountComparisonSessions, error) {
+func GetPaginatedCountComparisonSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedCountComparisonSessions, error) {
 	offset := (page - 1) * limit
 
 	// First, get the total count of sessions
+	where, args := historyFilter(types.GameCodeCountComparison, tags)
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeCountComparison).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count comparison session count: %w", err)
 	}
@@ -84,18 +92,19 @@ func GetPaginatedCountComparisonSessionsWithResults(db *sql.DB, page int, limit
 		SELECT
 			s.id, s.game_code, s.play_datetime, s.settings,
 			r.id, r.session_id, r.problem_number, r.is_correct, r.response_time_ms, r.player_choice,
-			r.correct_choice, r.left_word, r.right_word, r.left_word_count, r.right_word_count, r.applied_traps
+			r.correct_choice, r.left_word, r.right_word, r.left_word_count, r.right_word_count, r.applied_traps, r.ratio,
+			r.word_list_id, r.word_list_name, r.layout
 		FROM game_sessions s
 		JOIN count_comparison_results r ON s.id = r.session_id
 		WHERE s.id IN (
 			SELECT id FROM game_sessions
-			WHERE game_code = ?
+			WHERE `+where+`
 			ORDER BY play_datetime DESC
 			LIMIT ? OFFSET ?
 		)
 		ORDER BY s.play_datetime DESC, r.problem_number ASC
 	`
-	rows, err := db.Query(query, types.GameCodeCountComparison, limit, offset)
+	rows, err := db.Query(query, pageArgs(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query paginated count comparison sessions with results: %w", err)
 	}
@@ -112,7 +121,8 @@ func GetPaginatedCountComparisonSessionsWithResults(db *sql.DB, page int, limit
 		if err := rows.Scan(
 			&s.ID, &s.GameCode, &s.PlayDatetime, &settingsJSON,
 			&r.ID, &r.SessionID, &r.ProblemNumber, &r.IsCorrect, &r.ResponseTimeMs, &r.PlayerChoice,
-			&r.CorrectChoice, &r.LeftWord, &r.RightWord, &r.LeftWordCount, &r.RightWordCount, &r.AppliedTraps,
+			&r.CorrectChoice, &r.LeftWord, &r.RightWord, &r.LeftWordCount, &r.RightWordCount, &r.AppliedTraps, &r.Ratio,
+			&r.WordListID, &r.WordListName, &r.Layout,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan count comparison session/result: %w", err)
 		}
@@ -136,6 +146,9 @@ func GetPaginatedCountComparisonSessionsWithResults(db *sql.DB, page int, limit
 	sessions := make([]types.CountComparisonSessionWithResults, len(sessionOrder))
 	for i, id := range sessionOrder {
 		sessions[i] = *sessionMap[id]
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
 	}
 
 	return &types.PaginatedCountComparisonSessions{
@@ -221,3 +234,155 @@ func GetCountComparisonSessionStats(db *sql.DB, sessionID int64) (*types.CountCo
 
 	return stats, nil
 }
+
+// GetCountComparisonWordListStats aggregates accuracy and response time per word list
+// over all Count Comparison results.
+func GetCountComparisonWordListStats(db *sql.DB) ([]types.WordListStat, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT
+			word_list_id,
+			CASE WHEN word_list_id = ? THEN ? ELSE MAX(word_list_name) END,
+			COUNT(DISTINCT session_id), COUNT(*), SUM(is_correct), AVG(response_time_ms)
+		FROM count_comparison_results
+		WHERE session_id IN (SELECT s.id FROM game_sessions s WHERE `+counted+`)
+		GROUP BY word_list_id
+		ORDER BY word_list_id ASC`, types.BuiltinWordListID, types.BuiltinWordListName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word list stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []types.WordListStat{}
+	for rows.Next() {
+		var ws types.WordListStat
+		if err := rows.Scan(&ws.WordListID, &ws.WordListName, &ws.TotalSessions, &ws.TotalQuestions, &ws.TotalCorrect, &ws.AverageResponseTimeMs); err != nil {
+			return nil, fmt.Errorf("failed to scan word list stat: %w", err)
+		}
+		if ws.TotalQuestions > 0 {
+			ws.Accuracy = float64(ws.TotalCorrect) / float64(ws.TotalQuestions) * 100
+		}
+		stats = append(stats, ws)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetCountComparisonPsychometrics fits accuracy against count ratio for a session,
+// once over all problems and once each for problems with and without traps.
+func GetCountComparisonPsychometrics(db *sql.DB, sessionID int64) (*types.CountComparisonPsychometrics, error) {
+	results, err := GetCountComparisonResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get count comparison results for session %d: %w", sessionID, err)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no count comparison results found for session %d", sessionID)
+	}
+
+	all, noTrap, trap, err := splitRatioTrials(results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CountComparisonPsychometrics{
+		SessionID: sessionID,
+		All:       fitPsychometric("all", all),
+		NoTrap:    fitPsychometric("noTrap", noTrap),
+		Trap:      fitPsychometric("trap", trap),
+	}, nil
+}
+
+// GetCountComparisonThresholdTrend returns the fitted discrimination thresholds of every
+// Count Comparison session, oldest first. Thresholds are 0 where a group could not be fitted.
+func GetCountComparisonThresholdTrend(db *sql.DB) ([]types.CountComparisonThresholdPoint, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.play_datetime FROM game_sessions s
+		WHERE s.game_code = ? AND `+counted+`
+		ORDER BY s.play_datetime ASC, s.id ASC`, types.GameCodeCountComparison)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query count comparison sessions: %w", err)
+	}
+
+	var points []types.CountComparisonThresholdPoint
+	for rows.Next() {
+		var p types.CountComparisonThresholdPoint
+		if err := rows.Scan(&p.SessionID, &p.PlayDatetime); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan count comparison session: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	rows.Close()
+
+	trend := make([]types.CountComparisonThresholdPoint, 0, len(points))
+	for _, p := range points {
+		results, err := GetCountComparisonResultsForSession(db, p.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 0 {
+			continue
+		}
+
+		all, noTrap, trap, err := splitRatioTrials(results)
+		if err != nil {
+			return nil, err
+		}
+
+		allFit := fitPsychometric("all", all)
+		p.TotalQuestions = len(results)
+		p.Fitted = allFit.Fitted
+		p.Threshold = allFit.Threshold
+		if fit := fitPsychometric("noTrap", noTrap); fit.Fitted {
+			p.ThresholdNoTrap = fit.Threshold
+		}
+		if fit := fitPsychometric("trap", trap); fit.Fitted {
+			p.ThresholdWithTrap = fit.Threshold
+		}
+		trend = append(trend, p)
+	}
+
+	return trend, nil
+}
+
+// splitRatioTrials turns results into ratio trials, split by whether any trap was applied.
+// Results without a recorded ratio are skipped.
+func splitRatioTrials(results []types.CountComparisonResult) (all, noTrap, trap []ratioTrial, err error) {
+	for _, r := range results {
+		if r.Ratio <= 1 {
+			continue
+		}
+
+		var appliedTraps []types.AppliedTrap
+		if r.AppliedTraps != "" {
+			if err := json.Unmarshal([]byte(r.AppliedTraps), &appliedTraps); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to unmarshal applied traps for result ID %d: %w", r.ID, err)
+			}
+		}
+
+		t := ratioTrial{Ratio: r.Ratio, IsCorrect: r.IsCorrect}
+		all = append(all, t)
+		if len(appliedTraps) == 0 {
+			noTrap = append(noTrap, t)
+		} else {
+			trap = append(trap, t)
+		}
+	}
+	return all, noTrap, trap, nil
+}
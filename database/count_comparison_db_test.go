@@ -210,3 +210,96 @@ func TestGetCountComparisonSessionStats(t *testing.T) {
 	assert.InEpsilon(t, 100.0, noTrapStat.Accuracy, 0.01)
 	assert.InEpsilon(t, 150.0, noTrapStat.AverageResponseTime, 0.01)
 }
+
+func TestGetCountComparisonPsychometrics(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	settings := types.CountComparisonSettings{NumProblems: 60, GenerationMode: types.CountGenerationRatio}
+	sessionID, err := CreateGameSession(db, types.GameCodeCountComparison, settings)
+	assert.NoError(t, err)
+
+	noTrapJSON, _ := json.Marshal([]types.AppliedTrap{})
+	trapJSON, _ := json.Marshal([]types.AppliedTrap{{Type: "FontSize", AppliedTo: "left"}})
+
+	// Easy ratios are always right, hard ratios are right half of the time.
+	// Trap problems are one step harder than the same ratio without traps.
+	problem := 1
+	save := func(ratio float64, correct bool, traps []byte) {
+		SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: problem, IsCorrect: correct, ResponseTimeMs: 500, AppliedTraps: string(traps), Ratio: ratio})
+		problem++
+	}
+	for i := 0; i < 10; i++ {
+		save(1.5, true, noTrapJSON)
+		save(1.25, true, noTrapJSON)
+		save(1.1, i%2 == 0, noTrapJSON)
+		save(1.5, true, trapJSON)
+		save(1.25, i%2 == 0, trapJSON)
+		save(1.1, i%2 == 0, trapJSON)
+	}
+
+	psy, err := GetCountComparisonPsychometrics(db, sessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, psy.All.TotalQuestions)
+	assert.Len(t, psy.All.Points, 3)
+	assert.True(t, psy.All.Fitted)
+	assert.True(t, psy.NoTrap.Fitted)
+	assert.True(t, psy.Trap.Fitted)
+
+	// 75% correct lies between 1.1 (50%) and 1.25 (100%) without traps,
+	// and between 1.25 (50%) and 1.5 (100%) with traps.
+	assert.Greater(t, psy.NoTrap.ThresholdRatio, 1.1)
+	assert.Less(t, psy.NoTrap.ThresholdRatio, 1.25)
+	assert.Greater(t, psy.Trap.ThresholdRatio, 1.25)
+	assert.Less(t, psy.Trap.ThresholdRatio, 1.5)
+	assert.InDelta(t, psy.NoTrap.ThresholdRatio-1, psy.NoTrap.Threshold, 0.0001)
+
+	trend, err := GetCountComparisonThresholdTrend(db)
+	assert.NoError(t, err)
+	assert.Len(t, trend, 1)
+	assert.Equal(t, sessionID, trend[0].SessionID)
+	assert.Equal(t, psy.All.Threshold, trend[0].Threshold)
+	assert.Equal(t, psy.Trap.Threshold, trend[0].ThresholdWithTrap)
+}
+
+func TestGetCountComparisonPsychometrics_TooFewTrials(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeCountComparison, types.CountComparisonSettings{NumProblems: 2})
+	assert.NoError(t, err)
+	SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: 1, IsCorrect: true, Ratio: 1.5})
+	SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: 2, IsCorrect: false, Ratio: 1.1})
+
+	psy, err := GetCountComparisonPsychometrics(db, sessionID)
+	assert.NoError(t, err)
+	assert.False(t, psy.All.Fitted)
+	assert.Len(t, psy.All.Points, 2)
+}
+
+func TestGetCountComparisonWordListStats(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeCountComparison, types.CountComparisonSettings{NumProblems: 3})
+	assert.NoError(t, err)
+
+	SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: 1, IsCorrect: true, ResponseTimeMs: 100})
+	SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: 2, IsCorrect: false, ResponseTimeMs: 300, WordListID: 4, WordListName: "english"})
+	SaveCountComparisonResult(db, types.CountComparisonResult{SessionID: sessionID, ProblemNumber: 3, IsCorrect: true, ResponseTimeMs: 500, WordListID: 4, WordListName: "english"})
+
+	stats, err := GetCountComparisonWordListStats(db)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+
+	assert.Equal(t, types.BuiltinWordListName, stats[0].WordListName)
+	assert.Equal(t, 1, stats[0].TotalQuestions)
+	assert.InEpsilon(t, 100.0, stats[0].Accuracy, 0.01)
+
+	assert.Equal(t, int64(4), stats[1].WordListID)
+	assert.Equal(t, "english", stats[1].WordListName)
+	assert.Equal(t, 1, stats[1].TotalSessions)
+	assert.Equal(t, 2, stats[1].TotalQuestions)
+	assert.InEpsilon(t, 50.0, stats[1].Accuracy, 0.01)
+	assert.InEpsilon(t, 400.0, stats[1].AverageResponseTimeMs, 0.01)
+}
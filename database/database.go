@@ -4,47 +4,254 @@ import (
 	"encoding/json"
 	"database/sql"
 	_ "embed"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
 	_ "github.com/mattn/go-sqlite3"
 )
 
 //go:embed schema.sql
 var schemaSQL string
 
-// NewDatabase opens a database connection at the given path and initializes the schema.
+// columnMigration describes a column that was added to a table after it first shipped.
+// CREATE TABLE IF NOT EXISTS leaves older database files untouched, so these columns
+// are added on startup. Backfill, if set, runs once right after the column is added.
+type columnMigration struct {
+	Table      string
+	Column     string
+	Definition string
+	Backfill   string
+}
+
+// SchemaVersion is stored in the database file as its user_version. Raise it whenever
+// schema.sql or columnMigrations change, so that a backup made by a newer version of the
+// app is not restored into an older one that cannot read it.
+const SchemaVersion = 1
+
+var columnMigrations = []columnMigration{
+	{
+		Table:      "count_comparison_results",
+		Column:     "ratio",
+		Definition: "REAL NOT NULL DEFAULT 0",
+		Backfill: `UPDATE count_comparison_results
+			SET ratio = CAST(MAX(left_word_count, right_word_count) AS REAL) / MIN(left_word_count, right_word_count)
+			WHERE MIN(left_word_count, right_word_count) > 0`,
+	},
+	{Table: "count_comparison_results", Column: "word_list_id", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	{Table: "count_comparison_results", Column: "word_list_name", Definition: "TEXT NOT NULL DEFAULT ''"},
+	{Table: "count_comparison_results", Column: "layout", Definition: "TEXT NOT NULL DEFAULT ''"},
+	{Table: "cat_chaser_results", Column: "frame_schedule", Definition: "TEXT NOT NULL DEFAULT ''"},
+	{Table: "number_pressing_results_r1", Column: "player_number", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	{Table: "number_pressing_results_r2", Column: "missing_double_click_errors", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	{Table: "number_pressing_results_r2", Column: "pressed_skipped_errors", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	{Table: "number_pressing_results_r2", Column: "order_slip_errors", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	{Table: "number_pressing_results_r2", Column: "extra_press_errors", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	// Results saved before rule families existed all used double-click and skip.
+	{Table: "number_pressing_results_r2", Column: "rule", Definition: "TEXT NOT NULL DEFAULT 'doubleSkip'"},
+	{Table: "number_pressing_results_r2", Column: "reverse", Definition: "BOOLEAN NOT NULL DEFAULT 0"},
+	{Table: "number_pressing_results_r2", Column: "repeat_numbers", Definition: "TEXT NOT NULL DEFAULT '[]'"},
+	{Table: "number_pressing_results_r2", Column: "repeat_count", Definition: "INTEGER NOT NULL DEFAULT 0"},
+	// Round 1 always showed the keys in order before layouts were stored; Round 2 was
+	// shuffled on screen without keeping the order, so its old layouts stay unknown.
+	{
+		Table:      "number_pressing_results_r1",
+		Column:     "layout",
+		Definition: "TEXT NOT NULL DEFAULT ''",
+		Backfill:   "UPDATE number_pressing_results_r1 SET layout = '[1,2,3,4,5,6,7,8,9]' WHERE layout = ''",
+	},
+	{Table: "number_pressing_results_r2", Column: "layout", Definition: "TEXT NOT NULL DEFAULT ''"},
+	{Table: "game_sessions", Column: "battery_session_id", Definition: "INTEGER"},
+	{Table: "game_sessions", Column: "battery_step", Definition: "INTEGER"},
+	{Table: "game_sessions", Column: "notes", Definition: "TEXT NOT NULL DEFAULT ''"},
+	{Table: "game_sessions", Column: "deleted_at", Definition: "TEXT"},
+	// Sessions saved before statuses existed were never closed; they all counted as played.
+	{
+		Table:      "game_sessions",
+		Column:     "status",
+		Definition: "TEXT NOT NULL DEFAULT 'in_progress'",
+		Backfill:   "UPDATE game_sessions SET status = 'completed'",
+	},
+	{Table: "game_sessions", Column: "ended_at", Definition: "TEXT"},
+	{Table: "game_sessions", Column: "expected_trials", Definition: "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// Options controls how a database connection is set up.
+type Options struct {
+	// JournalMode is the SQLite journal mode, such as "WAL". Empty keeps SQLite's default.
+	JournalMode string
+	// ForeignKeys turns on foreign key enforcement, so ON DELETE CASCADE takes effect.
+	ForeignKeys bool
+	// BusyTimeout is how long a statement waits for a lock held by another connection
+	// before failing with "database is locked".
+	BusyTimeout time.Duration
+	// MaxOpenConns caps the connection pool. SQLite allows one writer at a time, so a
+	// single connection keeps writers from the async savers queued in Go rather than
+	// failing on a lock. 0 leaves the pool unbounded.
+	MaxOpenConns int
+}
+
+// DefaultOptions returns the settings the app runs with.
+func DefaultOptions() Options {
+	return Options{
+		JournalMode:  "WAL",
+		ForeignKeys:  true,
+		BusyTimeout:  5 * time.Second,
+		MaxOpenConns: 1,
+	}
+}
+
+// NewDatabase opens a database connection at the given path with the default options
+// and initializes the schema.
 func NewDatabase(path string) (*sql.DB, error) {
+	return OpenDatabase(path, DefaultOptions())
+}
+
+// OpenDatabase opens a database connection at the given path with the given options and
+// initializes the schema.
+func OpenDatabase(path string, opts Options) (*sql.DB, error) {
 	// Open the database file. It will be created if it doesn't exist.
-	db, err := sql.Open("sqlite3", path)
+	db, err := sql.Open("sqlite3", dsn(path, opts))
 	if err != nil {
 		return nil, err
 	}
+	if opts.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(opts.MaxOpenConns)
+	}
 
-	// Execute the schema script to create tables
-	_, err = db.Exec(schemaSQL)
-	if err != nil {
+	if err := initSchema(db); err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
 
+// initSchema creates any missing tables and columns and records the schema version.
+func initSchema(db *sql.DB) error {
+	// Execute the schema script to create tables
+	if _, err := db.Exec(schemaSQL); err != nil {
+		return err
+	}
+
+	if err := migrateColumns(db, columnMigrations); err != nil {
+		return err
+	}
+
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version < SchemaVersion {
+		if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion)); err != nil {
+			return fmt.Errorf("failed to set schema version: %w", err)
+		}
+	}
+	return nil
+}
+
+// schemaVersion reads the schema version recorded in a database, 0 if none is.
+func schemaVersion(db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// dsn builds the connection string for the sqlite3 driver. The driver applies these
+// settings to every connection it opens, which a PRAGMA run once would not.
+func dsn(path string, opts Options) string {
+	params := url.Values{}
+	if opts.JournalMode != "" {
+		params.Set("_journal_mode", opts.JournalMode)
+	}
+	if opts.ForeignKeys {
+		params.Set("_foreign_keys", "on")
+	}
+	if opts.BusyTimeout > 0 {
+		params.Set("_busy_timeout", strconv.FormatInt(opts.BusyTimeout.Milliseconds(), 10))
+	}
+	// Take the write lock when a transaction begins, so two transactions that read and
+	// then write cannot deadlock on upgrading their locks.
+	params.Set("_txlock", "immediate")
+	return path + "?" + params.Encode()
+}
+
+// migrateColumns adds any missing columns from the given list.
+func migrateColumns(db *sql.DB, migrations []columnMigration) error {
+	for _, m := range migrations {
+		exists, err := columnExists(db, m.Table, m.Column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s", m.Table, m.Column, m.Definition)); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", m.Table, m.Column, err)
+		}
+		if m.Backfill != "" {
+			if _, err := db.Exec(m.Backfill); err != nil {
+				return fmt.Errorf("failed to backfill column %s.%s: %w", m.Table, m.Column, err)
+			}
+		}
+	}
+	return nil
+}
+
+// columnExists reports whether the table has a column with the given name.
+func columnExists(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(`%s`)", table))
+	if err != nil {
+		return false, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return false, fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
 // CreateGameSession creates a new game session and returns the session ID.
 func CreateGameSession(db *sql.DB, gameCode string, settings interface{}) (int64, error) {
+	return StartGameSession(db, gameCode, settings, 0)
+}
+
+// StartGameSession creates a new in-progress game session that expects the given number
+// of trials, 0 if unknown, and returns the session ID.
+func StartGameSession(db *sql.DB, gameCode string, settings interface{}, expectedTrials int) (int64, error) {
 	settingsJSON, err := json.Marshal(settings)
 	if err != nil {
 		return 0, err
 	}
 
-	stmt, err := db.Prepare("INSERT INTO game_sessions (game_code, settings) VALUES (?, ?)")
+	stmt, err := db.Prepare("INSERT INTO game_sessions (game_code, settings, expected_trials) VALUES (?, ?, ?)")
 	if err != nil {
 		return 0, err
 	}
 	defer stmt.Close()
 
-	res, err := stmt.Exec(gameCode, string(settingsJSON))
+	res, err := stmt.Exec(gameCode, string(settingsJSON), expectedTrials)
 	if err != nil {
 		return 0, err
 	}
 
 	return res.LastInsertId()
 }
-
@@ -37,13 +37,29 @@ func TestDatabaseInitialization(t *testing.T) {
 	}
 
 	expectedTables := []string{
+		"battery_sessions",
+		"cat_chaser_results",
 		"count_comparison_results",
 		"game_sessions",
+		"goals",
 		"nback_results",
 		"number_pressing_results_r1",
 		"number_pressing_results_r2",
+		"personal_records",
+		"position_memory_results",
+		"preferences",
+		"road_results",
 		"rps_results",
+		"scheduling_results",
+		"score_norms",
+		"session_states",
+		"session_tags",
 		"shape_rotation_results",
+		"stroop_results",
+		"tower_results",
+		"weight_results",
+		"word_list_pairs",
+		"word_lists",
 	}
 
 	// Sort for consistent comparison
@@ -98,3 +114,44 @@ func TestCreateGameSession(t *testing.T) {
 		t.Errorf("Expected settings %v, but got %v", settings, retrievedSettingsStruct)
 	}
 }
+
+func TestMigrateColumns(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	// Simulate a database file created before the ratio column existed.
+	if _, err := db.Exec("DROP TABLE count_comparison_results"); err != nil {
+		t.Fatalf("Failed to drop table: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE count_comparison_results (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, session_id INTEGER NOT NULL, problem_number INTEGER NOT NULL,
+		is_correct BOOLEAN NOT NULL, response_time_ms INTEGER NOT NULL, player_choice TEXT, correct_choice TEXT NOT NULL,
+		left_word TEXT NOT NULL, right_word TEXT NOT NULL, left_word_count INTEGER NOT NULL, right_word_count INTEGER NOT NULL,
+		applied_traps TEXT)`); err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO count_comparison_results
+		(session_id, problem_number, is_correct, response_time_ms, correct_choice, left_word, right_word, left_word_count, right_word_count)
+		VALUES (1, 1, 1, 500, 'left', 'a', 'b', 12, 10)`); err != nil {
+		t.Fatalf("Failed to insert legacy row: %v", err)
+	}
+
+	if err := migrateColumns(db, columnMigrations); err != nil {
+		t.Fatalf("migrateColumns failed: %v", err)
+	}
+	// Running it again must be a no-op.
+	if err := migrateColumns(db, columnMigrations); err != nil {
+		t.Fatalf("second migrateColumns failed: %v", err)
+	}
+
+	var ratio float64
+	if err := db.QueryRow("SELECT ratio FROM count_comparison_results").Scan(&ratio); err != nil {
+		t.Fatalf("Failed to read migrated column: %v", err)
+	}
+	if ratio != 1.2 {
+		t.Errorf("Expected backfilled ratio 1.2, got %v", ratio)
+	}
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"acca-games/types"
+)
+
+// ErrCorrupt is returned when a database file fails its integrity check or is not a
+// database at all.
+var ErrCorrupt = errors.New("database is corrupt")
+
+// CheckIntegrity runs SQLite's integrity check and returns ErrCorrupt, with the problems
+// found, if it reports any.
+func CheckIntegrity(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		if isCorruptError(err) {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		return fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("failed to scan integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		if isCorruptError(err) {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		return fmt.Errorf("error after scanning rows: %w", err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCorrupt, problems[0])
+	}
+	return nil
+}
+
+// isCorruptError reports whether err is SQLite saying the file is damaged or is not a
+// database.
+func isCorruptError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB
+	}
+	return errors.Is(err, ErrCorrupt)
+}
+
+// OpenOrRecover opens the database at path and checks its integrity. A file that is
+// corrupt is moved aside, together with its WAL and shared memory files, and a fresh
+// database is created in its place. The returned recovery is nil unless that happened.
+// Other errors, such as a directory that cannot be written, are returned as they are.
+func OpenOrRecover(path string, opts Options) (*sql.DB, *types.DatabaseRecovery, error) {
+	db, err := OpenDatabase(path, opts)
+	if err == nil {
+		err = CheckIntegrity(db)
+		if err != nil {
+			db.Close()
+		}
+	}
+	if err == nil {
+		return db, nil, nil
+	}
+	if !isCorruptError(err) {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	movedTo := fmt.Sprintf("%s.corrupt-%s", path, now.Format("20060102-150405"))
+	for _, suffix := range fileSuffixes {
+		if err := os.Rename(path+suffix, movedTo+suffix); err != nil && !os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("failed to move corrupt database aside: %w", err)
+		}
+	}
+
+	db, openErr := OpenDatabase(path, opts)
+	if openErr != nil {
+		return nil, nil, fmt.Errorf("failed to create a new database after moving the corrupt one to %s: %w", movedTo, openErr)
+	}
+	recovery := &types.DatabaseRecovery{
+		MovedTo:     movedTo,
+		Problem:     err.Error(),
+		RecoveredAt: types.CustomTime{Time: now},
+	}
+	return db, recovery, nil
+}
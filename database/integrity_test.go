@@ -0,0 +1,100 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestOpenDatabase_Options(t *testing.T) {
+	db, err := NewDatabase(filepath.Join(t.TempDir(), "games.db"))
+	if err != nil {
+		t.Fatalf("NewDatabase failed: %v", err)
+	}
+	defer db.Close()
+
+	var journalMode string
+	var foreignKeys, busyTimeout int
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatalf("Failed to read journal mode: %v", err)
+	}
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		t.Fatalf("Failed to read foreign keys: %v", err)
+	}
+	if err := db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatalf("Failed to read busy timeout: %v", err)
+	}
+	if journalMode != "wal" || foreignKeys != 1 || busyTimeout != 5000 {
+		t.Errorf("Expected wal, foreign keys on and a 5000ms busy timeout, got %s, %d, %d", journalMode, foreignKeys, busyTimeout)
+	}
+	if stats := db.Stats(); stats.MaxOpenConnections != 1 {
+		t.Errorf("Expected a single connection, got %d", stats.MaxOpenConnections)
+	}
+
+	err = SaveShapeRotationResult(db, types.ShapeRotationResult{SessionID: 999, ProblemID: 1})
+	if err == nil {
+		t.Error("Expected a result for a missing session to break the foreign key")
+	}
+}
+
+func TestOpenOrRecover(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "games.db")
+
+	db, recovery, err := OpenOrRecover(path, DefaultOptions())
+	if err != nil {
+		t.Fatalf("OpenOrRecover failed: %v", err)
+	}
+	if recovery != nil {
+		t.Errorf("Expected a new database to need no recovery, got %+v", recovery)
+	}
+	if err := CheckIntegrity(db); err != nil {
+		t.Errorf("Expected a new database to pass the integrity check, got %v", err)
+	}
+	db.Close()
+
+	garbage := []byte("this is not a database, just some bytes where one should be")
+	if err := os.WriteFile(path, garbage, 0644); err != nil {
+		t.Fatalf("Failed to write garbage: %v", err)
+	}
+	os.Remove(path + "-wal")
+	os.Remove(path + "-shm")
+
+	if _, err := OpenDatabase(path, DefaultOptions()); !isCorruptError(err) {
+		t.Fatalf("Expected opening garbage to fail as corrupt, got %v", err)
+	}
+
+	db, recovery, err = OpenOrRecover(path, DefaultOptions())
+	if err != nil {
+		t.Fatalf("OpenOrRecover failed: %v", err)
+	}
+	defer db.Close()
+	if recovery == nil {
+		t.Fatal("Expected the garbage file to be recovered")
+	}
+	if filepath.Dir(recovery.MovedTo) != dir || recovery.Problem == "" {
+		t.Errorf("Unexpected recovery %+v", recovery)
+	}
+	moved, err := os.ReadFile(recovery.MovedTo)
+	if err != nil {
+		t.Fatalf("Failed to read the moved file: %v", err)
+	}
+	if string(moved) != string(garbage) {
+		t.Error("Expected the corrupt file to be kept as it was")
+	}
+	if _, err := CreateGameSession(db, types.GameCodeTower, types.TowerSettings{}); err != nil {
+		t.Errorf("Expected the new database to work, got %v", err)
+	}
+}
+
+func TestIsCorruptError(t *testing.T) {
+	if !isCorruptError(ErrCorrupt) {
+		t.Error("ErrCorrupt should count as corrupt")
+	}
+	if isCorruptError(errors.New("disk full")) {
+		t.Error("Other errors should not count as corrupt")
+	}
+}
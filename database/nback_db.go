@@ -57,13 +57,13 @@ func GetNBackResultsForSession(db *sql.DB, sessionID int64) ([]types.NBackResult
 }
 
 // GetPaginatedNBackSessionsWithResults fetches paginated sessions with their results.
-func GetPaginatedNBackSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedNBackSessions, error) {
+func GetPaginatedNBackSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedNBackSessions, error) {
 	offset := (page - 1) * limit
 
 	// First, get the total count of sessions
+	where, args := historyFilter(types.GameCodeNBack, tags)
 	var totalCount int
-
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeNBack).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total n-back session count: %w", err)
@@ -78,14 +78,14 @@ func GetPaginatedNBackSessionsWithResults(db *sql.DB, page int, limit int) (*typ
 		JOIN nback_results r ON s.id = r.session_id
 		WHERE s.id IN (
 			SELECT id FROM game_sessions
-			WHERE game_code = ?
+			WHERE `+where+`
 			ORDER BY play_datetime DESC
 			LIMIT ? OFFSET ?
 		)
 		ORDER BY s.play_datetime DESC, r.question_num ASC
 	`
 
-	rows, err := db.Query(query, types.GameCodeNBack, limit, offset)
+	rows, err := db.Query(query, pageArgs(args, limit, offset)...)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query paginated n-back sessions with results: %w", err)
@@ -126,6 +126,9 @@ func GetPaginatedNBackSessionsWithResults(db *sql.DB, page int, limit int) (*typ
 	sessions := make([]types.NBackSessionWithResults, len(sessionOrder))
 	for i, id := range sessionOrder {
 		sessions[i] = *sessionMap[id]
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
 	}
 
 	return &types.PaginatedNBackSessions{
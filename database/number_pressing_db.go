@@ -8,25 +8,83 @@ import (
 	"strings"
 )
 
+// numberPressingR1Columns are the columns read by scanNumberPressingResultR1, in order.
+const numberPressingR1Columns = "session_id, target_number, player_number, time_taken, is_correct, layout"
+
+// numberPressingR2Columns are the columns read by scanNumberPressingResultR2, in order.
+const numberPressingR2Columns = `session_id, rule, double_click_numbers, skip_numbers, reverse, repeat_numbers, repeat_count,
+	player_clicks, correct_clicks, time_taken, is_correct,
+	missing_double_click_errors, pressed_skipped_errors, order_slip_errors, extra_press_errors, layout`
+
 func SaveNumberPressingResultR1(db *sql.DB, result types.NumberPressingResultR1) error {
-	_, err := db.Exec("INSERT INTO number_pressing_results_r1 (session_id, target_number, time_taken, is_correct) VALUES (?, ?, ?, ?)",
-		result.SessionID, result.Problem.TargetNumber, result.TimeTaken, result.IsCorrect)
+	_, err := db.Exec("INSERT INTO number_pressing_results_r1 ("+numberPressingR1Columns+") VALUES (?, ?, ?, ?, ?, ?)",
+		result.SessionID, result.Problem.TargetNumber, result.PlayerNumber, result.TimeTaken, result.IsCorrect, marshalLayout(result.Problem.Layout))
 	return err
 }
 
+// marshalLayout stores a keypad layout as a JSON array, or as an empty string when unknown.
+func marshalLayout(layout []int) string {
+	if len(layout) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(layout)
+	return string(data)
+}
+
+// scanNumberPressingResultR1 reads a row selected with numberPressingR1Columns.
+func scanNumberPressingResultR1(rows *sql.Rows) (types.NumberPressingResultR1, error) {
+	var res types.NumberPressingResultR1
+	var layoutJSON string
+	if err := rows.Scan(&res.SessionID, &res.Problem.TargetNumber, &res.PlayerNumber, &res.TimeTaken, &res.IsCorrect, &layoutJSON); err != nil {
+		return res, err
+	}
+	if layoutJSON != "" {
+		json.Unmarshal([]byte(layoutJSON), &res.Problem.Layout)
+	}
+	return res, nil
+}
+
 func SaveNumberPressingResultR2(db *sql.DB, result types.NumberPressingResultR2) error {
 	doubleClickJSON, _ := json.Marshal(result.Problem.DoubleClick)
 	skipJSON, _ := json.Marshal(result.Problem.Skip)
+	repeatJSON, _ := json.Marshal(result.Problem.Repeat)
 	playerClicksJSON, _ := json.Marshal(result.PlayerClicks)
 	correctClicksJSON, _ := json.Marshal(result.CorrectClicks)
 
-	_, err := db.Exec("INSERT INTO number_pressing_results_r2 (session_id, double_click_numbers, skip_numbers, player_clicks, correct_clicks, time_taken, is_correct) VALUES (?, ?, ?, ?, ?, ?, ?)",
-		result.SessionID, string(doubleClickJSON), string(skipJSON), string(playerClicksJSON), string(correctClicksJSON), result.TimeTaken, result.IsCorrect)
+	rule := result.Problem.Rule
+	if rule == "" {
+		rule = types.NumberPressingRuleDoubleSkip
+	}
+
+	_, err := db.Exec("INSERT INTO number_pressing_results_r2 ("+numberPressingR2Columns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		result.SessionID, rule, string(doubleClickJSON), string(skipJSON), result.Problem.Reverse, string(repeatJSON), result.Problem.RepeatCount,
+		string(playerClicksJSON), string(correctClicksJSON), result.TimeTaken, result.IsCorrect,
+		result.Errors.MissingDoubleClick, result.Errors.PressedSkipped, result.Errors.OrderSlip, result.Errors.ExtraPress, marshalLayout(result.Problem.Layout))
 	return err
 }
 
+// scanNumberPressingResultR2 reads a row selected with numberPressingR2Columns.
+func scanNumberPressingResultR2(rows *sql.Rows) (types.NumberPressingResultR2, error) {
+	var res types.NumberPressingResultR2
+	var doubleClickJSON, skipJSON, repeatJSON, playerClicksJSON, correctClicksJSON, layoutJSON string
+	if err := rows.Scan(&res.SessionID, &res.Problem.Rule, &doubleClickJSON, &skipJSON, &res.Problem.Reverse, &repeatJSON, &res.Problem.RepeatCount,
+		&playerClicksJSON, &correctClicksJSON, &res.TimeTaken, &res.IsCorrect,
+		&res.Errors.MissingDoubleClick, &res.Errors.PressedSkipped, &res.Errors.OrderSlip, &res.Errors.ExtraPress, &layoutJSON); err != nil {
+		return res, err
+	}
+	json.Unmarshal([]byte(doubleClickJSON), &res.Problem.DoubleClick)
+	json.Unmarshal([]byte(skipJSON), &res.Problem.Skip)
+	json.Unmarshal([]byte(repeatJSON), &res.Problem.Repeat)
+	json.Unmarshal([]byte(playerClicksJSON), &res.PlayerClicks)
+	json.Unmarshal([]byte(correctClicksJSON), &res.CorrectClicks)
+	if layoutJSON != "" {
+		json.Unmarshal([]byte(layoutJSON), &res.Problem.Layout)
+	}
+	return res, nil
+}
+
 func GetNumberPressingResultsForSession(db *sql.DB, sessionID int64) (*types.NumberPressingResultsBundle, error) {
-	rowsR1, err := db.Query("SELECT session_id, target_number, time_taken, is_correct FROM number_pressing_results_r1 WHERE session_id = ?", sessionID)
+	rowsR1, err := db.Query("SELECT "+numberPressingR1Columns+" FROM number_pressing_results_r1 WHERE session_id = ?", sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -34,14 +92,14 @@ func GetNumberPressingResultsForSession(db *sql.DB, sessionID int64) (*types.Num
 
 	resultsR1 := make([]types.NumberPressingResultR1, 0)
 	for rowsR1.Next() {
-		var res types.NumberPressingResultR1
-		if err := rowsR1.Scan(&res.SessionID, &res.Problem.TargetNumber, &res.TimeTaken, &res.IsCorrect); err != nil {
+		res, err := scanNumberPressingResultR1(rowsR1)
+		if err != nil {
 			return nil, err
 		}
 		resultsR1 = append(resultsR1, res)
 	}
 
-	rowsR2, err := db.Query("SELECT session_id, double_click_numbers, skip_numbers, player_clicks, correct_clicks, time_taken, is_correct FROM number_pressing_results_r2 WHERE session_id = ?", sessionID)
+	rowsR2, err := db.Query("SELECT "+numberPressingR2Columns+" FROM number_pressing_results_r2 WHERE session_id = ?", sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -49,15 +107,10 @@ func GetNumberPressingResultsForSession(db *sql.DB, sessionID int64) (*types.Num
 
 	resultsR2 := make([]types.NumberPressingResultR2, 0)
 	for rowsR2.Next() {
-		var res types.NumberPressingResultR2
-		var doubleClickJSON, skipJSON, playerClicksJSON, correctClicksJSON string
-		if err := rowsR2.Scan(&res.SessionID, &doubleClickJSON, &skipJSON, &playerClicksJSON, &correctClicksJSON, &res.TimeTaken, &res.IsCorrect); err != nil {
+		res, err := scanNumberPressingResultR2(rowsR2)
+		if err != nil {
 			return nil, err
 		}
-		json.Unmarshal([]byte(doubleClickJSON), &res.Problem.DoubleClick)
-		json.Unmarshal([]byte(skipJSON), &res.Problem.Skip)
-		json.Unmarshal([]byte(playerClicksJSON), &res.PlayerClicks)
-		json.Unmarshal([]byte(correctClicksJSON), &res.CorrectClicks)
 		resultsR2 = append(resultsR2, res)
 	}
 
@@ -65,19 +118,20 @@ func GetNumberPressingResultsForSession(db *sql.DB, sessionID int64) (*types.Num
 }
 
 // GetPaginatedNumberPressingSessionsWithResults fetches paginated sessions with their results.
-func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedNumberPressingSessions, error) {
+func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedNumberPressingSessions, error) {
 
 	offset := (page - 1) * limit
 	
 	// 1. Get the total count of sessions
+	where, args := historyFilter(types.GameCodeNumberPressing, tags)
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeNumberPressing).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total number pressing session count: %w", err)
 	}
 
 	// 2. Get a page of session IDs
-	rows, err := db.Query("SELECT id FROM game_sessions WHERE game_code = ? ORDER BY play_datetime DESC LIMIT ? OFFSET ?", types.GameCodeNumberPressing, limit, offset)
+	rows, err := db.Query("SELECT id FROM game_sessions WHERE "+where+" ORDER BY play_datetime DESC LIMIT ? OFFSET ?", pageArgs(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query for paginated session IDs: %w", err)
 	}
@@ -131,7 +185,7 @@ func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit i
 	}
 
 	// 4. Fetch R1 results for these session IDs
-	r1Query := "SELECT session_id, target_number, time_taken, is_correct FROM number_pressing_results_r1 WHERE session_id IN (?" + strings.Repeat(",?", len(sessionIDs)-1) + ")"
+	r1Query := "SELECT "+numberPressingR1Columns+" FROM number_pressing_results_r1 WHERE session_id IN (?" + strings.Repeat(",?", len(sessionIDs)-1) + ")"
 	rowsR1, err := db.Query(r1Query, sessionIDs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query R1 results: %w", err)
@@ -139,8 +193,8 @@ func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit i
 	defer rowsR1.Close()
 
 	for rowsR1.Next() {
-		var res types.NumberPressingResultR1
-		if err := rowsR1.Scan(&res.SessionID, &res.Problem.TargetNumber, &res.TimeTaken, &res.IsCorrect); err != nil {
+		res, err := scanNumberPressingResultR1(rowsR1)
+		if err != nil {
 			return nil, err
 		}
 		if session, ok := sessionMap[res.SessionID]; ok {
@@ -153,7 +207,7 @@ func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit i
 	}
 
 	// 5. Fetch R2 results for these session IDs
-	r2Query := "SELECT session_id, double_click_numbers, skip_numbers, player_clicks, correct_clicks, time_taken, is_correct FROM number_pressing_results_r2 WHERE session_id IN (?" + strings.Repeat(",?", len(sessionIDs)-1) + ")"
+	r2Query := "SELECT "+numberPressingR2Columns+" FROM number_pressing_results_r2 WHERE session_id IN (?" + strings.Repeat(",?", len(sessionIDs)-1) + ")"
 	rowsR2, err := db.Query(r2Query, sessionIDs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query R2 results: %w", err)
@@ -161,15 +215,10 @@ func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit i
 	defer rowsR2.Close()
 
 	for rowsR2.Next() {
-		var res types.NumberPressingResultR2
-		var doubleClickJSON, skipJSON, playerClicksJSON, correctClicksJSON string
-		if err := rowsR2.Scan(&res.SessionID, &doubleClickJSON, &skipJSON, &playerClicksJSON, &correctClicksJSON, &res.TimeTaken, &res.IsCorrect); err != nil {
+		res, err := scanNumberPressingResultR2(rowsR2)
+		if err != nil {
 			return nil, err
 		}
-		json.Unmarshal([]byte(doubleClickJSON), &res.Problem.DoubleClick)
-		json.Unmarshal([]byte(skipJSON), &res.Problem.Skip)
-		json.Unmarshal([]byte(playerClicksJSON), &res.PlayerClicks)
-		json.Unmarshal([]byte(correctClicksJSON), &res.CorrectClicks)
 		if session, ok := sessionMap[res.SessionID]; ok {
 			session.Results.ResultsR2 = append(session.Results.ResultsR2, res)
 		}
@@ -183,6 +232,9 @@ func GetPaginatedNumberPressingSessionsWithResults(db *sql.DB, page int, limit i
 	finalResults := make([]types.NumberPressingSessionWithResults, len(sessionIDOrder))
 	for i, id := range sessionIDOrder {
 		finalResults[i] = *sessionMap[id]
+		if err := loadSessionDetails(db, &finalResults[i].GameSession); err != nil {
+			return nil, err
+		}
 	}
 
 	return &types.PaginatedNumberPressingSessions{
@@ -220,6 +272,7 @@ func GetNumberPressingSessionStats(db *sql.DB, sessionID int64) (*types.NumberPr
 		r1Stats.Accuracy = float64(r1Stats.TotalCorrect) / float64(r1Stats.TotalQuestions) * 100
 		r1Stats.AverageTimeTakenSec = r1TotalTime / float64(r1Stats.TotalQuestions)
 	}
+	r1Stats.TargetStats, r1Stats.PositionStats = calculateNumberPressingSearchStats(bundle.ResultsR1)
 	if r1Stats.TotalQuestions > 0 {
 		stats.RoundStats = append(stats.RoundStats, r1Stats)
 	}
@@ -227,29 +280,19 @@ func GetNumberPressingSessionStats(db *sql.DB, sessionID int64) (*types.NumberPr
 	// --- Aggregate Round 2 Stats ---
 	r2Stats := types.NumberPressingRoundStats{Round: 2}
 	var r2TotalTime float64
+	var r2Errors types.NumberPressingErrorCounts
 	conditionStatsMap := make(map[string]*types.NumberPressingConditionStat)
 
 	for _, r := range bundle.ResultsR2 {
 		r2Stats.TotalQuestions++
 		r2TotalTime += r.TimeTaken
+		r2Errors.Add(r.Errors)
 		if r.IsCorrect {
 			r2Stats.TotalCorrect++
 		}
 
-		// Construct condition string for R2
-		var conditionParts []string
-		if len(r.Problem.DoubleClick) > 0 {
-			dc, _ := json.Marshal(r.Problem.DoubleClick)
-			conditionParts = append(conditionParts, fmt.Sprintf("DC:%s", string(dc)))
-		}
-		if len(r.Problem.Skip) > 0 {
-			s, _ := json.Marshal(r.Problem.Skip)
-			conditionParts = append(conditionParts, fmt.Sprintf("S:%s", string(s)))
-		}
-		conditionStr := strings.Join(conditionParts, ", ")
-		if conditionStr == "" {
-			conditionStr = "No Specific Condition" // Fallback for problems without special conditions
-		}
+		// Group Round 2 by rule family
+		conditionStr := r.Problem.Rule
 
 		if _, ok := conditionStatsMap[conditionStr]; !ok {
 			conditionStatsMap[conditionStr] = &types.NumberPressingConditionStat{
@@ -259,6 +302,7 @@ func GetNumberPressingSessionStats(db *sql.DB, sessionID int64) (*types.NumberPr
 		condStat := conditionStatsMap[conditionStr]
 		condStat.TotalQuestions++
 		condStat.AverageTimeTakenSec += r.TimeTaken
+		condStat.ErrorCounts.Add(r.Errors)
 		if r.IsCorrect {
 			condStat.TotalCorrect++
 		}
@@ -267,6 +311,7 @@ func GetNumberPressingSessionStats(db *sql.DB, sessionID int64) (*types.NumberPr
 	if r2Stats.TotalQuestions > 0 {
 		r2Stats.Accuracy = float64(r2Stats.TotalCorrect) / float64(r2Stats.TotalQuestions) * 100
 		r2Stats.AverageTimeTakenSec = r2TotalTime / float64(r2Stats.TotalQuestions)
+		r2Stats.ErrorCounts = &r2Errors
 
 		// Finalize condition stats
 		for _, condStat := range conditionStatsMap {
@@ -301,3 +346,48 @@ func GetNumberPressingSessionStats(db *sql.DB, sessionID int64) (*types.NumberPr
 	return stats, nil
 }
 
+
+// calculateNumberPressingSearchStats groups Round 1 answers by target number and by the
+// keypad position the target was shown at. Answers without a stored layout only count
+// towards the target number.
+func calculateNumberPressingSearchStats(results []types.NumberPressingResultR1) ([]types.NumberPressingSearchStat, []types.NumberPressingSearchStat) {
+	var byTarget, byPosition [10]types.NumberPressingSearchStat
+	add := func(stat *types.NumberPressingSearchStat, r types.NumberPressingResultR1) {
+		stat.TotalQuestions++
+		if r.IsCorrect {
+			stat.TotalCorrect++
+			stat.AverageSearchTimeSec += r.TimeTaken
+		}
+	}
+
+	for _, r := range results {
+		if r.Problem.TargetNumber < 1 || r.Problem.TargetNumber > 9 {
+			continue
+		}
+		add(&byTarget[r.Problem.TargetNumber], r)
+		for pos, n := range r.Problem.Layout {
+			if n == r.Problem.TargetNumber && pos < 9 {
+				add(&byPosition[pos], r)
+				break
+			}
+		}
+	}
+
+	finalize := func(stats []types.NumberPressingSearchStat, firstKey int) []types.NumberPressingSearchStat {
+		var out []types.NumberPressingSearchStat
+		for i, stat := range stats {
+			if stat.TotalQuestions == 0 {
+				continue
+			}
+			stat.Key = firstKey + i
+			stat.Accuracy = float64(stat.TotalCorrect) / float64(stat.TotalQuestions) * 100
+			if stat.TotalCorrect > 0 {
+				stat.AverageSearchTimeSec /= float64(stat.TotalCorrect)
+			}
+			out = append(out, stat)
+		}
+		return out
+	}
+
+	return finalize(byTarget[1:], 1), finalize(byPosition[:9], 0)
+}
@@ -103,3 +103,128 @@ func TestGetPaginatedNumberPressingSessionsWithResults(t *testing.T) {
 		t.Errorf("Expected 1 R2 result for session, got %d", len(paginatedResult.Sessions[0].Results.ResultsR2))
 	}
 }
+
+func TestGetNumberPressingSessionStatsErrorBreakdown(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, _ := CreateGameSession(db, types.GameCodeNumberPressing, "{}")
+	problem := types.NumberPressingProblemR2{DoubleClick: []int{3}, Skip: []int{6}}
+	results := []types.NumberPressingResultR2{
+		{SessionID: sessionID, Problem: problem, TimeTaken: 5, Errors: types.NumberPressingErrorCounts{MissingDoubleClick: 1}},
+		{SessionID: sessionID, Problem: problem, TimeTaken: 5, Errors: types.NumberPressingErrorCounts{PressedSkipped: 1, OrderSlip: 1}},
+		{SessionID: sessionID, Problem: problem, TimeTaken: 5, IsCorrect: true},
+	}
+	for _, r := range results {
+		if err := SaveNumberPressingResultR2(db, r); err != nil {
+			t.Fatalf("SaveNumberPressingResultR2 failed: %v", err)
+		}
+	}
+
+	stats, err := GetNumberPressingSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetNumberPressingSessionStats failed: %v", err)
+	}
+	if len(stats.RoundStats) != 1 || stats.RoundStats[0].ErrorCounts == nil {
+		t.Fatalf("Expected round 2 stats with error counts, got %+v", stats.RoundStats)
+	}
+
+	expected := types.NumberPressingErrorCounts{MissingDoubleClick: 1, PressedSkipped: 1, OrderSlip: 1}
+	if *stats.RoundStats[0].ErrorCounts != expected {
+		t.Errorf("Expected round error counts %+v, got %+v", expected, *stats.RoundStats[0].ErrorCounts)
+	}
+	if len(stats.RoundStats[0].ConditionStats) != 1 || stats.RoundStats[0].ConditionStats[0].ErrorCounts != expected {
+		t.Errorf("Expected condition error counts %+v, got %+v", expected, stats.RoundStats[0].ConditionStats)
+	}
+}
+
+func TestGetNumberPressingSessionStatsGroupsByRule(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, _ := CreateGameSession(db, types.GameCodeNumberPressing, "{}")
+	results := []types.NumberPressingResultR2{
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR2{Rule: types.NumberPressingRuleReverse, Reverse: true}, IsCorrect: true},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR2{Rule: types.NumberPressingRuleReverse, Reverse: true}},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR2{Rule: types.NumberPressingRulePressN, Repeat: []int{4}, RepeatCount: 3}, IsCorrect: true},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR2{DoubleClick: []int{1}}}, // Saved without a rule
+	}
+	for _, r := range results {
+		if err := SaveNumberPressingResultR2(db, r); err != nil {
+			t.Fatalf("SaveNumberPressingResultR2 failed: %v", err)
+		}
+	}
+
+	bundle, err := GetNumberPressingResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetNumberPressingResultsForSession failed: %v", err)
+	}
+	if p := bundle.ResultsR2[2].Problem; !reflect.DeepEqual(p.Repeat, []int{4}) || p.RepeatCount != 3 {
+		t.Errorf("Expected repeat rule to round-trip, got %+v", p)
+	}
+
+	stats, err := GetNumberPressingSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetNumberPressingSessionStats failed: %v", err)
+	}
+	conditions := stats.RoundStats[0].ConditionStats
+	expected := map[string][2]int{ // rule -> {questions, correct}
+		types.NumberPressingRuleDoubleSkip: {1, 0},
+		types.NumberPressingRulePressN:     {1, 1},
+		types.NumberPressingRuleReverse:    {2, 1},
+	}
+	if len(conditions) != len(expected) {
+		t.Fatalf("Expected %d rule groups, got %+v", len(expected), conditions)
+	}
+	for _, c := range conditions {
+		want := expected[c.ConditionType]
+		if c.TotalQuestions != want[0] || c.TotalCorrect != want[1] {
+			t.Errorf("Rule %s: expected %d/%d, got %d/%d", c.ConditionType, want[1], want[0], c.TotalCorrect, c.TotalQuestions)
+		}
+	}
+}
+
+func TestGetNumberPressingSessionStatsSearchTimes(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, _ := CreateGameSession(db, types.GameCodeNumberPressing, "{}")
+	scrambled := []int{9, 8, 7, 6, 5, 4, 3, 2, 1}
+	results := []types.NumberPressingResultR1{
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR1{TargetNumber: 9, Layout: scrambled}, TimeTaken: 1.0, IsCorrect: true},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR1{TargetNumber: 9, Layout: scrambled}, TimeTaken: 2.0, IsCorrect: true},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR1{TargetNumber: 9, Layout: scrambled}, TimeTaken: 5.0},
+		{SessionID: sessionID, Problem: types.NumberPressingProblemR1{TargetNumber: 1}, TimeTaken: 3.0, IsCorrect: true}, // No layout
+	}
+	for _, r := range results {
+		if err := SaveNumberPressingResultR1(db, r); err != nil {
+			t.Fatalf("SaveNumberPressingResultR1 failed: %v", err)
+		}
+	}
+
+	stats, err := GetNumberPressingSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetNumberPressingSessionStats failed: %v", err)
+	}
+	r1 := stats.RoundStats[0]
+
+	if len(r1.TargetStats) != 2 || r1.TargetStats[0].Key != 1 || r1.TargetStats[1].Key != 9 {
+		t.Fatalf("Expected target stats for 1 and 9, got %+v", r1.TargetStats)
+	}
+	if nine := r1.TargetStats[1]; nine.TotalQuestions != 3 || nine.TotalCorrect != 2 || nine.AverageSearchTimeSec != 1.5 {
+		t.Errorf("Unexpected stats for target 9: %+v", nine)
+	}
+
+	if len(r1.PositionStats) != 1 || r1.PositionStats[0].Key != 0 || r1.PositionStats[0].TotalQuestions != 3 {
+		t.Errorf("Expected only position 0 with 3 answers, got %+v", r1.PositionStats)
+	}
+}
@@ -0,0 +1,234 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SavePositionMemoryResult saves the result of a single Position Memory sequence.
+func SavePositionMemoryResult(db *sql.DB, result types.PositionMemoryResult) error {
+	sequenceJSON, err := json.Marshal(result.Sequence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sequence: %w", err)
+	}
+	responseJSON, err := json.Marshal(result.Response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO position_memory_results (
+			session_id, sequence_num, mode, length, attempt, sequence, response,
+			is_correct, correct_positions, response_time_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.SequenceNum, result.Mode, result.Length, result.Attempt, string(sequenceJSON), string(responseJSON),
+		result.IsCorrect, result.CorrectPositions, result.ResponseTimeMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert position memory result: %w", err)
+	}
+	return nil
+}
+
+// GetPositionMemoryResultsForSession retrieves all results for a given session.
+func GetPositionMemoryResultsForSession(db *sql.DB, sessionID int64) ([]types.PositionMemoryResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, sequence_num, mode, length, attempt, sequence, response,
+		       is_correct, correct_positions, response_time_ms
+		FROM position_memory_results
+		WHERE session_id = ?
+		ORDER BY sequence_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.PositionMemoryResult, 0)
+	for rows.Next() {
+		var r types.PositionMemoryResult
+		var sequenceJSON, responseJSON string
+		if err := rows.Scan(&r.SessionID, &r.SequenceNum, &r.Mode, &r.Length, &r.Attempt, &sequenceJSON, &responseJSON,
+			&r.IsCorrect, &r.CorrectPositions, &r.ResponseTimeMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(sequenceJSON), &r.Sequence); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sequence: %w", err)
+		}
+		if err := json.Unmarshal([]byte(responseJSON), &r.Response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedPositionMemorySessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedPositionMemorySessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedPositionMemorySessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodePositionMemory, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.PositionMemorySessionWithResults
+	for rows.Next() {
+		var s types.PositionMemorySessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetPositionMemoryResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.PositionMemorySessionWithResults{}
+	}
+
+	return &types.PaginatedPositionMemorySessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetPositionMemorySessionStats calculates statistics for a given session.
+func GetPositionMemorySessionStats(db *sql.DB, sessionID int64) (*types.PositionMemorySessionStats, error) {
+	results, err := GetPositionMemoryResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.PositionMemorySessionStats{
+		SessionID:   sessionID,
+		LengthStats: []types.PositionMemoryLengthStats{},
+	}
+
+	lengthMap := make(map[int]*types.PositionMemoryLengthStats)
+	totalTime := 0.0
+	for _, r := range results {
+		stats.Mode = r.Mode
+		stats.TotalSequences++
+		totalTime += float64(r.ResponseTimeMs)
+
+		ls, ok := lengthMap[r.Length]
+		if !ok {
+			ls = &types.PositionMemoryLengthStats{Length: r.Length}
+			lengthMap[r.Length] = ls
+		}
+		ls.TotalSequences++
+		ls.AverageResponseTimeMs += float64(r.ResponseTimeMs) // Accumulate for now
+
+		if r.IsCorrect {
+			stats.TotalCorrect++
+			ls.TotalCorrect++
+			stats.MaxSpan = max(stats.MaxSpan, r.Length)
+		}
+	}
+
+	if stats.TotalSequences > 0 {
+		stats.Accuracy = float64(stats.TotalCorrect) / float64(stats.TotalSequences) * 100
+		stats.AverageResponseTimeMs = totalTime / float64(stats.TotalSequences)
+	}
+
+	// Lengths are small, so loop over them in order.
+	maxLength := 0
+	for length := range lengthMap {
+		maxLength = max(maxLength, length)
+	}
+	for length := 1; length <= maxLength; length++ {
+		ls, ok := lengthMap[length]
+		if !ok {
+			continue
+		}
+		ls.AverageResponseTimeMs /= float64(ls.TotalSequences)
+		stats.LengthStats = append(stats.LengthStats, *ls)
+	}
+
+	return stats, nil
+}
+
+// GetPositionMemorySpanHistory returns the span of every Position Memory session, oldest
+// first, and the best span reached in each mode.
+func GetPositionMemorySpanHistory(db *sql.DB) (*types.PositionMemorySpanHistory, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.play_datetime FROM game_sessions s
+		WHERE s.game_code = ? AND `+counted+`
+		ORDER BY s.play_datetime ASC, s.id ASC`, types.GameCodePositionMemory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var points []types.PositionMemorySpanPoint
+	for rows.Next() {
+		var p types.PositionMemorySpanPoint
+		if err := rows.Scan(&p.SessionID, &p.PlayDatetime); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	history := &types.PositionMemorySpanHistory{Sessions: make([]types.PositionMemorySpanPoint, 0, len(points))}
+	for _, p := range points {
+		stats, err := GetPositionMemorySessionStats(db, p.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if stats.TotalSequences == 0 {
+			continue
+		}
+		p.Mode = stats.Mode
+		p.MaxSpan = stats.MaxSpan
+		if p.Mode == types.PositionMemoryBackward {
+			history.BestBackwardSpan = max(history.BestBackwardSpan, p.MaxSpan)
+		} else {
+			history.BestForwardSpan = max(history.BestForwardSpan, p.MaxSpan)
+		}
+		history.Sessions = append(history.Sessions, p)
+	}
+
+	return history, nil
+}
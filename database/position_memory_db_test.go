@@ -0,0 +1,81 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetPositionMemoryResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodePositionMemory, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	fwd := types.PositionMemoryForward
+	results := []types.PositionMemoryResult{
+		{SessionID: sessionID, SequenceNum: 1, Mode: fwd, Length: 2, Attempt: 1, Sequence: []int{3, 5}, Response: []int{3, 5}, IsCorrect: true, CorrectPositions: 2, ResponseTimeMs: 1000},
+		{SessionID: sessionID, SequenceNum: 2, Mode: fwd, Length: 3, Attempt: 1, Sequence: []int{0, 8, 2}, Response: []int{0, 2, 8}, CorrectPositions: 1, ResponseTimeMs: 2000},
+		{SessionID: sessionID, SequenceNum: 3, Mode: fwd, Length: 3, Attempt: 2, Sequence: []int{7, 1, 4}, Response: []int{7, 1, 4}, IsCorrect: true, CorrectPositions: 3, ResponseTimeMs: 3000},
+		{SessionID: sessionID, SequenceNum: 4, Mode: fwd, Length: 4, Attempt: 1, Sequence: []int{6, 0, 3, 2}, Response: []int{}, ResponseTimeMs: 4000},
+	}
+	for _, r := range results {
+		if err := SavePositionMemoryResult(db, r); err != nil {
+			t.Fatalf("SavePositionMemoryResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetPositionMemoryResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetPositionMemoryResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetPositionMemorySessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetPositionMemorySessionStats failed: %v", err)
+	}
+	if stats.MaxSpan != 3 || stats.TotalSequences != 4 || stats.TotalCorrect != 2 || stats.Accuracy != 50 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	if len(stats.LengthStats) != 3 || stats.LengthStats[1].Length != 3 || stats.LengthStats[1].AverageResponseTimeMs != 2500 {
+		t.Errorf("Unexpected length stats: %+v", stats.LengthStats)
+	}
+
+	// A backward session with a span of 2, and an empty one that the history skips.
+	backwardID, _ := CreateGameSession(db, types.GameCodePositionMemory, "{}")
+	if err := SavePositionMemoryResult(db, types.PositionMemoryResult{
+		SessionID: backwardID, SequenceNum: 1, Mode: types.PositionMemoryBackward, Length: 2, Attempt: 1,
+		Sequence: []int{1, 2}, Response: []int{2, 1}, IsCorrect: true, CorrectPositions: 2, ResponseTimeMs: 1000,
+	}); err != nil {
+		t.Fatalf("SavePositionMemoryResult failed: %v", err)
+	}
+	if _, err := CreateGameSession(db, types.GameCodePositionMemory, "{}"); err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	history, err := GetPositionMemorySpanHistory(db)
+	if err != nil {
+		t.Fatalf("GetPositionMemorySpanHistory failed: %v", err)
+	}
+	if history.BestForwardSpan != 3 || history.BestBackwardSpan != 2 || len(history.Sessions) != 2 {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+
+	page, err := GetPaginatedPositionMemorySessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedPositionMemorySessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 3 || len(page.Sessions) != 3 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,495 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"acca-games/types"
+)
+
+const (
+	// dateFormat is the day part of play_datetime.
+	dateFormat = "2006-01-02"
+	// goalWindowDays is the number of days, today included, that goals are judged over.
+	goalWindowDays = 7
+)
+
+// sessionSummary is the headline of a game session: its accuracy or solve rate and the
+// time an answer took, together with the game's full session stats.
+type sessionSummary struct {
+	Accuracy       float64 // Percent
+	ResponseTimeMs float64
+	Stats          interface{}
+}
+
+// summarizeSession fetches a session's stats and picks out its accuracy and response time.
+func summarizeSession(db *sql.DB, gameCode string, sessionID int64) (*sessionSummary, error) {
+	switch gameCode {
+	case types.GameCodeNBack:
+		stats, err := GetNBackSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeRPS:
+		stats, err := GetRpsSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeShapeRotation:
+		stats, err := GetShapeRotationSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageSolveTimeMs, stats}, nil
+	case types.GameCodeNumberPressing:
+		stats, err := GetNumberPressingSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageTimeTakenSec * 1000, stats}, nil
+	case types.GameCodeCountComparison:
+		stats, err := GetCountComparisonSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeCatChaser:
+		stats, err := GetCatChaserSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeTower:
+		stats, err := GetTowerSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.SolveRate, stats.AverageTimeMs, stats}, nil
+	case types.GameCodeWeight:
+		stats, err := GetWeightSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeRoad:
+		stats, err := GetRoadSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.SuccessRate, stats.AverageTimeMs, stats}, nil
+	case types.GameCodePositionMemory:
+		stats, err := GetPositionMemorySessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.Accuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeStroop:
+		stats, err := GetStroopSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.OverallAccuracy, stats.AverageResponseTimeMs, stats}, nil
+	case types.GameCodeScheduling:
+		stats, err := GetSchedulingSessionStats(db, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionSummary{stats.Accuracy, stats.AverageResponseTimeMs, stats}, nil
+	}
+	return nil, fmt.Errorf("unknown game code: %s", gameCode)
+}
+
+// roundAccuracies returns the accuracy of each round for games whose stats have rounds.
+func roundAccuracies(stats interface{}) map[int]float64 {
+	rounds := make(map[int]float64)
+	switch s := stats.(type) {
+	case *types.RpsSessionStats:
+		for _, rs := range s.RoundStats {
+			rounds[rs.Round] = rs.Accuracy
+		}
+	case *types.NBackSessionStats:
+		for _, rs := range s.RoundStats {
+			rounds[rs.Round] = rs.Accuracy
+		}
+	case *types.ShapeRotationSessionStats:
+		for _, rs := range s.RoundStats {
+			rounds[rs.Round] = rs.Accuracy
+		}
+	case *types.NumberPressingSessionStats:
+		for _, rs := range s.RoundStats {
+			rounds[rs.Round] = rs.Accuracy
+		}
+	}
+	return rounds
+}
+
+// CreateGoal checks and stores a new goal and returns its ID.
+func CreateGoal(db *sql.DB, goal types.Goal) (int64, error) {
+	if _, err := scoreMetricFor(goal.GameCode); err != nil {
+		return 0, err
+	}
+	switch goal.Kind {
+	case types.GoalAccuracy:
+		if goal.Target > 100 {
+			return 0, fmt.Errorf("accuracy target must be at most 100, got %v", goal.Target)
+		}
+	case types.GoalResponseTime, types.GoalSessionsPerWeek:
+	default:
+		return 0, fmt.Errorf("unknown goal kind: %s", goal.Kind)
+	}
+	if !(goal.Target > 0) {
+		return 0, fmt.Errorf("goal target must be positive, got %v", goal.Target)
+	}
+
+	res, err := db.Exec("INSERT INTO goals (game_code, kind, target) VALUES (?, ?, ?)", goal.GameCode, goal.Kind, goal.Target)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert goal: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// DeleteGoal removes a goal.
+func DeleteGoal(db *sql.DB, goalID int64) error {
+	res, err := db.Exec("DELETE FROM goals WHERE id = ?", goalID)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal %d: %w", goalID, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("goal %d not found", goalID)
+	}
+	return nil
+}
+
+// GetGoals returns every goal, oldest first.
+func GetGoals(db *sql.DB) ([]types.Goal, error) {
+	rows, err := db.Query("SELECT id, game_code, kind, target, created_at FROM goals ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []types.Goal{}
+	for rows.Next() {
+		var g types.Goal
+		if err := rows.Scan(&g.ID, &g.GameCode, &g.Kind, &g.Target, &g.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return goals, nil
+}
+
+// EvaluateGoals judges every goal over the sessions of the last seven days up to now.
+func EvaluateGoals(db *sql.DB, now time.Time) ([]types.GoalProgress, error) {
+	goals, err := GetGoals(db)
+	if err != nil {
+		return nil, err
+	}
+	windowStart := now.AddDate(0, 0, 1-goalWindowDays).Format(dateFormat)
+
+	// Summaries are shared by every goal on the same game.
+	summaries := make(map[string][]*sessionSummary)
+	progress := make([]types.GoalProgress, len(goals))
+	for i, goal := range goals {
+		window, ok := summaries[goal.GameCode]
+		if !ok {
+			sessions, err := GetSessionsWithResults(db, goal.GameCode)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range sessions {
+				if s.PlayDatetime.Format(dateFormat) < windowStart {
+					continue
+				}
+				summary, err := summarizeSession(db, goal.GameCode, s.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to summarize session %d: %w", s.ID, err)
+				}
+				window = append(window, summary)
+			}
+			summaries[goal.GameCode] = window
+		}
+		progress[i] = evaluateGoal(goal, window)
+	}
+	return progress, nil
+}
+
+// evaluateGoal judges one goal on the summaries of the sessions in its window.
+func evaluateGoal(goal types.Goal, window []*sessionSummary) types.GoalProgress {
+	p := types.GoalProgress{Goal: goal, Sessions: len(window)}
+	if goal.Kind == types.GoalSessionsPerWeek {
+		current := float64(len(window))
+		p.Current = &current
+		p.Progress = math.Min(current/goal.Target, 1) * 100
+		p.Met = current >= goal.Target
+		return p
+	}
+	if len(window) == 0 {
+		return p
+	}
+
+	current := 0.0
+	for _, s := range window {
+		if goal.Kind == types.GoalAccuracy {
+			current += s.Accuracy // Accumulate for now
+		} else {
+			current += s.ResponseTimeMs
+		}
+	}
+	current /= float64(len(window))
+	p.Current = &current
+
+	if goal.Kind == types.GoalAccuracy {
+		p.Progress = math.Min(current/goal.Target, 1) * 100
+		p.Met = current >= goal.Target
+	} else {
+		if current > 0 {
+			p.Progress = math.Min(goal.Target/current, 1) * 100
+		} else {
+			p.Progress = 100
+		}
+		p.Met = current <= goal.Target
+	}
+	return p
+}
+
+// GetPracticeStreak counts the days in a row up to now on which at least one session
+// with results was played. A streak stays current until a whole day is missed.
+func GetPracticeStreak(db *sql.DB, now time.Time) (*types.PracticeStreak, error) {
+	var tables []string
+	for _, m := range scoreMetrics {
+		tables = append(tables, m.resultTables...)
+	}
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT DISTINCT date(s.play_datetime) FROM game_sessions s
+		WHERE ` + counted + ` AND ` + hasResults(tables) + `
+		ORDER BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query practice days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []time.Time
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan practice day: %w", err)
+		}
+		t, err := time.Parse(dateFormat, day)
+		if err != nil {
+			return nil, fmt.Errorf("invalid practice day %q: %w", day, err)
+		}
+		days = append(days, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	streak := &types.PracticeStreak{TotalDays: len(days)}
+	if len(days) == 0 {
+		return streak, nil
+	}
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && day.Sub(days[i-1]) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	last := days[len(days)-1]
+	today, _ := time.Parse(dateFormat, now.Format(dateFormat)) // Round trip of a formatted date
+	streak.LastPracticed = last.Format(dateFormat)
+	streak.PracticedToday = last.Equal(today)
+	if streak.PracticedToday || last.Equal(today.AddDate(0, 0, -1)) {
+		streak.Current = run
+	}
+	return streak, nil
+}
+
+// recordMode returns the record mode of a session from its settings.
+func recordMode(settings string) string {
+	var s struct {
+		IsRealMode bool `json:"isRealMode"`
+	}
+	if err := json.Unmarshal([]byte(settings), &s); err == nil && s.IsRealMode {
+		return types.RecordModeReal
+	}
+	return types.RecordModePractice
+}
+
+// UpdatePersonalRecords compares a finished session with the stored records: the game's
+// score metric for the whole session and the accuracy of each round. It returns the
+// records the session set, first ones included. A session without results, or one that
+// does not count towards stats, sets none.
+func UpdatePersonalRecords(db *sql.DB, sessionID int64) ([]types.PersonalRecord, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	var session types.GameSession
+	var counts bool
+	err = db.QueryRow("SELECT s.id, s.game_code, s.play_datetime, s.settings, "+counted+" FROM game_sessions s WHERE s.id = ?", sessionID).
+		Scan(&session.ID, &session.GameCode, &session.PlayDatetime, &session.Settings, &counts)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+
+	m, err := scoreMetricFor(session.GameCode)
+	if err != nil {
+		return nil, err
+	}
+	var played bool
+	err = db.QueryRow("SELECT "+hasResults(m.resultTables)+" FROM game_sessions s WHERE s.id = ?", sessionID).Scan(&played)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check results of session %d: %w", sessionID, err)
+	}
+	if !played || !counts {
+		return []types.PersonalRecord{}, nil
+	}
+
+	value, err := m.value(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := summarizeSession(db, session.GameCode, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := recordMode(session.Settings)
+	candidates := []types.PersonalRecord{{
+		GameCode: session.GameCode, Mode: mode, Metric: m.Metric, HigherIsBetter: m.HigherIsBetter, Value: value,
+	}}
+	rounds := roundAccuracies(summary.Stats)
+	maxRound := 0
+	for round := range rounds {
+		if round > maxRound {
+			maxRound = round
+		}
+	}
+	for round := 1; round <= maxRound; round++ {
+		accuracy, ok := rounds[round]
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, types.PersonalRecord{
+			GameCode: session.GameCode, Round: round, Mode: mode, Metric: "accuracy", HigherIsBetter: true, Value: accuracy,
+		})
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	set := []types.PersonalRecord{}
+	for _, r := range candidates {
+		r.SessionID = sessionID
+		r.AchievedAt = session.PlayDatetime
+
+		var best float64
+		err := tx.QueryRow(`
+			SELECT value FROM personal_records
+			WHERE game_code = ? AND round = ? AND mode = ? AND metric = ?`,
+			r.GameCode, r.Round, r.Mode, r.Metric).Scan(&best)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return nil, fmt.Errorf("failed to get record: %w", err)
+		case r.HigherIsBetter && r.Value <= best, !r.HigherIsBetter && r.Value >= best:
+			continue
+		default:
+			previous := best
+			r.PreviousValue = &previous
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO personal_records (game_code, round, mode, metric, value, session_id, achieved_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game_code, round, mode, metric)
+			DO UPDATE SET value = excluded.value, session_id = excluded.session_id, achieved_at = excluded.achieved_at`,
+			r.GameCode, r.Round, r.Mode, r.Metric, r.Value, r.SessionID, r.AchievedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save record: %w", err)
+		}
+		set = append(set, r)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit records: %w", err)
+	}
+	return set, nil
+}
+
+// RebuildPersonalRecords recomputes the records of a game from its sessions outside the
+// trash, replaying them oldest first. Games without a score metric keep no records.
+func RebuildPersonalRecords(db *sql.DB, gameCode string) error {
+	if _, err := scoreMetricFor(gameCode); err != nil {
+		return nil
+	}
+	sessions, err := GetSessionsWithResults(db, gameCode)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec("DELETE FROM personal_records WHERE game_code = ?", gameCode); err != nil {
+		return fmt.Errorf("failed to clear %s records: %w", gameCode, err)
+	}
+	for _, s := range sessions {
+		if _, err := UpdatePersonalRecords(db, s.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPersonalRecords returns every record, ordered by game, round, mode and metric.
+func GetPersonalRecords(db *sql.DB) ([]types.PersonalRecord, error) {
+	rows, err := db.Query(`
+		SELECT game_code, round, mode, metric, value, session_id, achieved_at
+		FROM personal_records
+		ORDER BY game_code, round, mode, metric`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []types.PersonalRecord{}
+	for rows.Next() {
+		var r types.PersonalRecord
+		if err := rows.Scan(&r.GameCode, &r.Round, &r.Mode, &r.Metric, &r.Value, &r.SessionID, &r.AchievedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan record: %w", err)
+		}
+		r.HigherIsBetter = true
+		if r.Round == 0 {
+			if m, err := scoreMetricFor(r.GameCode); err == nil {
+				r.HigherIsBetter = m.HigherIsBetter
+			}
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return records, nil
+}
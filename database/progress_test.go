@@ -0,0 +1,218 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"acca-games/types"
+)
+
+// saveTowerSession stores a Tower session that solves the given problems, played at the
+// given local time or now if zero.
+func saveTowerSession(t *testing.T, db *sql.DB, settings types.TowerSettings, at time.Time, solved ...bool) int64 {
+	t.Helper()
+	sessionID, err := CreateGameSession(db, types.GameCodeTower, settings)
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	if !at.IsZero() {
+		if _, err := db.Exec("UPDATE game_sessions SET play_datetime = ? WHERE id = ?", at.Format("2006-01-02 15:04:05"), sessionID); err != nil {
+			t.Fatalf("Failed to move session: %v", err)
+		}
+	}
+	for i, s := range solved {
+		r := types.TowerResult{SessionID: sessionID, ProblemNum: i + 1, Discs: 3,
+			Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{}, {}, {3, 2, 1}},
+			Moves: []types.TowerMove{}, OptimalMoves: 7, MoveLimit: 14, IsSolved: s, TimeTakenMs: 1000}
+		if err := SaveTowerResult(db, r); err != nil {
+			t.Fatalf("SaveTowerResult failed: %v", err)
+		}
+	}
+	return sessionID
+}
+
+func TestGoals(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	badGoals := []types.Goal{
+		{GameCode: "UNKNOWN", Kind: types.GoalAccuracy, Target: 50},
+		{GameCode: types.GameCodeTower, Kind: "speed", Target: 50},
+		{GameCode: types.GameCodeTower, Kind: types.GoalAccuracy, Target: 120},
+		{GameCode: types.GameCodeTower, Kind: types.GoalSessionsPerWeek, Target: 0},
+	}
+	for _, g := range badGoals {
+		if _, err := CreateGoal(db, g); err == nil {
+			t.Errorf("Expected goal %+v to be rejected", g)
+		}
+	}
+
+	for _, g := range []types.Goal{
+		{GameCode: types.GameCodeTower, Kind: types.GoalAccuracy, Target: 70},
+		{GameCode: types.GameCodeTower, Kind: types.GoalResponseTime, Target: 500},
+		{GameCode: types.GameCodeTower, Kind: types.GoalSessionsPerWeek, Target: 4},
+		{GameCode: types.GameCodeRoad, Kind: types.GoalAccuracy, Target: 50},
+	} {
+		if _, err := CreateGoal(db, g); err != nil {
+			t.Fatalf("CreateGoal failed: %v", err)
+		}
+	}
+
+	// Two sessions this week at 100% and 50%, and a bad one that is too old to count.
+	saveTowerSession(t, db, types.TowerSettings{}, time.Time{}, true, true)
+	saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -6), true, false)
+	saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -7), false, false)
+
+	progress, err := EvaluateGoals(db, now)
+	if err != nil {
+		t.Fatalf("EvaluateGoals failed: %v", err)
+	}
+	if len(progress) != 4 {
+		t.Fatalf("Expected 4 goals, got %d", len(progress))
+	}
+	if p := progress[0]; p.Sessions != 2 || *p.Current != 75 || !p.Met || p.Progress != 100 {
+		t.Errorf("Unexpected accuracy goal: %+v", p)
+	}
+	if p := progress[1]; *p.Current != 1000 || p.Met || p.Progress != 50 {
+		t.Errorf("Unexpected response time goal: %+v", p)
+	}
+	if p := progress[2]; *p.Current != 2 || p.Met || p.Progress != 50 {
+		t.Errorf("Unexpected sessions goal: %+v", p)
+	}
+	if p := progress[3]; p.Sessions != 0 || p.Current != nil || p.Met {
+		t.Errorf("Expected an unplayed game's goal to have no value, got %+v", p)
+	}
+
+	if err := DeleteGoal(db, progress[3].Goal.ID); err != nil {
+		t.Fatalf("DeleteGoal failed: %v", err)
+	}
+	if err := DeleteGoal(db, progress[3].Goal.ID); err == nil {
+		t.Errorf("Expected deleting a missing goal to fail")
+	}
+}
+
+func TestPracticeStreak(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	streak, err := GetPracticeStreak(db, now)
+	if err != nil {
+		t.Fatalf("GetPracticeStreak failed: %v", err)
+	}
+	if streak.Current != 0 || streak.Longest != 0 || streak.LastPracticed != "" {
+		t.Errorf("Expected no streak without sessions, got %+v", streak)
+	}
+
+	for _, daysAgo := range []int{8, 7, 6, 4, 2, 1, 1} {
+		saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -daysAgo), true)
+	}
+	// A session without results does not fill the gap.
+	saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -3))
+
+	streak, err = GetPracticeStreak(db, now)
+	if err != nil {
+		t.Fatalf("GetPracticeStreak failed: %v", err)
+	}
+	if streak.Current != 2 || streak.Longest != 3 || streak.PracticedToday || streak.TotalDays != 6 {
+		t.Errorf("Expected a current streak of 2 alive from yesterday, got %+v", streak)
+	}
+
+	saveTowerSession(t, db, types.TowerSettings{}, time.Time{}, false)
+	streak, err = GetPracticeStreak(db, now)
+	if err != nil {
+		t.Fatalf("GetPracticeStreak failed: %v", err)
+	}
+	if streak.Current != 3 || !streak.PracticedToday || streak.LastPracticed != now.Format("2006-01-02") {
+		t.Errorf("Expected today to extend the streak to 3, got %+v", streak)
+	}
+
+	streak, err = GetPracticeStreak(db, now.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("GetPracticeStreak failed: %v", err)
+	}
+	if streak.Current != 0 || streak.Longest != 3 {
+		t.Errorf("Expected a missed day to end the streak, got %+v", streak)
+	}
+}
+
+func TestPersonalRecords(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	first := saveTowerSession(t, db, types.TowerSettings{}, time.Time{}, true, false)
+	records, err := UpdatePersonalRecords(db, first)
+	if err != nil {
+		t.Fatalf("UpdatePersonalRecords failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Value != 50 || records[0].Mode != types.RecordModePractice || records[0].PreviousValue != nil {
+		t.Errorf("Expected a first practice record of 50, got %+v", records)
+	}
+
+	worse := saveTowerSession(t, db, types.TowerSettings{}, time.Time{}, false, false)
+	records, err = UpdatePersonalRecords(db, worse)
+	if err != nil || len(records) != 0 {
+		t.Errorf("Expected no record from a worse session, got %+v, %v", records, err)
+	}
+
+	better := saveTowerSession(t, db, types.TowerSettings{}, time.Time{}, true, true)
+	records, err = UpdatePersonalRecords(db, better)
+	if err != nil {
+		t.Fatalf("UpdatePersonalRecords failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Value != 100 || records[0].PreviousValue == nil || *records[0].PreviousValue != 50 {
+		t.Errorf("Expected the record to go from 50 to 100, got %+v", records)
+	}
+	// Completing the same session again changes nothing.
+	if records, err = UpdatePersonalRecords(db, better); err != nil || len(records) != 0 {
+		t.Errorf("Expected no new record on a second update, got %+v, %v", records, err)
+	}
+
+	// Real mode keeps its own records, and rounds get their own accuracy records.
+	rpsID, err := CreateGameSession(db, types.GameCodeRPS, types.RpsSettings{Rounds: []int{1, 2}, IsRealMode: true})
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	for i, correct := range []bool{true, true, true, false} {
+		r := types.RpsResult{SessionID: rpsID, Round: i/2 + 1, QuestionNum: i%2 + 1, ProblemCardHolder: "me",
+			GivenCard: "ROCK", IsCorrect: correct, ResponseTimeMs: 500, PlayerChoice: "PAPER", CorrectChoice: "PAPER"}
+		if err := SaveRpsResult(db, r); err != nil {
+			t.Fatalf("SaveRpsResult failed: %v", err)
+		}
+	}
+	records, err = UpdatePersonalRecords(db, rpsID)
+	if err != nil {
+		t.Fatalf("UpdatePersonalRecords failed: %v", err)
+	}
+	if len(records) != 3 || records[1].Round != 1 || records[1].Value != 100 || records[2].Value != 50 || records[2].Mode != types.RecordModeReal {
+		t.Errorf("Unexpected RPS records: %+v", records)
+	}
+
+	// An unplayed session sets nothing.
+	empty, err := CreateGameSession(db, types.GameCodeRPS, types.RpsSettings{})
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	if records, err = UpdatePersonalRecords(db, empty); err != nil || len(records) != 0 {
+		t.Errorf("Expected no records from an empty session, got %+v, %v", records, err)
+	}
+
+	all, err := GetPersonalRecords(db)
+	if err != nil {
+		t.Fatalf("GetPersonalRecords failed: %v", err)
+	}
+	if len(all) != 4 || all[3].GameCode != types.GameCodeTower || all[3].Value != 100 || all[3].SessionID != better {
+		t.Errorf("Unexpected stored records: %+v", all)
+	}
+}
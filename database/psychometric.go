@@ -0,0 +1,100 @@
+package database
+
+import (
+	"math"
+	"sort"
+
+	"acca-games/types"
+)
+
+// ratioTrial is a single two-choice answer at a known count ratio.
+type ratioTrial struct {
+	Ratio     float64
+	IsCorrect bool
+}
+
+const (
+	// minFitTrials is the smallest number of trials a psychometric fit is attempted on.
+	minFitTrials = 10
+	// psychometricLapse is the fixed lapse rate, so a single slip at an easy ratio
+	// does not drag the whole curve down.
+	psychometricLapse = 0.02
+	// thresholdAccuracy is the accuracy level that defines the discrimination threshold.
+	thresholdAccuracy = 0.75
+)
+
+// psychometricAccuracy is the expected accuracy of a two-choice task at Weber fraction w
+// for a logistic curve over log(w) that is centred at alpha with the given slope.
+func psychometricAccuracy(w, alpha, slope float64) float64 {
+	core := 1 / (1 + math.Exp(-slope*(math.Log(w)-math.Log(alpha))))
+	return 0.5 + 0.5*(1-psychometricLapse)*core
+}
+
+// fitPsychometric fits accuracy against Weber fraction by maximum likelihood over a
+// parameter grid. The curve runs from chance (50%) up to 1 - lapse/2. The reported
+// threshold is the Weber fraction at which the fitted curve reaches 75% correct.
+func fitPsychometric(condition string, trials []ratioTrial) types.PsychometricFit {
+	fit := types.PsychometricFit{
+		Condition:      condition,
+		TotalQuestions: len(trials),
+		Points:         psychometricPoints(trials),
+	}
+
+	if len(trials) < minFitTrials || len(fit.Points) < 2 {
+		return fit
+	}
+
+	bestLL := math.Inf(-1)
+	var bestAlpha, bestSlope float64
+	// Weber fractions from 0.01 to 1.0, log-spaced.
+	for i := 0; i <= 100; i++ {
+		alpha := math.Pow(10, -2+2*float64(i)/100)
+		for slope := 0.5; slope <= 20; slope += 0.5 {
+			ll := 0.0
+			for _, p := range fit.Points {
+				acc := psychometricAccuracy(p.Ratio-1, alpha, slope)
+				acc = math.Min(math.Max(acc, 1e-9), 1-1e-9)
+				wrong := p.TotalQuestions - p.TotalCorrect
+				ll += float64(p.TotalCorrect)*math.Log(acc) + float64(wrong)*math.Log(1-acc)
+			}
+			if ll > bestLL {
+				bestLL, bestAlpha, bestSlope = ll, alpha, slope
+			}
+		}
+	}
+
+	// Solve psychometricAccuracy(w) == thresholdAccuracy for w.
+	core := (thresholdAccuracy - 0.5) / (0.5 * (1 - psychometricLapse))
+	threshold := math.Exp(math.Log(bestAlpha) + math.Log(core/(1-core))/bestSlope)
+
+	fit.Fitted = true
+	fit.Threshold = math.Round(threshold*10000) / 10000
+	fit.ThresholdRatio = math.Round((1+threshold)*10000) / 10000
+	fit.Slope = bestSlope
+	return fit
+}
+
+// psychometricPoints groups trials by ratio (rounded to two decimals), sorted by ratio.
+func psychometricPoints(trials []ratioTrial) []types.PsychometricPoint {
+	pointMap := make(map[float64]*types.PsychometricPoint)
+	for _, t := range trials {
+		key := math.Round(t.Ratio*100) / 100
+		p, ok := pointMap[key]
+		if !ok {
+			p = &types.PsychometricPoint{Ratio: key}
+			pointMap[key] = p
+		}
+		p.TotalQuestions++
+		if t.IsCorrect {
+			p.TotalCorrect++
+		}
+	}
+
+	points := make([]types.PsychometricPoint, 0, len(pointMap))
+	for _, p := range pointMap {
+		p.Accuracy = float64(p.TotalCorrect) / float64(p.TotalQuestions) * 100
+		points = append(points, *p)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Ratio < points[j].Ratio })
+	return points
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveRoadResult saves the result of a single Road problem.
+func SaveRoadResult(db *sql.DB, result types.RoadResult) error {
+	problemJSON, err := json.Marshal(result.Problem)
+	if err != nil {
+		return fmt.Errorf("failed to marshal problem: %w", err)
+	}
+	layoutJSON, err := json.Marshal(result.Layout)
+	if err != nil {
+		return fmt.Errorf("failed to marshal layout: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO road_results (
+			session_id, problem_num, problem, layout, tiles_used, min_tiles, is_solved, time_taken_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.ProblemNum, string(problemJSON), string(layoutJSON),
+		result.TilesUsed, result.MinTiles, result.IsSolved, result.TimeTakenMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert road result: %w", err)
+	}
+	return nil
+}
+
+// GetRoadResultsForSession retrieves all results for a given session.
+func GetRoadResultsForSession(db *sql.DB, sessionID int64) ([]types.RoadResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, problem_num, problem, layout, tiles_used, min_tiles, is_solved, time_taken_ms
+		FROM road_results
+		WHERE session_id = ?
+		ORDER BY problem_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.RoadResult, 0)
+	for rows.Next() {
+		var r types.RoadResult
+		var problemJSON, layoutJSON string
+		if err := rows.Scan(&r.SessionID, &r.ProblemNum, &problemJSON, &layoutJSON,
+			&r.TilesUsed, &r.MinTiles, &r.IsSolved, &r.TimeTakenMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(problemJSON), &r.Problem); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal problem: %w", err)
+		}
+		if err := json.Unmarshal([]byte(layoutJSON), &r.Layout); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal layout: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedRoadSessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedRoadSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedRoadSessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodeRoad, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.RoadSessionWithResults
+	for rows.Next() {
+		var s types.RoadSessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetRoadResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.RoadSessionWithResults{}
+	}
+
+	return &types.PaginatedRoadSessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetRoadSessionStats calculates statistics for a given session.
+func GetRoadSessionStats(db *sql.DB, sessionID int64) (*types.RoadSessionStats, error) {
+	results, err := GetRoadResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.RoadSessionStats{SessionID: sessionID}
+
+	totalTime := 0.0
+	for _, r := range results {
+		stats.TotalProblems++
+		totalTime += float64(r.TimeTakenMs)
+
+		if r.IsSolved {
+			stats.TotalSolved++
+			stats.AverageExtraTiles += float64(r.TilesUsed - r.MinTiles) // Accumulate for now
+			stats.AverageSolveTimeMs += float64(r.TimeTakenMs)
+			if r.TilesUsed == r.MinTiles {
+				stats.TotalMinimal++
+			}
+		}
+	}
+
+	if stats.TotalProblems > 0 {
+		stats.SuccessRate = float64(stats.TotalSolved) / float64(stats.TotalProblems) * 100
+		stats.AverageTimeMs = totalTime / float64(stats.TotalProblems)
+	}
+	if stats.TotalSolved > 0 {
+		stats.AverageExtraTiles /= float64(stats.TotalSolved)
+		stats.AverageSolveTimeMs /= float64(stats.TotalSolved)
+	}
+
+	return stats, nil
+}
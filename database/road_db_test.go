@@ -0,0 +1,81 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetRoadResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeRoad, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	problem := types.RoadProblem{
+		ProblemNum: 1, GridRows: 3, GridCols: 3,
+		Obstacles: []types.RoadCell{{Row: 1, Col: 1}},
+		Start:     types.RoadEndpoint{Row: 0, Col: 0, Side: "E"},
+		Goal:      types.RoadEndpoint{Row: 2, Col: 2, Side: "N"},
+		Straights: 2, Curves: 2, MinTiles: 2,
+	}
+	results := []types.RoadResult{
+		{
+			SessionID: sessionID, ProblemNum: 1, Problem: problem,
+			Layout:    []types.RoadTile{{Row: 0, Col: 1, Kind: types.RoadTileEW}, {Row: 0, Col: 2, Kind: types.RoadTileSW}},
+			TilesUsed: 2, MinTiles: 2, IsSolved: false, TimeTakenMs: 2000,
+		},
+		{
+			SessionID: sessionID, ProblemNum: 2, Problem: problem,
+			Layout: []types.RoadTile{
+				{Row: 0, Col: 1, Kind: types.RoadTileEW}, {Row: 0, Col: 2, Kind: types.RoadTileSW},
+				{Row: 1, Col: 2, Kind: types.RoadTileNS},
+			},
+			TilesUsed: 3, MinTiles: 2, IsSolved: true, TimeTakenMs: 4000,
+		},
+		{
+			SessionID: sessionID, ProblemNum: 3, Problem: problem,
+			Layout:    []types.RoadTile{{Row: 0, Col: 1, Kind: types.RoadTileEW}, {Row: 0, Col: 2, Kind: types.RoadTileSW}},
+			TilesUsed: 2, MinTiles: 2, IsSolved: true, TimeTakenMs: 6000,
+		},
+	}
+	for _, r := range results {
+		if err := SaveRoadResult(db, r); err != nil {
+			t.Fatalf("SaveRoadResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetRoadResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetRoadResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetRoadSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetRoadSessionStats failed: %v", err)
+	}
+	if stats.TotalProblems != 3 || stats.TotalSolved != 2 || stats.TotalMinimal != 1 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	if stats.AverageExtraTiles != 0.5 || stats.AverageTimeMs != 4000 || stats.AverageSolveTimeMs != 5000 {
+		t.Errorf("Unexpected averages: %+v", stats)
+	}
+
+	page, err := GetPaginatedRoadSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedRoadSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 1 || len(page.Sessions) != 1 || len(page.Sessions[0].Results) != 3 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
@@ -66,12 +66,13 @@ func GetRpsResultsForSession(db *sql.DB, sessionID int64) ([]types.RpsResult, er
 }
 
 // GetPaginatedRpsSessionsWithResults fetches paginated RPS sessions with their results.
-func GetPaginatedRpsSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedRpsSessions, error) {
+func GetPaginatedRpsSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedRpsSessions, error) {
 	offset := (page - 1) * limit
 
 	// First, get the total count of sessions
+	where, args := historyFilter(types.GameCodeRPS, tags)
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeRPS).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total rps session count: %w", err)
 	}
@@ -85,14 +86,14 @@ func GetPaginatedRpsSessionsWithResults(db *sql.DB, page int, limit int) (*types
 		JOIN rps_results r ON s.id = r.session_id
 		WHERE s.id IN (
 			SELECT id FROM game_sessions
-			WHERE game_code = ?
+			WHERE `+where+`
 			ORDER BY play_datetime DESC
 			LIMIT ? OFFSET ?
 		)
 		ORDER BY s.play_datetime DESC, r.question_num ASC
 	`
 
-	rows, err := db.Query(query, types.GameCodeRPS, limit, offset)
+	rows, err := db.Query(query, pageArgs(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query paginated rps sessions with results: %w", err)
 	}
@@ -132,6 +133,9 @@ func GetPaginatedRpsSessionsWithResults(db *sql.DB, page int, limit int) (*types
 	sessions := make([]types.RpsSessionWithResults, len(sessionOrder))
 	for i, id := range sessionOrder {
 		sessions[i] = *sessionMap[id]
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
 	}
 
 	return &types.PaginatedRpsSessions{
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveSchedulingResult saves the result of a single Scheduling problem.
+func SaveSchedulingResult(db *sql.DB, result types.SchedulingResult) error {
+	constraintsJSON, err := json.Marshal(result.Constraints)
+	if err != nil {
+		return fmt.Errorf("failed to marshal constraints: %w", err)
+	}
+	placementJSON, err := json.Marshal(result.Placement)
+	if err != nil {
+		return fmt.Errorf("failed to marshal placement: %w", err)
+	}
+	solutionJSON, err := json.Marshal(result.Solution)
+	if err != nil {
+		return fmt.Errorf("failed to marshal solution: %w", err)
+	}
+	violatedJSON, err := json.Marshal(result.Violated)
+	if err != nil {
+		return fmt.Errorf("failed to marshal violated constraints: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO scheduling_results (
+			session_id, problem_num, num_people, constraints, placement, solution,
+			is_correct, violated, num_violated, response_time_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.ProblemNum, result.NumPeople, string(constraintsJSON), string(placementJSON), string(solutionJSON),
+		result.IsCorrect, string(violatedJSON), result.NumViolated, result.ResponseTimeMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert scheduling result: %w", err)
+	}
+	return nil
+}
+
+// GetSchedulingResultsForSession retrieves all results for a given session.
+func GetSchedulingResultsForSession(db *sql.DB, sessionID int64) ([]types.SchedulingResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, problem_num, num_people, constraints, placement, solution,
+		       is_correct, violated, num_violated, response_time_ms
+		FROM scheduling_results
+		WHERE session_id = ?
+		ORDER BY problem_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.SchedulingResult, 0)
+	for rows.Next() {
+		var r types.SchedulingResult
+		var constraintsJSON, placementJSON, solutionJSON, violatedJSON string
+		if err := rows.Scan(&r.SessionID, &r.ProblemNum, &r.NumPeople, &constraintsJSON, &placementJSON, &solutionJSON,
+			&r.IsCorrect, &violatedJSON, &r.NumViolated, &r.ResponseTimeMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(constraintsJSON), &r.Constraints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal constraints: %w", err)
+		}
+		if err := json.Unmarshal([]byte(placementJSON), &r.Placement); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal placement: %w", err)
+		}
+		if err := json.Unmarshal([]byte(solutionJSON), &r.Solution); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal solution: %w", err)
+		}
+		if err := json.Unmarshal([]byte(violatedJSON), &r.Violated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal violated constraints: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedSchedulingSessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedSchedulingSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedSchedulingSessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodeScheduling, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.SchedulingSessionWithResults
+	for rows.Next() {
+		var s types.SchedulingSessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetSchedulingResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.SchedulingSessionWithResults{}
+	}
+
+	return &types.PaginatedSchedulingSessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// schedulingKinds fixes the order of the per-kind stats.
+var schedulingKinds = []string{
+	types.SchedulingNotDay, types.SchedulingOnDay, types.SchedulingNotPeriod, types.SchedulingBefore,
+	types.SchedulingRightAfter, types.SchedulingSameDay, types.SchedulingDifferentDay,
+}
+
+// GetSchedulingSessionStats calculates statistics for a given session.
+func GetSchedulingSessionStats(db *sql.DB, sessionID int64) (*types.SchedulingSessionStats, error) {
+	results, err := GetSchedulingResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.SchedulingSessionStats{
+		SessionID: sessionID,
+		KindStats: []types.SchedulingKindStats{},
+	}
+
+	kindMap := make(map[string]*types.SchedulingKindStats)
+	totalTime := 0.0
+	totalWrong := 0
+	for _, r := range results {
+		stats.TotalProblems++
+		totalTime += float64(r.ResponseTimeMs)
+		if r.IsCorrect {
+			stats.TotalCorrect++
+		} else {
+			totalWrong++
+			stats.AverageViolated += float64(r.NumViolated) // Accumulate for now
+		}
+
+		for _, c := range r.Constraints {
+			ks, ok := kindMap[c.Kind]
+			if !ok {
+				ks = &types.SchedulingKindStats{Kind: c.Kind}
+				kindMap[c.Kind] = ks
+			}
+			ks.TotalShown++
+		}
+		for _, i := range r.Violated {
+			if i >= 0 && i < len(r.Constraints) {
+				kindMap[r.Constraints[i].Kind].TotalViolated++
+			}
+		}
+	}
+
+	if stats.TotalProblems > 0 {
+		stats.Accuracy = float64(stats.TotalCorrect) / float64(stats.TotalProblems) * 100
+		stats.AverageResponseTimeMs = totalTime / float64(stats.TotalProblems)
+	}
+	if totalWrong > 0 {
+		stats.AverageViolated /= float64(totalWrong)
+	}
+
+	for _, kind := range schedulingKinds {
+		ks, ok := kindMap[kind]
+		if !ok {
+			continue
+		}
+		ks.ViolationRate = float64(ks.TotalViolated) / float64(ks.TotalShown) * 100
+		stats.KindStats = append(stats.KindStats, *ks)
+	}
+
+	return stats, nil
+}
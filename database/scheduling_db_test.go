@@ -0,0 +1,71 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetSchedulingResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeScheduling, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	constraints := []types.SchedulingConstraint{
+		{Kind: types.SchedulingOnDay, Person: 0, Day: 0, Description: "민준은 1일차에만 올 수 있습니다."},
+		{Kind: types.SchedulingRightAfter, Person: 0, Other: 1, Description: "서연은 같은 날 민준 바로 다음에 옵니다."},
+		{Kind: types.SchedulingNotPeriod, Person: 2, Period: 0, Description: "Chloe is never free in period 1."},
+	}
+	solution := []types.SchedulingSlot{{Day: 0, Period: 0}, {Day: 0, Period: 1}, {Day: 1, Period: 1}}
+	results := []types.SchedulingResult{
+		{SessionID: sessionID, ProblemNum: 1, NumPeople: 3, Constraints: constraints, Placement: solution, Solution: solution,
+			IsCorrect: true, Violated: []int{}, ResponseTimeMs: 20000},
+		{SessionID: sessionID, ProblemNum: 2, NumPeople: 3, Constraints: constraints,
+			Placement: []types.SchedulingSlot{{Day: 1, Period: 0}, {Day: 0, Period: 1}, {Day: 0, Period: 0}}, Solution: solution,
+			Violated: []int{0, 1, 2}, NumViolated: 3, ResponseTimeMs: 30000},
+		{SessionID: sessionID, ProblemNum: 3, NumPeople: 3, Constraints: constraints,
+			Placement: []types.SchedulingSlot{{Day: 0, Period: 0}, {Day: 0, Period: 1}, {Day: 1, Period: 0}}, Solution: solution,
+			Violated: []int{2}, NumViolated: 1, ResponseTimeMs: 40000},
+	}
+	for _, r := range results {
+		if err := SaveSchedulingResult(db, r); err != nil {
+			t.Fatalf("SaveSchedulingResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetSchedulingResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetSchedulingResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetSchedulingSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetSchedulingSessionStats failed: %v", err)
+	}
+	if stats.TotalProblems != 3 || stats.TotalCorrect != 1 || stats.AverageViolated != 2 || stats.AverageResponseTimeMs != 30000 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	// Kinds come in a fixed order: onDay, notPeriod, rightAfter.
+	if len(stats.KindStats) != 3 || stats.KindStats[1].Kind != types.SchedulingNotPeriod || stats.KindStats[1].TotalViolated != 2 {
+		t.Errorf("Unexpected kind stats: %+v", stats.KindStats)
+	}
+
+	page, err := GetPaginatedSchedulingSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedSchedulingSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 1 || len(page.Sessions) != 1 || len(page.Sessions[0].Results) != 3 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
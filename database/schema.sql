@@ -8,7 +8,14 @@ CREATE TABLE IF NOT EXISTS `game_sessions` (
   `id` INTEGER PRIMARY KEY AUTOINCREMENT,
   `game_code` TEXT NOT NULL, -- 'RPS', 'SHAPE_ROTATE', 'NUMBER_PRESS', 'NBACK'
   `play_datetime` TEXT NOT NULL DEFAULT (datetime('now','localtime')), -- ISO8601 format
-  `settings` TEXT -- Storing game settings as a JSON string
+  `settings` TEXT, -- Storing game settings as a JSON string
+  `battery_session_id` INTEGER, -- Set when the session was played as part of a battery
+  `battery_step` INTEGER,
+  `status` TEXT NOT NULL DEFAULT 'in_progress', -- 'in_progress', 'completed' or 'abandoned'
+  `ended_at` TEXT, -- When the session was completed or abandoned
+  `expected_trials` INTEGER NOT NULL DEFAULT 0, -- 0 when the game decides as it goes
+  `notes` TEXT NOT NULL DEFAULT '',
+  `deleted_at` TEXT -- Set while the session is in the trash; NULL otherwise
 );
 
 -- -----------------------------------------------------
@@ -51,9 +58,11 @@ CREATE TABLE IF NOT EXISTS `number_pressing_results_r1` (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     session_id INTEGER NOT NULL,
     target_number INTEGER NOT NULL,
+    player_number INTEGER NOT NULL DEFAULT 0,
     time_taken REAL NOT NULL,
     is_correct BOOLEAN NOT NULL,
-    FOREIGN KEY (session_id) REFERENCES game_sessions(id)
+    layout TEXT NOT NULL DEFAULT '',    -- Store as JSON array string
+    FOREIGN KEY (session_id) REFERENCES game_sessions(id) ON DELETE CASCADE
 );
 
 -- -----------------------------------------------------
@@ -62,13 +71,22 @@ CREATE TABLE IF NOT EXISTS `number_pressing_results_r1` (
 CREATE TABLE IF NOT EXISTS `number_pressing_results_r2` (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     session_id INTEGER NOT NULL,
+    rule TEXT NOT NULL DEFAULT 'doubleSkip',
     double_click_numbers TEXT NOT NULL, -- Store as JSON array string
     skip_numbers TEXT NOT NULL,         -- Store as JSON array string
+    reverse BOOLEAN NOT NULL DEFAULT 0,
+    repeat_numbers TEXT NOT NULL DEFAULT '[]', -- Store as JSON array string
+    repeat_count INTEGER NOT NULL DEFAULT 0,
     player_clicks TEXT NOT NULL,        -- Store as JSON array string
     correct_clicks TEXT NOT NULL,       -- Store as JSON array string
     time_taken REAL NOT NULL,
     is_correct BOOLEAN NOT NULL,
-    FOREIGN KEY (session_id) REFERENCES game_sessions(id)
+    missing_double_click_errors INTEGER NOT NULL DEFAULT 0,
+    pressed_skipped_errors INTEGER NOT NULL DEFAULT 0,
+    order_slip_errors INTEGER NOT NULL DEFAULT 0,
+    extra_press_errors INTEGER NOT NULL DEFAULT 0,
+    layout TEXT NOT NULL DEFAULT '',    -- Store as JSON array string
+    FOREIGN KEY (session_id) REFERENCES game_sessions(id) ON DELETE CASCADE
 );
 
 -- -----------------------------------------------------
@@ -104,9 +122,36 @@ CREATE TABLE IF NOT EXISTS `count_comparison_results` (
   `left_word_count` INTEGER NOT NULL,
   `right_word_count` INTEGER NOT NULL,
   `applied_traps` TEXT, -- JSON string of []types.AppliedTrap
+  `ratio` REAL NOT NULL DEFAULT 0, -- larger count / smaller count
+  `word_list_id` INTEGER NOT NULL DEFAULT 0, -- 0 for the built-in list
+  `word_list_name` TEXT NOT NULL DEFAULT '',
+  `layout` TEXT NOT NULL DEFAULT '', -- JSON string of types.CountComparisonLayout
   FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
 );
 
+-- -----------------------------------------------------
+-- Table `word_lists` (Count Comparison)
+-- Stores user-imported word lists. The built-in list is embedded and not stored here.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `word_lists` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` TEXT NOT NULL UNIQUE,
+  `language` TEXT NOT NULL DEFAULT '', -- 'ko', 'en', ...
+  `created_at` TEXT NOT NULL DEFAULT (datetime('now','localtime'))
+);
+
+-- -----------------------------------------------------
+-- Table `word_list_pairs`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `word_list_pairs` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `list_id` INTEGER NOT NULL,
+  `left_word` TEXT NOT NULL,
+  `right_word` TEXT NOT NULL,
+  `category` TEXT NOT NULL DEFAULT '',
+  FOREIGN KEY (`list_id`) REFERENCES `word_lists` (`id`) ON DELETE CASCADE
+);
+
 -- -----------------------------------------------------
 -- Table `cat_chaser_results`
 -- -----------------------------------------------------
@@ -121,6 +166,207 @@ CREATE TABLE IF NOT EXISTS `cat_chaser_results` (
   `is_correct` BOOLEAN NOT NULL,
   `score` REAL NOT NULL,
   `response_time_ms` INTEGER NOT NULL,
+  `frame_schedule` TEXT NOT NULL DEFAULT '', -- JSON string of []types.CatChaserFrame
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+
+-- -----------------------------------------------------
+-- Table `tower_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `tower_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `problem_num` INTEGER NOT NULL,
+  `discs` INTEGER NOT NULL,
+  `start_config` TEXT NOT NULL, -- JSON string of [][]int, discs per peg from bottom to top
+  `goal_config` TEXT NOT NULL,  -- JSON string of [][]int
+  `moves` TEXT NOT NULL,        -- JSON string of []types.TowerMove
+  `move_count` INTEGER NOT NULL,
+  `optimal_moves` INTEGER NOT NULL,
+  `move_limit` INTEGER NOT NULL,
+  `is_solved` BOOLEAN NOT NULL,
+  `time_taken_ms` INTEGER NOT NULL,
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `weight_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `weight_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `problem_num` INTEGER NOT NULL,
+  `num_objects` INTEGER NOT NULL,
+  `hidden_order` TEXT NOT NULL, -- JSON string of []string, heaviest first
+  `weighings` TEXT NOT NULL,    -- JSON string of []types.WeightWeighing
+  `ranking` TEXT NOT NULL,      -- JSON string of []string, heaviest first
+  `weighings_used` INTEGER NOT NULL,
+  `optimal_weighings` INTEGER NOT NULL,
+  `is_correct` BOOLEAN NOT NULL,
+  `is_consistent` BOOLEAN NOT NULL,
+  `is_determined` BOOLEAN NOT NULL,
+  `response_time_ms` INTEGER NOT NULL,
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `road_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `road_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `problem_num` INTEGER NOT NULL,
+  `problem` TEXT NOT NULL, -- JSON string of types.RoadProblem
+  `layout` TEXT NOT NULL,  -- JSON string of []types.RoadTile
+  `tiles_used` INTEGER NOT NULL,
+  `min_tiles` INTEGER NOT NULL,
+  `is_solved` BOOLEAN NOT NULL,
+  `time_taken_ms` INTEGER NOT NULL,
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `position_memory_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `position_memory_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `sequence_num` INTEGER NOT NULL,
+  `mode` TEXT NOT NULL, -- 'forward' or 'backward'
+  `length` INTEGER NOT NULL,
+  `attempt` INTEGER NOT NULL,
+  `sequence` TEXT NOT NULL, -- JSON string of []int, block indexes as shown
+  `response` TEXT NOT NULL, -- JSON string of []int, block indexes as tapped
+  `is_correct` BOOLEAN NOT NULL,
+  `correct_positions` INTEGER NOT NULL,
+  `response_time_ms` INTEGER NOT NULL,
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `stroop_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `stroop_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `trial_num` INTEGER NOT NULL,
+  `condition` TEXT NOT NULL, -- 'congruent', 'incongruent' or 'neutral'
+  `word` TEXT NOT NULL,
+  `word_color` TEXT NOT NULL, -- Empty for neutral words
+  `ink_color` TEXT NOT NULL,
+  `response_key` TEXT NOT NULL, -- Empty on a timeout
+  `response_color` TEXT NOT NULL,
+  `is_correct` BOOLEAN NOT NULL,
+  `is_word_error` BOOLEAN NOT NULL,
+  `timed_out` BOOLEAN NOT NULL,
+  `response_time_ms` INTEGER NOT NULL,
   FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
 );
 
+-- -----------------------------------------------------
+-- Table `scheduling_results`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `scheduling_results` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `session_id` INTEGER NOT NULL,
+  `problem_num` INTEGER NOT NULL,
+  `num_people` INTEGER NOT NULL,
+  `constraints` TEXT NOT NULL, -- JSON string of []types.SchedulingConstraint
+  `placement` TEXT NOT NULL,   -- JSON string of []types.SchedulingSlot, one per person
+  `solution` TEXT NOT NULL,    -- JSON string of []types.SchedulingSlot
+  `is_correct` BOOLEAN NOT NULL,
+  `violated` TEXT NOT NULL,    -- JSON string of []int, indexes into constraints
+  `num_violated` INTEGER NOT NULL,
+  `response_time_ms` INTEGER NOT NULL,
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `battery_sessions`
+-- A run through a fixed sequence of games. The game sessions of each step point back
+-- here through game_sessions.battery_session_id and battery_step.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `battery_sessions` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `started_at` TEXT NOT NULL DEFAULT (datetime('now','localtime')),
+  `completed_at` TEXT,
+  `status` TEXT NOT NULL DEFAULT 'in_progress', -- 'in_progress' or 'completed'
+  `current_step` INTEGER NOT NULL DEFAULT 0,
+  `steps` TEXT NOT NULL -- JSON string of []types.BatteryStep
+);
+
+-- -----------------------------------------------------
+-- Table `score_norms`
+-- The imported norm table: the distribution of each game's score metric in a
+-- reference population. Importing a table replaces all rows.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `score_norms` (
+  `game_code` TEXT PRIMARY KEY,
+  `metric` TEXT NOT NULL,
+  `mean` REAL NOT NULL,
+  `std_dev` REAL NOT NULL,
+  `sample_size` INTEGER NOT NULL DEFAULT 0,
+  `table_name` TEXT NOT NULL DEFAULT '',
+  `imported_at` TEXT NOT NULL DEFAULT (datetime('now','localtime'))
+);
+
+-- -----------------------------------------------------
+-- Table `goals`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `goals` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `game_code` TEXT NOT NULL,
+  `kind` TEXT NOT NULL, -- 'accuracy', 'responseTime' or 'sessionsPerWeek'
+  `target` REAL NOT NULL,
+  `created_at` TEXT NOT NULL DEFAULT (datetime('now','localtime'))
+);
+
+-- -----------------------------------------------------
+-- Table `personal_records`
+-- The best value of each metric per game, round (0 for the whole session) and mode.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `personal_records` (
+  `game_code` TEXT NOT NULL,
+  `round` INTEGER NOT NULL,
+  `mode` TEXT NOT NULL, -- 'real' or 'practice'
+  `metric` TEXT NOT NULL,
+  `value` REAL NOT NULL,
+  `session_id` INTEGER NOT NULL,
+  `achieved_at` TEXT NOT NULL,
+  PRIMARY KEY (`game_code`, `round`, `mode`, `metric`),
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `session_tags`
+-- Free-form labels such as "tired" or "new strategy" attached to a session.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `session_tags` (
+  `session_id` INTEGER NOT NULL,
+  `tag` TEXT NOT NULL,
+  PRIMARY KEY (`session_id`, `tag`),
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
+
+-- -----------------------------------------------------
+-- Table `preferences`
+-- Player preferences stored as key/value pairs.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `preferences` (
+  `key` TEXT PRIMARY KEY,
+  `value` TEXT NOT NULL
+);
+
+-- -----------------------------------------------------
+-- Table `session_states`
+-- The generated problems and progress of a session still in progress, so that it can
+-- be resumed after the app closes. Removed once the session ends.
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `session_states` (
+  `session_id` INTEGER PRIMARY KEY,
+  `state` TEXT NOT NULL, -- JSON, shaped by each game
+  `next_trial` INTEGER NOT NULL DEFAULT 1, -- Number of the next unanswered trial
+  `updated_at` TEXT NOT NULL DEFAULT (datetime('now','localtime')),
+  FOREIGN KEY (`session_id`) REFERENCES `game_sessions` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,551 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	"acca-games/types"
+)
+
+// scoreMetric describes how the sessions of one game are scored.
+type scoreMetric struct {
+	types.ScoreMetric
+	resultTables []string // A session is scored once it has rows in any of these
+	value        func(db *sql.DB, sessionID int64) (float64, error)
+}
+
+// profileRecentSessions is how many of a game's latest sessions make up the player's
+// current standing in it.
+const profileRecentSessions = 5
+
+// competencyAreas lists the areas in the order the profile reports them.
+var competencyAreas = []string{
+	types.CompetencyMemory,
+	types.CompetencyAttention,
+	types.CompetencySpatial,
+	types.CompetencyReasoning,
+}
+
+var scoreMetrics = []scoreMetric{
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeNBack, Metric: "overallAccuracy", Area: types.CompetencyMemory, HigherIsBetter: true},
+		resultTables: []string{"nback_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetNBackSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.OverallAccuracy, nil
+		},
+	},
+	{
+		// The mean score per trial, so sessions of different lengths compare.
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeCatChaser, Metric: "averageScore", Area: types.CompetencyMemory, HigherIsBetter: true},
+		resultTables: []string{"cat_chaser_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetCatChaserSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			if stats.TotalQuestions == 0 {
+				return 0, nil
+			}
+			return stats.TotalScore / float64(stats.TotalQuestions), nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodePositionMemory, Metric: "maxSpan", Area: types.CompetencyMemory, HigherIsBetter: true},
+		resultTables: []string{"position_memory_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetPositionMemorySessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return float64(stats.MaxSpan), nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeNumberPressing, Metric: "overallAccuracy", Area: types.CompetencyAttention, HigherIsBetter: true},
+		resultTables: []string{"number_pressing_results_r1", "number_pressing_results_r2"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetNumberPressingSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.OverallAccuracy, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeCountComparison, Metric: "overallAccuracy", Area: types.CompetencyAttention, HigherIsBetter: true},
+		resultTables: []string{"count_comparison_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetCountComparisonSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.OverallAccuracy, nil
+		},
+	},
+	{
+		// The interference cost: how much slower incongruent trials are than congruent ones.
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeStroop, Metric: "interferenceResponseTime", Area: types.CompetencyAttention, HigherIsBetter: false},
+		resultTables: []string{"stroop_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetStroopSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.InterferenceResponseTime, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeShapeRotation, Metric: "averageSolveTimeMs", Area: types.CompetencySpatial, HigherIsBetter: false},
+		resultTables: []string{"shape_rotation_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetShapeRotationSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.AverageSolveTimeMs, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeRoad, Metric: "successRate", Area: types.CompetencySpatial, HigherIsBetter: true},
+		resultTables: []string{"road_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetRoadSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.SuccessRate, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeRPS, Metric: "overallAccuracy", Area: types.CompetencyReasoning, HigherIsBetter: true},
+		resultTables: []string{"rps_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetRpsSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.OverallAccuracy, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeTower, Metric: "solveRate", Area: types.CompetencyReasoning, HigherIsBetter: true},
+		resultTables: []string{"tower_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetTowerSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.SolveRate, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeWeight, Metric: "overallAccuracy", Area: types.CompetencyReasoning, HigherIsBetter: true},
+		resultTables: []string{"weight_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetWeightSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.OverallAccuracy, nil
+		},
+	},
+	{
+		ScoreMetric:  types.ScoreMetric{GameCode: types.GameCodeScheduling, Metric: "accuracy", Area: types.CompetencyReasoning, HigherIsBetter: true},
+		resultTables: []string{"scheduling_results"},
+		value: func(db *sql.DB, sessionID int64) (float64, error) {
+			stats, err := GetSchedulingSessionStats(db, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			return stats.Accuracy, nil
+		},
+	},
+}
+
+// scoreMetricFor returns the metric a game is scored on.
+func scoreMetricFor(gameCode string) (scoreMetric, error) {
+	for _, m := range scoreMetrics {
+		if m.GameCode == gameCode {
+			return m, nil
+		}
+	}
+	return scoreMetric{}, fmt.Errorf("game %s has no score metric", gameCode)
+}
+
+// GetScoreMetrics returns the metric every game is scored on, which is what a norm table
+// has to give the distribution of.
+func GetScoreMetrics() []types.ScoreMetric {
+	metrics := make([]types.ScoreMetric, len(scoreMetrics))
+	for i, m := range scoreMetrics {
+		metrics[i] = m.ScoreMetric
+	}
+	return metrics
+}
+
+// ImportNormTable checks a norm table and replaces the stored norms with it.
+func ImportNormTable(db *sql.DB, table types.NormTable) error {
+	seen := make(map[string]bool)
+	for _, n := range table.Norms {
+		m, err := scoreMetricFor(n.GameCode)
+		if err != nil {
+			return err
+		}
+		if n.Metric != m.Metric {
+			return fmt.Errorf("norm for %s is on %q, but the game is scored on %q", n.GameCode, n.Metric, m.Metric)
+		}
+		if !(n.StdDev > 0) {
+			return fmt.Errorf("norm for %s must have a positive standard deviation", n.GameCode)
+		}
+		if seen[n.GameCode] {
+			return fmt.Errorf("norm table has more than one norm for %s", n.GameCode)
+		}
+		seen[n.GameCode] = true
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM score_norms"); err != nil {
+		return fmt.Errorf("failed to clear norms: %w", err)
+	}
+	for _, n := range table.Norms {
+		_, err := tx.Exec(`
+			INSERT INTO score_norms (game_code, metric, mean, std_dev, sample_size, table_name)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			n.GameCode, n.Metric, n.Mean, n.StdDev, n.SampleSize, table.Name)
+		if err != nil {
+			return fmt.Errorf("failed to insert norm for %s: %w", n.GameCode, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetNormTable returns the stored norm table, with no norms if none was imported.
+func GetNormTable(db *sql.DB) (*types.NormTable, error) {
+	rows, err := db.Query(`
+		SELECT game_code, metric, mean, std_dev, sample_size, table_name
+		FROM score_norms ORDER BY game_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query norms: %w", err)
+	}
+	defer rows.Close()
+
+	table := &types.NormTable{Norms: []types.ScoreNorm{}}
+	for rows.Next() {
+		var n types.ScoreNorm
+		if err := rows.Scan(&n.GameCode, &n.Metric, &n.Mean, &n.StdDev, &n.SampleSize, &table.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan norm: %w", err)
+		}
+		table.Norms = append(table.Norms, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return table, nil
+}
+
+// normsByGame returns the stored norms keyed by game code.
+func normsByGame(db *sql.DB) (map[string]types.ScoreNorm, error) {
+	table, err := GetNormTable(db)
+	if err != nil {
+		return nil, err
+	}
+	norms := make(map[string]types.ScoreNorm, len(table.Norms))
+	for _, n := range table.Norms {
+		norms[n.GameCode] = n
+	}
+	return norms, nil
+}
+
+// scoredSession is a session together with its metric value.
+type scoredSession struct {
+	ID           int64
+	PlayDatetime types.CustomTime
+	Value        float64
+}
+
+// hasResults is an SQL condition on the game_sessions row s that holds when the session
+// has rows in any of the result tables.
+func hasResults(tables []string) string {
+	exists := make([]string, len(tables))
+	for i, table := range tables {
+		exists[i] = "EXISTS (SELECT 1 FROM " + table + " r WHERE r.session_id = s.id)"
+	}
+	return "(" + strings.Join(exists, " OR ") + ")"
+}
+
+// GetSessionsWithResults returns every session of a game that has at least one result,
+// oldest first. Only sessions that count towards stats are included.
+func GetSessionsWithResults(db *sql.DB, gameCode string) ([]types.GameSession, error) {
+	m, err := scoreMetricFor(gameCode)
+	if err != nil {
+		return nil, err
+	}
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.game_code, s.play_datetime, s.settings FROM game_sessions s
+		WHERE s.game_code = ? AND `+counted+` AND `+hasResults(m.resultTables)+`
+		ORDER BY s.play_datetime ASC, s.id ASC`, gameCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s sessions: %w", gameCode, err)
+	}
+	defer rows.Close()
+
+	sessions := []types.GameSession{}
+	for rows.Next() {
+		var s types.GameSession
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return sessions, nil
+}
+
+// scoredSessions returns every session of a game that has results, oldest first.
+func scoredSessions(db *sql.DB, m scoreMetric) ([]scoredSession, error) {
+	gameSessions, err := GetSessionsWithResults(db, m.GameCode)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]scoredSession, len(gameSessions))
+	for i, gs := range gameSessions {
+		sessions[i] = scoredSession{ID: gs.ID, PlayDatetime: gs.PlayDatetime}
+	}
+	for i := range sessions {
+		value, err := m.value(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Value = value
+	}
+	return sessions, nil
+}
+
+// normalizeSessions scores each session against the ones played before it and against
+// the norm, if there is one.
+func normalizeSessions(m scoreMetric, sessions []scoredSession, norm *types.ScoreNorm) []types.NormalizedScore {
+	sign := 1.0
+	if !m.HigherIsBetter {
+		sign = -1
+	}
+
+	scores := make([]types.NormalizedScore, len(sessions))
+	for i, s := range sessions {
+		score := types.NormalizedScore{
+			SessionID:    s.ID,
+			GameCode:     m.GameCode,
+			PlayDatetime: s.PlayDatetime,
+			Metric:       m.Metric,
+			Value:        s.Value,
+			HistoryCount: i,
+		}
+
+		if i > 0 {
+			beaten, mean := 0.0, 0.0
+			for _, h := range sessions[:i] {
+				switch d := sign * (s.Value - h.Value); {
+				case d > 0:
+					beaten++
+				case d == 0:
+					beaten += 0.5 // Ties count half
+				}
+				mean += h.Value
+			}
+			percentile := beaten / float64(i) * 100
+			score.HistoryPercentile = &percentile
+
+			mean /= float64(i)
+			if i > 1 {
+				variance := 0.0
+				for _, h := range sessions[:i] {
+					variance += (h.Value - mean) * (h.Value - mean)
+				}
+				if sd := math.Sqrt(variance / float64(i-1)); sd > 0 {
+					z := sign * (s.Value - mean) / sd
+					score.HistoryZ = &z
+				}
+			}
+		}
+
+		if norm != nil {
+			score.NormZ, score.NormPercentile = normScore(m, *norm, s.Value)
+		}
+		scores[i] = score
+	}
+	return scores
+}
+
+// normScore returns the z-score and percentile of a value under a norm, signed so that
+// positive is better.
+func normScore(m scoreMetric, norm types.ScoreNorm, value float64) (*float64, *float64) {
+	z := (value - norm.Mean) / norm.StdDev
+	if !m.HigherIsBetter {
+		z = -z
+	}
+	percentile := normalPercentile(z)
+	return &z, &percentile
+}
+
+// normalPercentile is the percentage of a normal population below z.
+func normalPercentile(z float64) float64 {
+	return 50 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// meanOf returns the mean of the values that are set, or nil if none are.
+func meanOf(values []*float64) *float64 {
+	sum, n := 0.0, 0
+	for _, v := range values {
+		if v != nil {
+			sum += *v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	mean := sum / float64(n)
+	return &mean
+}
+
+// GetNormalizedScores scores every session of a game that has results, oldest first.
+func GetNormalizedScores(db *sql.DB, gameCode string) ([]types.NormalizedScore, error) {
+	m, err := scoreMetricFor(gameCode)
+	if err != nil {
+		return nil, err
+	}
+	norms, err := normsByGame(db)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := scoredSessions(db, m)
+	if err != nil {
+		return nil, err
+	}
+
+	var norm *types.ScoreNorm
+	if n, ok := norms[gameCode]; ok {
+		norm = &n
+	}
+	return normalizeSessions(m, sessions, norm), nil
+}
+
+// GetNormalizedSessionScore scores a single session against the player's earlier
+// sessions of the same game and against the norm table.
+func GetNormalizedSessionScore(db *sql.DB, sessionID int64) (*types.NormalizedScore, error) {
+	var gameCode string
+	err := db.QueryRow("SELECT game_code FROM game_sessions WHERE id = ?", sessionID).Scan(&gameCode)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+
+	scores, err := GetNormalizedScores(db, gameCode)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range scores {
+		if s.SessionID == sessionID {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("session %d has no results to score", sessionID)
+}
+
+// GetCompetencyProfile groups the games into competency areas and reports where the
+// player stands in each, judged by their most recent sessions.
+func GetCompetencyProfile(db *sql.DB) (*types.CompetencyProfile, error) {
+	norms, err := normsByGame(db)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &types.CompetencyProfile{Areas: []types.CompetencyScore{}}
+	for _, area := range competencyAreas {
+		cs := types.CompetencyScore{Area: area, Games: []types.GameScore{}}
+		var historyZs, normZs []*float64
+		for _, m := range scoreMetrics {
+			if m.Area != area {
+				continue
+			}
+			sessions, err := scoredSessions(db, m)
+			if err != nil {
+				return nil, err
+			}
+			if len(sessions) == 0 {
+				continue
+			}
+			scores := normalizeSessions(m, sessions, nil)
+
+			recent := len(sessions)
+			if recent > profileRecentSessions {
+				recent = profileRecentSessions
+			}
+			gs := types.GameScore{
+				GameCode:       m.GameCode,
+				Metric:         m.Metric,
+				Area:           area,
+				TotalSessions:  len(sessions),
+				RecentSessions: recent,
+			}
+			var recentZs []*float64
+			for i := len(sessions) - recent; i < len(sessions); i++ {
+				gs.RecentValue += sessions[i].Value // Accumulate for now
+				recentZs = append(recentZs, scores[i].HistoryZ)
+			}
+			gs.RecentValue /= float64(recent)
+			gs.HistoryZ = meanOf(recentZs)
+			if norm, ok := norms[m.GameCode]; ok {
+				gs.NormZ, gs.NormPercentile = normScore(m, norm, gs.RecentValue)
+			}
+
+			cs.Games = append(cs.Games, gs)
+			historyZs = append(historyZs, gs.HistoryZ)
+			normZs = append(normZs, gs.NormZ)
+		}
+
+		cs.HistoryZ = meanOf(historyZs)
+		cs.NormZ = meanOf(normZs)
+		if cs.NormZ != nil {
+			percentile := normalPercentile(*cs.NormZ)
+			cs.NormPercentile = &percentile
+		}
+		profile.Areas = append(profile.Areas, cs)
+	}
+
+	profile.WeakestArea = weakestArea(profile.Areas, func(cs types.CompetencyScore) *float64 { return cs.NormZ })
+	if profile.WeakestArea == "" {
+		profile.WeakestArea = weakestArea(profile.Areas, func(cs types.CompetencyScore) *float64 { return cs.HistoryZ })
+	}
+	return profile, nil
+}
+
+// weakestArea returns the area with the lowest z-score, or "" if no area has one.
+func weakestArea(areas []types.CompetencyScore, z func(types.CompetencyScore) *float64) string {
+	weakest := ""
+	lowest := math.Inf(1)
+	for _, cs := range areas {
+		if v := z(cs); v != nil && *v < lowest {
+			weakest, lowest = cs.Area, *v
+		}
+	}
+	return weakest
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"math"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestNormalizedScoresAndProfile(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	// Three Tower sessions solving 0, 1 and 2 of two problems, and one without results.
+	var towerSessions []int64
+	for solved := 0; solved <= 2; solved++ {
+		sessionID, err := CreateGameSession(db, types.GameCodeTower, "{}")
+		if err != nil {
+			t.Fatalf("Failed to create game session: %v", err)
+		}
+		towerSessions = append(towerSessions, sessionID)
+		for p := 1; p <= 2; p++ {
+			r := types.TowerResult{SessionID: sessionID, ProblemNum: p, Discs: 3,
+				Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{}, {}, {3, 2, 1}},
+				Moves: []types.TowerMove{}, OptimalMoves: 7, MoveLimit: 14, IsSolved: p <= solved, TimeTakenMs: 1000}
+			if err := SaveTowerResult(db, r); err != nil {
+				t.Fatalf("SaveTowerResult failed: %v", err)
+			}
+		}
+	}
+	if _, err := CreateGameSession(db, types.GameCodeTower, "{}"); err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+	// Two Shape Rotation sessions, the second one slower.
+	for _, solveTime := range []int{2000, 4000} {
+		sessionID, err := SaveShapeRotationSession(db, types.ShapeRotationSettings{NumProblems: 1, Round: 1})
+		if err != nil {
+			t.Fatalf("SaveShapeRotationSession failed: %v", err)
+		}
+		r := types.ShapeRotationResult{SessionID: sessionID, ProblemID: 1, UserSolution: []string{}, IsCorrect: true, SolveTime: solveTime}
+		if err := SaveShapeRotationResult(db, r); err != nil {
+			t.Fatalf("SaveShapeRotationResult failed: %v", err)
+		}
+	}
+
+	scores, err := GetNormalizedScores(db, types.GameCodeTower)
+	if err != nil {
+		t.Fatalf("GetNormalizedScores failed: %v", err)
+	}
+	if len(scores) != 3 {
+		t.Fatalf("Expected the 3 sessions with results to be scored, got %d", len(scores))
+	}
+	if scores[0].HistoryPercentile != nil || scores[1].HistoryZ != nil || *scores[1].HistoryPercentile != 100 {
+		t.Errorf("Unexpected history of the early sessions: %+v, %+v", scores[0], scores[1])
+	}
+	// Solve rates 0 and 50 before it: mean 25, standard deviation 35.36.
+	last := scores[2]
+	if last.Value != 100 || last.HistoryCount != 2 || math.Abs(*last.HistoryZ-75/math.Sqrt(1250)) > 1e-9 {
+		t.Errorf("Unexpected score of the last session: %+v", last)
+	}
+	if last.NormZ != nil {
+		t.Errorf("Expected no norm score before a norm table is imported, got %v", *last.NormZ)
+	}
+
+	profile, err := GetCompetencyProfile(db)
+	if err != nil {
+		t.Fatalf("GetCompetencyProfile failed: %v", err)
+	}
+	if len(profile.Areas) != 4 || len(profile.Areas[0].Games) != 0 || profile.WeakestArea != types.CompetencyReasoning {
+		t.Errorf("Expected reasoning, the only area with a history score, to be weakest, got %+v", profile)
+	}
+
+	badTables := []types.NormTable{
+		{Norms: []types.ScoreNorm{{GameCode: "UNKNOWN", Metric: "solveRate", StdDev: 1}}},
+		{Norms: []types.ScoreNorm{{GameCode: types.GameCodeTower, Metric: "accuracy", StdDev: 1}}},
+		{Norms: []types.ScoreNorm{{GameCode: types.GameCodeTower, Metric: "solveRate", StdDev: 0}}},
+		{Norms: []types.ScoreNorm{{GameCode: types.GameCodeTower, Metric: "solveRate", StdDev: 1}, {GameCode: types.GameCodeTower, Metric: "solveRate", StdDev: 2}}},
+	}
+	for _, table := range badTables {
+		if err := ImportNormTable(db, table); err == nil {
+			t.Errorf("Expected norm table %+v to be rejected", table)
+		}
+	}
+
+	norms := types.NormTable{Name: "test", Norms: []types.ScoreNorm{
+		{GameCode: types.GameCodeTower, Metric: "solveRate", Mean: 50, StdDev: 25, SampleSize: 100},
+		{GameCode: types.GameCodeShapeRotation, Metric: "averageSolveTimeMs", Mean: 2000, StdDev: 1000},
+	}}
+	if err := ImportNormTable(db, norms); err != nil {
+		t.Fatalf("ImportNormTable failed: %v", err)
+	}
+	stored, err := GetNormTable(db)
+	if err != nil {
+		t.Fatalf("GetNormTable failed: %v", err)
+	}
+	if stored.Name != "test" || len(stored.Norms) != 2 {
+		t.Errorf("Unexpected stored norm table: %+v", stored)
+	}
+
+	score, err := GetNormalizedSessionScore(db, towerSessions[2])
+	if err != nil {
+		t.Fatalf("GetNormalizedSessionScore failed: %v", err)
+	}
+	if *score.NormZ != 2 || math.Abs(*score.NormPercentile-97.725) > 0.001 {
+		t.Errorf("Expected norm z 2 at the 97.7th percentile, got %v and %v", *score.NormZ, *score.NormPercentile)
+	}
+
+	profile, err = GetCompetencyProfile(db)
+	if err != nil {
+		t.Fatalf("GetCompetencyProfile failed: %v", err)
+	}
+	spatial := profile.Areas[2]
+	// A mean solve time of 3000ms is one standard deviation slower than the norm.
+	if spatial.Area != types.CompetencySpatial || len(spatial.Games) != 1 || spatial.Games[0].RecentValue != 3000 || *spatial.NormZ != -1 {
+		t.Errorf("Unexpected spatial score: %+v", spatial)
+	}
+	if profile.WeakestArea != types.CompetencySpatial {
+		t.Errorf("Expected spatial to be weakest against the norms, got %s", profile.WeakestArea)
+	}
+}
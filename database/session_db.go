@@ -0,0 +1,443 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// excludeIncompleteKey is the preference that leaves sessions which were not completed
+// out of stats and trends.
+const excludeIncompleteKey = "excludeIncompleteSessions"
+
+// historyFilter returns the WHERE condition and its arguments that pick a game's sessions
+// for the history pages: sessions in the trash are left out, and when tags are given only
+// sessions carrying every one of them are kept. Column names are unqualified, so the
+// condition must be used against an unaliased game_sessions.
+func historyFilter(gameCode string, tags []string) (string, []interface{}) {
+	where := "game_code = ? AND deleted_at IS NULL"
+	args := []interface{}{gameCode}
+	for _, tag := range normalizeTags(tags) {
+		where += " AND EXISTS (SELECT 1 FROM session_tags t WHERE t.session_id = game_sessions.id AND t.tag = ?)"
+		args = append(args, tag)
+	}
+	return where, args
+}
+
+// pageArgs appends the LIMIT and OFFSET of a history page to the filter arguments.
+func pageArgs(args []interface{}, limit, offset int) []interface{} {
+	return append(append([]interface{}{}, args...), limit, offset)
+}
+
+// normalizeTags trims the tags and drops empty and repeated ones, keeping their order.
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// loadSessionDetails fills in the lifecycle, notes and tags of a session.
+func loadSessionDetails(db *sql.DB, s *types.GameSession) error {
+	err := db.QueryRow("SELECT status, ended_at, expected_trials, notes FROM game_sessions WHERE id = ?", s.ID).
+		Scan(&s.Status, &s.EndedAt, &s.ExpectedTrials, &s.Notes)
+	if err != nil {
+		return fmt.Errorf("failed to get details of session %d: %w", s.ID, err)
+	}
+	tags, err := GetSessionTags(db, s.ID)
+	if err != nil {
+		return err
+	}
+	s.Tags = tags
+	return nil
+}
+
+// GetSessionTags returns the tags of a session in alphabetical order.
+func GetSessionTags(db *sql.DB, sessionID int64) ([]string, error) {
+	rows, err := db.Query("SELECT tag FROM session_tags WHERE session_id = ? ORDER BY tag ASC", sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags of session %d: %w", sessionID, err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return tags, nil
+}
+
+// GetAllTags returns every tag used by a session outside the trash, in alphabetical order.
+func GetAllTags(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT DISTINCT t.tag FROM session_tags t
+		JOIN game_sessions s ON s.id = t.session_id
+		WHERE s.deleted_at IS NULL
+		ORDER BY t.tag ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return tags, nil
+}
+
+// SetSessionNotes replaces the free-text notes of a session.
+func SetSessionNotes(db *sql.DB, sessionID int64, notes string) error {
+	res, err := db.Exec("UPDATE game_sessions SET notes = ? WHERE id = ?", notes, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to set notes of session %d: %w", sessionID, err)
+	}
+	return requireSession(res, sessionID)
+}
+
+// SetSessionTags replaces the tags of a session. Tags are trimmed, and empty or repeated
+// ones are dropped.
+func SetSessionTags(db *sql.DB, sessionID int64, tags []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM game_sessions WHERE id = ?)", sessionID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	if !exists {
+		return fmt.Errorf("session %d not found", sessionID)
+	}
+
+	if _, err := tx.Exec("DELETE FROM session_tags WHERE session_id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to clear tags of session %d: %w", sessionID, err)
+	}
+	for _, tag := range normalizeTags(tags) {
+		if _, err := tx.Exec("INSERT INTO session_tags (session_id, tag) VALUES (?, ?)", sessionID, tag); err != nil {
+			return fmt.Errorf("failed to tag session %d: %w", sessionID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tags: %w", err)
+	}
+	return nil
+}
+
+// requireSession turns an update that touched no row into a not-found error.
+func requireSession(res sql.Result, sessionID int64) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check session %d: %w", sessionID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("session %d not found", sessionID)
+	}
+	return nil
+}
+
+// DeleteSession moves a session to the trash. Its results are kept until it is purged,
+// but it no longer shows up in history, statistics or personal records.
+func DeleteSession(db *sql.DB, sessionID int64) error {
+	gameCode, deleted, err := sessionState(db, sessionID)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return nil
+	}
+	if _, err := db.Exec("UPDATE game_sessions SET deleted_at = datetime('now','localtime') WHERE id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to delete session %d: %w", sessionID, err)
+	}
+	return RebuildPersonalRecords(db, gameCode)
+}
+
+// RestoreSession takes a session back out of the trash.
+func RestoreSession(db *sql.DB, sessionID int64) error {
+	gameCode, deleted, err := sessionState(db, sessionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return nil
+	}
+	if _, err := db.Exec("UPDATE game_sessions SET deleted_at = NULL WHERE id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to restore session %d: %w", sessionID, err)
+	}
+	return RebuildPersonalRecords(db, gameCode)
+}
+
+// sessionState returns the game of a session and whether it is in the trash.
+func sessionState(db *sql.DB, sessionID int64) (string, bool, error) {
+	var gameCode string
+	var deleted bool
+	err := db.QueryRow("SELECT game_code, deleted_at IS NOT NULL FROM game_sessions WHERE id = ?", sessionID).Scan(&gameCode, &deleted)
+	if err == sql.ErrNoRows {
+		return "", false, fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	return gameCode, deleted, nil
+}
+
+// PurgeSession permanently removes a session in the trash together with its results,
+// tags and records. Older database files were created without cascading foreign keys,
+// so every dependent table is cleared explicitly.
+func PurgeSession(db *sql.DB, sessionID int64) error {
+	_, deleted, err := sessionState(db, sessionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("session %d is not in the trash", sessionID)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tables := []string{"session_tags", "session_states", "personal_records"}
+	for _, m := range scoreMetrics {
+		tables = append(tables, m.resultTables...)
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE session_id = ?", sessionID); err != nil {
+			return fmt.Errorf("failed to purge %s of session %d: %w", table, sessionID, err)
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM game_sessions WHERE id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to purge session %d: %w", sessionID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return nil
+}
+
+// PurgeDeletedSessions empties the trash and returns the number of sessions removed.
+func PurgeDeletedSessions(db *sql.DB) (int, error) {
+	deleted, err := GetDeletedSessions(db)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range deleted {
+		if err := PurgeSession(db, s.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(deleted), nil
+}
+
+// GetDeletedSessions returns the sessions in the trash, most recently deleted first.
+func GetDeletedSessions(db *sql.DB) ([]types.DeletedSession, error) {
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings, deleted_at
+		FROM game_sessions
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []types.DeletedSession{}
+	for rows.Next() {
+		var s types.DeletedSession
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings, &s.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	rows.Close()
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// GetSessionStatus returns the lifecycle status of a session.
+func GetSessionStatus(db *sql.DB, sessionID int64) (string, error) {
+	var status string
+	err := db.QueryRow("SELECT status FROM game_sessions WHERE id = ?", sessionID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	return status, nil
+}
+
+// AllTrialsAnswered reports whether a session in progress has a result for every trial it
+// expects. Sessions that expect no set number of trials never have.
+func AllTrialsAnswered(db *sql.DB, sessionID int64) (bool, error) {
+	var gameCode, status string
+	var expected int
+	err := db.QueryRow("SELECT game_code, status, expected_trials FROM game_sessions WHERE id = ?", sessionID).
+		Scan(&gameCode, &status, &expected)
+	if err == sql.ErrNoRows {
+		return false, fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	if status != types.SessionStatusInProgress || expected <= 0 {
+		return false, nil
+	}
+
+	m, err := scoreMetricFor(gameCode)
+	if err != nil {
+		return false, err
+	}
+	answered := 0
+	for _, table := range m.resultTables {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE session_id = ?", sessionID).Scan(&n); err != nil {
+			return false, fmt.Errorf("failed to count results of session %d: %w", sessionID, err)
+		}
+		answered += n
+	}
+	return answered >= expected, nil
+}
+
+// EndGameSession marks an in-progress session as completed.
+func EndGameSession(db *sql.DB, sessionID int64) error {
+	return closeGameSession(db, sessionID, types.SessionStatusCompleted)
+}
+
+// AbandonGameSession marks an in-progress session as abandoned.
+func AbandonGameSession(db *sql.DB, sessionID int64) error {
+	return closeGameSession(db, sessionID, types.SessionStatusAbandoned)
+}
+
+// closeGameSession moves an in-progress session to its final status.
+func closeGameSession(db *sql.DB, sessionID int64, status string) error {
+	res, err := db.Exec(`
+		UPDATE game_sessions SET status = ?, ended_at = datetime('now','localtime')
+		WHERE id = ? AND status = ?`, status, sessionID, types.SessionStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to close session %d: %w", sessionID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check session %d: %w", sessionID, err)
+	}
+	if n > 0 {
+		return clearSessionState(db, sessionID)
+	}
+
+	var current string
+	err = db.QueryRow("SELECT status FROM game_sessions WHERE id = ?", sessionID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	return fmt.Errorf("session %d is already %s", sessionID, current)
+}
+
+// AbandonStaleSessions marks every session still in progress that started before
+// startedBefore as abandoned, and returns how many there were. A session with a saved
+// state can still be resumed, so it is kept unless its state was last saved before
+// savedBefore.
+func AbandonStaleSessions(db *sql.DB, startedBefore, savedBefore time.Time) (int64, error) {
+	res, err := db.Exec(`
+		UPDATE game_sessions SET status = ?, ended_at = datetime('now','localtime')
+		WHERE status = ? AND play_datetime < ?
+		AND NOT EXISTS (
+			SELECT 1 FROM session_states st
+			WHERE st.session_id = game_sessions.id AND st.updated_at >= ?)`,
+		types.SessionStatusAbandoned, types.SessionStatusInProgress,
+		startedBefore.Format("2006-01-02 15:04:05"), savedBefore.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to abandon stale sessions: %w", err)
+	}
+	_, err = db.Exec(`
+		DELETE FROM session_states
+		WHERE session_id IN (SELECT id FROM game_sessions WHERE status != ?)`, types.SessionStatusInProgress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear state of stale sessions: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// GetExcludeIncompleteSessions reports whether stats and trends leave out sessions that
+// were not completed.
+func GetExcludeIncompleteSessions(db *sql.DB) (bool, error) {
+	var value string
+	err := db.QueryRow("SELECT value FROM preferences WHERE key = ?", excludeIncompleteKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get preference %s: %w", excludeIncompleteKey, err)
+	}
+	return value == "true", nil
+}
+
+// SetExcludeIncompleteSessions sets whether stats and trends leave out sessions that were
+// not completed, and rebuilds the personal records to match.
+func SetExcludeIncompleteSessions(db *sql.DB, exclude bool) error {
+	_, err := db.Exec(`
+		INSERT INTO preferences (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+		excludeIncompleteKey, fmt.Sprint(exclude))
+	if err != nil {
+		return fmt.Errorf("failed to set preference %s: %w", excludeIncompleteKey, err)
+	}
+	for _, m := range scoreMetrics {
+		if err := RebuildPersonalRecords(db, m.GameCode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countedSessions returns an SQL condition on the game_sessions row s that holds for the
+// sessions that count towards stats and trends: those outside the trash and, if the
+// player chose so, only completed ones.
+func countedSessions(db *sql.DB) (string, error) {
+	exclude, err := GetExcludeIncompleteSessions(db)
+	if err != nil {
+		return "", err
+	}
+	if exclude {
+		return "s.deleted_at IS NULL AND s.status = '" + types.SessionStatusCompleted + "'", nil
+	}
+	return "s.deleted_at IS NULL", nil
+}
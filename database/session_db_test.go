@@ -0,0 +1,318 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"acca-games/types"
+)
+
+func TestSessionTagsAndNotes(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	first := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -2), true)
+	second := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -1), true)
+	third := saveTowerSession(t, db, types.TowerSettings{}, now, true)
+
+	if err := SetSessionTags(db, first, []string{" tired ", "", "tired", "new strategy"}); err != nil {
+		t.Fatalf("SetSessionTags failed: %v", err)
+	}
+	if err := SetSessionTags(db, second, []string{"tired"}); err != nil {
+		t.Fatalf("SetSessionTags failed: %v", err)
+	}
+	if err := SetSessionNotes(db, first, "Slept badly"); err != nil {
+		t.Fatalf("SetSessionNotes failed: %v", err)
+	}
+	if err := SetSessionTags(db, 999, []string{"tired"}); err == nil {
+		t.Error("Expected tagging a missing session to fail")
+	}
+	if err := SetSessionNotes(db, 999, "x"); err == nil {
+		t.Error("Expected noting a missing session to fail")
+	}
+
+	page, err := GetPaginatedTowerSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 3 || len(page.Sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d of %d", len(page.Sessions), page.TotalCount)
+	}
+	if s := page.Sessions[2]; s.ID != first || s.Notes != "Slept badly" || !reflect.DeepEqual(s.Tags, []string{"new strategy", "tired"}) {
+		t.Errorf("Unexpected labels on the first session: %+v", s.GameSession)
+	}
+	if s := page.Sessions[0]; s.ID != third || !reflect.DeepEqual(s.Tags, []string{}) {
+		t.Errorf("Expected the untagged session to have no tags, got %+v", s.GameSession)
+	}
+
+	tired, err := GetPaginatedTowerSessionsWithResults(db, 1, 10, "tired")
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if tired.TotalCount != 2 || len(tired.Sessions) != 2 || tired.Sessions[0].ID != second {
+		t.Errorf("Expected the two tired sessions, got %+v", tired)
+	}
+	both, err := GetPaginatedTowerSessionsWithResults(db, 1, 10, "tired", "new strategy")
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if both.TotalCount != 1 || len(both.Sessions) != 1 || both.Sessions[0].ID != first {
+		t.Errorf("Expected only the session with both tags, got %+v", both)
+	}
+
+	tags, err := GetAllTags(db)
+	if err != nil {
+		t.Fatalf("GetAllTags failed: %v", err)
+	}
+	if !reflect.DeepEqual(tags, []string{"new strategy", "tired"}) {
+		t.Errorf("Unexpected tags: %v", tags)
+	}
+}
+
+func TestDeleteRestoreAndPurgeSession(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	best := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -1), true, true)
+	other := saveTowerSession(t, db, types.TowerSettings{}, now, true, false)
+	for _, id := range []int64{best, other} {
+		if _, err := UpdatePersonalRecords(db, id); err != nil {
+			t.Fatalf("UpdatePersonalRecords failed: %v", err)
+		}
+	}
+	if err := SetSessionTags(db, best, []string{"botched"}); err != nil {
+		t.Fatalf("SetSessionTags failed: %v", err)
+	}
+
+	solveRate := func() (float64, int64) {
+		t.Helper()
+		records, err := GetPersonalRecords(db)
+		if err != nil {
+			t.Fatalf("GetPersonalRecords failed: %v", err)
+		}
+		for _, r := range records {
+			if r.Round == 0 {
+				return r.Value, r.SessionID
+			}
+		}
+		t.Fatalf("No session record in %+v", records)
+		return 0, 0
+	}
+	if value, sessionID := solveRate(); value != 100 || sessionID != best {
+		t.Fatalf("Expected the first session to hold the record, got %v from %d", value, sessionID)
+	}
+
+	if err := PurgeSession(db, best); err == nil {
+		t.Error("Expected purging a session outside the trash to fail")
+	}
+	if err := DeleteSession(db, best); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	if err := DeleteSession(db, 999); err == nil {
+		t.Error("Expected deleting a missing session to fail")
+	}
+
+	page, err := GetPaginatedTowerSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 1 || page.Sessions[0].ID != other {
+		t.Errorf("Expected only the remaining session in history, got %+v", page)
+	}
+	sessions, err := GetSessionsWithResults(db, types.GameCodeTower)
+	if err != nil {
+		t.Fatalf("GetSessionsWithResults failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Errorf("Expected deleted sessions to be left out of stats, got %d sessions", len(sessions))
+	}
+	if value, sessionID := solveRate(); value != 50 || sessionID != other {
+		t.Errorf("Expected the record to fall back to the remaining session, got %v from %d", value, sessionID)
+	}
+	tags, err := GetAllTags(db)
+	if err != nil {
+		t.Fatalf("GetAllTags failed: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("Expected tags of deleted sessions to be hidden, got %v", tags)
+	}
+
+	deleted, err := GetDeletedSessions(db)
+	if err != nil {
+		t.Fatalf("GetDeletedSessions failed: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != best || !reflect.DeepEqual(deleted[0].Tags, []string{"botched"}) {
+		t.Fatalf("Unexpected trash: %+v", deleted)
+	}
+
+	if err := RestoreSession(db, best); err != nil {
+		t.Fatalf("RestoreSession failed: %v", err)
+	}
+	if value, sessionID := solveRate(); value != 100 || sessionID != best {
+		t.Errorf("Expected the restored session to regain the record, got %v from %d", value, sessionID)
+	}
+
+	if err := DeleteSession(db, best); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	purged, err := PurgeDeletedSessions(db)
+	if err != nil {
+		t.Fatalf("PurgeDeletedSessions failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 purged session, got %d", purged)
+	}
+
+	var sessionsLeft, resultsLeft, tagsLeft int
+	if err := db.QueryRow("SELECT COUNT(*) FROM game_sessions").Scan(&sessionsLeft); err != nil {
+		t.Fatalf("Failed to count sessions: %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM tower_results WHERE session_id = ?", best).Scan(&resultsLeft); err != nil {
+		t.Fatalf("Failed to count results: %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM session_tags").Scan(&tagsLeft); err != nil {
+		t.Fatalf("Failed to count tags: %v", err)
+	}
+	if sessionsLeft != 1 || resultsLeft != 0 || tagsLeft != 0 {
+		t.Errorf("Expected the purged session to be gone, got %d sessions, %d results and %d tags", sessionsLeft, resultsLeft, tagsLeft)
+	}
+	if err := RestoreSession(db, best); err == nil {
+		t.Error("Expected restoring a purged session to fail")
+	}
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	stale := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -2), true)
+	recent := saveTowerSession(t, db, types.TowerSettings{}, now.Add(-time.Hour), false)
+
+	abandoned, err := AbandonStaleSessions(db, now.Add(-24*time.Hour), now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("AbandonStaleSessions failed: %v", err)
+	}
+	if abandoned != 1 {
+		t.Errorf("Expected 1 stale session, got %d", abandoned)
+	}
+	if err := EndGameSession(db, recent); err != nil {
+		t.Fatalf("EndGameSession failed: %v", err)
+	}
+	if err := EndGameSession(db, recent); err == nil {
+		t.Error("Expected ending a completed session to fail")
+	}
+	if err := AbandonGameSession(db, stale); err == nil {
+		t.Error("Expected abandoning an abandoned session to fail")
+	}
+	if err := EndGameSession(db, 999); err == nil {
+		t.Error("Expected ending a missing session to fail")
+	}
+
+	page, err := GetPaginatedTowerSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if len(page.Sessions) != 2 {
+		t.Fatalf("Expected history to keep incomplete sessions, got %d", len(page.Sessions))
+	}
+	if s := page.Sessions[0].GameSession; s.ID != recent || s.Status != types.SessionStatusCompleted || s.EndedAt.IsZero() {
+		t.Errorf("Expected the recent session to be completed, got %+v", s)
+	}
+	if s := page.Sessions[1].GameSession; s.ID != stale || s.Status != types.SessionStatusAbandoned {
+		t.Errorf("Expected the stale session to be abandoned, got %+v", s)
+	}
+
+	sessions, err := GetSessionsWithResults(db, types.GameCodeTower)
+	if err != nil {
+		t.Fatalf("GetSessionsWithResults failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("Expected incomplete sessions to count by default, got %d", len(sessions))
+	}
+
+	if err := SetExcludeIncompleteSessions(db, true); err != nil {
+		t.Fatalf("SetExcludeIncompleteSessions failed: %v", err)
+	}
+	if exclude, err := GetExcludeIncompleteSessions(db); err != nil || !exclude {
+		t.Errorf("Expected the preference to be stored, got %v, %v", exclude, err)
+	}
+	sessions, err = GetSessionsWithResults(db, types.GameCodeTower)
+	if err != nil {
+		t.Fatalf("GetSessionsWithResults failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != recent {
+		t.Errorf("Expected only the completed session to count, got %+v", sessions)
+	}
+	records, err := GetPersonalRecords(db)
+	if err != nil {
+		t.Fatalf("GetPersonalRecords failed: %v", err)
+	}
+	for _, r := range records {
+		if r.SessionID != recent {
+			t.Errorf("Expected records from the completed session only, got %+v", r)
+		}
+	}
+	if set, err := UpdatePersonalRecords(db, stale); err != nil || len(set) != 0 {
+		t.Errorf("Expected the abandoned session to set no records, got %+v, %v", set, err)
+	}
+}
+
+func TestAllTrialsAnswered(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := StartGameSession(db, types.GameCodeStroop, types.StroopSettings{}, 2)
+	if err != nil {
+		t.Fatalf("StartGameSession failed: %v", err)
+	}
+	open, err := CreateGameSession(db, types.GameCodeStroop, types.StroopSettings{})
+	if err != nil {
+		t.Fatalf("CreateGameSession failed: %v", err)
+	}
+
+	for trial := 1; trial <= 2; trial++ {
+		if done, err := AllTrialsAnswered(db, sessionID); err != nil || done {
+			t.Fatalf("Expected %d answers not to be enough, got %v, %v", trial-1, done, err)
+		}
+		for _, id := range []int64{sessionID, open} {
+			if err := SaveStroopResult(db, types.StroopResult{SessionID: id, TrialNum: trial, Condition: types.StroopCongruent}); err != nil {
+				t.Fatalf("SaveStroopResult failed: %v", err)
+			}
+		}
+	}
+	if done, err := AllTrialsAnswered(db, sessionID); err != nil || !done {
+		t.Errorf("Expected every trial to be answered, got %v, %v", done, err)
+	}
+	if done, err := AllTrialsAnswered(db, open); err != nil || done {
+		t.Errorf("Expected a session with no set number of trials never to be done, got %v, %v", done, err)
+	}
+
+	if err := EndGameSession(db, sessionID); err != nil {
+		t.Fatalf("EndGameSession failed: %v", err)
+	}
+	if done, err := AllTrialsAnswered(db, sessionID); err != nil || done {
+		t.Errorf("Expected a completed session to have nothing left to complete, got %v, %v", done, err)
+	}
+	if status, err := GetSessionStatus(db, sessionID); err != nil || status != types.SessionStatusCompleted {
+		t.Errorf("Expected the session to be completed, got %q, %v", status, err)
+	}
+	if _, err := GetSessionStatus(db, 999); err == nil {
+		t.Error("Expected a missing session to have no status")
+	}
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"acca-games/types"
+)
+
+// SaveSessionState stores the generated problems and progress of a session, so that it
+// can be resumed if the app closes before the session ends. The state is stored as JSON
+// and replaces any saved before.
+func SaveSessionState(db *sql.DB, sessionID int64, state interface{}, nextTrial int) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state of session %d: %w", sessionID, err)
+	}
+	_, err = db.Exec(`
+		INSERT INTO session_states (session_id, state, next_trial, updated_at)
+		VALUES (?, ?, ?, datetime('now','localtime'))
+		ON CONFLICT (session_id) DO UPDATE SET
+			state = excluded.state, next_trial = excluded.next_trial, updated_at = excluded.updated_at`,
+		sessionID, string(data), nextTrial)
+	if err != nil {
+		return fmt.Errorf("failed to save state of session %d: %w", sessionID, err)
+	}
+	return nil
+}
+
+// SetSessionProgress moves the progress pointer of a saved session to the next
+// unanswered trial, leaving the state as it is. Sessions without a saved state, such as
+// those started before states were kept, are left alone.
+func SetSessionProgress(db *sql.DB, sessionID int64, nextTrial int) error {
+	_, err := db.Exec(`
+		UPDATE session_states SET next_trial = ?, updated_at = datetime('now','localtime')
+		WHERE session_id = ?`, nextTrial, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to save progress of session %d: %w", sessionID, err)
+	}
+	return nil
+}
+
+// AdvanceSessionProgress moves the progress pointer of a saved session on by one trial,
+// for games whose answers do not say which trial they belong to.
+func AdvanceSessionProgress(db *sql.DB, sessionID int64) error {
+	_, err := db.Exec(`
+		UPDATE session_states SET next_trial = next_trial + 1, updated_at = datetime('now','localtime')
+		WHERE session_id = ?`, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to save progress of session %d: %w", sessionID, err)
+	}
+	return nil
+}
+
+// LoadSessionState reads the saved state of a session into state and returns the number
+// of the next unanswered trial. Only sessions still in progress can be resumed.
+func LoadSessionState(db *sql.DB, sessionID int64, state interface{}) (int, error) {
+	var status string
+	var deletedAt types.CustomTime
+	err := db.QueryRow("SELECT status, deleted_at FROM game_sessions WHERE id = ?", sessionID).Scan(&status, &deletedAt)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("session %d not found", sessionID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	if !deletedAt.IsZero() {
+		return 0, fmt.Errorf("session %d is in the trash", sessionID)
+	}
+	if status != types.SessionStatusInProgress {
+		return 0, fmt.Errorf("session %d is already %s", sessionID, status)
+	}
+
+	var data string
+	var nextTrial int
+	err = db.QueryRow("SELECT state, next_trial FROM session_states WHERE session_id = ?", sessionID).Scan(&data, &nextTrial)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("session %d has no saved state", sessionID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get state of session %d: %w", sessionID, err)
+	}
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal state of session %d: %w", sessionID, err)
+	}
+	return nextTrial, nil
+}
+
+// clearSessionState drops the saved state of a session that has ended.
+func clearSessionState(db *sql.DB, sessionID int64) error {
+	if _, err := db.Exec("DELETE FROM session_states WHERE session_id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to clear state of session %d: %w", sessionID, err)
+	}
+	return nil
+}
+
+// GetResumableSessions returns the sessions still in progress that have a saved state,
+// newest first.
+func GetResumableSessions(db *sql.DB) ([]types.GameSession, error) {
+	rows, err := db.Query(`
+		SELECT s.id, s.game_code, s.play_datetime, s.settings
+		FROM game_sessions s
+		JOIN session_states st ON st.session_id = s.id
+		WHERE s.status = ? AND s.deleted_at IS NULL
+		ORDER BY s.play_datetime DESC, s.id DESC`, types.SessionStatusInProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resumable sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []types.GameSession{}
+	for rows.Next() {
+		var s types.GameSession
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			return nil, fmt.Errorf("failed to scan resumable session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	rows.Close()
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"acca-games/types"
+)
+
+func TestSessionState(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	type state struct {
+		Problems []int `json:"problems"`
+	}
+	sessionID, err := StartGameSession(db, types.GameCodeTower, types.TowerSettings{}, 3)
+	if err != nil {
+		t.Fatalf("StartGameSession failed: %v", err)
+	}
+	if err := SaveSessionState(db, sessionID, state{Problems: []int{4, 5, 6}}, 1); err != nil {
+		t.Fatalf("SaveSessionState failed: %v", err)
+	}
+	if err := SetSessionProgress(db, sessionID, 2); err != nil {
+		t.Fatalf("SetSessionProgress failed: %v", err)
+	}
+	if err := AdvanceSessionProgress(db, sessionID); err != nil {
+		t.Fatalf("AdvanceSessionProgress failed: %v", err)
+	}
+
+	var loaded state
+	next, err := LoadSessionState(db, sessionID, &loaded)
+	if err != nil {
+		t.Fatalf("LoadSessionState failed: %v", err)
+	}
+	if next != 3 || len(loaded.Problems) != 3 || loaded.Problems[2] != 6 {
+		t.Errorf("Unexpected state %+v pointing at %d", loaded, next)
+	}
+
+	resumable, err := GetResumableSessions(db)
+	if err != nil {
+		t.Fatalf("GetResumableSessions failed: %v", err)
+	}
+	if len(resumable) != 1 || resumable[0].ID != sessionID || resumable[0].ExpectedTrials != 3 {
+		t.Errorf("Expected the saved session to be resumable, got %+v", resumable)
+	}
+
+	// Progress on a session without a saved state is ignored rather than failing the answer.
+	unsaved := saveTowerSession(t, db, types.TowerSettings{}, time.Now(), true)
+	if err := AdvanceSessionProgress(db, unsaved); err != nil {
+		t.Errorf("Expected progress without a saved state to be ignored, got %v", err)
+	}
+	if _, err := LoadSessionState(db, unsaved, &loaded); err == nil {
+		t.Error("Expected loading a session without a saved state to fail")
+	}
+
+	if err := EndGameSession(db, sessionID); err != nil {
+		t.Fatalf("EndGameSession failed: %v", err)
+	}
+	if _, err := LoadSessionState(db, sessionID, &loaded); err == nil {
+		t.Error("Expected resuming a completed session to fail")
+	}
+	var statesLeft int
+	if err := db.QueryRow("SELECT COUNT(*) FROM session_states").Scan(&statesLeft); err != nil {
+		t.Fatalf("Failed to count states: %v", err)
+	}
+	if statesLeft != 0 {
+		t.Errorf("Expected the state to be cleared once the session ended, got %d states", statesLeft)
+	}
+}
+
+func TestAbandonStaleSessions_KeepsResumable(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+
+	// All three started days ago; only the one saved recently can still be resumed.
+	resumable := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -3))
+	forgotten := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -60))
+	unsaved := saveTowerSession(t, db, types.TowerSettings{}, now.AddDate(0, 0, -3))
+	for _, id := range []int64{resumable, forgotten} {
+		if err := SaveSessionState(db, id, []int{1, 2, 3}, 2); err != nil {
+			t.Fatalf("SaveSessionState failed: %v", err)
+		}
+	}
+	if _, err := db.Exec("UPDATE session_states SET updated_at = ? WHERE session_id = ?",
+		now.AddDate(0, 0, -45).Format("2006-01-02 15:04:05"), forgotten); err != nil {
+		t.Fatalf("Failed to age state: %v", err)
+	}
+
+	abandoned, err := AbandonStaleSessions(db, now.Add(-24*time.Hour), now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("AbandonStaleSessions failed: %v", err)
+	}
+	if abandoned != 2 {
+		t.Errorf("Expected 2 stale sessions, got %d", abandoned)
+	}
+	for id, want := range map[int64]string{
+		resumable: types.SessionStatusInProgress,
+		forgotten: types.SessionStatusAbandoned,
+		unsaved:   types.SessionStatusAbandoned,
+	} {
+		var status string
+		if err := db.QueryRow("SELECT status FROM game_sessions WHERE id = ?", id).Scan(&status); err != nil {
+			t.Fatalf("Failed to read status: %v", err)
+		}
+		if status != want {
+			t.Errorf("Session %d: expected %s, got %s", id, want, status)
+		}
+	}
+
+	var statesLeft int
+	if _, err := LoadSessionState(db, resumable, &[]int{}); err != nil {
+		t.Errorf("Expected the recently saved session to stay resumable, got %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM session_states WHERE session_id = ?", forgotten).Scan(&statesLeft); err != nil || statesLeft != 0 {
+		t.Errorf("Expected the state of the abandoned session to be cleared, got %d (%v)", statesLeft, err)
+	}
+}
@@ -18,7 +18,8 @@ func SaveShapeRotationSession(db *sql.DB, settings types.ShapeRotationSettings)
 		Settings: string(settingsJSON),
 	}
 
-	result, err := db.Exec(`INSERT INTO game_sessions (game_code, settings) VALUES (?, ?)`, session.GameCode, session.Settings)
+	result, err := db.Exec(`INSERT INTO game_sessions (game_code, settings, expected_trials) VALUES (?, ?, ?)`,
+		session.GameCode, session.Settings, settings.NumProblems)
 	if err != nil {
 		return 0, err
 	}
@@ -65,12 +66,13 @@ func GetShapeRotationResultsForSession(db *sql.DB, sessionID int64) ([]types.Sha
 }
 
 // GetPaginatedShapeRotationSessionsWithResults fetches paginated sessions with their results.
-func GetPaginatedShapeRotationSessionsWithResults(db *sql.DB, page int, limit int) (*types.PaginatedShapeRotationSessions, error) {
+func GetPaginatedShapeRotationSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedShapeRotationSessions, error) {
 	offset := (page - 1) * limit
 
 	// First, get the total count of sessions
+	where, args := historyFilter(types.GameCodeShapeRotation, tags)
 	var totalCount int
-	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE game_code = ?", types.GameCodeShapeRotation).Scan(&totalCount)
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total shape rotation session count: %w", err)
 	}
@@ -83,14 +85,14 @@ func GetPaginatedShapeRotationSessionsWithResults(db *sql.DB, page int, limit in
 		JOIN shape_rotation_results r ON s.id = r.session_id
 		WHERE s.id IN (
 			SELECT id FROM game_sessions
-			WHERE game_code = ?
+			WHERE `+where+`
 			ORDER BY play_datetime DESC
 			LIMIT ? OFFSET ?
 		)
 		ORDER BY s.play_datetime DESC
 	`
 
-	rows, err := db.Query(query, types.GameCodeShapeRotation, limit, offset)
+	rows, err := db.Query(query, pageArgs(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query paginated shape rotation sessions with results: %w", err)
 	}
@@ -130,6 +132,9 @@ func GetPaginatedShapeRotationSessionsWithResults(db *sql.DB, page int, limit in
 	sessions := make([]types.ShapeRotationSessionWithResults, len(sessionOrder))
 	for i, id := range sessionOrder {
 		sessions[i] = *sessionMap[id]
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
 	}
 
 	return &types.PaginatedShapeRotationSessions{
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"fmt"
+)
+
+// SaveStroopResult saves the result of a single Stroop trial.
+func SaveStroopResult(db *sql.DB, result types.StroopResult) error {
+	_, err := db.Exec(`
+		INSERT INTO stroop_results (
+			session_id, trial_num, condition, word, word_color, ink_color, response_key, response_color,
+			is_correct, is_word_error, timed_out, response_time_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.TrialNum, result.Condition, result.Word, result.WordColor, result.InkColor,
+		result.ResponseKey, result.ResponseColor, result.IsCorrect, result.IsWordError, result.TimedOut, result.ResponseTimeMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert stroop result: %w", err)
+	}
+	return nil
+}
+
+// GetStroopResultsForSession retrieves all results for a given session.
+func GetStroopResultsForSession(db *sql.DB, sessionID int64) ([]types.StroopResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, trial_num, condition, word, word_color, ink_color, response_key, response_color,
+		       is_correct, is_word_error, timed_out, response_time_ms
+		FROM stroop_results
+		WHERE session_id = ?
+		ORDER BY trial_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.StroopResult, 0)
+	for rows.Next() {
+		var r types.StroopResult
+		if err := rows.Scan(&r.SessionID, &r.TrialNum, &r.Condition, &r.Word, &r.WordColor, &r.InkColor,
+			&r.ResponseKey, &r.ResponseColor, &r.IsCorrect, &r.IsWordError, &r.TimedOut, &r.ResponseTimeMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedStroopSessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedStroopSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedStroopSessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodeStroop, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.StroopSessionWithResults
+	for rows.Next() {
+		var s types.StroopSessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetStroopResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.StroopSessionWithResults{}
+	}
+
+	return &types.PaginatedStroopSessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetStroopSessionStats calculates statistics for a given session.
+func GetStroopSessionStats(db *sql.DB, sessionID int64) (*types.StroopSessionStats, error) {
+	results, err := GetStroopResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return calculateStroopStats(sessionID, results), nil
+}
+
+// GetStroopInterferenceTrend returns the interference cost of every Stroop session, oldest
+// first. Sessions without both congruent and incongruent trials are skipped.
+func GetStroopInterferenceTrend(db *sql.DB) ([]types.StroopInterferencePoint, error) {
+	counted, err := countedSessions(db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`
+		SELECT s.id, s.play_datetime FROM game_sessions s
+		WHERE s.game_code = ? AND `+counted+`
+		ORDER BY s.play_datetime ASC, s.id ASC`, types.GameCodeStroop)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var points []types.StroopInterferencePoint
+	for rows.Next() {
+		var p types.StroopInterferencePoint
+		if err := rows.Scan(&p.SessionID, &p.PlayDatetime); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	trend := make([]types.StroopInterferencePoint, 0, len(points))
+	for _, p := range points {
+		results, err := GetStroopResultsForSession(db, p.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		stats := calculateStroopStats(p.SessionID, results)
+		if stats.ConditionStats[0].TotalTrials == 0 || stats.ConditionStats[1].TotalTrials == 0 {
+			continue
+		}
+		p.TotalTrials = stats.TotalTrials
+		p.InterferenceResponseTime = stats.InterferenceResponseTime
+		p.InterferenceAccuracy = stats.InterferenceAccuracy
+		trend = append(trend, p)
+	}
+
+	return trend, nil
+}
+
+// calculateStroopStats computes accuracy and the mean response time of correct trials for
+// each condition, and the interference cost as incongruent minus congruent. A condition
+// with no correct trials has no mean response time, so the response time cost is left at 0.
+func calculateStroopStats(sessionID int64, results []types.StroopResult) *types.StroopSessionStats {
+	stats := &types.StroopSessionStats{SessionID: sessionID}
+
+	conditions := []string{types.StroopCongruent, types.StroopIncongruent, types.StroopNeutral}
+	byCondition := make(map[string]*types.StroopConditionStats, len(conditions))
+	for _, c := range conditions {
+		byCondition[c] = &types.StroopConditionStats{Condition: c}
+	}
+
+	totalCorrectTime := 0.0
+	for _, r := range results {
+		stats.TotalTrials++
+		cs, ok := byCondition[r.Condition]
+		if !ok {
+			continue
+		}
+		cs.TotalTrials++
+		if r.TimedOut {
+			cs.TotalTimedOut++
+		}
+		if r.IsWordError {
+			stats.TotalWordErrors++
+		}
+		if r.IsCorrect {
+			stats.TotalCorrect++
+			cs.TotalCorrect++
+			cs.AverageResponseTimeMs += float64(r.ResponseTimeMs) // Accumulate for now
+			totalCorrectTime += float64(r.ResponseTimeMs)
+		}
+	}
+
+	if stats.TotalTrials > 0 {
+		stats.OverallAccuracy = float64(stats.TotalCorrect) / float64(stats.TotalTrials) * 100
+	}
+	if stats.TotalCorrect > 0 {
+		stats.AverageResponseTimeMs = totalCorrectTime / float64(stats.TotalCorrect)
+	}
+
+	stats.ConditionStats = make([]types.StroopConditionStats, 0, len(conditions))
+	for _, c := range conditions {
+		cs := byCondition[c]
+		if cs.TotalTrials > 0 {
+			cs.Accuracy = float64(cs.TotalCorrect) / float64(cs.TotalTrials) * 100
+		}
+		if cs.TotalCorrect > 0 {
+			cs.AverageResponseTimeMs /= float64(cs.TotalCorrect)
+		}
+		stats.ConditionStats = append(stats.ConditionStats, *cs)
+	}
+
+	congruent, incongruent := byCondition[types.StroopCongruent], byCondition[types.StroopIncongruent]
+	if congruent.TotalTrials > 0 && incongruent.TotalTrials > 0 {
+		stats.InterferenceAccuracy = incongruent.Accuracy - congruent.Accuracy
+		if congruent.TotalCorrect > 0 && incongruent.TotalCorrect > 0 {
+			stats.InterferenceResponseTime = incongruent.AverageResponseTimeMs - congruent.AverageResponseTimeMs
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetStroopResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeStroop, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	results := []types.StroopResult{
+		{SessionID: sessionID, TrialNum: 1, Condition: types.StroopCongruent, Word: "빨강", WordColor: "red", InkColor: "red",
+			ResponseKey: "r", ResponseColor: "red", IsCorrect: true, ResponseTimeMs: 500},
+		{SessionID: sessionID, TrialNum: 2, Condition: types.StroopCongruent, Word: "파랑", WordColor: "blue", InkColor: "blue",
+			ResponseKey: "b", ResponseColor: "blue", IsCorrect: true, ResponseTimeMs: 600},
+		{SessionID: sessionID, TrialNum: 3, Condition: types.StroopIncongruent, Word: "빨강", WordColor: "red", InkColor: "green",
+			ResponseKey: "g", ResponseColor: "green", IsCorrect: true, ResponseTimeMs: 700},
+		{SessionID: sessionID, TrialNum: 4, Condition: types.StroopIncongruent, Word: "파랑", WordColor: "blue", InkColor: "yellow",
+			ResponseKey: "b", ResponseColor: "blue", IsWordError: true, ResponseTimeMs: 650},
+		{SessionID: sessionID, TrialNum: 5, Condition: types.StroopNeutral, Word: "의자", InkColor: "red",
+			TimedOut: true, ResponseTimeMs: 2000},
+	}
+	for _, r := range results {
+		if err := SaveStroopResult(db, r); err != nil {
+			t.Fatalf("SaveStroopResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetStroopResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetStroopResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetStroopSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetStroopSessionStats failed: %v", err)
+	}
+	if stats.TotalTrials != 5 || stats.TotalCorrect != 3 || stats.TotalWordErrors != 1 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	// Correct congruent trials average 550ms, the one correct incongruent trial took 700ms.
+	if stats.InterferenceResponseTime != 150 || stats.InterferenceAccuracy != -50 {
+		t.Errorf("Expected interference of 150ms and -50 points, got %v and %v", stats.InterferenceResponseTime, stats.InterferenceAccuracy)
+	}
+	if len(stats.ConditionStats) != 3 || stats.ConditionStats[2].TotalTimedOut != 1 {
+		t.Errorf("Unexpected condition stats: %+v", stats.ConditionStats)
+	}
+
+	// A session with neutral trials only has no interference cost and is left out of the trend.
+	neutralID, _ := CreateGameSession(db, types.GameCodeStroop, "{}")
+	if err := SaveStroopResult(db, types.StroopResult{SessionID: neutralID, TrialNum: 1, Condition: types.StroopNeutral,
+		Word: "책상", InkColor: "red", ResponseKey: "r", ResponseColor: "red", IsCorrect: true, ResponseTimeMs: 500}); err != nil {
+		t.Fatalf("SaveStroopResult failed: %v", err)
+	}
+
+	trend, err := GetStroopInterferenceTrend(db)
+	if err != nil {
+		t.Fatalf("GetStroopInterferenceTrend failed: %v", err)
+	}
+	if len(trend) != 1 || trend[0].SessionID != sessionID || trend[0].InterferenceResponseTime != 150 {
+		t.Errorf("Unexpected trend: %+v", trend)
+	}
+
+	page, err := GetPaginatedStroopSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedStroopSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 2 || len(page.Sessions) != 2 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveTowerResult saves the result of a single Tower problem.
+func SaveTowerResult(db *sql.DB, result types.TowerResult) error {
+	startJSON, err := json.Marshal(result.Start)
+	if err != nil {
+		return fmt.Errorf("failed to marshal start configuration: %w", err)
+	}
+	goalJSON, err := json.Marshal(result.Goal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal goal configuration: %w", err)
+	}
+	movesJSON, err := json.Marshal(result.Moves)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moves: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO tower_results (
+			session_id, problem_num, discs, start_config, goal_config, moves,
+			move_count, optimal_moves, move_limit, is_solved, time_taken_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.ProblemNum, result.Discs, string(startJSON), string(goalJSON), string(movesJSON),
+		result.MoveCount, result.OptimalMoves, result.MoveLimit, result.IsSolved, result.TimeTakenMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert tower result: %w", err)
+	}
+	return nil
+}
+
+// GetTowerResultsForSession retrieves all results for a given session.
+func GetTowerResultsForSession(db *sql.DB, sessionID int64) ([]types.TowerResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, problem_num, discs, start_config, goal_config, moves,
+		       move_count, optimal_moves, move_limit, is_solved, time_taken_ms
+		FROM tower_results
+		WHERE session_id = ?
+		ORDER BY problem_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.TowerResult, 0)
+	for rows.Next() {
+		var r types.TowerResult
+		var startJSON, goalJSON, movesJSON string
+		if err := rows.Scan(&r.SessionID, &r.ProblemNum, &r.Discs, &startJSON, &goalJSON, &movesJSON,
+			&r.MoveCount, &r.OptimalMoves, &r.MoveLimit, &r.IsSolved, &r.TimeTakenMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(startJSON), &r.Start); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal start configuration: %w", err)
+		}
+		if err := json.Unmarshal([]byte(goalJSON), &r.Goal); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal goal configuration: %w", err)
+		}
+		if err := json.Unmarshal([]byte(movesJSON), &r.Moves); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal moves: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedTowerSessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedTowerSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedTowerSessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodeTower, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.TowerSessionWithResults
+	for rows.Next() {
+		var s types.TowerSessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetTowerResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.TowerSessionWithResults{}
+	}
+
+	return &types.PaginatedTowerSessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetTowerSessionStats calculates statistics for a given session.
+func GetTowerSessionStats(db *sql.DB, sessionID int64) (*types.TowerSessionStats, error) {
+	results, err := GetTowerResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.TowerSessionStats{
+		SessionID: sessionID,
+		DiscStats: []types.TowerDiscStats{},
+	}
+
+	discMap := make(map[int]*types.TowerDiscStats)
+	totalTime := 0.0
+	for _, r := range results {
+		stats.TotalProblems++
+		totalTime += float64(r.TimeTakenMs)
+
+		ds, ok := discMap[r.Discs]
+		if !ok {
+			ds = &types.TowerDiscStats{Discs: r.Discs}
+			discMap[r.Discs] = ds
+		}
+		ds.TotalProblems++
+		ds.AverageTimeMs += float64(r.TimeTakenMs) // Accumulate for now
+
+		if r.IsSolved {
+			extra := float64(r.MoveCount - r.OptimalMoves)
+			stats.TotalSolved++
+			stats.AverageExtraMoves += extra
+			ds.TotalSolved++
+			ds.AverageExtraMoves += extra
+			if r.MoveCount == r.OptimalMoves {
+				stats.TotalOptimal++
+			}
+		}
+	}
+
+	if stats.TotalProblems > 0 {
+		stats.SolveRate = float64(stats.TotalSolved) / float64(stats.TotalProblems) * 100
+		stats.AverageTimeMs = totalTime / float64(stats.TotalProblems)
+	}
+	if stats.TotalSolved > 0 {
+		stats.AverageExtraMoves /= float64(stats.TotalSolved)
+	}
+
+	// Disc counts are small, so loop over them in order.
+	maxDiscs := 0
+	for discs := range discMap {
+		maxDiscs = max(maxDiscs, discs)
+	}
+	for discs := 1; discs <= maxDiscs; discs++ {
+		ds, ok := discMap[discs]
+		if !ok {
+			continue
+		}
+		ds.SolveRate = float64(ds.TotalSolved) / float64(ds.TotalProblems) * 100
+		ds.AverageTimeMs /= float64(ds.TotalProblems)
+		if ds.TotalSolved > 0 {
+			ds.AverageExtraMoves /= float64(ds.TotalSolved)
+		}
+		stats.DiscStats = append(stats.DiscStats, *ds)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetTowerResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeTower, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	results := []types.TowerResult{
+		{
+			SessionID: sessionID, ProblemNum: 1, Discs: 3,
+			Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{3, 2}, {}, {1}},
+			Moves: []types.TowerMove{{From: 0, To: 2}}, MoveCount: 1, OptimalMoves: 1, MoveLimit: 2,
+			IsSolved: true, TimeTakenMs: 1000,
+		},
+		{
+			SessionID: sessionID, ProblemNum: 2, Discs: 3,
+			Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{3}, {2}, {1}},
+			Moves:     []types.TowerMove{{From: 0, To: 1}, {From: 0, To: 2}, {From: 1, To: 0}, {From: 2, To: 1}, {From: 0, To: 2}},
+			MoveCount: 5, OptimalMoves: 3, MoveLimit: 6, IsSolved: true, TimeTakenMs: 3000,
+		},
+		{
+			SessionID: sessionID, ProblemNum: 3, Discs: 4,
+			Start: [][]int{{4, 3, 2, 1}, {}, {}}, Goal: [][]int{{}, {4, 3, 2, 1}, {}},
+			Moves: []types.TowerMove{}, MoveCount: 0, OptimalMoves: 15, MoveLimit: 30, TimeTakenMs: 5000,
+		},
+	}
+	for _, r := range results {
+		if err := SaveTowerResult(db, r); err != nil {
+			t.Fatalf("SaveTowerResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetTowerResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetTowerResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetTowerSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetTowerSessionStats failed: %v", err)
+	}
+	if stats.TotalProblems != 3 || stats.TotalSolved != 2 || stats.TotalOptimal != 1 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	if stats.AverageExtraMoves != 1 || stats.AverageTimeMs != 3000 {
+		t.Errorf("Expected 1 extra move and 3000ms on average, got %v and %v", stats.AverageExtraMoves, stats.AverageTimeMs)
+	}
+	if len(stats.DiscStats) != 2 || stats.DiscStats[0].Discs != 3 || stats.DiscStats[1].SolveRate != 0 {
+		t.Errorf("Unexpected disc stats: %+v", stats.DiscStats)
+	}
+
+	page, err := GetPaginatedTowerSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedTowerSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 1 || len(page.Sessions) != 1 || len(page.Sessions[0].Results) != 3 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
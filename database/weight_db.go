@@ -0,0 +1,199 @@
+package database
+
+import (
+	"acca-games/types"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveWeightResult saves the result of a single Weight Comparison problem.
+func SaveWeightResult(db *sql.DB, result types.WeightResult) error {
+	hiddenOrderJSON, err := json.Marshal(result.HiddenOrder)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hidden order: %w", err)
+	}
+	weighingsJSON, err := json.Marshal(result.Weighings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal weighings: %w", err)
+	}
+	rankingJSON, err := json.Marshal(result.Ranking)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ranking: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO weight_results (
+			session_id, problem_num, num_objects, hidden_order, weighings, ranking,
+			weighings_used, optimal_weighings, is_correct, is_consistent, is_determined, response_time_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.SessionID, result.ProblemNum, result.NumObjects, string(hiddenOrderJSON), string(weighingsJSON), string(rankingJSON),
+		result.WeighingsUsed, result.OptimalWeighings, result.IsCorrect, result.IsConsistent, result.IsDetermined, result.ResponseTimeMs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert weight result: %w", err)
+	}
+	return nil
+}
+
+// GetWeightResultsForSession retrieves all results for a given session.
+func GetWeightResultsForSession(db *sql.DB, sessionID int64) ([]types.WeightResult, error) {
+	rows, err := db.Query(`
+		SELECT session_id, problem_num, num_objects, hidden_order, weighings, ranking,
+		       weighings_used, optimal_weighings, is_correct, is_consistent, is_determined, response_time_ms
+		FROM weight_results
+		WHERE session_id = ?
+		ORDER BY problem_num ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]types.WeightResult, 0)
+	for rows.Next() {
+		var r types.WeightResult
+		var hiddenOrderJSON, weighingsJSON, rankingJSON string
+		if err := rows.Scan(&r.SessionID, &r.ProblemNum, &r.NumObjects, &hiddenOrderJSON, &weighingsJSON, &rankingJSON,
+			&r.WeighingsUsed, &r.OptimalWeighings, &r.IsCorrect, &r.IsConsistent, &r.IsDetermined, &r.ResponseTimeMs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(hiddenOrderJSON), &r.HiddenOrder); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal hidden order: %w", err)
+		}
+		if err := json.Unmarshal([]byte(weighingsJSON), &r.Weighings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal weighings: %w", err)
+		}
+		if err := json.Unmarshal([]byte(rankingJSON), &r.Ranking); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ranking: %w", err)
+		}
+		results = append(results, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaginatedWeightSessionsWithResults fetches sessions with pagination and includes results for each session.
+func GetPaginatedWeightSessionsWithResults(db *sql.DB, page int, limit int, tags ...string) (*types.PaginatedWeightSessions, error) {
+	offset := (page - 1) * limit
+
+	where, args := historyFilter(types.GameCodeWeight, tags)
+	var totalCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM game_sessions WHERE "+where, args...).Scan(&totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, game_code, play_datetime, settings
+		FROM game_sessions
+		WHERE `+where+`
+		ORDER BY play_datetime DESC
+		LIMIT ? OFFSET ?`, pageArgs(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var sessions []types.WeightSessionWithResults
+	for rows.Next() {
+		var s types.WeightSessionWithResults
+		if err := rows.Scan(&s.ID, &s.GameCode, &s.PlayDatetime, &s.Settings); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	for i := range sessions {
+		if err := loadSessionDetails(db, &sessions[i].GameSession); err != nil {
+			return nil, err
+		}
+		results, err := GetWeightResultsForSession(db, sessions[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results for session %d: %w", sessions[i].ID, err)
+		}
+		sessions[i].Results = results
+	}
+
+	if sessions == nil {
+		sessions = []types.WeightSessionWithResults{}
+	}
+
+	return &types.PaginatedWeightSessions{
+		Sessions:   sessions,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetWeightSessionStats calculates statistics for a given session.
+func GetWeightSessionStats(db *sql.DB, sessionID int64) (*types.WeightSessionStats, error) {
+	results, err := GetWeightResultsForSession(db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.WeightSessionStats{
+		SessionID:   sessionID,
+		ObjectStats: []types.WeightObjectStats{},
+	}
+
+	objectMap := make(map[int]*types.WeightObjectStats)
+	totalTime := 0.0
+	for _, r := range results {
+		stats.TotalProblems++
+		totalTime += float64(r.ResponseTimeMs)
+		stats.AverageExtraWeighings += float64(r.WeighingsUsed - r.OptimalWeighings)
+		if r.IsCorrect {
+			stats.TotalCorrect++
+			if r.WeighingsUsed <= r.OptimalWeighings {
+				stats.TotalEfficient++
+			}
+		}
+		if !r.IsDetermined {
+			stats.TotalGuessed++
+		}
+
+		objStats, ok := objectMap[r.NumObjects]
+		if !ok {
+			objStats = &types.WeightObjectStats{NumObjects: r.NumObjects, OptimalWeighings: r.OptimalWeighings}
+			objectMap[r.NumObjects] = objStats
+		}
+		objStats.TotalProblems++
+		if r.IsCorrect {
+			objStats.TotalCorrect++
+		}
+		objStats.AverageWeighings += float64(r.WeighingsUsed)       // Accumulate for now
+		objStats.AverageResponseTimeMs += float64(r.ResponseTimeMs) // Accumulate for now
+	}
+
+	if stats.TotalProblems > 0 {
+		stats.OverallAccuracy = float64(stats.TotalCorrect) / float64(stats.TotalProblems) * 100
+		stats.AverageExtraWeighings /= float64(stats.TotalProblems)
+		stats.AverageResponseTimeMs = totalTime / float64(stats.TotalProblems)
+	}
+
+	// Object counts are small, so loop over them in order.
+	maxObjects := 0
+	for n := range objectMap {
+		maxObjects = max(maxObjects, n)
+	}
+	for n := 1; n <= maxObjects; n++ {
+		objStats, ok := objectMap[n]
+		if !ok {
+			continue
+		}
+		objStats.Accuracy = float64(objStats.TotalCorrect) / float64(objStats.TotalProblems) * 100
+		objStats.AverageWeighings /= float64(objStats.TotalProblems)
+		objStats.AverageResponseTimeMs /= float64(objStats.TotalProblems)
+		stats.ObjectStats = append(stats.ObjectStats, *objStats)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"acca-games/types"
+)
+
+func TestSaveAndGetWeightResults(t *testing.T) {
+	db, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to initialize in-memory database: %v", err)
+	}
+	defer db.Close()
+
+	sessionID, err := CreateGameSession(db, types.GameCodeWeight, "{}")
+	if err != nil {
+		t.Fatalf("Failed to create game session: %v", err)
+	}
+
+	results := []types.WeightResult{
+		{
+			SessionID: sessionID, ProblemNum: 1, NumObjects: 3,
+			HiddenOrder: []string{"B", "A", "C"},
+			Weighings: []types.WeightWeighing{
+				{Left: "A", Right: "B", Heavier: "B"},
+				{Left: "A", Right: "C", Heavier: "A"},
+			},
+			Ranking: []string{"B", "A", "C"}, WeighingsUsed: 2, OptimalWeighings: 3,
+			IsCorrect: true, IsConsistent: true, IsDetermined: true, ResponseTimeMs: 3000,
+		},
+		{
+			SessionID: sessionID, ProblemNum: 2, NumObjects: 3,
+			HiddenOrder: []string{"C", "B", "A"}, Weighings: []types.WeightWeighing{},
+			Ranking: []string{"A", "B", "C"}, WeighingsUsed: 0, OptimalWeighings: 3,
+			IsConsistent: true, ResponseTimeMs: 1000,
+		},
+	}
+	for _, r := range results {
+		if err := SaveWeightResult(db, r); err != nil {
+			t.Fatalf("SaveWeightResult failed: %v", err)
+		}
+	}
+
+	saved, err := GetWeightResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetWeightResultsForSession failed: %v", err)
+	}
+	if !reflect.DeepEqual(saved, results) {
+		t.Errorf("Expected results %+v, got %+v", results, saved)
+	}
+
+	stats, err := GetWeightSessionStats(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetWeightSessionStats failed: %v", err)
+	}
+	if stats.TotalProblems != 2 || stats.TotalCorrect != 1 || stats.TotalEfficient != 1 || stats.TotalGuessed != 1 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	if stats.OverallAccuracy != 50 || stats.AverageExtraWeighings != -2 || stats.AverageResponseTimeMs != 2000 {
+		t.Errorf("Unexpected averages: %+v", stats)
+	}
+	if len(stats.ObjectStats) != 1 || stats.ObjectStats[0].AverageWeighings != 1 {
+		t.Errorf("Unexpected object stats: %+v", stats.ObjectStats)
+	}
+
+	page, err := GetPaginatedWeightSessionsWithResults(db, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedWeightSessionsWithResults failed: %v", err)
+	}
+	if page.TotalCount != 1 || len(page.Sessions[0].Results) != 2 {
+		t.Errorf("Unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"acca-games/types"
+)
+
+// SaveWordList stores a word list and its pairs, returning the new list ID.
+func SaveWordList(db *sql.DB, list types.WordList) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO word_lists (name, language) VALUES (?, ?)", list.Name, list.Language)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert word list: %w", err)
+	}
+	listID, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO word_list_pairs (list_id, left_word, right_word, category) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, pair := range list.Pairs {
+		if _, err := stmt.Exec(listID, pair.Left, pair.Right, pair.Category); err != nil {
+			return 0, fmt.Errorf("failed to insert word pair: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit word list: %w", err)
+	}
+	return listID, nil
+}
+
+// GetWordList fetches a stored word list with all its pairs.
+func GetWordList(db *sql.DB, id int64) (*types.WordList, error) {
+	list := &types.WordList{}
+	err := db.QueryRow("SELECT id, name, language, created_at FROM word_lists WHERE id = ?", id).
+		Scan(&list.ID, &list.Name, &list.Language, &list.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("word list %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word list: %w", err)
+	}
+
+	rows, err := db.Query("SELECT left_word, right_word, category FROM word_list_pairs WHERE list_id = ? ORDER BY id ASC", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word pairs: %w", err)
+	}
+	defer rows.Close()
+
+	list.Pairs = []types.WordPair{}
+	for rows.Next() {
+		var pair types.WordPair
+		if err := rows.Scan(&pair.Left, &pair.Right, &pair.Category); err != nil {
+			return nil, fmt.Errorf("failed to scan word pair: %w", err)
+		}
+		list.Pairs = append(list.Pairs, pair)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetWordLists fetches summaries of all stored word lists. An empty language returns every list.
+func GetWordLists(db *sql.DB, language string) ([]types.WordListSummary, error) {
+	rows, err := db.Query(`
+		SELECT l.id, l.name, l.language, l.created_at, p.category, COUNT(p.id)
+		FROM word_lists l
+		LEFT JOIN word_list_pairs p ON p.list_id = l.id
+		WHERE ? = '' OR l.language = ?
+		GROUP BY l.id, p.category
+		ORDER BY l.name ASC`, language, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word lists: %w", err)
+	}
+	defer rows.Close()
+
+	summaryMap := make(map[int64]*types.WordListSummary)
+	var order []int64
+	for rows.Next() {
+		var s types.WordListSummary
+		var category sql.NullString
+		var pairCount int
+		if err := rows.Scan(&s.ID, &s.Name, &s.Language, &s.CreatedAt, &category, &pairCount); err != nil {
+			return nil, fmt.Errorf("failed to scan word list: %w", err)
+		}
+
+		if _, ok := summaryMap[s.ID]; !ok {
+			s.Categories = []string{}
+			summaryMap[s.ID] = &s
+			order = append(order, s.ID)
+		}
+		summary := summaryMap[s.ID]
+		summary.PairCount += pairCount
+		if category.Valid && category.String != "" {
+			summary.Categories = append(summary.Categories, category.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	summaries := make([]types.WordListSummary, 0, len(order))
+	for _, id := range order {
+		sort.Strings(summaryMap[id].Categories)
+		summaries = append(summaries, *summaryMap[id])
+	}
+	return summaries, nil
+}
+
+// DeleteWordList removes a stored word list and its pairs.
+// Results keep the list name they were played with.
+func DeleteWordList(db *sql.DB, id int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM word_list_pairs WHERE list_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete word pairs: %w", err)
+	}
+	res, err := tx.Exec("DELETE FROM word_lists WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete word list: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("word list %d not found", id)
+	}
+
+	return tx.Commit()
+}
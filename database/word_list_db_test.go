@@ -0,0 +1,60 @@
+package database
+
+import (
+	"testing"
+
+	"acca-games/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordListRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	list := types.WordList{
+		Name:     "english",
+		Language: "en",
+		Pairs: []types.WordPair{
+			{Left: "hot", Right: "cold", Category: "temperature"},
+			{Left: "up", Right: "down", Category: "direction"},
+			{Left: "big", Right: "small"},
+		},
+	}
+	id, err := SaveWordList(db, list)
+	assert.NoError(t, err)
+
+	loaded, err := GetWordList(db, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "english", loaded.Name)
+	assert.Equal(t, "en", loaded.Language)
+	assert.Equal(t, list.Pairs, loaded.Pairs)
+	assert.False(t, loaded.CreatedAt.IsZero())
+
+	_, err = SaveWordList(db, types.WordList{Name: "korean", Language: "ko", Pairs: []types.WordPair{{Left: "낮", Right: "밤"}}})
+	assert.NoError(t, err)
+
+	_, err = SaveWordList(db, list)
+	assert.Error(t, err, "List names must be unique")
+
+	summaries, err := GetWordLists(db, "")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, "english", summaries[0].Name)
+	assert.Equal(t, 3, summaries[0].PairCount)
+	assert.Equal(t, []string{"direction", "temperature"}, summaries[0].Categories)
+
+	summaries, err = GetWordLists(db, "ko")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "korean", summaries[0].Name)
+
+	assert.NoError(t, DeleteWordList(db, id))
+	_, err = GetWordList(db, id)
+	assert.Error(t, err)
+	assert.Error(t, DeleteWordList(db, id))
+
+	var pairCount int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM word_list_pairs WHERE list_id = ?", id).Scan(&pairCount))
+	assert.Zero(t, pairCount)
+}
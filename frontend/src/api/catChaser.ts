@@ -2,6 +2,8 @@ import {
   StartCatChaserGame,
   SubmitCatChaserAnswer,
   GetPaginatedCatChaserSessionsWithResults,
+  EndCatChaserGame,
+  AbandonCatChaserGame,
 } from '@wails/go/main/App';
 import { cat_chaser, types } from '@wails/go/models';
 
@@ -12,6 +14,7 @@ export const startCatChaserGame = (
 };
 
 export const submitCatChaserAnswer = (
+  sessionID: number,
   round: number,
   targetColor: string,
   playerChoice: string,
@@ -19,6 +22,7 @@ export const submitCatChaserAnswer = (
   responseTimeMs: number,
 ): Promise<types.CatChaserResult> => {
   return SubmitCatChaserAnswer(
+    sessionID,
     round,
     targetColor,
     playerChoice,
@@ -30,6 +34,17 @@ export const submitCatChaserAnswer = (
 export const getPaginatedCatChaserSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedCatChaserSessions> => {
-  return GetPaginatedCatChaserSessionsWithResults(page, limit);
+  return GetPaginatedCatChaserSessionsWithResults(page, limit, tags);
+};
+
+export const endCatChaserGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndCatChaserGame(sessionID);
+};
+
+export const abandonCatChaserGame = (sessionID: number): Promise<void> => {
+  return AbandonCatChaserGame(sessionID);
 };
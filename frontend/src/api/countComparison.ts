@@ -4,6 +4,8 @@ import {
   SubmitCountComparisonAnswer,
   GetPaginatedCountComparisonSessionsWithResults,
   GetCountComparisonSessionStats,
+  EndCountComparisonGame,
+  AbandonCountComparisonGame,
 } from '@wails/go/main/App';
 import { types } from '@wails/go/models';
 
@@ -13,23 +15,25 @@ export const startCountComparisonGame = (
   return StartCountComparisonGame(settings);
 };
 
-export const getNextCountComparisonProblem = (): Promise<
-  types.CountComparisonProblem | null
-> => {
-  return GetNextCountComparisonProblem();
+export const getNextCountComparisonProblem = (
+  sessionID: number,
+): Promise<types.CountComparisonProblem | null> => {
+  return GetNextCountComparisonProblem(sessionID);
 };
 
 export const submitCountComparisonAnswer = (
+  sessionID: number,
   submission: types.CountComparisonSubmission,
 ): Promise<void> => {
-  return SubmitCountComparisonAnswer(submission);
+  return SubmitCountComparisonAnswer(sessionID, submission);
 };
 
 export const getPaginatedCountComparisonSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedCountComparisonSessions> => {
-  return GetPaginatedCountComparisonSessionsWithResults(page, limit);
+  return GetPaginatedCountComparisonSessionsWithResults(page, limit, tags);
 };
 
 export const getCountComparisonSessionStats = (
@@ -37,3 +41,13 @@ export const getCountComparisonSessionStats = (
 ): Promise<types.CountComparisonSessionStats> => {
   return GetCountComparisonSessionStats(sessionID);
 };
+
+export const endCountComparisonGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndCountComparisonGame(sessionID);
+};
+
+export const abandonCountComparisonGame = (sessionID: number): Promise<void> => {
+  return AbandonCountComparisonGame(sessionID);
+};
@@ -3,6 +3,8 @@ import {
   GetShapeGroups,
   StartNBackGame,
   SubmitNBackAnswer,
+  EndNBackGame,
+  AbandonNBackGame,
 } from '@wails/go/main/App';
 import { nback, types } from '@wails/go/models';
 
@@ -17,16 +19,28 @@ export const startNBackGame = (
 };
 
 export const submitNBackAnswer = (
+  sessionID: number,
   playerChoice: string,
   responseTimeMs: number,
   trialNum: number,
 ): Promise<types.NBackResult> => {
-  return SubmitNBackAnswer(playerChoice, responseTimeMs, trialNum);
+  return SubmitNBackAnswer(sessionID, playerChoice, responseTimeMs, trialNum);
 };
 
 export const getPaginatedNBackSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedNBackSessions> => {
-  return GetPaginatedNBackSessionsWithResults(page, limit);
+  return GetPaginatedNBackSessionsWithResults(page, limit, tags);
+};
+
+export const endNBackGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndNBackGame(sessionID);
+};
+
+export const abandonNBackGame = (sessionID: number): Promise<void> => {
+  return AbandonNBackGame(sessionID);
 };
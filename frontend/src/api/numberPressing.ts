@@ -1,9 +1,24 @@
 import { types } from '@wails/go/models';
-import { GetPaginatedNumberPressingSessionsWithResults } from '@wails/go/main/App';
+import {
+  GetPaginatedNumberPressingSessionsWithResults,
+  EndNumberPressingGame,
+  AbandonNumberPressingGame,
+} from '@wails/go/main/App';
 
 export const getPaginatedNumberPressingSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedNumberPressingSessions> => {
-  return GetPaginatedNumberPressingSessionsWithResults(page, limit);
+  return GetPaginatedNumberPressingSessionsWithResults(page, limit, tags);
+};
+
+export const endNumberPressingGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndNumberPressingGame(sessionID);
+};
+
+export const abandonNumberPressingGame = (sessionID: number): Promise<void> => {
+  return AbandonNumberPressingGame(sessionID);
 };
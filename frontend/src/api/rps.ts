@@ -2,6 +2,8 @@ import {
   StartRpsGame,
   SubmitRpsAnswer,
   GetPaginatedRpsSessionsWithResults,
+  EndRpsGame,
+  AbandonRpsGame,
 } from '@wails/go/main/App';
 import { rps, types } from '@wails/go/models';
 
@@ -12,16 +14,28 @@ export const startRpsGame = (
 };
 
 export const submitRpsAnswer = (
+  sessionID: number,
   playerChoice: string,
   responseTimeMs: number,
   questionNum: number,
 ): Promise<types.RpsResult> => {
-  return SubmitRpsAnswer(playerChoice, responseTimeMs, questionNum);
+  return SubmitRpsAnswer(sessionID, playerChoice, responseTimeMs, questionNum);
 };
 
 export const getPaginatedRpsSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedRpsSessions> => {
-  return GetPaginatedRpsSessionsWithResults(page, limit);
+  return GetPaginatedRpsSessionsWithResults(page, limit, tags);
+};
+
+export const endRpsGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndRpsGame(sessionID);
+};
+
+export const abandonRpsGame = (sessionID: number): Promise<void> => {
+  return AbandonRpsGame(sessionID);
 };
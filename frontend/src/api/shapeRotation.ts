@@ -1,9 +1,24 @@
 import { types } from '@wails/go/models';
-import { GetPaginatedShapeRotationSessionsWithResults } from '@wails/go/main/App';
+import {
+  GetPaginatedShapeRotationSessionsWithResults,
+  EndShapeRotationGame,
+  AbandonShapeRotationGame,
+} from '@wails/go/main/App';
 
 export const getPaginatedShapeRotationSessionsWithResults = (
   page: number,
   limit: number,
+  tags: string[] = [],
 ): Promise<types.PaginatedShapeRotationSessions> => {
-  return GetPaginatedShapeRotationSessionsWithResults(page, limit);
+  return GetPaginatedShapeRotationSessionsWithResults(page, limit, tags);
+};
+
+export const endShapeRotationGame = (
+  sessionID: number,
+): Promise<types.PersonalRecord[]> => {
+  return EndShapeRotationGame(sessionID);
+};
+
+export const abandonShapeRotationGame = (sessionID: number): Promise<void> => {
+  return AbandonShapeRotationGame(sessionID);
 };
@@ -15,6 +15,7 @@ export function CatChaserGame() {
     submitAnswer,
     startRound,
     lastResult,
+    abandonGame,
     resetGame,
   } = useCatChaserStore();
 
@@ -61,6 +62,11 @@ export function CatChaserGame() {
       proceedToNext();
   };
 
+  const handleExit = () => {
+      abandonGame();
+      resetGame();
+  };
+
   const proceedToNext = () => {
       setFeedbackVisible(false);
       if (step === 'DECISION_RED') {
@@ -106,7 +112,7 @@ export function CatChaserGame() {
   }
 
   return (
-      <GameLayout onExit={resetGame}>
+      <GameLayout onExit={handleExit}>
           <div className="w-full h-full flex flex-col items-center justify-center relative">
               <div className="absolute top-0 right-0 p-4 font-mono text-xl">
                   {currentRound} / {gameState?.settings.numTrials}
@@ -1,6 +1,12 @@
 import { create } from 'zustand';
 import { cat_chaser, types } from '@wails/go/models';
-import { startCatChaserGame, submitCatChaserAnswer, getPaginatedCatChaserSessionsWithResults } from '@api/catChaser';
+import {
+  startCatChaserGame,
+  submitCatChaserAnswer,
+  getPaginatedCatChaserSessionsWithResults,
+  endCatChaserGame,
+  abandonCatChaserGame,
+} from '@api/catChaser';
 import { GameMode } from '@constants/gameModes';
 
 type GameStep = 'MOUSE' | 'ISI' | 'CAT_NORMAL' | 'CAT_HIGHLIGHT' | 'DECISION_RED' | 'DECISION_BLUE' | 'FEEDBACK';
@@ -36,6 +42,8 @@ interface CatChaserStore {
     confidence: number,
     timeMs: number
   ) => Promise<void>;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
 }
 
@@ -75,6 +83,7 @@ export const useCatChaserStore = create<CatChaserStore>((set, get) => ({
     const { gameState } = get();
     if (!gameState || !gameState.problems || round > gameState.problems.length) {
         set({ gameMode: 'result' });
+        get().endGame();
         return;
     }
     const problem = gameState.problems[round - 1];
@@ -88,9 +97,11 @@ export const useCatChaserStore = create<CatChaserStore>((set, get) => ({
   setStep: (step) => set({ step }),
 
   submitAnswer: async (targetColor, choice, confidence, timeMs) => {
-    const { currentRound, results } = get();
+    const { gameState, currentRound, results } = get();
+    if (!gameState) return;
     try {
       const result = await submitCatChaserAnswer(
+        gameState.id,
         currentRound,
         targetColor,
         choice,
@@ -103,6 +114,26 @@ export const useCatChaserStore = create<CatChaserStore>((set, get) => ({
     }
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endCatChaserGame(sessionId);
+    } catch (err) {
+      console.error('Failed to end game:', err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonCatChaserGame(sessionId);
+    } catch (err) {
+      console.error('Failed to abandon game:', err);
+    }
+  },
+
   resetGame: () => set({
     gameState: null,
     sessionId: null,
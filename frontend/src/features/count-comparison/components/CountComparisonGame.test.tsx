@@ -20,6 +20,7 @@ const mockStore = create<any>((set) => ({
   setSettings: vi.fn((settings) => set({ settings })),
   startGame: vi.fn(),
   resetGame: vi.fn(),
+  abandonGame: vi.fn(),
   fetchNextProblem: vi.fn(),
   submitAnswer: vi.fn(),
 }));
@@ -121,12 +122,13 @@ describe('CountComparisonGame component', () => {
     expect(screen.getByTestId('progress-bar')).toBeInTheDocument();
   });
   
-  it('calls resetGame on exit', async () => {
-    const { resetGame } = mockStore.getState();
+  it('abandons and resets the game on exit', async () => {
+    const { abandonGame, resetGame } = mockStore.getState();
     renderWithRouter(<CountComparisonGame />);
 
     fireEvent.click(screen.getByRole('button', { name: /나가기/i }));
 
+    expect(abandonGame).toHaveBeenCalledTimes(1);
     expect(resetGame).toHaveBeenCalledTimes(1);
   });
 
@@ -10,7 +10,7 @@ import { GameLayout } from '@components/layout/GameLayout';
 
 const CountComparisonGame: React.FC = () => {
   const navigate = useNavigate();
-  const { currentProblem, settings, submitAnswer, fetchNextProblem, resetGame, loading } =
+  const { currentProblem, settings, submitAnswer, fetchNextProblem, abandonGame, resetGame, loading } =
     useCountComparisonStore();
 
   const [phase, setPhase] = useState<'presentation' | 'input' | 'feedback'>('presentation');
@@ -69,9 +69,10 @@ const CountComparisonGame: React.FC = () => {
   const handleExit = useCallback(() => {
     presentationTimer.stop();
     inputTimer.stop();
+    abandonGame();
     resetGame();
     navigate('/games');
-  }, [presentationTimer, inputTimer, resetGame, navigate]);
+  }, [presentationTimer, inputTimer, abandonGame, resetGame, navigate]);
 
   useEffect(() => {
     if (currentProblem && settings) {
@@ -4,6 +4,7 @@ import {
   getNextCountComparisonProblem,
   submitCountComparisonAnswer,
   getPaginatedCountComparisonSessionsWithResults,
+  endCountComparisonGame,
 } from '@api/countComparison';
 import { types } from '@wails/go/models';
 import { act } from '@testing-library/react';
@@ -34,6 +35,8 @@ describe('useCountComparisonStore', () => {
       await useCountComparisonStore.getState().startGame(mockSettings);
     });
 
+    expect(getNextCountComparisonProblem).toHaveBeenCalledWith(123);
+
     const state = useCountComparisonStore.getState();
     expect(state.gameMode).toBe('playing');
     expect(state.sessionId).toBe(123);
@@ -60,6 +63,9 @@ describe('useCountComparisonStore', () => {
   it('should fetch the next problem', async () => {
     const mockProblem = { problemNumber: 2 };
     (getNextCountComparisonProblem as any).mockResolvedValue(mockProblem);
+    act(() => {
+      useCountComparisonStore.setState({ sessionId: 123 });
+    });
 
     await act(async () => {
       await useCountComparisonStore.getState().fetchNextProblem();
@@ -72,6 +78,9 @@ describe('useCountComparisonStore', () => {
 
   it('should transition to result mode when there are no more problems', async () => {
     (getNextCountComparisonProblem as any).mockResolvedValue(null);
+    act(() => {
+      useCountComparisonStore.setState({ sessionId: 123 });
+    });
 
     await act(async () => {
       await useCountComparisonStore.getState().fetchNextProblem();
@@ -80,6 +89,7 @@ describe('useCountComparisonStore', () => {
     const state = useCountComparisonStore.getState();
     expect(state.currentProblem).toBeNull();
     expect(state.gameMode).toBe('result');
+    expect(endCountComparisonGame).toHaveBeenCalledWith(123);
   });
 
   it('should submit an answer', async () => {
@@ -89,7 +99,7 @@ describe('useCountComparisonStore', () => {
       responseTimeMs: 500,
     };
     act(() => {
-        useCountComparisonStore.setState({ currentProblem: { correctSide: 'left' } as any });
+        useCountComparisonStore.setState({ sessionId: 123, currentProblem: { correctSide: 'left' } as any });
     });
     (submitCountComparisonAnswer as any).mockResolvedValue(undefined);
 
@@ -98,7 +108,7 @@ describe('useCountComparisonStore', () => {
       isCorrect = await useCountComparisonStore.getState().submitAnswer(submission);
     });
 
-    expect(submitCountComparisonAnswer).toHaveBeenCalledWith(submission);
+    expect(submitCountComparisonAnswer).toHaveBeenCalledWith(123, submission);
     expect(isCorrect).toBe(true);
   });
 
@@ -5,6 +5,8 @@ import {
   getNextCountComparisonProblem,
   submitCountComparisonAnswer,
   getPaginatedCountComparisonSessionsWithResults,
+  endCountComparisonGame,
+  abandonCountComparisonGame,
 } from '@api/countComparison';
 
 interface CountComparisonState {
@@ -24,6 +26,8 @@ interface CountComparisonState {
   startGame: (settings: types.CountComparisonSettings) => Promise<number>; // Changed return type to Promise<number>
   fetchNextProblem: () => Promise<void>;
   submitAnswer: (submission: types.CountComparisonSubmission) => Promise<boolean>;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   resetGame: () => void;
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
 }
@@ -62,11 +66,16 @@ export const useCountComparisonStore = create<CountComparisonState>((set, get) =
   fetchNextProblem: async () => {
     set({ loading: true, error: null });
     try {
-      const problem = await getNextCountComparisonProblem();
+      const { sessionId } = get();
+      if (sessionId === null) {
+        throw new Error("No game in play.");
+      }
+      const problem = await getNextCountComparisonProblem(sessionId);
       if (problem) {
         set({ currentProblem: problem, loading: false });
       } else {
         set({ currentProblem: null, gameMode: 'result', loading: false });
+        await get().endGame();
       }
     } catch (err: any) {
       set({ error: err.message || 'Unknown error', loading: false });
@@ -76,7 +85,11 @@ export const useCountComparisonStore = create<CountComparisonState>((set, get) =
   submitAnswer: async (submission: types.CountComparisonSubmission): Promise<boolean> => {
     set({ loading: true, error: null });
     try {
-      await submitCountComparisonAnswer(submission);
+      const { sessionId } = get();
+      if (sessionId === null) {
+        throw new Error("No game in play.");
+      }
+      await submitCountComparisonAnswer(sessionId, submission);
       const problem = get().currentProblem;
       if (!problem) {
         throw new Error("No current problem to submit answer for.");
@@ -90,6 +103,26 @@ export const useCountComparisonStore = create<CountComparisonState>((set, get) =
     }
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endCountComparisonGame(sessionId);
+    } catch (err) {
+      console.error("Failed to end count comparison game:", err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonCountComparisonGame(sessionId);
+    } catch (err) {
+      console.error("Failed to abandon count comparison game:", err);
+    }
+  },
+
   resetGame: () => {
     set({
       settings: {
@@ -30,6 +30,8 @@ vi.mock('@components/shapes/nback/Square', () => ({ Square: () => <div data-test
 describe('NBackGame component', () => {
   const mockSubmitAnswer = vi.fn();
   const mockResetGame = vi.fn();
+  const mockEndGame = vi.fn();
+  const mockAbandonGame = vi.fn();
   const mockSetGameMode = vi.fn();
 
   const mockGameState: nback.NBackGameState = nback.NBackGameState.createFrom({
@@ -50,6 +52,8 @@ describe('NBackGame component', () => {
     (useNBackStore as jest.Mock).mockReturnValue({
       gameState: null,
       submitAnswer: mockSubmitAnswer,
+      endGame: mockEndGame,
+      abandonGame: mockAbandonGame,
       resetGame: mockResetGame,
       setGameMode: mockSetGameMode,
     });
@@ -67,6 +71,8 @@ describe('NBackGame component', () => {
     (useNBackStore as jest.Mock).mockReturnValue({
       gameState: mockGameState,
       submitAnswer: mockSubmitAnswer,
+      endGame: mockEndGame,
+      abandonGame: mockAbandonGame,
       resetGame: mockResetGame,
       setGameMode: mockSetGameMode,
     });
@@ -84,6 +90,8 @@ describe('NBackGame component', () => {
     (useNBackStore as jest.Mock).mockReturnValue({
       gameState: mockGameState,
       submitAnswer: mockSubmitAnswer,
+      endGame: mockEndGame,
+      abandonGame: mockAbandonGame,
       resetGame: mockResetGame,
       setGameMode: mockSetGameMode,
     });
@@ -93,11 +101,12 @@ describe('NBackGame component', () => {
         expect(screen.getByTestId('progress-bar')).toBeInTheDocument();
       });
 
-  it('calls resetGame on exit', async () => {
+  it('abandons and resets the game on exit', async () => {
     await renderGameComponent();
 
     fireEvent.click(screen.getByRole('button', { name: /나가기/i }));
 
+    expect(mockAbandonGame).toHaveBeenCalledTimes(1);
     expect(mockResetGame).toHaveBeenCalledTimes(1);
   });
 });
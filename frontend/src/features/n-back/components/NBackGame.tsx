@@ -38,7 +38,7 @@ const shapeMap: { [key: string]: ComponentType } = {
 };
 
 export function NBackGame() {
-  const { gameState, submitAnswer, resetGame, setGameMode } = useNBackStore();
+  const { gameState, submitAnswer, endGame, abandonGame, resetGame, setGameMode } = useNBackStore();
 
     const [currentTrial, setCurrentTrial] = useState(0);
     const [isInputAllowed, setIsInputAllowed] = useState(false);
@@ -60,6 +60,7 @@ export function NBackGame() {
       if (feedbackTimerRef.current) clearTimeout(feedbackTimerRef.current);
       if (feedbackClearTimerRef.current) clearTimeout(feedbackClearTimerRef.current);
       if (progressAnimatorRef.current) cancelAnimationFrame(progressAnimatorRef.current);
+      abandonGame();
       resetGame();
     };
   
@@ -104,6 +105,7 @@ export function NBackGame() {
       }
       if (currentTrial >= gameState.settings.numTrials) {
         setGameMode('result');
+        endGame();
         return;
       }
   
@@ -152,7 +154,7 @@ export function NBackGame() {
         if (feedbackClearTimerRef.current) clearTimeout(feedbackClearTimerRef.current);
         if (progressAnimatorRef.current) cancelAnimationFrame(progressAnimatorRef.current);
       };
-    }, [currentTrial, gameState, setGameMode, handleSubmitAnswer, advanceToNextTrial]);
+    }, [currentTrial, gameState, setGameMode, endGame, handleSubmitAnswer, advanceToNextTrial]);
   
     const handleKeyPressCallback = useCallback(async (e: KeyboardEvent) => {
       if (!isInputAllowed || !['ArrowLeft', 'ArrowRight', ' '].includes(e.key)) return;
@@ -72,19 +72,25 @@ describe('useNBackStore', () => {
     it('should submit an answer and return the result', async () => {
       const mockResult = types.NBackResult.createFrom({ sessionID: 1, round: 1, questionNum: 1, isCorrect: true, responseTimeMs: 500, playerChoice: 'LEFT', correctChoice: 'LEFT' });
       (submitNBackAnswer as jest.Mock).mockResolvedValue(mockResult);
+      act(() => {
+        useNBackStore.setState({ gameState: nback.NBackGameState.createFrom({ id: 1 }) });
+      });
 
       let result;
       await act(async () => {
         result = await useNBackStore.getState().submitAnswer('LEFT', 500, 0);
       });
 
-      expect(submitNBackAnswer).toHaveBeenCalledWith('LEFT', 500, 0);
+      expect(submitNBackAnswer).toHaveBeenCalledWith(1, 'LEFT', 500, 0);
       expect(result).toEqual(mockResult);
     });
 
     it('should return null when submitting an answer fails', async () => {
       (submitNBackAnswer as jest.Mock).mockRejectedValue(new Error('Failed to submit'));
       console.error = vi.fn(); // Suppress console.error for this test
+      act(() => {
+        useNBackStore.setState({ gameState: nback.NBackGameState.createFrom({ id: 1 }) });
+      });
 
       let result;
       await act(async () => {
@@ -4,6 +4,8 @@ import {
   getPaginatedNBackSessionsWithResults,
   startNBackGame,
   submitNBackAnswer,
+  endNBackGame,
+  abandonNBackGame,
 } from '@api/nback';
 import { GameMode } from "@constants/gameModes";
 
@@ -23,11 +25,13 @@ interface NBackState {
   setGameMode: (mode: GameMode) => void;
   startGame: (settings: types.NBackSettings) => Promise<void>;
   submitAnswer: (choice: string, responseTime: number, trial: number) => Promise<types.NBackResult | null>;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   resetGame: () => void;
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
 }
 
-export const useNBackStore = create<NBackState>((set) => ({
+export const useNBackStore = create<NBackState>((set, get) => ({
   gameState: null,
   gameMode: 'setup',
   sessionId: null,
@@ -50,14 +54,36 @@ export const useNBackStore = create<NBackState>((set) => ({
   },
 
   submitAnswer: async (choice, responseTime, trial) => {
+    const { gameState } = get();
+    if (!gameState) return null;
     try {
-      return await submitNBackAnswer(choice, responseTime, trial);
+      return await submitNBackAnswer(gameState.id, choice, responseTime, trial);
     } catch (err) {
       console.error("Failed to submit N-Back answer:", err);
       return null;
     }
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endNBackGame(sessionId);
+    } catch (err) {
+      console.error("Failed to end N-Back game:", err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonNBackGame(sessionId);
+    } catch (err) {
+      console.error("Failed to abandon N-Back game:", err);
+    }
+  },
+
   resetGame: () => {
     set({ gameState: null, gameMode: 'setup', sessionId: null, error: null, loading: false });
   },
@@ -14,6 +14,8 @@ vi.mock('@wails/go/main/App', () => ({
 describe('NumberPressingGame component', () => {
   const mockSetGameMode = vi.fn();
   const mockResetGame = vi.fn();
+  const mockEndGame = vi.fn();
+  const mockAbandonGame = vi.fn();
   const mockSubmitAnswerR1 = vi.fn();
   const mockSubmitAnswerR2 = vi.fn();
 
@@ -40,6 +42,8 @@ describe('NumberPressingGame component', () => {
       gameState: null,
       gameMode: 'game',
       setGameMode: mockSetGameMode,
+      endGame: mockEndGame,
+      abandonGame: mockAbandonGame,
       resetGame: mockResetGame,
       submitAnswerR1: mockSubmitAnswerR1,
       submitAnswerR2: mockSubmitAnswerR2,
@@ -141,5 +145,6 @@ describe('NumberPressingGame component', () => {
     });
 
     expect(screen.getByText('라운드 1 종료')).toBeInTheDocument();
+    expect(mockEndGame).toHaveBeenCalledTimes(1);
   });
 });
@@ -37,6 +37,8 @@ export function NumberPressingGame() {
   const {
     gameState,
     gameMode,
+    endGame,
+    abandonGame,
     resetGame,
     setGameMode,
     submitAnswerR1,
@@ -108,8 +110,9 @@ export function NumberPressingGame() {
 
   const handleExit = useCallback(() => {
     clearTimers();
+    abandonGame();
     resetGame();
-  }, [clearTimers, resetGame]);
+  }, [clearTimers, abandonGame, resetGame]);
 
   const advanceToNextProblem = useCallback(() => {
     const problemsInRound = currentRound === 1 ? currentProblemsR1.length : currentProblemsR2.length;
@@ -146,13 +149,15 @@ export function NumberPressingGame() {
     const isCorrect = clickedNumber === currentProblemR1.targetNumber;
     const result = new types.NumberPressingResultR1({
       sessionID: gameState!.id,
+      problemNumber: currentProblemIndex + 1,
       problem: currentProblemR1,
+      playerNumber: clickedNumber,
       timeTaken,
       isCorrect,
     });
     await submitAnswerR1(result);
     showFeedback(isCorrect);
-  }, [status, currentProblemR1, gameState, showFeedback, currentRound, submitAnswerR1, problemStartTimeRef]);
+  }, [status, currentProblemR1, currentProblemIndex, gameState, showFeedback, currentRound, submitAnswerR1, problemStartTimeRef]);
 
   const handleR2Click = useCallback(async (clickedNumber: number) => {
     if (status !== 'playing' || !currentProblemR2 || currentRound === null) return;
@@ -166,6 +171,7 @@ export function NumberPressingGame() {
       const timeTaken = (Date.now() - problemStartTimeRef.current) / 1000;
       const result = new types.NumberPressingResultR2({
         sessionID: gameState!.id,
+        problemNumber: currentProblemIndex + 1,
         problem: currentProblemR2,
         playerClicks: newSequence,
         correctClicks: correctSequence,
@@ -181,6 +187,7 @@ export function NumberPressingGame() {
       const timeTaken = (Date.now() - problemStartTimeRef.current) / 1000;
       const result = new types.NumberPressingResultR2({
         sessionID: gameState!.id,
+        problemNumber: currentProblemIndex + 1,
         problem: currentProblemR2,
         playerClicks: newSequence,
         correctClicks: correctSequence,
@@ -190,7 +197,7 @@ export function NumberPressingGame() {
       await submitAnswerR2(result);
       showFeedback(true);
     }
-  }, [status, playerSequence, correctSequence, currentProblemR2, gameState, showFeedback, currentRound, submitAnswerR2, problemStartTimeRef]);
+  }, [status, playerSequence, correctSequence, currentProblemR2, currentProblemIndex, gameState, showFeedback, currentRound, submitAnswerR2, problemStartTimeRef]);
 
   useEffect(() => {
     if (!gameState) {
@@ -217,8 +224,7 @@ export function NumberPressingGame() {
       problemStartTimeRef.current = Date.now(); // This still tracks individual problem start time
 
       if (currentRound === 2 && currentProblemR2) {
-        const numbers = Array.from({ length: 9 }, (_, i) => i + 1);
-        setShuffledNumbers(numbers.sort(() => Math.random() - 0.5));
+        setShuffledNumbers(currentProblemR2.layout?.length ? [...currentProblemR2.layout] : [1, 2, 3, 4, 5, 6, 7, 8, 9]);
         CalculateCorrectClicksR2(currentProblemR2).then(setCorrectSequence);
         setPlayerSequence([]);
       }
@@ -235,6 +241,7 @@ export function NumberPressingGame() {
         }, 2000);
         timersRef.current.push(transitionTimer);
       } else {
+        endGame();
         const finishTimer = setTimeout(() => setGameMode('result'), 2000);
         timersRef.current.push(finishTimer);
       }
@@ -243,7 +250,7 @@ export function NumberPressingGame() {
         const readyTimer = setTimeout(() => setStatus('playing'), 1500);
         timersRef.current.push(readyTimer);
     }
-  }, [status, currentRound, currentProblemIndex, currentProblemR2, gameState, clearTimers, startRoundTimer, resetRoundTimer, setGameMode]);
+  }, [status, currentRound, currentProblemIndex, currentProblemR2, gameState, clearTimers, startRoundTimer, resetRoundTimer, setGameMode, endGame]);
 
   if (!gameState) return <div>Loading...</div>;
 
@@ -286,7 +293,7 @@ export function NumberPressingGame() {
             <p className="text-lg font-semibold">활성화된 숫자를 누르세요.</p>
           </Card>
           <div className="grid grid-cols-3 gap-4">
-            {[1, 2, 3, 4, 5, 6, 7, 8, 9].map(num => (
+            {(currentProblemR1?.layout?.length ? currentProblemR1.layout : [1, 2, 3, 4, 5, 6, 7, 8, 9]).map(num => (
               <NumberButton 
                 key={num} 
                 number={num} 
@@ -308,6 +315,12 @@ export function NumberPressingGame() {
               <>
                 <p className="text-lg font-semibold">두 번 클릭: {currentProblemR2.doubleClick.join(', ') || '없음'}</p>
                 <p className="text-lg font-semibold">건너뛰기: {currentProblemR2.skip.join(', ') || '없음'}</p>
+                {currentProblemR2.reverse && (
+                  <p className="text-lg font-semibold">역순: 9부터 1까지 누르세요</p>
+                )}
+                {currentProblemR2.repeat?.length > 0 && (
+                  <p className="text-lg font-semibold">{currentProblemR2.repeatCount}번 클릭: {currentProblemR2.repeat.join(', ')}</p>
+                )}
               </>
             ) : (
               <p className="text-lg font-semibold">조건 로딩 중...</p>
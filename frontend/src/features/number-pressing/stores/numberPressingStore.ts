@@ -5,7 +5,11 @@ import {
   SubmitNumberPressingResultR1,
   SubmitNumberPressingResultR2,
 } from '@wails/go/main/App';
-import { getPaginatedNumberPressingSessionsWithResults } from '@api/numberPressing';
+import {
+  getPaginatedNumberPressingSessionsWithResults,
+  endNumberPressingGame,
+  abandonNumberPressingGame,
+} from '@api/numberPressing';
 import { GameMode } from "@constants/gameModes";
 
 interface NumberPressingState {
@@ -25,11 +29,13 @@ interface NumberPressingState {
   startGame: (settings: types.NumberPressingSetup) => Promise<void>;
   submitAnswerR1: (result: types.NumberPressingResultR1) => Promise<void>;
   submitAnswerR2: (result: types.NumberPressingResultR2) => Promise<void>;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   resetGame: () => void;
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
 }
 
-export const useNumberPressingStore = create<NumberPressingState>((set) => ({
+export const useNumberPressingStore = create<NumberPressingState>((set, get) => ({
   gameState: null,
   gameMode: 'setup',
   sessionId: null,
@@ -67,6 +73,26 @@ export const useNumberPressingStore = create<NumberPressingState>((set) => ({
     }
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endNumberPressingGame(sessionId);
+    } catch (err) {
+      console.error("Failed to end Number Pressing game:", err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonNumberPressingGame(sessionId);
+    } catch (err) {
+      console.error("Failed to abandon Number Pressing game:", err);
+    }
+  },
+
   resetGame: () => {
     set({
       gameState: null,
@@ -18,6 +18,8 @@ describe('RpsGame component', () => {
   const mockNavigate = vi.fn();
   const mockSubmitAnswer = vi.fn();
   const mockResetGame = vi.fn();
+  const mockEndGame = vi.fn();
+  const mockAbandonGame = vi.fn();
 
   beforeEach(() => {
     vi.clearAllMocks();
@@ -26,6 +28,8 @@ describe('RpsGame component', () => {
     (useRpsStore as jest.Mock).mockReturnValue({
       gameState: null,
       submitAnswer: mockSubmitAnswer,
+      endGame: mockEndGame,
+      abandonGame: mockAbandonGame,
       resetGame: mockResetGame,
       setGameMode: vi.fn(),
     });
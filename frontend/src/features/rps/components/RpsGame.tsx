@@ -9,6 +9,8 @@ export function RpsGame() {
   const {
     gameState,
     submitAnswer: submitAnswerToAction,
+    endGame,
+    abandonGame,
     resetGame,
     setGameMode,
   } = useRpsStore();
@@ -28,6 +30,7 @@ export function RpsGame() {
     if (advanceTimerRef.current) clearTimeout(advanceTimerRef.current);
     if (feedbackTimerRef.current) clearTimeout(feedbackTimerRef.current);
     if (progressAnimatorRef.current) cancelAnimationFrame(progressAnimatorRef.current);
+    abandonGame();
     resetGame();
   };
 
@@ -35,10 +38,11 @@ export function RpsGame() {
     setFeedback(null);
     if (gameState && currentTrial + 1 >= gameState.problems.length) {
       setGameMode('result');
+      endGame();
     } else {
       setCurrentTrial(prev => prev + 1);
     }
-  }, [currentTrial, gameState, setGameMode]);
+  }, [currentTrial, gameState, setGameMode, endGame]);
 
   const submitAnswer = useCallback(async (choice: string, responseTime: number) => {
     try {
@@ -64,6 +68,7 @@ export function RpsGame() {
     }
     if (currentTrial >= gameState.problems.length) {
       setGameMode('result');
+      endGame();
       return;
     }
 
@@ -97,7 +102,7 @@ export function RpsGame() {
       if (feedbackTimerRef.current) clearTimeout(feedbackTimerRef.current);
       if (progressAnimatorRef.current) cancelAnimationFrame(progressAnimatorRef.current);
     };
-  }, [currentTrial, gameState, setGameMode, submitAnswer]);
+  }, [currentTrial, gameState, setGameMode, endGame, submitAnswer]);
 
   const handleKeyPress = useCallback(async (e: KeyboardEvent) => {
     if (answeredRef.current || !['ArrowLeft', 'ArrowDown', 'ArrowRight'].includes(e.key)) return;
@@ -64,19 +64,25 @@ describe('useRpsStore', () => {
     it('should submit an answer and return the result', async () => {
       const mockResult = types.RpsResult.createFrom({ sessionID: 1, round: 1, questionNum: 1, problemCardHolder: 'me', givenCard: 'ROCK', isCorrect: true, responseTimeMs: 500, playerChoice: 'PAPER', correctChoice: 'PAPER' });
       (submitRpsAnswer as jest.Mock).mockResolvedValue(mockResult);
+      act(() => {
+        useRpsStore.setState({ gameState: rps.GameState.createFrom({ id: 1 }) });
+      });
 
       let result;
       await act(async () => {
         result = await useRpsStore.getState().submitAnswer('PAPER', 500, 1);
       });
 
-      expect(submitRpsAnswer).toHaveBeenCalledWith('PAPER', 500, 1);
+      expect(submitRpsAnswer).toHaveBeenCalledWith(1, 'PAPER', 500, 1);
       expect(result).toEqual(mockResult);
     });
 
      it('should return null when submitting an answer fails', async () => {
       (submitRpsAnswer as jest.Mock).mockRejectedValue(new Error('Failed to submit'));
       console.error = vi.fn(); // Suppress console.error for this test
+      act(() => {
+        useRpsStore.setState({ gameState: rps.GameState.createFrom({ id: 1 }) });
+      });
 
       let result;
       await act(async () => {
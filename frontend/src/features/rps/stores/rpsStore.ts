@@ -4,6 +4,8 @@ import {
   getPaginatedRpsSessionsWithResults,
   startRpsGame,
   submitRpsAnswer,
+  endRpsGame,
+  abandonRpsGame,
 } from '@api/rps';
 import { GameMode } from "@constants/gameModes";
 
@@ -23,11 +25,13 @@ interface RpsState {
   setGameMode: (mode: GameMode) => void;
   startGame: (settings: types.RpsSettings) => Promise<void>;
   submitAnswer: (choice: string, responseTime: number, trial: number) => Promise<types.RpsResult | null>;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   resetGame: () => void;
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
 }
 
-export const useRpsStore = create<RpsState>((set) => ({
+export const useRpsStore = create<RpsState>((set, get) => ({
   gameState: null,
   gameMode: 'setup',
   sessionId: null,
@@ -49,14 +53,36 @@ export const useRpsStore = create<RpsState>((set) => ({
   },
 
   submitAnswer: async (playerChoice, responseTimeMs, questionNum) => {
+    const { gameState } = get();
+    if (!gameState) return null;
     try {
-      return await submitRpsAnswer(playerChoice, responseTimeMs, questionNum);
+      return await submitRpsAnswer(gameState.id, playerChoice, responseTimeMs, questionNum);
     } catch (err) {
       console.error("Failed to submit rps result", err);
       return null;
     }
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endRpsGame(sessionId);
+    } catch (err) {
+      console.error("Failed to end rps game", err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonRpsGame(sessionId);
+    } catch (err) {
+      console.error("Failed to abandon rps game", err);
+    }
+  },
+
   resetGame: () => {
     set({ gameState: null, gameMode: 'setup', sessionId: null, error: null, loading: false });
   },
@@ -28,6 +28,8 @@ describe('ShapeRotationGame component', () => {
   const mockNextProblem = vi.fn();
   const mockSetGameMode = vi.fn();
   const mockResetGame = vi.fn();
+  const mockEndGame = vi.fn();
+  const mockAbandonGame = vi.fn();
 
   const mockProblem: shape_rotation.Problem = {
     InitialShape: 'F',
@@ -53,6 +55,8 @@ describe('ShapeRotationGame component', () => {
     clearTransforms: mockClearTransforms,
     nextProblem: mockNextProblem,
     setGameMode: mockSetGameMode,
+    endGame: mockEndGame,
+    abandonGame: mockAbandonGame,
     resetGame: mockResetGame,
   };
 
@@ -129,11 +133,13 @@ describe('ShapeRotationGame component', () => {
 
     expect(App.SubmitShapeRotationAnswerAsync).toHaveBeenCalled();
     expect(mockSetGameMode).toHaveBeenCalledWith('result');
+    expect(mockEndGame).toHaveBeenCalledTimes(1);
   });
 
   it('calls handleExit when exit button is clicked', () => {
     renderComponent();
     fireEvent.click(screen.getByRole('button', { name: '나가기' }));
+    expect(mockAbandonGame).toHaveBeenCalled();
     expect(mockResetGame).toHaveBeenCalled();
   });
 });
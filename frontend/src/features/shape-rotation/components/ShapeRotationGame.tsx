@@ -25,6 +25,8 @@ const ShapeRotationGame: FC = () => {
     clearTransforms,
     nextProblem,
     setGameMode,
+    endGame,
+    abandonGame,
     resetGame,
   } = useShapeRotationStore();
 
@@ -43,14 +45,16 @@ const ShapeRotationGame: FC = () => {
     // Or if called manually, it would be Date.now() - startTimeRef.current
     const elapsedTime = Date.now() - startTimeRef.current;
     
-    SubmitShapeRotationAnswerAsync(sessionId, currentProblem, userSolution, elapsedTime, clickCount);
+    const submitted = SubmitShapeRotationAnswerAsync(sessionId, currentProblem, userSolution, elapsedTime, clickCount);
 
     if (currentProblemIndex < problems.length - 1) {
       nextProblem();
     } else {
+      // Ending waits for queued answers, so the last one only has to be queued first.
+      submitted.then(() => endGame());
       setGameMode('result');
     }
-  }, [sessionId, currentProblem, userSolution, clickCount, currentProblemIndex, problems.length, nextProblem, setGameMode]);
+  }, [sessionId, currentProblem, userSolution, clickCount, currentProblemIndex, problems.length, nextProblem, setGameMode, endGame]);
 
   const { remainingTime, progress, start, stop } = useGameLifecycle({
     onTimeUp: handleSubmit,
@@ -59,9 +63,10 @@ const ShapeRotationGame: FC = () => {
 
   const handleExit = useCallback(() => {
     stop();
+    abandonGame();
     resetGame();
     navigate('/games');
-  }, [stop, resetGame, navigate]);
+  }, [stop, abandonGame, resetGame, navigate]);
 
   useEffect(() => {
     if (!currentProblem) {
@@ -1,6 +1,10 @@
 import { create } from 'zustand';
 import { types } from '@wails/go/models';
-import { getPaginatedShapeRotationSessionsWithResults } from '@api/shapeRotation';
+import {
+  getPaginatedShapeRotationSessionsWithResults,
+  endShapeRotationGame,
+  abandonShapeRotationGame,
+} from '@api/shapeRotation';
 import { GameMode } from "@constants/gameModes";
 
 // Temporary definition until wails generates the real one
@@ -53,6 +57,8 @@ interface ShapeRotationState {
   clearTransforms: () => void;
   submitAnswer: () => void; // This will be complex
   nextProblem: () => void;
+  endGame: () => Promise<void>;
+  abandonGame: () => Promise<void>;
   resetGame: () => void;
 
   fetchPaginatedSessions: (page: number, limit: number) => Promise<void>;
@@ -133,6 +139,26 @@ const useShapeRotationStore = create<ShapeRotationState>((set, get) => ({
     }));
   },
 
+  endGame: async () => {
+    const { sessionId } = get();
+    if (sessionId === null) return;
+    try {
+      await endShapeRotationGame(sessionId);
+    } catch (err) {
+      console.error('Failed to end shape-rotation game:', err);
+    }
+  },
+
+  abandonGame: async () => {
+    const { sessionId, gameMode } = get();
+    if (sessionId === null || gameMode !== 'playing') return;
+    try {
+      await abandonShapeRotationGame(sessionId);
+    } catch (err) {
+      console.error('Failed to abandon shape-rotation game:', err);
+    }
+  },
+
   resetGame: () => {
     set({
       gameMode: 'setup',
@@ -3,10 +3,19 @@ import { createSettingsParser } from './settingsHelper';
 
 export const parseSettings = createSettingsParser(types.CatChaserSettings, 'Cat Chaser');
 
+// Difficulty is stored as { mode, miceCount }; sessions saved before that hold a string
+// such as 'auto' or '6'.
+type CatChaserDifficulty = { mode: 'auto' | 'fixed'; miceCount: number } | string;
+
+const formatDifficulty = (difficulty: CatChaserDifficulty): string => {
+  if (typeof difficulty === 'string') {
+    return difficulty === 'auto' || difficulty === '' ? '자동 (난이도 증가)' : `${difficulty}마리`;
+  }
+  return difficulty?.mode === 'fixed' ? `${difficulty.miceCount}마리` : '자동 (난이도 증가)';
+};
+
 export const formatSettings = (settings: types.CatChaserSettings): string[] => {
-  const difficultyText = settings.difficulty === 'auto' 
-      ? '자동 (난이도 증가)' 
-      : `${settings.difficulty}마리`;
+  const difficultyText = formatDifficulty(settings.difficulty as unknown as CatChaserDifficulty);
 
   return [
     `실전 모드: ${settings.isRealMode ? 'ON' : 'OFF'}`,
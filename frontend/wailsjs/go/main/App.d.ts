@@ -4,34 +4,171 @@ import {types} from '../models';
 import {shape_rotation} from '../models';
 import {cat_chaser} from '../models';
 import {nback} from '../models';
+import {position_memory} from '../models';
+import {road} from '../models';
 import {rps} from '../models';
+import {scheduling} from '../models';
+import {main} from '../models';
+import {stroop} from '../models';
+import {tower} from '../models';
+import {weight} from '../models';
+
+export function AbandonCatChaserGame(arg1:number):Promise<void>;
+
+export function AbandonCountComparisonGame(arg1:number):Promise<void>;
+
+export function AbandonNBackGame(arg1:number):Promise<void>;
+
+export function AbandonNumberPressingGame(arg1:number):Promise<void>;
+
+export function AbandonPositionMemoryGame(arg1:number):Promise<void>;
+
+export function AbandonRoadGame(arg1:number):Promise<void>;
+
+export function AbandonRpsGame(arg1:number):Promise<void>;
+
+export function AbandonSchedulingGame(arg1:number):Promise<void>;
+
+export function AbandonShapeRotationGame(arg1:number):Promise<void>;
+
+export function AbandonStroopGame(arg1:number):Promise<void>;
+
+export function AbandonTowerGame(arg1:number):Promise<void>;
+
+export function AbandonWeightGame(arg1:number):Promise<void>;
 
 export function CalculateCorrectClicksR2(arg1:types.NumberPressingProblemR2):Promise<Array<number>>;
 
+export function CompleteBatteryStep(arg1:number,arg2:number):Promise<types.BatterySession>;
+
+export function CompleteSession(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function CreateBackup():Promise<types.BackupInfo>;
+
+export function CreateGoal(arg1:types.Goal):Promise<types.Goal>;
+
+export function DeleteCountComparisonWordList(arg1:number):Promise<void>;
+
+export function DeleteGoal(arg1:number):Promise<void>;
+
+export function DeleteSession(arg1:number):Promise<void>;
+
+export function EndCatChaserGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndCountComparisonGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndNBackGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndNumberPressingGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndPositionMemoryGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndRoadGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndRpsGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndSchedulingGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndShapeRotationGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndStroopGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndTowerGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function EndWeightGame(arg1:number):Promise<Array<types.PersonalRecord>>;
+
+export function FinishTowerProblem(arg1:number,arg2:number,arg3:number):Promise<types.TowerResult>;
+
+export function GetAllTags():Promise<Array<string>>;
+
+export function GetBatteryReport(arg1:number):Promise<types.BatteryReport>;
+
+export function GetBatterySession(arg1:number):Promise<types.BatterySession>;
+
+export function GetCatChaserCalibration(arg1:number):Promise<types.CatChaserCalibration>;
+
+export function GetCatChaserCalibrationTrend():Promise<Array<types.CatChaserCalibrationTrendPoint>>;
+
 export function GetCatChaserSessionStats(arg1:number):Promise<types.CatChaserSessionStats>;
 
+export function GetCompetencyProfile():Promise<types.CompetencyProfile>;
+
+export function GetCountComparisonPsychometrics(arg1:number):Promise<types.CountComparisonPsychometrics>;
+
 export function GetCountComparisonSessionStats(arg1:number):Promise<types.CountComparisonSessionStats>;
 
+export function GetCountComparisonThresholdTrend():Promise<Array<types.CountComparisonThresholdPoint>>;
+
+export function GetCountComparisonWordListStats():Promise<Array<types.WordListStat>>;
+
+export function GetCountComparisonWordLists(arg1:string):Promise<Array<types.WordListSummary>>;
+
+export function GetDailyPlan():Promise<types.DailyPlan>;
+
+export function GetDatabaseRecovery():Promise<types.DatabaseRecovery>;
+
+export function GetDefaultBatterySteps():Promise<Array<types.BatteryStep>>;
+
+export function GetDeletedSessions():Promise<Array<types.DeletedSession>>;
+
+export function GetExcludeIncompleteSessions():Promise<boolean>;
+
+export function GetGoalProgress():Promise<Array<types.GoalProgress>>;
+
 export function GetNBackSessionStats(arg1:number):Promise<types.NBackSessionStats>;
 
-export function GetNextCountComparisonProblem():Promise<types.CountComparisonProblem>;
+export function GetNextCountComparisonProblem(arg1:number):Promise<types.CountComparisonProblem>;
+
+export function GetNormTable():Promise<types.NormTable>;
+
+export function GetNormalizedScores(arg1:string):Promise<Array<types.NormalizedScore>>;
+
+export function GetNormalizedSessionScore(arg1:number):Promise<types.NormalizedScore>;
 
 export function GetNumberPressingSessionStats(arg1:number):Promise<types.NumberPressingSessionStats>;
 
-export function GetPaginatedCatChaserSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedCatChaserSessions>;
+export function GetPaginatedCatChaserSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedCatChaserSessions>;
+
+export function GetPaginatedCountComparisonSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedCountComparisonSessions>;
+
+export function GetPaginatedNBackSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedNBackSessions>;
+
+export function GetPaginatedNumberPressingSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedNumberPressingSessions>;
 
-export function GetPaginatedCountComparisonSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedCountComparisonSessions>;
+export function GetPaginatedPositionMemorySessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedPositionMemorySessions>;
 
-export function GetPaginatedNBackSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedNBackSessions>;
+export function GetPaginatedRoadSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedRoadSessions>;
 
-export function GetPaginatedNumberPressingSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedNumberPressingSessions>;
+export function GetPaginatedRpsSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedRpsSessions>;
 
-export function GetPaginatedRpsSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedRpsSessions>;
+export function GetPaginatedSchedulingSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedSchedulingSessions>;
 
-export function GetPaginatedShapeRotationSessionsWithResults(arg1:number,arg2:number):Promise<types.PaginatedShapeRotationSessions>;
+export function GetPaginatedShapeRotationSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedShapeRotationSessions>;
+
+export function GetPaginatedStroopSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedStroopSessions>;
+
+export function GetPaginatedTowerSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedTowerSessions>;
+
+export function GetPaginatedWeightSessionsWithResults(arg1:number,arg2:number,arg3:Array<string>):Promise<types.PaginatedWeightSessions>;
+
+export function GetPersonalRecords():Promise<Array<types.PersonalRecord>>;
+
+export function GetPositionMemorySessionStats(arg1:number):Promise<types.PositionMemorySessionStats>;
+
+export function GetPositionMemorySpanHistory():Promise<types.PositionMemorySpanHistory>;
+
+export function GetPracticeStreak():Promise<types.PracticeStreak>;
+
+export function GetResumableSessions():Promise<Array<types.GameSession>>;
+
+export function GetRoadSessionStats(arg1:number):Promise<types.RoadSessionStats>;
 
 export function GetRpsSessionStats(arg1:number):Promise<types.RpsSessionStats>;
 
+export function GetSchedulingSessionStats(arg1:number):Promise<types.SchedulingSessionStats>;
+
+export function GetScoreMetrics():Promise<Array<types.ScoreMetric>>;
+
 export function GetSessionResults(arg1:string,arg2:number):Promise<string>;
 
 export function GetShapeGroups():Promise<Record<string, Array<string>>>;
@@ -40,8 +177,66 @@ export function GetShapeRotationProblems(arg1:number,arg2:number):Promise<Array<
 
 export function GetShapeRotationSessionStats(arg1:number):Promise<types.ShapeRotationSessionStats>;
 
+export function GetStroopInterferenceTrend():Promise<Array<types.StroopInterferencePoint>>;
+
+export function GetStroopSessionStats(arg1:number):Promise<types.StroopSessionStats>;
+
+export function GetTowerSessionStats(arg1:number):Promise<types.TowerSessionStats>;
+
+export function GetWeightSessionStats(arg1:number):Promise<types.WeightSessionStats>;
+
+export function ImportCountComparisonWordList(arg1:string,arg2:string,arg3:string,arg4:string):Promise<types.WordList>;
+
+export function ImportNormTable(arg1:string):Promise<types.NormTable>;
+
+export function ListBackups():Promise<Array<types.BackupInfo>>;
+
+export function PurgeDeletedSessions():Promise<number>;
+
+export function PurgeSession(arg1:number):Promise<void>;
+
+export function RestoreBackup(arg1:string):Promise<types.BackupInfo>;
+
+export function RestoreSession(arg1:number):Promise<void>;
+
+export function ResumeBattery():Promise<types.BatterySession>;
+
+export function ResumeCatChaserSession(arg1:number):Promise<cat_chaser.CatChaserGameState>;
+
+export function ResumeCountComparisonSession(arg1:number):Promise<types.CountComparisonProblem>;
+
+export function ResumeNBackSession(arg1:number):Promise<nback.NBackGameState>;
+
+export function ResumeNumberPressingSession(arg1:number):Promise<types.NumberPressingGameState>;
+
+export function ResumePositionMemorySession(arg1:number):Promise<position_memory.PositionMemoryGameState>;
+
+export function ResumeRoadSession(arg1:number):Promise<road.RoadGameState>;
+
+export function ResumeRpsSession(arg1:number):Promise<rps.GameState>;
+
+export function ResumeSchedulingSession(arg1:number):Promise<scheduling.SchedulingGameState>;
+
+export function ResumeShapeRotationSession(arg1:number):Promise<main.ShapeRotationGameState>;
+
+export function ResumeStroopSession(arg1:number):Promise<stroop.StroopGameState>;
+
+export function ResumeTowerSession(arg1:number):Promise<tower.TowerGameState>;
+
+export function ResumeWeightSession(arg1:number):Promise<weight.WeightGameState>;
+
 export function SaveShapeRotationSession(arg1:types.ShapeRotationSettings):Promise<number>;
 
+export function SetExcludeIncompleteSessions(arg1:boolean):Promise<void>;
+
+export function SetSessionNotes(arg1:number,arg2:string):Promise<void>;
+
+export function SetSessionTags(arg1:number,arg2:Array<string>):Promise<void>;
+
+export function StartBattery(arg1:Array<types.BatteryStep>):Promise<types.BatterySession>;
+
+export function StartBatteryStep(arg1:number):Promise<types.BatteryStepStart>;
+
 export function StartCatChaserGame(arg1:types.CatChaserSettings):Promise<cat_chaser.CatChaserGameState>;
 
 export function StartCountComparisonGame(arg1:types.CountComparisonSettings):Promise<number>;
@@ -50,18 +245,46 @@ export function StartNBackGame(arg1:types.NBackSettings):Promise<nback.NBackGame
 
 export function StartNumberPressingGame(arg1:types.NumberPressingSetup):Promise<types.NumberPressingGameState>;
 
+export function StartPositionMemoryGame(arg1:types.PositionMemorySettings):Promise<position_memory.PositionMemoryGameState>;
+
+export function StartRoadGame(arg1:types.RoadSettings):Promise<road.RoadGameState>;
+
 export function StartRpsGame(arg1:types.RpsSettings):Promise<rps.GameState>;
 
-export function SubmitCatChaserAnswer(arg1:number,arg2:string,arg3:string,arg4:number,arg5:number):Promise<types.CatChaserResult>;
+export function StartSchedulingGame(arg1:types.SchedulingSettings):Promise<scheduling.SchedulingGameState>;
+
+export function StartShapeRotationGame(arg1:types.ShapeRotationSettings):Promise<main.ShapeRotationGameState>;
+
+export function StartStroopGame(arg1:types.StroopSettings):Promise<stroop.StroopGameState>;
+
+export function StartTowerGame(arg1:types.TowerSettings):Promise<tower.TowerGameState>;
 
-export function SubmitCountComparisonAnswer(arg1:types.CountComparisonSubmission):Promise<void>;
+export function StartWeightGame(arg1:types.WeightSettings):Promise<weight.WeightGameState>;
 
-export function SubmitNBackAnswer(arg1:string,arg2:number,arg3:number):Promise<types.NBackResult>;
+export function SubmitCatChaserAnswer(arg1:number,arg2:number,arg3:string,arg4:string,arg5:number,arg6:number):Promise<types.CatChaserResult>;
+
+export function SubmitCountComparisonAnswer(arg1:number,arg2:types.CountComparisonSubmission):Promise<void>;
+
+export function SubmitNBackAnswer(arg1:number,arg2:string,arg3:number,arg4:number):Promise<types.NBackResult>;
 
 export function SubmitNumberPressingResultR1(arg1:types.NumberPressingResultR1):Promise<void>;
 
 export function SubmitNumberPressingResultR2(arg1:types.NumberPressingResultR2):Promise<void>;
 
-export function SubmitRpsAnswer(arg1:string,arg2:number,arg3:number):Promise<types.RpsResult>;
+export function SubmitPositionMemorySequence(arg1:number,arg2:number,arg3:Array<number>,arg4:number):Promise<types.PositionMemorySubmitResult>;
+
+export function SubmitRoadLayout(arg1:number,arg2:number,arg3:Array<types.RoadTile>,arg4:number):Promise<types.RoadResult>;
+
+export function SubmitRpsAnswer(arg1:number,arg2:string,arg3:number,arg4:number):Promise<types.RpsResult>;
+
+export function SubmitSchedulingPlacement(arg1:number,arg2:number,arg3:Array<types.SchedulingSlot>,arg4:number):Promise<types.SchedulingResult>;
 
 export function SubmitShapeRotationAnswerAsync(arg1:number,arg2:shape_rotation.ShapeRotationProblemWithFinalShape,arg3:Array<string>,arg4:number,arg5:number):Promise<void>;
+
+export function SubmitStroopAnswer(arg1:number,arg2:number,arg3:string,arg4:number):Promise<types.StroopResult>;
+
+export function SubmitTowerMove(arg1:number,arg2:number,arg3:number,arg4:number,arg5:number):Promise<types.TowerMoveResult>;
+
+export function SubmitWeightRanking(arg1:number,arg2:number,arg3:Array<string>,arg4:number):Promise<types.WeightResult>;
+
+export function WeighObjects(arg1:number,arg2:number,arg3:string,arg4:string):Promise<types.WeightWeighing>;
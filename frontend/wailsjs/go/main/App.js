@@ -2,58 +2,318 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AbandonCatChaserGame(arg1) {
+  return window['go']['main']['App']['AbandonCatChaserGame'](arg1);
+}
+
+export function AbandonCountComparisonGame(arg1) {
+  return window['go']['main']['App']['AbandonCountComparisonGame'](arg1);
+}
+
+export function AbandonNBackGame(arg1) {
+  return window['go']['main']['App']['AbandonNBackGame'](arg1);
+}
+
+export function AbandonNumberPressingGame(arg1) {
+  return window['go']['main']['App']['AbandonNumberPressingGame'](arg1);
+}
+
+export function AbandonPositionMemoryGame(arg1) {
+  return window['go']['main']['App']['AbandonPositionMemoryGame'](arg1);
+}
+
+export function AbandonRoadGame(arg1) {
+  return window['go']['main']['App']['AbandonRoadGame'](arg1);
+}
+
+export function AbandonRpsGame(arg1) {
+  return window['go']['main']['App']['AbandonRpsGame'](arg1);
+}
+
+export function AbandonSchedulingGame(arg1) {
+  return window['go']['main']['App']['AbandonSchedulingGame'](arg1);
+}
+
+export function AbandonShapeRotationGame(arg1) {
+  return window['go']['main']['App']['AbandonShapeRotationGame'](arg1);
+}
+
+export function AbandonStroopGame(arg1) {
+  return window['go']['main']['App']['AbandonStroopGame'](arg1);
+}
+
+export function AbandonTowerGame(arg1) {
+  return window['go']['main']['App']['AbandonTowerGame'](arg1);
+}
+
+export function AbandonWeightGame(arg1) {
+  return window['go']['main']['App']['AbandonWeightGame'](arg1);
+}
+
 export function CalculateCorrectClicksR2(arg1) {
   return window['go']['main']['App']['CalculateCorrectClicksR2'](arg1);
 }
 
+export function CompleteBatteryStep(arg1, arg2) {
+  return window['go']['main']['App']['CompleteBatteryStep'](arg1, arg2);
+}
+
+export function CompleteSession(arg1) {
+  return window['go']['main']['App']['CompleteSession'](arg1);
+}
+
+export function CreateBackup() {
+  return window['go']['main']['App']['CreateBackup']();
+}
+
+export function CreateGoal(arg1) {
+  return window['go']['main']['App']['CreateGoal'](arg1);
+}
+
+export function DeleteCountComparisonWordList(arg1) {
+  return window['go']['main']['App']['DeleteCountComparisonWordList'](arg1);
+}
+
+export function DeleteGoal(arg1) {
+  return window['go']['main']['App']['DeleteGoal'](arg1);
+}
+
+export function DeleteSession(arg1) {
+  return window['go']['main']['App']['DeleteSession'](arg1);
+}
+
+export function EndCatChaserGame(arg1) {
+  return window['go']['main']['App']['EndCatChaserGame'](arg1);
+}
+
+export function EndCountComparisonGame(arg1) {
+  return window['go']['main']['App']['EndCountComparisonGame'](arg1);
+}
+
+export function EndNBackGame(arg1) {
+  return window['go']['main']['App']['EndNBackGame'](arg1);
+}
+
+export function EndNumberPressingGame(arg1) {
+  return window['go']['main']['App']['EndNumberPressingGame'](arg1);
+}
+
+export function EndPositionMemoryGame(arg1) {
+  return window['go']['main']['App']['EndPositionMemoryGame'](arg1);
+}
+
+export function EndRoadGame(arg1) {
+  return window['go']['main']['App']['EndRoadGame'](arg1);
+}
+
+export function EndRpsGame(arg1) {
+  return window['go']['main']['App']['EndRpsGame'](arg1);
+}
+
+export function EndSchedulingGame(arg1) {
+  return window['go']['main']['App']['EndSchedulingGame'](arg1);
+}
+
+export function EndShapeRotationGame(arg1) {
+  return window['go']['main']['App']['EndShapeRotationGame'](arg1);
+}
+
+export function EndStroopGame(arg1) {
+  return window['go']['main']['App']['EndStroopGame'](arg1);
+}
+
+export function EndTowerGame(arg1) {
+  return window['go']['main']['App']['EndTowerGame'](arg1);
+}
+
+export function EndWeightGame(arg1) {
+  return window['go']['main']['App']['EndWeightGame'](arg1);
+}
+
+export function FinishTowerProblem(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FinishTowerProblem'](arg1, arg2, arg3);
+}
+
+export function GetAllTags() {
+  return window['go']['main']['App']['GetAllTags']();
+}
+
+export function GetBatteryReport(arg1) {
+  return window['go']['main']['App']['GetBatteryReport'](arg1);
+}
+
+export function GetBatterySession(arg1) {
+  return window['go']['main']['App']['GetBatterySession'](arg1);
+}
+
+export function GetCatChaserCalibration(arg1) {
+  return window['go']['main']['App']['GetCatChaserCalibration'](arg1);
+}
+
+export function GetCatChaserCalibrationTrend() {
+  return window['go']['main']['App']['GetCatChaserCalibrationTrend']();
+}
+
 export function GetCatChaserSessionStats(arg1) {
   return window['go']['main']['App']['GetCatChaserSessionStats'](arg1);
 }
 
+export function GetCompetencyProfile() {
+  return window['go']['main']['App']['GetCompetencyProfile']();
+}
+
+export function GetCountComparisonPsychometrics(arg1) {
+  return window['go']['main']['App']['GetCountComparisonPsychometrics'](arg1);
+}
+
 export function GetCountComparisonSessionStats(arg1) {
   return window['go']['main']['App']['GetCountComparisonSessionStats'](arg1);
 }
 
+export function GetCountComparisonThresholdTrend() {
+  return window['go']['main']['App']['GetCountComparisonThresholdTrend']();
+}
+
+export function GetCountComparisonWordListStats() {
+  return window['go']['main']['App']['GetCountComparisonWordListStats']();
+}
+
+export function GetCountComparisonWordLists(arg1) {
+  return window['go']['main']['App']['GetCountComparisonWordLists'](arg1);
+}
+
+export function GetDailyPlan() {
+  return window['go']['main']['App']['GetDailyPlan']();
+}
+
+export function GetDatabaseRecovery() {
+  return window['go']['main']['App']['GetDatabaseRecovery']();
+}
+
+export function GetDefaultBatterySteps() {
+  return window['go']['main']['App']['GetDefaultBatterySteps']();
+}
+
+export function GetDeletedSessions() {
+  return window['go']['main']['App']['GetDeletedSessions']();
+}
+
+export function GetExcludeIncompleteSessions() {
+  return window['go']['main']['App']['GetExcludeIncompleteSessions']();
+}
+
+export function GetGoalProgress() {
+  return window['go']['main']['App']['GetGoalProgress']();
+}
+
 export function GetNBackSessionStats(arg1) {
   return window['go']['main']['App']['GetNBackSessionStats'](arg1);
 }
 
-export function GetNextCountComparisonProblem() {
-  return window['go']['main']['App']['GetNextCountComparisonProblem']();
+export function GetNextCountComparisonProblem(arg1) {
+  return window['go']['main']['App']['GetNextCountComparisonProblem'](arg1);
+}
+
+export function GetNormTable() {
+  return window['go']['main']['App']['GetNormTable']();
+}
+
+export function GetNormalizedScores(arg1) {
+  return window['go']['main']['App']['GetNormalizedScores'](arg1);
+}
+
+export function GetNormalizedSessionScore(arg1) {
+  return window['go']['main']['App']['GetNormalizedSessionScore'](arg1);
 }
 
 export function GetNumberPressingSessionStats(arg1) {
   return window['go']['main']['App']['GetNumberPressingSessionStats'](arg1);
 }
 
-export function GetPaginatedCatChaserSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedCatChaserSessionsWithResults'](arg1, arg2);
+export function GetPaginatedCatChaserSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedCatChaserSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedCountComparisonSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedCountComparisonSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedNBackSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedNBackSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedNumberPressingSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedNumberPressingSessionsWithResults'](arg1, arg2, arg3);
 }
 
-export function GetPaginatedCountComparisonSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedCountComparisonSessionsWithResults'](arg1, arg2);
+export function GetPaginatedPositionMemorySessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedPositionMemorySessionsWithResults'](arg1, arg2, arg3);
 }
 
-export function GetPaginatedNBackSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedNBackSessionsWithResults'](arg1, arg2);
+export function GetPaginatedRoadSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedRoadSessionsWithResults'](arg1, arg2, arg3);
 }
 
-export function GetPaginatedNumberPressingSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedNumberPressingSessionsWithResults'](arg1, arg2);
+export function GetPaginatedRpsSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedRpsSessionsWithResults'](arg1, arg2, arg3);
 }
 
-export function GetPaginatedRpsSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedRpsSessionsWithResults'](arg1, arg2);
+export function GetPaginatedSchedulingSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedSchedulingSessionsWithResults'](arg1, arg2, arg3);
 }
 
-export function GetPaginatedShapeRotationSessionsWithResults(arg1, arg2) {
-  return window['go']['main']['App']['GetPaginatedShapeRotationSessionsWithResults'](arg1, arg2);
+export function GetPaginatedShapeRotationSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedShapeRotationSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedStroopSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedStroopSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedTowerSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedTowerSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPaginatedWeightSessionsWithResults(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPaginatedWeightSessionsWithResults'](arg1, arg2, arg3);
+}
+
+export function GetPersonalRecords() {
+  return window['go']['main']['App']['GetPersonalRecords']();
+}
+
+export function GetPositionMemorySessionStats(arg1) {
+  return window['go']['main']['App']['GetPositionMemorySessionStats'](arg1);
+}
+
+export function GetPositionMemorySpanHistory() {
+  return window['go']['main']['App']['GetPositionMemorySpanHistory']();
+}
+
+export function GetPracticeStreak() {
+  return window['go']['main']['App']['GetPracticeStreak']();
+}
+
+export function GetResumableSessions() {
+  return window['go']['main']['App']['GetResumableSessions']();
+}
+
+export function GetRoadSessionStats(arg1) {
+  return window['go']['main']['App']['GetRoadSessionStats'](arg1);
 }
 
 export function GetRpsSessionStats(arg1) {
   return window['go']['main']['App']['GetRpsSessionStats'](arg1);
 }
 
+export function GetSchedulingSessionStats(arg1) {
+  return window['go']['main']['App']['GetSchedulingSessionStats'](arg1);
+}
+
+export function GetScoreMetrics() {
+  return window['go']['main']['App']['GetScoreMetrics']();
+}
+
 export function GetSessionResults(arg1, arg2) {
   return window['go']['main']['App']['GetSessionResults'](arg1, arg2);
 }
@@ -70,10 +330,126 @@ export function GetShapeRotationSessionStats(arg1) {
   return window['go']['main']['App']['GetShapeRotationSessionStats'](arg1);
 }
 
+export function GetStroopInterferenceTrend() {
+  return window['go']['main']['App']['GetStroopInterferenceTrend']();
+}
+
+export function GetStroopSessionStats(arg1) {
+  return window['go']['main']['App']['GetStroopSessionStats'](arg1);
+}
+
+export function GetTowerSessionStats(arg1) {
+  return window['go']['main']['App']['GetTowerSessionStats'](arg1);
+}
+
+export function GetWeightSessionStats(arg1) {
+  return window['go']['main']['App']['GetWeightSessionStats'](arg1);
+}
+
+export function ImportCountComparisonWordList(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ImportCountComparisonWordList'](arg1, arg2, arg3, arg4);
+}
+
+export function ImportNormTable(arg1) {
+  return window['go']['main']['App']['ImportNormTable'](arg1);
+}
+
+export function ListBackups() {
+  return window['go']['main']['App']['ListBackups']();
+}
+
+export function PurgeDeletedSessions() {
+  return window['go']['main']['App']['PurgeDeletedSessions']();
+}
+
+export function PurgeSession(arg1) {
+  return window['go']['main']['App']['PurgeSession'](arg1);
+}
+
+export function RestoreBackup(arg1) {
+  return window['go']['main']['App']['RestoreBackup'](arg1);
+}
+
+export function RestoreSession(arg1) {
+  return window['go']['main']['App']['RestoreSession'](arg1);
+}
+
+export function ResumeBattery() {
+  return window['go']['main']['App']['ResumeBattery']();
+}
+
+export function ResumeCatChaserSession(arg1) {
+  return window['go']['main']['App']['ResumeCatChaserSession'](arg1);
+}
+
+export function ResumeCountComparisonSession(arg1) {
+  return window['go']['main']['App']['ResumeCountComparisonSession'](arg1);
+}
+
+export function ResumeNBackSession(arg1) {
+  return window['go']['main']['App']['ResumeNBackSession'](arg1);
+}
+
+export function ResumeNumberPressingSession(arg1) {
+  return window['go']['main']['App']['ResumeNumberPressingSession'](arg1);
+}
+
+export function ResumePositionMemorySession(arg1) {
+  return window['go']['main']['App']['ResumePositionMemorySession'](arg1);
+}
+
+export function ResumeRoadSession(arg1) {
+  return window['go']['main']['App']['ResumeRoadSession'](arg1);
+}
+
+export function ResumeRpsSession(arg1) {
+  return window['go']['main']['App']['ResumeRpsSession'](arg1);
+}
+
+export function ResumeSchedulingSession(arg1) {
+  return window['go']['main']['App']['ResumeSchedulingSession'](arg1);
+}
+
+export function ResumeShapeRotationSession(arg1) {
+  return window['go']['main']['App']['ResumeShapeRotationSession'](arg1);
+}
+
+export function ResumeStroopSession(arg1) {
+  return window['go']['main']['App']['ResumeStroopSession'](arg1);
+}
+
+export function ResumeTowerSession(arg1) {
+  return window['go']['main']['App']['ResumeTowerSession'](arg1);
+}
+
+export function ResumeWeightSession(arg1) {
+  return window['go']['main']['App']['ResumeWeightSession'](arg1);
+}
+
 export function SaveShapeRotationSession(arg1) {
   return window['go']['main']['App']['SaveShapeRotationSession'](arg1);
 }
 
+export function SetExcludeIncompleteSessions(arg1) {
+  return window['go']['main']['App']['SetExcludeIncompleteSessions'](arg1);
+}
+
+export function SetSessionNotes(arg1, arg2) {
+  return window['go']['main']['App']['SetSessionNotes'](arg1, arg2);
+}
+
+export function SetSessionTags(arg1, arg2) {
+  return window['go']['main']['App']['SetSessionTags'](arg1, arg2);
+}
+
+export function StartBattery(arg1) {
+  return window['go']['main']['App']['StartBattery'](arg1);
+}
+
+export function StartBatteryStep(arg1) {
+  return window['go']['main']['App']['StartBatteryStep'](arg1);
+}
+
 export function StartCatChaserGame(arg1) {
   return window['go']['main']['App']['StartCatChaserGame'](arg1);
 }
@@ -90,20 +466,48 @@ export function StartNumberPressingGame(arg1) {
   return window['go']['main']['App']['StartNumberPressingGame'](arg1);
 }
 
+export function StartPositionMemoryGame(arg1) {
+  return window['go']['main']['App']['StartPositionMemoryGame'](arg1);
+}
+
+export function StartRoadGame(arg1) {
+  return window['go']['main']['App']['StartRoadGame'](arg1);
+}
+
 export function StartRpsGame(arg1) {
   return window['go']['main']['App']['StartRpsGame'](arg1);
 }
 
-export function SubmitCatChaserAnswer(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['App']['SubmitCatChaserAnswer'](arg1, arg2, arg3, arg4, arg5);
+export function StartSchedulingGame(arg1) {
+  return window['go']['main']['App']['StartSchedulingGame'](arg1);
+}
+
+export function StartShapeRotationGame(arg1) {
+  return window['go']['main']['App']['StartShapeRotationGame'](arg1);
+}
+
+export function StartStroopGame(arg1) {
+  return window['go']['main']['App']['StartStroopGame'](arg1);
 }
 
-export function SubmitCountComparisonAnswer(arg1) {
-  return window['go']['main']['App']['SubmitCountComparisonAnswer'](arg1);
+export function StartTowerGame(arg1) {
+  return window['go']['main']['App']['StartTowerGame'](arg1);
 }
 
-export function SubmitNBackAnswer(arg1, arg2, arg3) {
-  return window['go']['main']['App']['SubmitNBackAnswer'](arg1, arg2, arg3);
+export function StartWeightGame(arg1) {
+  return window['go']['main']['App']['StartWeightGame'](arg1);
+}
+
+export function SubmitCatChaserAnswer(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['SubmitCatChaserAnswer'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function SubmitCountComparisonAnswer(arg1, arg2) {
+  return window['go']['main']['App']['SubmitCountComparisonAnswer'](arg1, arg2);
+}
+
+export function SubmitNBackAnswer(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitNBackAnswer'](arg1, arg2, arg3, arg4);
 }
 
 export function SubmitNumberPressingResultR1(arg1) {
@@ -114,10 +518,38 @@ export function SubmitNumberPressingResultR2(arg1) {
   return window['go']['main']['App']['SubmitNumberPressingResultR2'](arg1);
 }
 
-export function SubmitRpsAnswer(arg1, arg2, arg3) {
-  return window['go']['main']['App']['SubmitRpsAnswer'](arg1, arg2, arg3);
+export function SubmitPositionMemorySequence(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitPositionMemorySequence'](arg1, arg2, arg3, arg4);
+}
+
+export function SubmitRoadLayout(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitRoadLayout'](arg1, arg2, arg3, arg4);
+}
+
+export function SubmitRpsAnswer(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitRpsAnswer'](arg1, arg2, arg3, arg4);
+}
+
+export function SubmitSchedulingPlacement(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitSchedulingPlacement'](arg1, arg2, arg3, arg4);
 }
 
 export function SubmitShapeRotationAnswerAsync(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['SubmitShapeRotationAnswerAsync'](arg1, arg2, arg3, arg4, arg5);
 }
+
+export function SubmitStroopAnswer(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitStroopAnswer'](arg1, arg2, arg3, arg4);
+}
+
+export function SubmitTowerMove(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SubmitTowerMove'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SubmitWeightRanking(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SubmitWeightRanking'](arg1, arg2, arg3, arg4);
+}
+
+export function WeighObjects(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['WeighObjects'](arg1, arg2, arg3, arg4);
+}
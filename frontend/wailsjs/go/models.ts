@@ -4,6 +4,8 @@ export namespace cat_chaser {
 	    settings: types.CatChaserSettings;
 	    problems: types.CatChaserProblem[];
 	    id: number;
+	    answered: string[][];
+	    nextRound: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new CatChaserGameState(source);
@@ -14,6 +16,49 @@ export namespace cat_chaser {
 	        this.settings = this.convertValues(source["settings"], types.CatChaserSettings);
 	        this.problems = this.convertValues(source["problems"], types.CatChaserProblem);
 	        this.id = source["id"];
+	        this.answered = source["answered"];
+	        this.nextRound = source["nextRound"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace main {
+	
+	export class ShapeRotationGameState {
+	    id: number;
+	    settings: types.ShapeRotationSettings;
+	    problems: shape_rotation.ShapeRotationProblemWithFinalShape[];
+	    nextProblem: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeRotationGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.settings = this.convertValues(source["settings"], types.ShapeRotationSettings);
+	        this.problems = this.convertValues(source["problems"], shape_rotation.ShapeRotationProblemWithFinalShape);
+	        this.nextProblem = source["nextProblem"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -43,6 +88,7 @@ export namespace nback {
 	    settings: types.NBackSettings;
 	    shapeSequence: string[];
 	    id: number;
+	    nextQuestion: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new NBackGameState(source);
@@ -53,6 +99,89 @@ export namespace nback {
 	        this.settings = this.convertValues(source["settings"], types.NBackSettings);
 	        this.shapeSequence = source["shapeSequence"];
 	        this.id = source["id"];
+	        this.nextQuestion = source["nextQuestion"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace position_memory {
+	
+	export class PositionMemoryGameState {
+	    settings: types.PositionMemorySettings;
+	    blocks: types.PositionMemoryBlock[];
+	    current?: types.PositionMemorySequence;
+	    id: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemoryGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], types.PositionMemorySettings);
+	        this.blocks = this.convertValues(source["blocks"], types.PositionMemoryBlock);
+	        this.current = this.convertValues(source["current"], types.PositionMemorySequence);
+	        this.id = source["id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace road {
+	
+	export class RoadGameState {
+	    settings: types.RoadSettings;
+	    problems: types.RoadProblem[];
+	    id: number;
+	    nextProblem: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], types.RoadSettings);
+	        this.problems = this.convertValues(source["problems"], types.RoadProblem);
+	        this.id = source["id"];
+	        this.nextProblem = source["nextProblem"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -103,6 +232,7 @@ export namespace rps {
 	    problems: Problem[];
 	    id: number;
 	    gameCode: string;
+	    nextQuestion: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new GameState(source);
@@ -114,6 +244,48 @@ export namespace rps {
 	        this.problems = this.convertValues(source["problems"], Problem);
 	        this.id = source["id"];
 	        this.gameCode = source["gameCode"];
+	        this.nextQuestion = source["nextQuestion"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace scheduling {
+	
+	export class SchedulingGameState {
+	    settings: types.SchedulingSettings;
+	    problems: types.SchedulingProblem[];
+	    id: number;
+	    nextProblem: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], types.SchedulingSettings);
+	        this.problems = this.convertValues(source["problems"], types.SchedulingProblem);
+	        this.id = source["id"];
+	        this.nextProblem = source["nextProblem"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -176,114 +348,24 @@ export namespace shape_rotation {
 
 }
 
-export namespace types {
-	
-	export class AppliedTrap {
-	    type: string;
-	    appliedTo: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new AppliedTrap(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
-	        this.appliedTo = source["appliedTo"];
-	    }
-	}
-	export class CatChaserProblem {
-	    round: number;
-	    micePositions: number[];
-	    catPositions: number[];
-	    redCatIndex: number;
-	    blueCatIndex: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new CatChaserProblem(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.round = source["round"];
-	        this.micePositions = source["micePositions"];
-	        this.catPositions = source["catPositions"];
-	        this.redCatIndex = source["redCatIndex"];
-	        this.blueCatIndex = source["blueCatIndex"];
-	    }
-	}
-	export class CatChaserResult {
-	    sessionId: number;
-	    round: number;
-	    targetColor: string;
-	    playerChoice: string;
-	    confidence: number;
-	    correctChoice: string;
-	    isCorrect: boolean;
-	    score: number;
-	    responseTimeMs: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new CatChaserResult(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionId = source["sessionId"];
-	        this.round = source["round"];
-	        this.targetColor = source["targetColor"];
-	        this.playerChoice = source["playerChoice"];
-	        this.confidence = source["confidence"];
-	        this.correctChoice = source["correctChoice"];
-	        this.isCorrect = source["isCorrect"];
-	        this.score = source["score"];
-	        this.responseTimeMs = source["responseTimeMs"];
-	    }
-	}
-	export class CatChaserRoundStats {
-	    round: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    totalScore: number;
-	    accuracy: number;
-	    averageResponseTimeMs: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new CatChaserRoundStats(source);
-	    }
+export namespace stroop {
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.round = source["round"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.totalScore = source["totalScore"];
-	        this.accuracy = source["accuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	    }
-	}
-	export class CatChaserSessionStats {
-	    sessionId: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    totalScore: number;
-	    overallAccuracy: number;
-	    averageResponseTimeMs: number;
-	    roundStats: CatChaserRoundStats[];
+	export class StroopGameState {
+	    settings: types.StroopSettings;
+	    trials: types.StroopTrial[];
+	    id: number;
+	    nextTrial: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CatChaserSessionStats(source);
+	        return new StroopGameState(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionId = source["sessionId"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.totalScore = source["totalScore"];
-	        this.overallAccuracy = source["overallAccuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.roundStats = this.convertValues(source["roundStats"], CatChaserRoundStats);
+	        this.settings = this.convertValues(source["settings"], types.StroopSettings);
+	        this.trials = this.convertValues(source["trials"], types.StroopTrial);
+	        this.id = source["id"];
+	        this.nextTrial = source["nextTrial"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -304,24 +386,27 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class CatChaserSessionWithResults {
+
+}
+
+export namespace tower {
+	
+	export class TowerGameState {
+	    settings: types.TowerSettings;
+	    problems: types.TowerProblem[];
 	    id: number;
-	    gameCode: string;
-	    playDatetime: string;
-	    settings: string;
-	    results: CatChaserResult[];
+	    nextProblem: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CatChaserSessionWithResults(source);
+	        return new TowerGameState(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], types.TowerSettings);
+	        this.problems = this.convertValues(source["problems"], types.TowerProblem);
 	        this.id = source["id"];
-	        this.gameCode = source["gameCode"];
-	        this.playDatetime = source["playDatetime"];
-	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], CatChaserResult);
+	        this.nextProblem = source["nextProblem"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -342,52 +427,81 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class CatChaserSettings {
-	    numTrials: number;
-	    difficulty: string;
-	    showTime: number;
-	    responseTimeLimit: number;
-	    isRealMode: boolean;
+
+}
+
+export namespace types {
 	
-	    static createFrom(source: any = {}) {
-	        return new CatChaserSettings(source);
+	export class AppliedTrap {
+	    type: string;
+	    appliedTo: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppliedTrap(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.numTrials = source["numTrials"];
-	        this.difficulty = source["difficulty"];
-	        this.showTime = source["showTime"];
-	        this.responseTimeLimit = source["responseTimeLimit"];
-	        this.isRealMode = source["isRealMode"];
+	        this.type = source["type"];
+	        this.appliedTo = source["appliedTo"];
 	    }
 	}
-	export class DensityParams {
-	    areaMultiplier: number;
-	    gapProbability: number;
+	export class BackupInfo {
+	    name: string;
+	    sizeBytes: number;
+	    createdAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new DensityParams(source);
+	        return new BackupInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.areaMultiplier = source["areaMultiplier"];
-	        this.gapProbability = source["gapProbability"];
+	        this.name = source["name"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.createdAt = source["createdAt"];
 	    }
 	}
-	export class DensityInfo {
-	    left: DensityParams;
-	    right: DensityParams;
+	export class BatteryStepReport {
+	    step: number;
+	    gameCode: string;
+	    sessionId: number;
+	    score: number;
+	    stats: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new DensityInfo(source);
+	        return new BatteryStepReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.left = this.convertValues(source["left"], DensityParams);
-	        this.right = this.convertValues(source["right"], DensityParams);
+	        this.step = source["step"];
+	        this.gameCode = source["gameCode"];
+	        this.sessionId = source["sessionId"];
+	        this.score = source["score"];
+	        this.stats = source["stats"];
+	    }
+	}
+	export class BatteryReport {
+	    batteryId: number;
+	    status: string;
+	    startedAt: string;
+	    completedAt: string;
+	    compositeScore: number;
+	    steps: BatteryStepReport[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BatteryReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.batteryId = source["batteryId"];
+	        this.status = source["status"];
+	        this.startedAt = source["startedAt"];
+	        this.completedAt = source["completedAt"];
+	        this.compositeScore = source["compositeScore"];
+	        this.steps = this.convertValues(source["steps"], BatteryStepReport);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -408,54 +522,46 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class WordDetail {
-	    text: string;
-	    size: number;
-	    weight: number;
-	    isGap: boolean;
-	    gapWidth: number;
+	export class BatteryStepProgress {
+	    step: number;
+	    gameCode: string;
+	    settings: any;
+	    sessionId: number;
+	    status: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new WordDetail(source);
+	        return new BatteryStepProgress(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.text = source["text"];
-	        this.size = source["size"];
-	        this.weight = source["weight"];
-	        this.isGap = source["isGap"];
-	        this.gapWidth = source["gapWidth"];
+	        this.step = source["step"];
+	        this.gameCode = source["gameCode"];
+	        this.settings = source["settings"];
+	        this.sessionId = source["sessionId"];
+	        this.status = source["status"];
 	    }
 	}
-	export class CountComparisonProblem {
-	    problemNumber: number;
-	    leftWords: WordDetail[];
-	    rightWords: WordDetail[];
-	    leftWordText: string;
-	    rightWordText: string;
-	    density: DensityInfo;
-	    presentationTime: number;
-	    inputTime: number;
-	    correctSide: string;
-	    appliedTraps: AppliedTrap[];
+	export class BatterySession {
+	    id: number;
+	    startedAt: string;
+	    completedAt: string;
+	    status: string;
+	    currentStep: number;
+	    steps: BatteryStepProgress[];
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonProblem(source);
+	        return new BatterySession(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.problemNumber = source["problemNumber"];
-	        this.leftWords = this.convertValues(source["leftWords"], WordDetail);
-	        this.rightWords = this.convertValues(source["rightWords"], WordDetail);
-	        this.leftWordText = source["leftWordText"];
-	        this.rightWordText = source["rightWordText"];
-	        this.density = this.convertValues(source["density"], DensityInfo);
-	        this.presentationTime = source["presentationTime"];
-	        this.inputTime = source["inputTime"];
-	        this.correctSide = source["correctSide"];
-	        this.appliedTraps = this.convertValues(source["appliedTraps"], AppliedTrap);
+	        this.id = source["id"];
+	        this.startedAt = source["startedAt"];
+	        this.completedAt = source["completedAt"];
+	        this.status = source["status"];
+	        this.currentStep = source["currentStep"];
+	        this.steps = this.convertValues(source["steps"], BatteryStepProgress);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -476,80 +582,40 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class CountComparisonResult {
-	    id: number;
-	    sessionId: number;
-	    problemNumber: number;
-	    isCorrect: boolean;
-	    responseTimeMs: number;
-	    playerChoice: string;
-	    correctChoice: string;
-	    leftWord: string;
-	    rightWord: string;
-	    leftWordCount: number;
-	    rightWordCount: number;
-	    appliedTraps: string;
+	export class BatteryStep {
+	    gameCode: string;
+	    settings: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonResult(source);
+	        return new BatteryStep(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionId = source["sessionId"];
-	        this.problemNumber = source["problemNumber"];
-	        this.isCorrect = source["isCorrect"];
-	        this.responseTimeMs = source["responseTimeMs"];
-	        this.playerChoice = source["playerChoice"];
-	        this.correctChoice = source["correctChoice"];
-	        this.leftWord = source["leftWord"];
-	        this.rightWord = source["rightWord"];
-	        this.leftWordCount = source["leftWordCount"];
-	        this.rightWordCount = source["rightWordCount"];
-	        this.appliedTraps = source["appliedTraps"];
+	        this.gameCode = source["gameCode"];
+	        this.settings = source["settings"];
 	    }
 	}
-	export class TrapStat {
-	    trapType: string;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageResponseTime: number;
 	
-	    static createFrom(source: any = {}) {
-	        return new TrapStat(source);
-	    }
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.trapType = source["trapType"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageResponseTime = source["averageResponseTime"];
-	    }
-	}
-	export class CountComparisonSessionStats {
+	export class BatteryStepStart {
+	    battery?: BatterySession;
+	    step: number;
+	    gameCode: string;
 	    sessionId: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    overallAccuracy: number;
-	    averageResponseTimeMs: number;
-	    trapStats: TrapStat[];
+	    state: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonSessionStats(source);
+	        return new BatteryStepStart(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.battery = this.convertValues(source["battery"], BatterySession);
+	        this.step = source["step"];
+	        this.gameCode = source["gameCode"];
 	        this.sessionId = source["sessionId"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.overallAccuracy = source["overallAccuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.trapStats = this.convertValues(source["trapStats"], TrapStat);
+	        this.state = source["state"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -570,24 +636,50 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class CountComparisonSessionWithResults {
-	    id: number;
-	    gameCode: string;
-	    playDatetime: string;
-	    settings: string;
-	    results: CountComparisonResult[];
+	export class ConfidenceLevelStat {
+	    confidence: number;
+	    statedProbability: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonSessionWithResults(source);
+	        return new ConfidenceLevelStat(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.gameCode = source["gameCode"];
-	        this.playDatetime = source["playDatetime"];
-	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], CountComparisonResult);
+	        this.confidence = source["confidence"];
+	        this.statedProbability = source["statedProbability"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	    }
+	}
+	export class CatChaserCalibration {
+	    sessionId: number;
+	    totalAnswered: number;
+	    accuracy: number;
+	    meanConfidence: number;
+	    brierScore: number;
+	    calibrationError: number;
+	    overconfidenceIndex: number;
+	    levels: ConfidenceLevelStat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CatChaserCalibration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalAnswered = source["totalAnswered"];
+	        this.accuracy = source["accuracy"];
+	        this.meanConfidence = source["meanConfidence"];
+	        this.brierScore = source["brierScore"];
+	        this.calibrationError = source["calibrationError"];
+	        this.overconfidenceIndex = source["overconfidenceIndex"];
+	        this.levels = this.convertValues(source["levels"], ConfidenceLevelStat);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -608,108 +700,102 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class CountComparisonSettings {
-	    numProblems: number;
-	    presentationTime: number;
-	    inputTime: number;
-	    isRealMode: boolean;
+	export class CatChaserCalibrationTrendPoint {
+	    sessionId: number;
+	    playDatetime: string;
+	    totalAnswered: number;
+	    accuracy: number;
+	    brierScore: number;
+	    calibrationError: number;
+	    overconfidenceIndex: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonSettings(source);
+	        return new CatChaserCalibrationTrendPoint(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.numProblems = source["numProblems"];
-	        this.presentationTime = source["presentationTime"];
-	        this.inputTime = source["inputTime"];
-	        this.isRealMode = source["isRealMode"];
+	        this.sessionId = source["sessionId"];
+	        this.playDatetime = source["playDatetime"];
+	        this.totalAnswered = source["totalAnswered"];
+	        this.accuracy = source["accuracy"];
+	        this.brierScore = source["brierScore"];
+	        this.calibrationError = source["calibrationError"];
+	        this.overconfidenceIndex = source["overconfidenceIndex"];
 	    }
 	}
-	export class CountComparisonSubmission {
-	    problemNumber: number;
-	    playerChoice: string;
-	    responseTimeMs: number;
+	export class CatChaserDifficulty {
+	    mode: string;
+	    miceCount: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CountComparisonSubmission(source);
+	        return new CatChaserDifficulty(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.problemNumber = source["problemNumber"];
-	        this.playerChoice = source["playerChoice"];
-	        this.responseTimeMs = source["responseTimeMs"];
+	        this.mode = source["mode"];
+	        this.miceCount = source["miceCount"];
 	    }
 	}
-	
-	
-	export class NBackLevelStat {
-	    nBackLevel: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageResponseTimeMs: number;
+	export class CatChaserFrame {
+	    startMs: number;
+	    durationMs: number;
+	    micePositions: number[];
+	    catPositions: number[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackLevelStat(source);
+	        return new CatChaserFrame(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.nBackLevel = source["nBackLevel"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.startMs = source["startMs"];
+	        this.durationMs = source["durationMs"];
+	        this.micePositions = source["micePositions"];
+	        this.catPositions = source["catPositions"];
 	    }
 	}
-	export class NBackResult {
-	    id: number;
-	    sessionId: number;
-	    round: number;
-	    questionNum: number;
-	    isCorrect: boolean;
-	    responseTimeMs: number;
-	    playerChoice: string;
-	    correctChoice: string;
+	export class CatTarget {
+	    color: string;
+	    catIndex: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackResult(source);
+	        return new CatTarget(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionId = source["sessionId"];
-	        this.round = source["round"];
-	        this.questionNum = source["questionNum"];
-	        this.isCorrect = source["isCorrect"];
-	        this.responseTimeMs = source["responseTimeMs"];
-	        this.playerChoice = source["playerChoice"];
-	        this.correctChoice = source["correctChoice"];
+	        this.color = source["color"];
+	        this.catIndex = source["catIndex"];
 	    }
 	}
-	export class NBackRoundStats {
+	export class CatChaserProblem {
 	    round: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageResponseTimeMs: number;
-	    nBackLevelStats?: NBackLevelStat[];
+	    gridRows: number;
+	    gridCols: number;
+	    micePositions: number[];
+	    catPositions: number[];
+	    targets: CatTarget[];
+	    redCatIndex: number;
+	    blueCatIndex: number;
+	    frames: CatChaserFrame[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackRoundStats(source);
+	        return new CatChaserProblem(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.round = source["round"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.nBackLevelStats = this.convertValues(source["nBackLevelStats"], NBackLevelStat);
+	        this.gridRows = source["gridRows"];
+	        this.gridCols = source["gridCols"];
+	        this.micePositions = source["micePositions"];
+	        this.catPositions = source["catPositions"];
+	        this.targets = this.convertValues(source["targets"], CatTarget);
+	        this.redCatIndex = source["redCatIndex"];
+	        this.blueCatIndex = source["blueCatIndex"];
+	        this.frames = this.convertValues(source["frames"], CatChaserFrame);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -730,16 +816,69 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NBackSessionStats {
+	export class CatChaserResult {
+	    sessionId: number;
+	    round: number;
+	    targetColor: string;
+	    playerChoice: string;
+	    confidence: number;
+	    correctChoice: string;
+	    isCorrect: boolean;
+	    score: number;
+	    responseTimeMs: number;
+	    frameSchedule: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CatChaserResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.round = source["round"];
+	        this.targetColor = source["targetColor"];
+	        this.playerChoice = source["playerChoice"];
+	        this.confidence = source["confidence"];
+	        this.correctChoice = source["correctChoice"];
+	        this.isCorrect = source["isCorrect"];
+	        this.score = source["score"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	        this.frameSchedule = source["frameSchedule"];
+	    }
+	}
+	export class CatChaserRoundStats {
+	    round: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    totalScore: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CatChaserRoundStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.totalScore = source["totalScore"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	export class CatChaserSessionStats {
 	    sessionId: number;
 	    totalQuestions: number;
 	    totalCorrect: number;
+	    totalScore: number;
 	    overallAccuracy: number;
 	    averageResponseTimeMs: number;
-	    roundStats: NBackRoundStats[];
+	    roundStats: CatChaserRoundStats[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackSessionStats(source);
+	        return new CatChaserSessionStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
@@ -747,9 +886,10 @@ export namespace types {
 	        this.sessionId = source["sessionId"];
 	        this.totalQuestions = source["totalQuestions"];
 	        this.totalCorrect = source["totalCorrect"];
+	        this.totalScore = source["totalScore"];
 	        this.overallAccuracy = source["overallAccuracy"];
 	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.roundStats = this.convertValues(source["roundStats"], NBackRoundStats);
+	        this.roundStats = this.convertValues(source["roundStats"], CatChaserRoundStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -770,15 +910,20 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NBackSessionWithResults {
+	export class CatChaserSessionWithResults {
 	    id: number;
 	    gameCode: string;
 	    playDatetime: string;
 	    settings: string;
-	    results: NBackResult[];
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: CatChaserResult[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackSessionWithResults(source);
+	        return new CatChaserSessionWithResults(source);
 	    }
 	
 	    constructor(source: any = {}) {
@@ -787,7 +932,12 @@ export namespace types {
 	        this.gameCode = source["gameCode"];
 	        this.playDatetime = source["playDatetime"];
 	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], NBackResult);
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], CatChaserResult);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -808,108 +958,40 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NBackSettings {
+	export class CatChaserSettings {
 	    numTrials: number;
-	    presentationTime: number;
-	    nBackLevel: number;
-	    shapeGroup: string;
+	    difficulty: CatChaserDifficulty;
+	    showTime: number;
+	    responseTimeLimit: number;
 	    isRealMode: boolean;
+	    gridRows: number;
+	    gridCols: number;
+	    numTargets: number;
+	    caughtRatio?: number;
+	    mouseSteps: number;
+	    catSteps: number;
+	    distractorMoves: number;
+	    frameMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new NBackSettings(source);
+	        return new CatChaserSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.numTrials = source["numTrials"];
-	        this.presentationTime = source["presentationTime"];
-	        this.nBackLevel = source["nBackLevel"];
-	        this.shapeGroup = source["shapeGroup"];
-	        this.isRealMode = source["isRealMode"];
-	    }
-	}
-	export class NumberPressingConditionStat {
-	    conditionType: string;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageTimeTakenSec: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new NumberPressingConditionStat(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.conditionType = source["conditionType"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
-	    }
-	}
-	export class NumberPressingProblemR2 {
-	    doubleClick: number[];
-	    skip: number[];
-	
-	    static createFrom(source: any = {}) {
-	        return new NumberPressingProblemR2(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.doubleClick = source["doubleClick"];
-	        this.skip = source["skip"];
-	    }
-	}
-	export class NumberPressingProblemR1 {
-	    targetNumber: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new NumberPressingProblemR1(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.targetNumber = source["targetNumber"];
-	    }
-	}
-	export class NumberPressingSetup {
-	    isRealMode: boolean;
-	    rounds: number[];
-	    problemsPerRound: number;
-	    timeLimitR1: number;
-	    timeLimitR2: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new NumberPressingSetup(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.difficulty = this.convertValues(source["difficulty"], CatChaserDifficulty);
+	        this.showTime = source["showTime"];
+	        this.responseTimeLimit = source["responseTimeLimit"];
 	        this.isRealMode = source["isRealMode"];
-	        this.rounds = source["rounds"];
-	        this.problemsPerRound = source["problemsPerRound"];
-	        this.timeLimitR1 = source["timeLimitR1"];
-	        this.timeLimitR2 = source["timeLimitR2"];
-	    }
-	}
-	export class NumberPressingGameState {
-	    setup: NumberPressingSetup;
-	    problemsR1: NumberPressingProblemR1[];
-	    problemsR2: NumberPressingProblemR2[];
-	    id: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new NumberPressingGameState(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.setup = this.convertValues(source["setup"], NumberPressingSetup);
-	        this.problemsR1 = this.convertValues(source["problemsR1"], NumberPressingProblemR1);
-	        this.problemsR2 = this.convertValues(source["problemsR2"], NumberPressingProblemR2);
-	        this.id = source["id"];
+	        this.gridRows = source["gridRows"];
+	        this.gridCols = source["gridCols"];
+	        this.numTargets = source["numTargets"];
+	        this.caughtRatio = source["caughtRatio"];
+	        this.mouseSteps = source["mouseSteps"];
+	        this.catSteps = source["catSteps"];
+	        this.distractorMoves = source["distractorMoves"];
+	        this.frameMs = source["frameMs"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -931,25 +1013,52 @@ export namespace types {
 		}
 	}
 	
+	export class GameScore {
+	    gameCode: string;
+	    metric: string;
+	    area: string;
+	    totalSessions: number;
+	    recentSessions: number;
+	    recentValue: number;
+	    historyZ?: number;
+	    normZ?: number;
+	    normPercentile?: number;
 	
-	export class NumberPressingResultR1 {
-	    id: number;
-	    sessionID: number;
-	    problem: NumberPressingProblemR1;
-	    timeTaken: number;
-	    isCorrect: boolean;
+	    static createFrom(source: any = {}) {
+	        return new GameScore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gameCode = source["gameCode"];
+	        this.metric = source["metric"];
+	        this.area = source["area"];
+	        this.totalSessions = source["totalSessions"];
+	        this.recentSessions = source["recentSessions"];
+	        this.recentValue = source["recentValue"];
+	        this.historyZ = source["historyZ"];
+	        this.normZ = source["normZ"];
+	        this.normPercentile = source["normPercentile"];
+	    }
+	}
+	export class CompetencyScore {
+	    area: string;
+	    games: GameScore[];
+	    historyZ?: number;
+	    normZ?: number;
+	    normPercentile?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingResultR1(source);
+	        return new CompetencyScore(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionID = source["sessionID"];
-	        this.problem = this.convertValues(source["problem"], NumberPressingProblemR1);
-	        this.timeTaken = source["timeTaken"];
-	        this.isCorrect = source["isCorrect"];
+	        this.area = source["area"];
+	        this.games = this.convertValues(source["games"], GameScore);
+	        this.historyZ = source["historyZ"];
+	        this.normZ = source["normZ"];
+	        this.normPercentile = source["normPercentile"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -970,28 +1079,18 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NumberPressingResultR2 {
-	    id: number;
-	    sessionID: number;
-	    problem: NumberPressingProblemR2;
-	    playerClicks: number[];
-	    correctClicks: number[];
-	    timeTaken: number;
-	    isCorrect: boolean;
+	export class CompetencyProfile {
+	    areas: CompetencyScore[];
+	    weakestArea: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingResultR2(source);
+	        return new CompetencyProfile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionID = source["sessionID"];
-	        this.problem = this.convertValues(source["problem"], NumberPressingProblemR2);
-	        this.playerClicks = source["playerClicks"];
-	        this.correctClicks = source["correctClicks"];
-	        this.timeTaken = source["timeTaken"];
-	        this.isCorrect = source["isCorrect"];
+	        this.areas = this.convertValues(source["areas"], CompetencyScore);
+	        this.weakestArea = source["weakestArea"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1012,58 +1111,34 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NumberPressingResultsBundle {
-	    resultsR1: NumberPressingResultR1[];
-	    resultsR2: NumberPressingResultR2[];
+	
+	
+	export class DensityParams {
+	    areaMultiplier: number;
+	    gapProbability: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingResultsBundle(source);
+	        return new DensityParams(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.resultsR1 = this.convertValues(source["resultsR1"], NumberPressingResultR1);
-	        this.resultsR2 = this.convertValues(source["resultsR2"], NumberPressingResultR2);
+	        this.areaMultiplier = source["areaMultiplier"];
+	        this.gapProbability = source["gapProbability"];
 	    }
-	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
 	}
-	export class NumberPressingRoundStats {
-	    round: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageTimeTakenSec: number;
-	    conditionStats?: NumberPressingConditionStat[];
+	export class DensityInfo {
+	    left: DensityParams;
+	    right: DensityParams;
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingRoundStats(source);
+	        return new DensityInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.round = source["round"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
-	        this.conditionStats = this.convertValues(source["conditionStats"], NumberPressingConditionStat);
+	        this.left = this.convertValues(source["left"], DensityParams);
+	        this.right = this.convertValues(source["right"], DensityParams);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1084,26 +1159,2959 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NumberPressingSessionStats {
-	    sessionId: number;
-	    totalQuestions: number;
+	export class WordDetail {
+	    text: string;
+	    size: number;
+	    weight: number;
+	    isGap: boolean;
+	    gapWidth: number;
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.size = source["size"];
+	        this.weight = source["weight"];
+	        this.isGap = source["isGap"];
+	        this.gapWidth = source["gapWidth"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+	export class CountComparisonProblem {
+	    problemNumber: number;
+	    leftWords: WordDetail[];
+	    rightWords: WordDetail[];
+	    leftWordText: string;
+	    rightWordText: string;
+	    density: DensityInfo;
+	    presentationTime: number;
+	    inputTime: number;
+	    correctSide: string;
+	    appliedTraps: AppliedTrap[];
+	    ratio: number;
+	    canvasWidth: number;
+	    canvasHeight: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonProblem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.problemNumber = source["problemNumber"];
+	        this.leftWords = this.convertValues(source["leftWords"], WordDetail);
+	        this.rightWords = this.convertValues(source["rightWords"], WordDetail);
+	        this.leftWordText = source["leftWordText"];
+	        this.rightWordText = source["rightWordText"];
+	        this.density = this.convertValues(source["density"], DensityInfo);
+	        this.presentationTime = source["presentationTime"];
+	        this.inputTime = source["inputTime"];
+	        this.correctSide = source["correctSide"];
+	        this.appliedTraps = this.convertValues(source["appliedTraps"], AppliedTrap);
+	        this.ratio = source["ratio"];
+	        this.canvasWidth = source["canvasWidth"];
+	        this.canvasHeight = source["canvasHeight"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PsychometricPoint {
+	    ratio: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PsychometricPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ratio = source["ratio"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	    }
+	}
+	export class PsychometricFit {
+	    condition: string;
+	    totalQuestions: number;
+	    points: PsychometricPoint[];
+	    fitted: boolean;
+	    threshold: number;
+	    thresholdRatio: number;
+	    slope: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PsychometricFit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.condition = source["condition"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.points = this.convertValues(source["points"], PsychometricPoint);
+	        this.fitted = source["fitted"];
+	        this.threshold = source["threshold"];
+	        this.thresholdRatio = source["thresholdRatio"];
+	        this.slope = source["slope"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CountComparisonPsychometrics {
+	    sessionId: number;
+	    all: PsychometricFit;
+	    noTrap: PsychometricFit;
+	    trap: PsychometricFit;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonPsychometrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.all = this.convertValues(source["all"], PsychometricFit);
+	        this.noTrap = this.convertValues(source["noTrap"], PsychometricFit);
+	        this.trap = this.convertValues(source["trap"], PsychometricFit);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CountComparisonResult {
+	    id: number;
+	    sessionId: number;
+	    problemNumber: number;
+	    isCorrect: boolean;
+	    responseTimeMs: number;
+	    playerChoice: string;
+	    correctChoice: string;
+	    leftWord: string;
+	    rightWord: string;
+	    leftWordCount: number;
+	    rightWordCount: number;
+	    appliedTraps: string;
+	    ratio: number;
+	    wordListId: number;
+	    wordListName: string;
+	    layout: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.problemNumber = source["problemNumber"];
+	        this.isCorrect = source["isCorrect"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	        this.playerChoice = source["playerChoice"];
+	        this.correctChoice = source["correctChoice"];
+	        this.leftWord = source["leftWord"];
+	        this.rightWord = source["rightWord"];
+	        this.leftWordCount = source["leftWordCount"];
+	        this.rightWordCount = source["rightWordCount"];
+	        this.appliedTraps = source["appliedTraps"];
+	        this.ratio = source["ratio"];
+	        this.wordListId = source["wordListId"];
+	        this.wordListName = source["wordListName"];
+	        this.layout = source["layout"];
+	    }
+	}
+	export class TrapStat {
+	    trapType: string;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTime: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trapType = source["trapType"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTime = source["averageResponseTime"];
+	    }
+	}
+	export class CountComparisonSessionStats {
+	    sessionId: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    overallAccuracy: number;
+	    averageResponseTimeMs: number;
+	    trapStats: TrapStat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.trapStats = this.convertValues(source["trapStats"], TrapStat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CountComparisonSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: CountComparisonResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], CountComparisonResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CountComparisonSettings {
+	    numProblems: number;
+	    presentationTime: number;
+	    inputTime: number;
+	    isRealMode: boolean;
+	    generationMode: string;
+	    ratioLevels: number[];
+	    wordListId: number;
+	    categories: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.numProblems = source["numProblems"];
+	        this.presentationTime = source["presentationTime"];
+	        this.inputTime = source["inputTime"];
+	        this.isRealMode = source["isRealMode"];
+	        this.generationMode = source["generationMode"];
+	        this.ratioLevels = source["ratioLevels"];
+	        this.wordListId = source["wordListId"];
+	        this.categories = source["categories"];
+	    }
+	}
+	export class CountComparisonSubmission {
+	    problemNumber: number;
+	    playerChoice: string;
+	    responseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonSubmission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.problemNumber = source["problemNumber"];
+	        this.playerChoice = source["playerChoice"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	    }
+	}
+	export class CountComparisonThresholdPoint {
+	    sessionId: number;
+	    playDatetime: string;
+	    totalQuestions: number;
+	    threshold: number;
+	    thresholdNoTrap: number;
+	    thresholdWithTrap: number;
+	    fitted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CountComparisonThresholdPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.playDatetime = source["playDatetime"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.threshold = source["threshold"];
+	        this.thresholdNoTrap = source["thresholdNoTrap"];
+	        this.thresholdWithTrap = source["thresholdWithTrap"];
+	        this.fitted = source["fitted"];
+	    }
+	}
+	export class CustomTime {
+	
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomTime(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	
+	    }
+	}
+	export class WeakSpot {
+	    gameCode: string;
+	    round: number;
+	    condition: string;
+	    level: number;
+	    label: string;
+	    sessions: number;
+	    totalQuestions: number;
+	    lastPlayed: string;
+	    accuracy: number;
+	    trend: number;
+	    priority: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeakSpot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gameCode = source["gameCode"];
+	        this.round = source["round"];
+	        this.condition = source["condition"];
+	        this.level = source["level"];
+	        this.label = source["label"];
+	        this.sessions = source["sessions"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.lastPlayed = source["lastPlayed"];
+	        this.accuracy = source["accuracy"];
+	        this.trend = source["trend"];
+	        this.priority = source["priority"];
+	    }
+	}
+	export class PracticeItem {
+	    rank: number;
+	    gameCode: string;
+	    spot?: WeakSpot;
+	    reason: string;
+	    settings: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PracticeItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rank = source["rank"];
+	        this.gameCode = source["gameCode"];
+	        this.spot = this.convertValues(source["spot"], WeakSpot);
+	        this.reason = source["reason"];
+	        this.settings = source["settings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DailyPlan {
+	    date: string;
+	    items: PracticeItem[];
+	    weakSpots: WeakSpot[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.items = this.convertValues(source["items"], PracticeItem);
+	        this.weakSpots = this.convertValues(source["weakSpots"], WeakSpot);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatabaseRecovery {
+	    movedTo: string;
+	    problem: string;
+	    recoveredAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseRecovery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.movedTo = source["movedTo"];
+	        this.problem = source["problem"];
+	        this.recoveredAt = source["recoveredAt"];
+	    }
+	}
+	export class DeletedSession {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    deletedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeletedSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.deletedAt = source["deletedAt"];
+	    }
+	}
+	
+	
+	
+	export class GameSession {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GameSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	    }
+	}
+	export class Goal {
+	    id: number;
+	    gameCode: string;
+	    kind: string;
+	    target: number;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Goal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.kind = source["kind"];
+	        this.target = source["target"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class GoalProgress {
+	    goal: Goal;
+	    sessions: number;
+	    current?: number;
+	    progress: number;
+	    met: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoalProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.goal = this.convertValues(source["goal"], Goal);
+	        this.sessions = source["sessions"];
+	        this.current = source["current"];
+	        this.progress = source["progress"];
+	        this.met = source["met"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NBackLevelStat {
+	    nBackLevel: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackLevelStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nBackLevel = source["nBackLevel"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	export class NBackResult {
+	    id: number;
+	    sessionId: number;
+	    round: number;
+	    questionNum: number;
+	    isCorrect: boolean;
+	    responseTimeMs: number;
+	    playerChoice: string;
+	    correctChoice: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.round = source["round"];
+	        this.questionNum = source["questionNum"];
+	        this.isCorrect = source["isCorrect"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	        this.playerChoice = source["playerChoice"];
+	        this.correctChoice = source["correctChoice"];
+	    }
+	}
+	export class NBackRoundStats {
+	    round: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	    nBackLevelStats?: NBackLevelStat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackRoundStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.nBackLevelStats = this.convertValues(source["nBackLevelStats"], NBackLevelStat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NBackSessionStats {
+	    sessionId: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    overallAccuracy: number;
+	    averageResponseTimeMs: number;
+	    roundStats: NBackRoundStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.roundStats = this.convertValues(source["roundStats"], NBackRoundStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NBackSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: NBackResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], NBackResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NBackSettings {
+	    numTrials: number;
+	    presentationTime: number;
+	    nBackLevel: number;
+	    shapeGroup: string;
+	    isRealMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NBackSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.numTrials = source["numTrials"];
+	        this.presentationTime = source["presentationTime"];
+	        this.nBackLevel = source["nBackLevel"];
+	        this.shapeGroup = source["shapeGroup"];
+	        this.isRealMode = source["isRealMode"];
+	    }
+	}
+	export class ScoreNorm {
+	    gameCode: string;
+	    metric: string;
+	    mean: number;
+	    stdDev: number;
+	    sampleSize?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreNorm(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gameCode = source["gameCode"];
+	        this.metric = source["metric"];
+	        this.mean = source["mean"];
+	        this.stdDev = source["stdDev"];
+	        this.sampleSize = source["sampleSize"];
+	    }
+	}
+	export class NormTable {
+	    name: string;
+	    norms: ScoreNorm[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NormTable(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.norms = this.convertValues(source["norms"], ScoreNorm);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NormalizedScore {
+	    sessionId: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    metric: string;
+	    value: number;
+	    historyCount: number;
+	    historyZ?: number;
+	    historyPercentile?: number;
+	    normZ?: number;
+	    normPercentile?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NormalizedScore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.metric = source["metric"];
+	        this.value = source["value"];
+	        this.historyCount = source["historyCount"];
+	        this.historyZ = source["historyZ"];
+	        this.historyPercentile = source["historyPercentile"];
+	        this.normZ = source["normZ"];
+	        this.normPercentile = source["normPercentile"];
+	    }
+	}
+	export class NumberPressingErrorCounts {
+	    missingDoubleClick: number;
+	    pressedSkipped: number;
+	    orderSlip: number;
+	    extraPress: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingErrorCounts(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.missingDoubleClick = source["missingDoubleClick"];
+	        this.pressedSkipped = source["pressedSkipped"];
+	        this.orderSlip = source["orderSlip"];
+	        this.extraPress = source["extraPress"];
+	    }
+	}
+	export class NumberPressingConditionStat {
+	    conditionType: string;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageTimeTakenSec: number;
+	    errorCounts: NumberPressingErrorCounts;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingConditionStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.conditionType = source["conditionType"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
+	        this.errorCounts = this.convertValues(source["errorCounts"], NumberPressingErrorCounts);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class NumberPressingProblemR2 {
+	    rule: string;
+	    doubleClick: number[];
+	    skip: number[];
+	    reverse: boolean;
+	    repeat: number[];
+	    repeatCount: number;
+	    layout: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingProblemR2(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.doubleClick = source["doubleClick"];
+	        this.skip = source["skip"];
+	        this.reverse = source["reverse"];
+	        this.repeat = source["repeat"];
+	        this.repeatCount = source["repeatCount"];
+	        this.layout = source["layout"];
+	    }
+	}
+	export class NumberPressingProblemR1 {
+	    targetNumber: number;
+	    layout: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingProblemR1(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.targetNumber = source["targetNumber"];
+	        this.layout = source["layout"];
+	    }
+	}
+	export class NumberPressingSetup {
+	    isRealMode: boolean;
+	    rounds: number[];
+	    problemsPerRound: number;
+	    timeLimitR1: number;
+	    timeLimitR2: number;
+	    ruleTypes: string[];
+	    layoutMode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingSetup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isRealMode = source["isRealMode"];
+	        this.rounds = source["rounds"];
+	        this.problemsPerRound = source["problemsPerRound"];
+	        this.timeLimitR1 = source["timeLimitR1"];
+	        this.timeLimitR2 = source["timeLimitR2"];
+	        this.ruleTypes = source["ruleTypes"];
+	        this.layoutMode = source["layoutMode"];
+	    }
+	}
+	export class NumberPressingGameState {
+	    setup: NumberPressingSetup;
+	    problemsR1: NumberPressingProblemR1[];
+	    problemsR2: NumberPressingProblemR2[];
+	    layout?: number[];
+	    id: number;
+	    nextProblem: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.setup = this.convertValues(source["setup"], NumberPressingSetup);
+	        this.problemsR1 = this.convertValues(source["problemsR1"], NumberPressingProblemR1);
+	        this.problemsR2 = this.convertValues(source["problemsR2"], NumberPressingProblemR2);
+	        this.layout = source["layout"];
+	        this.id = source["id"];
+	        this.nextProblem = source["nextProblem"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class NumberPressingResultR1 {
+	    id: number;
+	    sessionID: number;
+	    problemNumber: number;
+	    problem: NumberPressingProblemR1;
+	    playerNumber: number;
+	    timeTaken: number;
+	    isCorrect: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingResultR1(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionID = source["sessionID"];
+	        this.problemNumber = source["problemNumber"];
+	        this.problem = this.convertValues(source["problem"], NumberPressingProblemR1);
+	        this.playerNumber = source["playerNumber"];
+	        this.timeTaken = source["timeTaken"];
+	        this.isCorrect = source["isCorrect"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NumberPressingResultR2 {
+	    id: number;
+	    sessionID: number;
+	    problemNumber: number;
+	    problem: NumberPressingProblemR2;
+	    playerClicks: number[];
+	    correctClicks: number[];
+	    timeTaken: number;
+	    isCorrect: boolean;
+	    errors: NumberPressingErrorCounts;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingResultR2(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionID = source["sessionID"];
+	        this.problemNumber = source["problemNumber"];
+	        this.problem = this.convertValues(source["problem"], NumberPressingProblemR2);
+	        this.playerClicks = source["playerClicks"];
+	        this.correctClicks = source["correctClicks"];
+	        this.timeTaken = source["timeTaken"];
+	        this.isCorrect = source["isCorrect"];
+	        this.errors = this.convertValues(source["errors"], NumberPressingErrorCounts);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NumberPressingResultsBundle {
+	    resultsR1: NumberPressingResultR1[];
+	    resultsR2: NumberPressingResultR2[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingResultsBundle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resultsR1 = this.convertValues(source["resultsR1"], NumberPressingResultR1);
+	        this.resultsR2 = this.convertValues(source["resultsR2"], NumberPressingResultR2);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NumberPressingSearchStat {
+	    key: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageSearchTimeSec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingSearchStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageSearchTimeSec = source["averageSearchTimeSec"];
+	    }
+	}
+	export class NumberPressingRoundStats {
+	    round: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageTimeTakenSec: number;
+	    conditionStats?: NumberPressingConditionStat[];
+	    targetStats?: NumberPressingSearchStat[];
+	    positionStats?: NumberPressingSearchStat[];
+	    errorCounts?: NumberPressingErrorCounts;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingRoundStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
+	        this.conditionStats = this.convertValues(source["conditionStats"], NumberPressingConditionStat);
+	        this.targetStats = this.convertValues(source["targetStats"], NumberPressingSearchStat);
+	        this.positionStats = this.convertValues(source["positionStats"], NumberPressingSearchStat);
+	        this.errorCounts = this.convertValues(source["errorCounts"], NumberPressingErrorCounts);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class NumberPressingSessionStats {
+	    sessionId: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    overallAccuracy: number;
+	    averageTimeTakenSec: number;
+	    roundStats: NumberPressingRoundStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
+	        this.roundStats = this.convertValues(source["roundStats"], NumberPressingRoundStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NumberPressingSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: NumberPressingResultsBundle;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberPressingSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], NumberPressingResultsBundle);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PaginatedCatChaserSessions {
+	    sessions: CatChaserSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedCatChaserSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], CatChaserSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedCountComparisonSessions {
+	    sessions: CountComparisonSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedCountComparisonSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], CountComparisonSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedNBackSessions {
+	    sessions: NBackSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedNBackSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], NBackSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedNumberPressingSessions {
+	    sessions: NumberPressingSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedNumberPressingSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], NumberPressingSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PositionMemoryResult {
+	    sessionId: number;
+	    sequenceNum: number;
+	    mode: string;
+	    length: number;
+	    attempt: number;
+	    sequence: number[];
+	    response: number[];
+	    isCorrect: boolean;
+	    correctPositions: number;
+	    responseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemoryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.sequenceNum = source["sequenceNum"];
+	        this.mode = source["mode"];
+	        this.length = source["length"];
+	        this.attempt = source["attempt"];
+	        this.sequence = source["sequence"];
+	        this.response = source["response"];
+	        this.isCorrect = source["isCorrect"];
+	        this.correctPositions = source["correctPositions"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	    }
+	}
+	export class PositionMemorySessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: PositionMemoryResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], PositionMemoryResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedPositionMemorySessions {
+	    sessions: PositionMemorySessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedPositionMemorySessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], PositionMemorySessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoadTile {
+	    row: number;
+	    col: number;
+	    kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadTile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	        this.kind = source["kind"];
+	    }
+	}
+	export class RoadEndpoint {
+	    row: number;
+	    col: number;
+	    side: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadEndpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	        this.side = source["side"];
+	    }
+	}
+	export class RoadCell {
+	    row: number;
+	    col: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadCell(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	    }
+	}
+	export class RoadProblem {
+	    problemNum: number;
+	    gridRows: number;
+	    gridCols: number;
+	    obstacles: RoadCell[];
+	    start: RoadEndpoint;
+	    goal: RoadEndpoint;
+	    straights: number;
+	    curves: number;
+	    minTiles: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadProblem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.problemNum = source["problemNum"];
+	        this.gridRows = source["gridRows"];
+	        this.gridCols = source["gridCols"];
+	        this.obstacles = this.convertValues(source["obstacles"], RoadCell);
+	        this.start = this.convertValues(source["start"], RoadEndpoint);
+	        this.goal = this.convertValues(source["goal"], RoadEndpoint);
+	        this.straights = source["straights"];
+	        this.curves = source["curves"];
+	        this.minTiles = source["minTiles"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoadResult {
+	    sessionId: number;
+	    problemNum: number;
+	    problem: RoadProblem;
+	    layout: RoadTile[];
+	    tilesUsed: number;
+	    minTiles: number;
+	    isSolved: boolean;
+	    timeTakenMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.problemNum = source["problemNum"];
+	        this.problem = this.convertValues(source["problem"], RoadProblem);
+	        this.layout = this.convertValues(source["layout"], RoadTile);
+	        this.tilesUsed = source["tilesUsed"];
+	        this.minTiles = source["minTiles"];
+	        this.isSolved = source["isSolved"];
+	        this.timeTakenMs = source["timeTakenMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoadSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: RoadResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], RoadResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedRoadSessions {
+	    sessions: RoadSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedRoadSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], RoadSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RpsResult {
+	    id: number;
+	    sessionId: number;
+	    round: number;
+	    questionNum: number;
+	    problemCardHolder: string;
+	    givenCard: string;
+	    isCorrect: boolean;
+	    responseTimeMs: number;
+	    playerChoice: string;
+	    correctChoice: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RpsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.round = source["round"];
+	        this.questionNum = source["questionNum"];
+	        this.problemCardHolder = source["problemCardHolder"];
+	        this.givenCard = source["givenCard"];
+	        this.isCorrect = source["isCorrect"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	        this.playerChoice = source["playerChoice"];
+	        this.correctChoice = source["correctChoice"];
+	    }
+	}
+	export class RpsSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: RpsResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RpsSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], RpsResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedRpsSessions {
+	    sessions: RpsSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedRpsSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], RpsSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SchedulingSlot {
+	    day: number;
+	    period: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingSlot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.day = source["day"];
+	        this.period = source["period"];
+	    }
+	}
+	export class SchedulingConstraint {
+	    kind: string;
+	    person: number;
+	    other: number;
+	    day: number;
+	    period: number;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingConstraint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.person = source["person"];
+	        this.other = source["other"];
+	        this.day = source["day"];
+	        this.period = source["period"];
+	        this.description = source["description"];
+	    }
+	}
+	export class SchedulingResult {
+	    sessionId: number;
+	    problemNum: number;
+	    numPeople: number;
+	    constraints: SchedulingConstraint[];
+	    placement: SchedulingSlot[];
+	    solution: SchedulingSlot[];
+	    isCorrect: boolean;
+	    violated: number[];
+	    numViolated: number;
+	    responseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.problemNum = source["problemNum"];
+	        this.numPeople = source["numPeople"];
+	        this.constraints = this.convertValues(source["constraints"], SchedulingConstraint);
+	        this.placement = this.convertValues(source["placement"], SchedulingSlot);
+	        this.solution = this.convertValues(source["solution"], SchedulingSlot);
+	        this.isCorrect = source["isCorrect"];
+	        this.violated = source["violated"];
+	        this.numViolated = source["numViolated"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SchedulingSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: SchedulingResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], SchedulingResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedSchedulingSessions {
+	    sessions: SchedulingSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedSchedulingSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], SchedulingSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShapeRotationResult {
+	    id: number;
+	    sessionId: number;
+	    problemId: number;
+	    userSolution: string[];
+	    isCorrect: boolean;
+	    solveTime: number;
+	    clickCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeRotationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.problemId = source["problemId"];
+	        this.userSolution = source["userSolution"];
+	        this.isCorrect = source["isCorrect"];
+	        this.solveTime = source["solveTime"];
+	        this.clickCount = source["clickCount"];
+	    }
+	}
+	export class ShapeRotationSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: ShapeRotationResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeRotationSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], ShapeRotationResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedShapeRotationSessions {
+	    sessions: ShapeRotationSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedShapeRotationSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], ShapeRotationSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StroopResult {
+	    sessionId: number;
+	    trialNum: number;
+	    condition: string;
+	    word: string;
+	    wordColor: string;
+	    inkColor: string;
+	    responseKey: string;
+	    responseColor: string;
+	    isCorrect: boolean;
+	    isWordError: boolean;
+	    timedOut: boolean;
+	    responseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StroopResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.trialNum = source["trialNum"];
+	        this.condition = source["condition"];
+	        this.word = source["word"];
+	        this.wordColor = source["wordColor"];
+	        this.inkColor = source["inkColor"];
+	        this.responseKey = source["responseKey"];
+	        this.responseColor = source["responseColor"];
+	        this.isCorrect = source["isCorrect"];
+	        this.isWordError = source["isWordError"];
+	        this.timedOut = source["timedOut"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	    }
+	}
+	export class StroopSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: StroopResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StroopSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], StroopResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedStroopSessions {
+	    sessions: StroopSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedStroopSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], StroopSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TowerMove {
+	    from: number;
+	    to: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TowerMove(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class TowerResult {
+	    sessionId: number;
+	    problemNum: number;
+	    discs: number;
+	    start: number[][];
+	    goal: number[][];
+	    moves: TowerMove[];
+	    moveCount: number;
+	    optimalMoves: number;
+	    moveLimit: number;
+	    isSolved: boolean;
+	    timeTakenMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TowerResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.problemNum = source["problemNum"];
+	        this.discs = source["discs"];
+	        this.start = source["start"];
+	        this.goal = source["goal"];
+	        this.moves = this.convertValues(source["moves"], TowerMove);
+	        this.moveCount = source["moveCount"];
+	        this.optimalMoves = source["optimalMoves"];
+	        this.moveLimit = source["moveLimit"];
+	        this.isSolved = source["isSolved"];
+	        this.timeTakenMs = source["timeTakenMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TowerSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: TowerResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TowerSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], TowerResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedTowerSessions {
+	    sessions: TowerSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedTowerSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], TowerSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeightWeighing {
+	    left: string;
+	    right: string;
+	    heavier: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeightWeighing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.left = source["left"];
+	        this.right = source["right"];
+	        this.heavier = source["heavier"];
+	    }
+	}
+	export class WeightResult {
+	    sessionId: number;
+	    problemNum: number;
+	    numObjects: number;
+	    hiddenOrder: string[];
+	    weighings: WeightWeighing[];
+	    ranking: string[];
+	    weighingsUsed: number;
+	    optimalWeighings: number;
+	    isCorrect: boolean;
+	    isConsistent: boolean;
+	    isDetermined: boolean;
+	    responseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeightResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.problemNum = source["problemNum"];
+	        this.numObjects = source["numObjects"];
+	        this.hiddenOrder = source["hiddenOrder"];
+	        this.weighings = this.convertValues(source["weighings"], WeightWeighing);
+	        this.ranking = source["ranking"];
+	        this.weighingsUsed = source["weighingsUsed"];
+	        this.optimalWeighings = source["optimalWeighings"];
+	        this.isCorrect = source["isCorrect"];
+	        this.isConsistent = source["isConsistent"];
+	        this.isDetermined = source["isDetermined"];
+	        this.responseTimeMs = source["responseTimeMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeightSessionWithResults {
+	    id: number;
+	    gameCode: string;
+	    playDatetime: string;
+	    settings: string;
+	    status: string;
+	    endedAt: string;
+	    expectedTrials: number;
+	    notes: string;
+	    tags: string[];
+	    results: WeightResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WeightSessionWithResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.gameCode = source["gameCode"];
+	        this.playDatetime = source["playDatetime"];
+	        this.settings = source["settings"];
+	        this.status = source["status"];
+	        this.endedAt = source["endedAt"];
+	        this.expectedTrials = source["expectedTrials"];
+	        this.notes = source["notes"];
+	        this.tags = source["tags"];
+	        this.results = this.convertValues(source["results"], WeightResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaginatedWeightSessions {
+	    sessions: WeightSessionWithResults[];
+	    totalCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaginatedWeightSessions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], WeightSessionWithResults);
+	        this.totalCount = source["totalCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PersonalRecord {
+	    gameCode: string;
+	    round: number;
+	    mode: string;
+	    metric: string;
+	    higherIsBetter: boolean;
+	    value: number;
+	    sessionId: number;
+	    achievedAt: string;
+	    previousValue?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PersonalRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gameCode = source["gameCode"];
+	        this.round = source["round"];
+	        this.mode = source["mode"];
+	        this.metric = source["metric"];
+	        this.higherIsBetter = source["higherIsBetter"];
+	        this.value = source["value"];
+	        this.sessionId = source["sessionId"];
+	        this.achievedAt = source["achievedAt"];
+	        this.previousValue = source["previousValue"];
+	    }
+	}
+	export class PositionMemoryBlock {
+	    row: number;
+	    col: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemoryBlock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.col = source["col"];
+	    }
+	}
+	export class PositionMemoryLengthStats {
+	    length: number;
+	    totalSequences: number;
+	    totalCorrect: number;
+	    averageResponseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemoryLengthStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.length = source["length"];
+	        this.totalSequences = source["totalSequences"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	
+	export class PositionMemorySequence {
+	    sequenceNum: number;
+	    length: number;
+	    attempt: number;
+	    blocks: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySequence(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sequenceNum = source["sequenceNum"];
+	        this.length = source["length"];
+	        this.attempt = source["attempt"];
+	        this.blocks = source["blocks"];
+	    }
+	}
+	export class PositionMemorySessionStats {
+	    sessionId: number;
+	    mode: string;
+	    maxSpan: number;
+	    totalSequences: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	    lengthStats: PositionMemoryLengthStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.mode = source["mode"];
+	        this.maxSpan = source["maxSpan"];
+	        this.totalSequences = source["totalSequences"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.lengthStats = this.convertValues(source["lengthStats"], PositionMemoryLengthStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PositionMemorySettings {
+	    numBlocks: number;
+	    startLength: number;
+	    maxLength: number;
+	    mode: string;
+	    flashMs: number;
+	    isRealMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.numBlocks = source["numBlocks"];
+	        this.startLength = source["startLength"];
+	        this.maxLength = source["maxLength"];
+	        this.mode = source["mode"];
+	        this.flashMs = source["flashMs"];
+	        this.isRealMode = source["isRealMode"];
+	    }
+	}
+	export class PositionMemorySpanPoint {
+	    sessionId: number;
+	    playDatetime: string;
+	    mode: string;
+	    maxSpan: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySpanPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.playDatetime = source["playDatetime"];
+	        this.mode = source["mode"];
+	        this.maxSpan = source["maxSpan"];
+	    }
+	}
+	export class PositionMemorySpanHistory {
+	    bestForwardSpan: number;
+	    bestBackwardSpan: number;
+	    sessions: PositionMemorySpanPoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySpanHistory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bestForwardSpan = source["bestForwardSpan"];
+	        this.bestBackwardSpan = source["bestBackwardSpan"];
+	        this.sessions = this.convertValues(source["sessions"], PositionMemorySpanPoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PositionMemorySubmitResult {
+	    result: PositionMemoryResult;
+	    span: number;
+	    finished: boolean;
+	    next?: PositionMemorySequence;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionMemorySubmitResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.result = this.convertValues(source["result"], PositionMemoryResult);
+	        this.span = source["span"];
+	        this.finished = source["finished"];
+	        this.next = this.convertValues(source["next"], PositionMemorySequence);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PracticeStreak {
+	    current: number;
+	    longest: number;
+	    practicedToday: boolean;
+	    lastPracticed: string;
+	    totalDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PracticeStreak(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = source["current"];
+	        this.longest = source["longest"];
+	        this.practicedToday = source["practicedToday"];
+	        this.lastPracticed = source["lastPracticed"];
+	        this.totalDays = source["totalDays"];
+	    }
+	}
+	
+	
+	
+	
+	
+	
+	export class RoadSessionStats {
+	    sessionId: number;
+	    totalProblems: number;
+	    totalSolved: number;
+	    totalMinimal: number;
+	    successRate: number;
+	    averageExtraTiles: number;
+	    averageTimeMs: number;
+	    averageSolveTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalProblems = source["totalProblems"];
+	        this.totalSolved = source["totalSolved"];
+	        this.totalMinimal = source["totalMinimal"];
+	        this.successRate = source["successRate"];
+	        this.averageExtraTiles = source["averageExtraTiles"];
+	        this.averageTimeMs = source["averageTimeMs"];
+	        this.averageSolveTimeMs = source["averageSolveTimeMs"];
+	    }
+	}
+	
+	export class RoadSettings {
+	    numProblems: number;
+	    gridRows: number;
+	    gridCols: number;
+	    obstacles?: number;
+	    spareTiles?: number;
+	    minTiles: number;
+	    isRealMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoadSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.numProblems = source["numProblems"];
+	        this.gridRows = source["gridRows"];
+	        this.gridCols = source["gridCols"];
+	        this.obstacles = source["obstacles"];
+	        this.spareTiles = source["spareTiles"];
+	        this.minTiles = source["minTiles"];
+	        this.isRealMode = source["isRealMode"];
+	    }
+	}
+	
+	export class RpsProblemCardHolderStat {
+	    problemCardHolder: string;
+	    totalQuestions: number;
 	    totalCorrect: number;
-	    overallAccuracy: number;
-	    averageTimeTakenSec: number;
-	    roundStats: NumberPressingRoundStats[];
+	    accuracy: number;
+	    averageResponseTimeMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingSessionStats(source);
+	        return new RpsProblemCardHolderStat(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionId = source["sessionId"];
+	        this.problemCardHolder = source["problemCardHolder"];
 	        this.totalQuestions = source["totalQuestions"];
 	        this.totalCorrect = source["totalCorrect"];
-	        this.overallAccuracy = source["overallAccuracy"];
-	        this.averageTimeTakenSec = source["averageTimeTakenSec"];
-	        this.roundStats = this.convertValues(source["roundStats"], NumberPressingRoundStats);
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	
+	export class RpsRoundStats {
+	    round: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	    problemCardHolderStats: RpsProblemCardHolderStat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RpsRoundStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.problemCardHolderStats = this.convertValues(source["problemCardHolderStats"], RpsProblemCardHolderStat);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1124,24 +4132,26 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class NumberPressingSessionWithResults {
-	    id: number;
-	    gameCode: string;
-	    playDatetime: string;
-	    settings: string;
-	    results: NumberPressingResultsBundle;
+	export class RpsSessionStats {
+	    sessionId: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    overallAccuracy: number;
+	    averageResponseTimeMs: number;
+	    roundStats: RpsRoundStats[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NumberPressingSessionWithResults(source);
+	        return new RpsSessionStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.gameCode = source["gameCode"];
-	        this.playDatetime = source["playDatetime"];
-	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], NumberPressingResultsBundle);
+	        this.sessionId = source["sessionId"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.roundStats = this.convertValues(source["roundStats"], RpsRoundStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1163,50 +4173,61 @@ export namespace types {
 		}
 	}
 	
-	export class PaginatedCatChaserSessions {
-	    sessions: CatChaserSessionWithResults[];
-	    totalCount: number;
+	export class RpsSettings {
+	    rounds: number[];
+	    questionsPerRound: number;
+	    timeLimitMs: number;
+	    isRealMode: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedCatChaserSessions(source);
+	        return new RpsSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], CatChaserSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.rounds = source["rounds"];
+	        this.questionsPerRound = source["questionsPerRound"];
+	        this.timeLimitMs = source["timeLimitMs"];
+	        this.isRealMode = source["isRealMode"];
 	    }
+	}
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
+	export class SchedulingKindStats {
+	    kind: string;
+	    totalShown: number;
+	    totalViolated: number;
+	    violationRate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchedulingKindStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.totalShown = source["totalShown"];
+	        this.totalViolated = source["totalViolated"];
+	        this.violationRate = source["violationRate"];
+	    }
 	}
-	export class PaginatedCountComparisonSessions {
-	    sessions: CountComparisonSessionWithResults[];
-	    totalCount: number;
+	export class SchedulingProblem {
+	    problemNum: number;
+	    people: string[];
+	    numDays: number;
+	    numPeriods: number;
+	    constraints: SchedulingConstraint[];
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedCountComparisonSessions(source);
+	        return new SchedulingProblem(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], CountComparisonSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.problemNum = source["problemNum"];
+	        this.people = source["people"];
+	        this.numDays = source["numDays"];
+	        this.numPeriods = source["numPeriods"];
+	        this.constraints = this.convertValues(source["constraints"], SchedulingConstraint);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1227,18 +4248,29 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class PaginatedNBackSessions {
-	    sessions: NBackSessionWithResults[];
-	    totalCount: number;
+	
+	export class SchedulingSessionStats {
+	    sessionId: number;
+	    totalProblems: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageViolated: number;
+	    averageResponseTimeMs: number;
+	    kindStats: SchedulingKindStats[];
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedNBackSessions(source);
+	        return new SchedulingSessionStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], NBackSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.sessionId = source["sessionId"];
+	        this.totalProblems = source["totalProblems"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageViolated = source["averageViolated"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.kindStats = this.convertValues(source["kindStats"], SchedulingKindStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1259,18 +4291,92 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class PaginatedNumberPressingSessions {
-	    sessions: NumberPressingSessionWithResults[];
-	    totalCount: number;
+	
+	export class SchedulingSettings {
+	    numProblems: number;
+	    numPeople: number;
+	    numDays: number;
+	    numPeriods: number;
+	    isRealMode: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedNumberPressingSessions(source);
+	        return new SchedulingSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], NumberPressingSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.numProblems = source["numProblems"];
+	        this.numPeople = source["numPeople"];
+	        this.numDays = source["numDays"];
+	        this.numPeriods = source["numPeriods"];
+	        this.isRealMode = source["isRealMode"];
+	    }
+	}
+	
+	export class ScoreMetric {
+	    gameCode: string;
+	    metric: string;
+	    area: string;
+	    higherIsBetter: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreMetric(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gameCode = source["gameCode"];
+	        this.metric = source["metric"];
+	        this.area = source["area"];
+	        this.higherIsBetter = source["higherIsBetter"];
+	    }
+	}
+	
+	
+	export class ShapeRotationRoundStats {
+	    round: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageSolveTimeMs: number;
+	    averageClickCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeRotationRoundStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageSolveTimeMs = source["averageSolveTimeMs"];
+	        this.averageClickCount = source["averageClickCount"];
+	    }
+	}
+	export class ShapeRotationSessionStats {
+	    sessionId: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    overallAccuracy: number;
+	    averageSolveTimeMs: number;
+	    averageClickCount: number;
+	    roundStats: ShapeRotationRoundStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeRotationSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageSolveTimeMs = source["averageSolveTimeMs"];
+	        this.averageClickCount = source["averageClickCount"];
+	        this.roundStats = this.convertValues(source["roundStats"], ShapeRotationRoundStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1291,54 +4397,94 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class RpsResult {
-	    id: number;
-	    sessionId: number;
+	
+	export class ShapeRotationSettings {
+	    numProblems: number;
+	    timeLimit: number;
 	    round: number;
-	    questionNum: number;
-	    problemCardHolder: string;
-	    givenCard: string;
-	    isCorrect: boolean;
-	    responseTimeMs: number;
-	    playerChoice: string;
-	    correctChoice: string;
+	    isRealMode: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsResult(source);
+	        return new ShapeRotationSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionId = source["sessionId"];
+	        this.numProblems = source["numProblems"];
+	        this.timeLimit = source["timeLimit"];
 	        this.round = source["round"];
-	        this.questionNum = source["questionNum"];
-	        this.problemCardHolder = source["problemCardHolder"];
-	        this.givenCard = source["givenCard"];
-	        this.isCorrect = source["isCorrect"];
-	        this.responseTimeMs = source["responseTimeMs"];
-	        this.playerChoice = source["playerChoice"];
-	        this.correctChoice = source["correctChoice"];
+	        this.isRealMode = source["isRealMode"];
+	    }
+	}
+	export class StroopConditionStats {
+	    condition: string;
+	    totalTrials: number;
+	    totalCorrect: number;
+	    totalTimedOut: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StroopConditionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.condition = source["condition"];
+	        this.totalTrials = source["totalTrials"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.totalTimedOut = source["totalTimedOut"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	export class StroopInterferencePoint {
+	    sessionId: number;
+	    playDatetime: string;
+	    totalTrials: number;
+	    interferenceResponseTime: number;
+	    interferenceAccuracy: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StroopInterferencePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.playDatetime = source["playDatetime"];
+	        this.totalTrials = source["totalTrials"];
+	        this.interferenceResponseTime = source["interferenceResponseTime"];
+	        this.interferenceAccuracy = source["interferenceAccuracy"];
 	    }
 	}
-	export class RpsSessionWithResults {
-	    id: number;
-	    gameCode: string;
-	    playDatetime: string;
-	    settings: string;
-	    results: RpsResult[];
+	
+	export class StroopSessionStats {
+	    sessionId: number;
+	    totalTrials: number;
+	    totalCorrect: number;
+	    totalWordErrors: number;
+	    overallAccuracy: number;
+	    averageResponseTimeMs: number;
+	    interferenceResponseTime: number;
+	    interferenceAccuracy: number;
+	    conditionStats: StroopConditionStats[];
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsSessionWithResults(source);
+	        return new StroopSessionStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.gameCode = source["gameCode"];
-	        this.playDatetime = source["playDatetime"];
-	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], RpsResult);
+	        this.sessionId = source["sessionId"];
+	        this.totalTrials = source["totalTrials"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.totalWordErrors = source["totalWordErrors"];
+	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.interferenceResponseTime = source["interferenceResponseTime"];
+	        this.interferenceAccuracy = source["interferenceAccuracy"];
+	        this.conditionStats = this.convertValues(source["conditionStats"], StroopConditionStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1359,18 +4505,43 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class PaginatedRpsSessions {
-	    sessions: RpsSessionWithResults[];
-	    totalCount: number;
+	
+	export class StroopTrialMix {
+	    congruent: number;
+	    incongruent: number;
+	    neutral: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedRpsSessions(source);
+	        return new StroopTrialMix(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], RpsSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.congruent = source["congruent"];
+	        this.incongruent = source["incongruent"];
+	        this.neutral = source["neutral"];
+	    }
+	}
+	export class StroopSettings {
+	    numTrials: number;
+	    mix: StroopTrialMix;
+	    colors: string[];
+	    responseKeys: Record<string, string>;
+	    responseTimeLimitMs: number;
+	    isRealMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StroopSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.numTrials = source["numTrials"];
+	        this.mix = this.convertValues(source["mix"], StroopTrialMix);
+	        this.colors = source["colors"];
+	        this.responseKeys = source["responseKeys"];
+	        this.responseTimeLimitMs = source["responseTimeLimitMs"];
+	        this.isRealMode = source["isRealMode"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1391,48 +4562,66 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class ShapeRotationResult {
-	    id: number;
-	    sessionId: number;
-	    problemId: number;
-	    userSolution: string[];
-	    isCorrect: boolean;
-	    solveTime: number;
-	    clickCount: number;
+	export class StroopTrial {
+	    trialNum: number;
+	    condition: string;
+	    word: string;
+	    inkColor: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShapeRotationResult(source);
+	        return new StroopTrial(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionId = source["sessionId"];
-	        this.problemId = source["problemId"];
-	        this.userSolution = source["userSolution"];
-	        this.isCorrect = source["isCorrect"];
-	        this.solveTime = source["solveTime"];
-	        this.clickCount = source["clickCount"];
+	        this.trialNum = source["trialNum"];
+	        this.condition = source["condition"];
+	        this.word = source["word"];
+	        this.inkColor = source["inkColor"];
 	    }
 	}
-	export class ShapeRotationSessionWithResults {
-	    id: number;
-	    gameCode: string;
-	    playDatetime: string;
-	    settings: string;
-	    results: ShapeRotationResult[];
+	
+	export class TowerDiscStats {
+	    discs: number;
+	    totalProblems: number;
+	    totalSolved: number;
+	    solveRate: number;
+	    averageExtraMoves: number;
+	    averageTimeMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShapeRotationSessionWithResults(source);
+	        return new TowerDiscStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.gameCode = source["gameCode"];
-	        this.playDatetime = source["playDatetime"];
-	        this.settings = source["settings"];
-	        this.results = this.convertValues(source["results"], ShapeRotationResult);
+	        this.discs = source["discs"];
+	        this.totalProblems = source["totalProblems"];
+	        this.totalSolved = source["totalSolved"];
+	        this.solveRate = source["solveRate"];
+	        this.averageExtraMoves = source["averageExtraMoves"];
+	        this.averageTimeMs = source["averageTimeMs"];
+	    }
+	}
+	
+	export class TowerMoveResult {
+	    pegs: number[][];
+	    moveCount: number;
+	    solved: boolean;
+	    finished: boolean;
+	    result?: TowerResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new TowerMoveResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pegs = source["pegs"];
+	        this.moveCount = source["moveCount"];
+	        this.solved = source["solved"];
+	        this.finished = source["finished"];
+	        this.result = this.convertValues(source["result"], TowerResult);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1453,18 +4642,53 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class PaginatedShapeRotationSessions {
-	    sessions: ShapeRotationSessionWithResults[];
-	    totalCount: number;
+	export class TowerProblem {
+	    problemNum: number;
+	    discs: number;
+	    start: number[][];
+	    goal: number[][];
+	    optimalMoves: number;
+	    moveLimit: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new PaginatedShapeRotationSessions(source);
+	        return new TowerProblem(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessions = this.convertValues(source["sessions"], ShapeRotationSessionWithResults);
-	        this.totalCount = source["totalCount"];
+	        this.problemNum = source["problemNum"];
+	        this.discs = source["discs"];
+	        this.start = source["start"];
+	        this.goal = source["goal"];
+	        this.optimalMoves = source["optimalMoves"];
+	        this.moveLimit = source["moveLimit"];
+	    }
+	}
+	
+	export class TowerSessionStats {
+	    sessionId: number;
+	    totalProblems: number;
+	    totalSolved: number;
+	    totalOptimal: number;
+	    solveRate: number;
+	    averageExtraMoves: number;
+	    averageTimeMs: number;
+	    discStats: TowerDiscStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TowerSessionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.totalProblems = source["totalProblems"];
+	        this.totalSolved = source["totalSolved"];
+	        this.totalOptimal = source["totalOptimal"];
+	        this.solveRate = source["solveRate"];
+	        this.averageExtraMoves = source["averageExtraMoves"];
+	        this.averageTimeMs = source["averageTimeMs"];
+	        this.discStats = this.convertValues(source["discStats"], TowerDiscStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1485,87 +4709,100 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class RpsProblemCardHolderStat {
-	    problemCardHolder: string;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageResponseTimeMs: number;
+	
+	export class TowerSettings {
+	    numProblems: number;
+	    minDiscs: number;
+	    maxDiscs: number;
+	    minMoves: number;
+	    maxMoves: number;
+	    extraMoves?: number;
+	    isRealMode: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsProblemCardHolderStat(source);
+	        return new TowerSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.problemCardHolder = source["problemCardHolder"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	        this.numProblems = source["numProblems"];
+	        this.minDiscs = source["minDiscs"];
+	        this.maxDiscs = source["maxDiscs"];
+	        this.minMoves = source["minMoves"];
+	        this.maxMoves = source["maxMoves"];
+	        this.extraMoves = source["extraMoves"];
+	        this.isRealMode = source["isRealMode"];
 	    }
 	}
 	
-	export class RpsRoundStats {
-	    round: number;
-	    totalQuestions: number;
+	
+	export class WeightObjectStats {
+	    numObjects: number;
+	    totalProblems: number;
 	    totalCorrect: number;
 	    accuracy: number;
+	    averageWeighings: number;
+	    optimalWeighings: number;
 	    averageResponseTimeMs: number;
-	    problemCardHolderStats: RpsProblemCardHolderStat[];
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsRoundStats(source);
+	        return new WeightObjectStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.round = source["round"];
-	        this.totalQuestions = source["totalQuestions"];
+	        this.numObjects = source["numObjects"];
+	        this.totalProblems = source["totalProblems"];
 	        this.totalCorrect = source["totalCorrect"];
 	        this.accuracy = source["accuracy"];
+	        this.averageWeighings = source["averageWeighings"];
+	        this.optimalWeighings = source["optimalWeighings"];
 	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.problemCardHolderStats = this.convertValues(source["problemCardHolderStats"], RpsProblemCardHolderStat);
 	    }
+	}
+	export class WeightProblem {
+	    problemNum: number;
+	    objects: string[];
+	    optimalWeighings: number;
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
+	    static createFrom(source: any = {}) {
+	        return new WeightProblem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.problemNum = source["problemNum"];
+	        this.objects = source["objects"];
+	        this.optimalWeighings = source["optimalWeighings"];
+	    }
 	}
-	export class RpsSessionStats {
+	
+	export class WeightSessionStats {
 	    sessionId: number;
-	    totalQuestions: number;
+	    totalProblems: number;
 	    totalCorrect: number;
+	    totalGuessed: number;
+	    totalEfficient: number;
 	    overallAccuracy: number;
+	    averageExtraWeighings: number;
 	    averageResponseTimeMs: number;
-	    roundStats: RpsRoundStats[];
+	    objectStats: WeightObjectStats[];
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsSessionStats(source);
+	        return new WeightSessionStats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.sessionId = source["sessionId"];
-	        this.totalQuestions = source["totalQuestions"];
+	        this.totalProblems = source["totalProblems"];
 	        this.totalCorrect = source["totalCorrect"];
+	        this.totalGuessed = source["totalGuessed"];
+	        this.totalEfficient = source["totalEfficient"];
 	        this.overallAccuracy = source["overallAccuracy"];
+	        this.averageExtraWeighings = source["averageExtraWeighings"];
 	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
-	        this.roundStats = this.convertValues(source["roundStats"], RpsRoundStats);
+	        this.objectStats = this.convertValues(source["objectStats"], WeightObjectStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1587,69 +4824,60 @@ export namespace types {
 		}
 	}
 	
-	export class RpsSettings {
-	    rounds: number[];
-	    questionsPerRound: number;
-	    timeLimitMs: number;
+	export class WeightSettings {
+	    numProblems: number;
+	    numObjects: number;
+	    maxWeighings: number;
 	    isRealMode: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new RpsSettings(source);
+	        return new WeightSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.rounds = source["rounds"];
-	        this.questionsPerRound = source["questionsPerRound"];
-	        this.timeLimitMs = source["timeLimitMs"];
+	        this.numProblems = source["numProblems"];
+	        this.numObjects = source["numObjects"];
+	        this.maxWeighings = source["maxWeighings"];
 	        this.isRealMode = source["isRealMode"];
 	    }
 	}
 	
-	export class ShapeRotationRoundStats {
-	    round: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    accuracy: number;
-	    averageSolveTimeMs: number;
-	    averageClickCount: number;
+	
+	export class WordPair {
+	    left: string;
+	    right: string;
+	    category: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShapeRotationRoundStats(source);
+	        return new WordPair(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.round = source["round"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.accuracy = source["accuracy"];
-	        this.averageSolveTimeMs = source["averageSolveTimeMs"];
-	        this.averageClickCount = source["averageClickCount"];
+	        this.left = source["left"];
+	        this.right = source["right"];
+	        this.category = source["category"];
 	    }
 	}
-	export class ShapeRotationSessionStats {
-	    sessionId: number;
-	    totalQuestions: number;
-	    totalCorrect: number;
-	    overallAccuracy: number;
-	    averageSolveTimeMs: number;
-	    averageClickCount: number;
-	    roundStats: ShapeRotationRoundStats[];
+	export class WordList {
+	    id: number;
+	    name: string;
+	    language: string;
+	    pairs: WordPair[];
+	    createdAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShapeRotationSessionStats(source);
+	        return new WordList(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionId = source["sessionId"];
-	        this.totalQuestions = source["totalQuestions"];
-	        this.totalCorrect = source["totalCorrect"];
-	        this.overallAccuracy = source["overallAccuracy"];
-	        this.averageSolveTimeMs = source["averageSolveTimeMs"];
-	        this.averageClickCount = source["averageClickCount"];
-	        this.roundStats = this.convertValues(source["roundStats"], ShapeRotationRoundStats);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.language = source["language"];
+	        this.pairs = this.convertValues(source["pairs"], WordPair);
+	        this.createdAt = source["createdAt"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1670,26 +4898,93 @@ export namespace types {
 		    return a;
 		}
 	}
+	export class WordListStat {
+	    wordListId: number;
+	    wordListName: string;
+	    totalSessions: number;
+	    totalQuestions: number;
+	    totalCorrect: number;
+	    accuracy: number;
+	    averageResponseTimeMs: number;
 	
-	export class ShapeRotationSettings {
-	    numProblems: number;
-	    timeLimit: number;
-	    round: number;
-	    isRealMode: boolean;
+	    static createFrom(source: any = {}) {
+	        return new WordListStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.wordListId = source["wordListId"];
+	        this.wordListName = source["wordListName"];
+	        this.totalSessions = source["totalSessions"];
+	        this.totalQuestions = source["totalQuestions"];
+	        this.totalCorrect = source["totalCorrect"];
+	        this.accuracy = source["accuracy"];
+	        this.averageResponseTimeMs = source["averageResponseTimeMs"];
+	    }
+	}
+	export class WordListSummary {
+	    id: number;
+	    name: string;
+	    language: string;
+	    pairCount: number;
+	    categories: string[];
+	    createdAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShapeRotationSettings(source);
+	        return new WordListSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.numProblems = source["numProblems"];
-	        this.timeLimit = source["timeLimit"];
-	        this.round = source["round"];
-	        this.isRealMode = source["isRealMode"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.language = source["language"];
+	        this.pairCount = source["pairCount"];
+	        this.categories = source["categories"];
+	        this.createdAt = source["createdAt"];
 	    }
 	}
+
+}
+
+export namespace weight {
+	
+	export class WeightGameState {
+	    settings: types.WeightSettings;
+	    problems: types.WeightProblem[];
+	    id: number;
+	    nextProblem: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeightGameState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], types.WeightSettings);
+	        this.problems = this.convertValues(source["problems"], types.WeightProblem);
+	        this.id = source["id"];
+	        this.nextProblem = source["nextProblem"];
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -0,0 +1,226 @@
+package backup
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+const (
+	// DefaultInterval is how often the scheduled backup runs.
+	DefaultInterval = 24 * time.Hour
+	// DefaultKeep is how many backups are kept before the oldest are deleted.
+	DefaultKeep = 14
+
+	namePrefix = "acca_games-"
+	nameSuffix = ".db"
+	nameTime   = "20060102-150405.000"
+)
+
+// Service makes, rotates and restores backups of the database. Backups are files in one
+// directory, named after the time they were made.
+type Service struct {
+	db   *sql.DB
+	dir  string
+	keep int
+	mu   sync.Mutex // Held while a backup or restore runs
+	now  func() time.Time
+	// onRestore is called after a restore replaced the database, so that games in play,
+	// whose sessions may be gone or renumbered, can be dropped.
+	onRestore func()
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewService creates a new service that keeps the newest keep backups in dir and calls
+// onRestore, if set, after every restore.
+func NewService(db *sql.DB, dir string, keep int, onRestore func()) *Service {
+	if keep < 1 {
+		keep = 1
+	}
+	return &Service{db: db, dir: dir, keep: keep, now: time.Now, onRestore: onRestore}
+}
+
+// Backup makes a backup now and deletes the oldest ones beyond the number kept.
+func (s *Service) Backup() (*types.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.backup()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rotate(); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *Service) backup() (*types.BackupInfo, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	now := s.now()
+	name := namePrefix + now.Format(nameTime) + nameSuffix
+	path := filepath.Join(s.dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", name)
+	}
+	if err := database.BackupDatabase(s.db, path); err != nil {
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	return &types.BackupInfo{Name: name, SizeBytes: stat.Size(), CreatedAt: types.CustomTime{Time: now}}, nil
+}
+
+// rotate deletes the oldest backups beyond the number kept.
+func (s *Service) rotate() error {
+	backups, err := s.list()
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(s.keep, len(backups)):] {
+		if err := os.Remove(filepath.Join(s.dir, b.Name)); err != nil {
+			return fmt.Errorf("failed to delete old backup %s: %w", b.Name, err)
+		}
+	}
+	return nil
+}
+
+// List returns the backups, newest first.
+func (s *Service) List() ([]types.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list()
+}
+
+func (s *Service) list() ([]types.BackupInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []types.BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []types.BackupInfo{}
+	for _, e := range entries {
+		createdAt, ok := parseName(e.Name())
+		if !ok || !e.Type().IsRegular() {
+			continue
+		}
+		stat, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup %s: %w", e.Name(), err)
+		}
+		backups = append(backups, types.BackupInfo{Name: e.Name(), SizeBytes: stat.Size(), CreatedAt: types.CustomTime{Time: createdAt}})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt.Time) })
+	return backups, nil
+}
+
+// parseName returns the time a backup was made from its file name, and false for files
+// that are not backups.
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, nameSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(nameTime, strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), nameSuffix), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Restore replaces the database with the named backup, once the backup has been checked
+// to be intact and of a schema version this app can read. The database as it was is
+// backed up first and returned, so a restore can itself be undone.
+func (s *Service) Restore(name string) (*types.BackupInfo, error) {
+	if _, ok := parseName(name); !ok || filepath.Base(name) != name {
+		return nil, fmt.Errorf("%q is not a backup", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.dir, name)
+	if _, err := database.ValidateBackup(path); err != nil {
+		return nil, fmt.Errorf("cannot restore %s: %w", name, err)
+	}
+	previous, err := s.backup()
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up the current database before restoring: %w", err)
+	}
+	if err := database.RestoreDatabase(s.db, path); err != nil {
+		return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+	}
+	if s.onRestore != nil {
+		s.onRestore()
+	}
+	if err := s.rotate(); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// Start runs a backup whenever the newest one is older than interval, checking once at
+// start and then as each backup falls due. onError is called for backups that fail.
+func (s *Service) Start(interval time.Duration, onError func(error)) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		for {
+			wait, err := s.untilDue(interval)
+			if err != nil {
+				onError(err)
+				wait = interval
+			}
+			if wait <= 0 {
+				if _, err := s.Backup(); err != nil {
+					onError(err)
+				}
+				wait = interval
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-s.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// untilDue returns how long until the next scheduled backup is due, 0 or less if it is.
+func (s *Service) untilDue(interval time.Duration) (time.Duration, error) {
+	backups, err := s.List()
+	if err != nil || len(backups) == 0 {
+		return 0, err
+	}
+	return backups[0].CreatedAt.Add(interval).Sub(s.now()), nil
+}
+
+// Stop ends the scheduled backups started by Start, waiting for one in progress.
+func (s *Service) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/stroop"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestService opens a database in a temporary directory and a service whose clock
+// moves on an hour every time it is read.
+func newTestService(t *testing.T, keep int) *Service {
+	dir := t.TempDir()
+	db, err := database.NewDatabase(filepath.Join(dir, "games.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	service := NewService(db, filepath.Join(dir, "backups"), keep, nil)
+	clock := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	service.now = func() time.Time {
+		clock = clock.Add(time.Hour)
+		return clock
+	}
+	return service
+}
+
+func TestService_BackupRotates(t *testing.T) {
+	service := newTestService(t, 2)
+
+	backups, err := service.List()
+	assert.NoError(t, err)
+	assert.Empty(t, backups, "No backup directory yet")
+
+	var made []string
+	for i := 0; i < 3; i++ {
+		info, err := service.Backup()
+		assert.NoError(t, err)
+		assert.Positive(t, info.SizeBytes)
+		made = append(made, info.Name)
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(service.dir, "notes.txt"), []byte("mine"), 0644))
+
+	backups, err = service.List()
+	assert.NoError(t, err)
+	if assert.Len(t, backups, 2, "Only the newest two are kept") {
+		assert.Equal(t, made[2], backups[0].Name)
+		assert.Equal(t, made[1], backups[1].Name)
+	}
+	_, err = os.Stat(filepath.Join(service.dir, made[0]))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(service.dir, "notes.txt"))
+	assert.NoError(t, err, "Other files are left alone")
+}
+
+func TestService_Restore(t *testing.T) {
+	service := newTestService(t, DefaultKeep)
+
+	_, err := database.CreateGameSession(service.db, types.GameCodeTower, types.TowerSettings{})
+	assert.NoError(t, err)
+	first, err := service.Backup()
+	assert.NoError(t, err)
+	_, err = database.CreateGameSession(service.db, types.GameCodeRoad, types.RoadSettings{})
+	assert.NoError(t, err)
+
+	previous, err := service.Restore(first.Name)
+	assert.NoError(t, err)
+	var n int
+	assert.NoError(t, service.db.QueryRow("SELECT COUNT(*) FROM game_sessions").Scan(&n))
+	assert.Equal(t, 1, n)
+
+	// The database as it was before the restore was kept and can be restored in turn.
+	_, err = service.Restore(previous.Name)
+	assert.NoError(t, err)
+	assert.NoError(t, service.db.QueryRow("SELECT COUNT(*) FROM game_sessions").Scan(&n))
+	assert.Equal(t, 2, n)
+
+	_, err = service.Restore("../games.db")
+	assert.Error(t, err, "Only backups in the backup directory can be restored")
+	_, err = service.Restore("acca_games-20000101-000000.000.db")
+	assert.Error(t, err, "A missing backup cannot be restored")
+
+	broken := "acca_games-20200101-000000.000.db"
+	assert.NoError(t, os.WriteFile(filepath.Join(service.dir, broken), []byte("not a database"), 0644))
+	backups, err := service.List()
+	assert.NoError(t, err)
+	_, err = service.Restore(broken)
+	assert.Error(t, err, "A corrupt backup is refused")
+	after, err := service.List()
+	assert.NoError(t, err)
+	assert.Len(t, after, len(backups), "A refused restore makes no backup")
+}
+
+func TestService_Scheduled(t *testing.T) {
+	service := newTestService(t, DefaultKeep)
+
+	var errs []error
+	service.Start(DefaultInterval, func(err error) { errs = append(errs, err) })
+	assert.Eventually(t, func() bool {
+		backups, err := service.List()
+		return err == nil && len(backups) == 1
+	}, 5*time.Second, 10*time.Millisecond, "A backup is made at start when none is recent")
+	service.Stop()
+	assert.Empty(t, errs)
+
+	// The backup just made is recent, so starting again makes no other.
+	service.Start(DefaultInterval, func(err error) { errs = append(errs, err) })
+	service.Stop()
+	backups, err := service.List()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestService_RestoreClearsGamesInPlay(t *testing.T) {
+	service := newTestService(t, DefaultKeep)
+	games := stroop.NewService(service.db)
+	service.onRestore = games.ClearGames
+
+	before, err := service.Backup()
+	assert.NoError(t, err)
+	state, err := games.StartGame(types.StroopSettings{NumTrials: 4})
+	assert.NoError(t, err)
+	_, err = games.SubmitAnswer(state.ID, 1, "r", 500)
+	assert.NoError(t, err)
+
+	_, err = service.Restore(before.Name)
+	assert.NoError(t, err)
+
+	// The session is gone from the restored database, and its ID may be handed out again.
+	_, err = games.SubmitAnswer(state.ID, 2, "r", 500)
+	assert.ErrorContains(t, err, "not in play", "A game started before the restore takes no more answers")
+	again, err := games.StartGame(types.StroopSettings{NumTrials: 4})
+	assert.NoError(t, err)
+	_, err = games.SubmitAnswer(again.ID, 1, "r", 500)
+	assert.NoError(t, err)
+}
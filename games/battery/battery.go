@@ -0,0 +1,169 @@
+package battery
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+// Starter starts one game with a battery step's settings, with real mode turned on, and
+// returns the game state together with the new game session ID.
+type Starter func(settings json.RawMessage) (state interface{}, sessionID int64, err error)
+
+// NewStarter adapts a game's StartGame to a Starter. sessionID reads the session ID off
+// the returned state, and setRealMode turns real mode on in the decoded settings.
+func NewStarter[S any, T any](start func(S) (T, error), sessionID func(T) int64, setRealMode func(*S)) Starter {
+	return func(raw json.RawMessage) (interface{}, int64, error) {
+		var settings S
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &settings); err != nil {
+				return nil, 0, fmt.Errorf("invalid settings: %w", err)
+			}
+		}
+		setRealMode(&settings)
+		state, err := start(settings)
+		if err != nil {
+			return nil, 0, err
+		}
+		return state, sessionID(state), nil
+	}
+}
+
+// Game is a game that can be played as a battery step. Abandon takes a session of it that
+// was left unfinished out of play.
+type Game struct {
+	Start   Starter
+	Abandon func(sessionID int64) error
+}
+
+// Service runs batteries: fixed sequences of games played one after another.
+type Service struct {
+	db    *sql.DB
+	games map[string]Game
+}
+
+// NewService creates a new service that can play the games in games.
+func NewService(db *sql.DB, games map[string]Game) *Service {
+	return &Service{db: db, games: games}
+}
+
+// StartBattery creates a battery with the given steps, or the default steps if none are given.
+func (s *Service) StartBattery(steps []types.BatteryStep) (*types.BatterySession, error) {
+	if len(steps) == 0 {
+		steps = DefaultSteps()
+	}
+	for i, step := range steps {
+		if _, ok := s.games[step.GameCode]; !ok {
+			return nil, fmt.Errorf("step %d: game %s cannot be played in a battery", i, step.GameCode)
+		}
+	}
+
+	batteryID, err := database.CreateBatterySession(s.db, steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create battery session: %w", err)
+	}
+	return database.GetBatterySession(s.db, batteryID)
+}
+
+// StartStep starts the game of the battery's current step. Starting a step again, for
+// example after the app was closed mid-game, abandons the session it began before and
+// begins that game afresh.
+func (s *Service) StartStep(batteryID int64) (*types.BatteryStepStart, error) {
+	b, err := database.GetBatterySession(s.db, batteryID)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status == types.BatteryStatusCompleted {
+		return nil, fmt.Errorf("battery %d is already completed", batteryID)
+	}
+
+	step := b.Steps[b.CurrentStep]
+	game, ok := s.games[step.GameCode]
+	if !ok {
+		return nil, fmt.Errorf("game %s cannot be played in a battery", step.GameCode)
+	}
+	if step.SessionID != 0 {
+		status, err := database.GetSessionStatus(s.db, step.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if status == types.SessionStatusInProgress {
+			if err := game.Abandon(step.SessionID); err != nil {
+				return nil, fmt.Errorf("failed to abandon session %d: %w", step.SessionID, err)
+			}
+		}
+	}
+	state, sessionID, err := game.Start(step.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", step.GameCode, err)
+	}
+	if err := database.LinkBatteryStep(s.db, batteryID, b.CurrentStep, sessionID); err != nil {
+		return nil, err
+	}
+
+	b, err = database.GetBatterySession(s.db, batteryID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BatteryStepStart{
+		Battery:   b,
+		Step:      step.Step,
+		GameCode:  step.GameCode,
+		SessionID: sessionID,
+		State:     state,
+	}, nil
+}
+
+// CompleteStep records that the game of the current step is finished and moves on. The
+// session must be the one StartStep began for that step, and must have been completed.
+func (s *Service) CompleteStep(batteryID int64, sessionID int64) (*types.BatterySession, error) {
+	b, err := database.GetBatterySession(s.db, batteryID)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status == types.BatteryStatusCompleted {
+		return nil, fmt.Errorf("battery %d is already completed", batteryID)
+	}
+	step := b.Steps[b.CurrentStep]
+	if step.SessionID == 0 || step.SessionID != sessionID {
+		return nil, fmt.Errorf("session %d is not the current game of battery %d", sessionID, batteryID)
+	}
+	status, err := database.GetSessionStatus(s.db, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if status != types.SessionStatusCompleted {
+		return nil, fmt.Errorf("session %d is %s, not completed", sessionID, status)
+	}
+
+	if err := database.AdvanceBatterySession(s.db, batteryID, len(b.Steps)); err != nil {
+		return nil, err
+	}
+	return database.GetBatterySession(s.db, batteryID)
+}
+
+// ResumeBattery returns the most recent battery that is not yet completed, or nil.
+func (s *Service) ResumeBattery() (*types.BatterySession, error) {
+	return database.GetUnfinishedBatterySession(s.db)
+}
+
+// DefaultSteps is the full test: the six original games, each with the settings of the
+// real test.
+func DefaultSteps() []types.BatteryStep {
+	return []types.BatteryStep{
+		step(types.GameCodeRPS, types.RpsSettings{Rounds: []int{1, 2, 3}, QuestionsPerRound: 10, TimeLimitMs: 3000}),
+		step(types.GameCodeShapeRotation, types.ShapeRotationSettings{NumProblems: 10, TimeLimit: 180, Round: 1}),
+		step(types.GameCodeNumberPressing, types.NumberPressingSetup{Rounds: []int{1, 2}, ProblemsPerRound: 10, TimeLimitR1: 30, TimeLimitR2: 60}),
+		step(types.GameCodeCountComparison, types.CountComparisonSettings{NumProblems: 10, PresentationTime: 1000, InputTime: 3000}),
+		step(types.GameCodeCatChaser, types.CatChaserSettings{NumTrials: 6, Difficulty: types.CatChaserDifficulty{Mode: types.CatChaserDifficultyAuto}, ShowTime: 1.0, ResponseTimeLimit: 3.0}),
+		step(types.GameCodeNBack, types.NBackSettings{NumTrials: 25, PresentationTime: 3000, NBackLevel: 0, ShapeGroup: "group1"}),
+	}
+}
+
+func step(gameCode string, settings interface{}) types.BatteryStep {
+	raw, _ := json.Marshal(settings) // Plain settings structs always marshal
+	return types.BatteryStep{GameCode: gameCode, Settings: raw}
+}
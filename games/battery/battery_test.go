@@ -0,0 +1,113 @@
+package battery
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGames start every game by creating a bare session, recording the settings they
+// were given.
+func fakeGames(db *sql.DB, started *[]types.TowerSettings) map[string]Game {
+	start := func(settings types.TowerSettings) (int64, error) {
+		*started = append(*started, settings)
+		return database.CreateGameSession(db, types.GameCodeTower, settings)
+	}
+	return map[string]Game{
+		types.GameCodeTower: {
+			Start:   NewStarter(start, func(id int64) int64 { return id }, func(s *types.TowerSettings) { s.IsRealMode = true }),
+			Abandon: func(sessionID int64) error { return database.AbandonGameSession(db, sessionID) },
+		},
+	}
+}
+
+func TestBatteryRun(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var started []types.TowerSettings
+	s := NewService(db, fakeGames(db, &started))
+
+	_, err = s.StartBattery(nil)
+	assert.Error(t, err, "The default steps need starters for every game")
+	_, err = s.StartBattery([]types.BatteryStep{{GameCode: types.GameCodeWeight}})
+	assert.Error(t, err)
+
+	b, err := s.StartBattery([]types.BatteryStep{
+		{GameCode: types.GameCodeTower, Settings: json.RawMessage(`{"numProblems":3,"minDiscs":3,"maxDiscs":3}`)},
+		{GameCode: types.GameCodeTower, Settings: json.RawMessage(`{"numProblems":5,"isRealMode":false}`)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, types.BatteryStatusInProgress, b.Status)
+	assert.Len(t, b.Steps, 2)
+
+	_, err = s.CompleteStep(b.ID, 1)
+	assert.Error(t, err, "The current step has not been started")
+
+	first, err := s.StartStep(b.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, first.Step)
+	assert.Equal(t, first.SessionID, first.State)
+	assert.Equal(t, types.BatteryStepStarted, first.Battery.Steps[0].Status)
+	assert.Equal(t, types.TowerSettings{NumProblems: 3, MinDiscs: 3, MaxDiscs: 3, IsRealMode: true}, started[0])
+
+	// The app is closed mid-game: the battery resumes at the same step.
+	resumed, err := s.ResumeBattery()
+	assert.NoError(t, err)
+	if !assert.NotNil(t, resumed) {
+		return
+	}
+	assert.Equal(t, b.ID, resumed.ID)
+	assert.Equal(t, 0, resumed.CurrentStep)
+
+	restarted, err := s.StartStep(b.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.SessionID, restarted.SessionID)
+	status, err := database.GetSessionStatus(db, first.SessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.SessionStatusAbandoned, status, "The step's earlier session is abandoned")
+	_, err = s.CompleteStep(b.ID, first.SessionID)
+	assert.Error(t, err, "Only the latest session of a step may complete it")
+
+	_, err = s.CompleteStep(b.ID, restarted.SessionID)
+	assert.Error(t, err, "The game must be finished before its step is")
+	assert.NoError(t, database.EndGameSession(db, restarted.SessionID))
+	b, err = s.CompleteStep(b.ID, restarted.SessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, b.CurrentStep)
+	assert.Equal(t, types.BatteryStepCompleted, b.Steps[0].Status)
+
+	second, err := s.StartStep(b.ID)
+	assert.NoError(t, err)
+	assert.True(t, started[2].IsRealMode, "Real mode is forced whatever the settings say")
+	assert.NoError(t, database.EndGameSession(db, second.SessionID))
+	b, err = s.CompleteStep(b.ID, second.SessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.BatteryStatusCompleted, b.Status)
+
+	_, err = s.StartStep(b.ID)
+	assert.Error(t, err)
+	resumed, err = s.ResumeBattery()
+	assert.NoError(t, err)
+	assert.Nil(t, resumed)
+
+	report, err := database.GetBatteryReport(db, b.ID)
+	assert.NoError(t, err)
+	assert.Len(t, report.Steps, 2)
+	assert.Equal(t, restarted.SessionID, report.Steps[0].SessionID)
+}
+
+func TestDefaultSteps(t *testing.T) {
+	steps := DefaultSteps()
+	assert.Len(t, steps, 6)
+	for _, step := range steps {
+		var settings map[string]interface{}
+		assert.NoError(t, json.Unmarshal(step.Settings, &settings))
+		assert.NotEmpty(t, settings, step.GameCode)
+	}
+}
@@ -2,72 +2,141 @@ package cat_chaser
 
 import (
 	"acca-games/database"
+	"acca-games/games/session"
 	"acca-games/types"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
-	"strconv"
 	"time"
 )
 
 // CatChaserGameState holds the current state of the game.
 type CatChaserGameState struct {
-	Settings types.CatChaserSettings  `json:"settings"`
-	Problems []types.CatChaserProblem `json:"problems"`
-	ID       int64                    `json:"id"`
+	Settings  types.CatChaserSettings  `json:"settings"`
+	Problems  []types.CatChaserProblem `json:"problems"`
+	ID        int64                    `json:"id"`
+	Answered  [][]string               `json:"answered"`  // Target colors answered so far, per round
+	NextRound int                      `json:"nextRound"` // First round with a target left to answer
 }
 
 // Service for the Cat Chaser game.
 type Service struct {
-	db           *sql.DB
-	currentState *CatChaserGameState
+	db    *sql.DB
+	games *session.Manager[CatChaserGameState]
 }
 
 // NewService creates a new service.
 func NewService(db *sql.DB) *Service {
-	return &Service{db: db}
+	return &Service{db: db, games: session.NewManager[CatChaserGameState](session.DefaultIdleTimeout)}
 }
 
 // StartGame initializes a new game session.
 func (s *Service) StartGame(settings types.CatChaserSettings) (*CatChaserGameState, error) {
+	settings = withDefaults(settings)
+
 	// Generate problems based on settings
-	problems := generateProblems(settings.NumTrials, settings.Difficulty)
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate problems: %w", err)
+	}
 
-	sessionID, err := database.CreateGameSession(s.db, types.GameCodeCatChaser, settings)
+	trials := 0
+	for _, p := range problems {
+		trials += len(p.Targets)
+	}
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeCatChaser, settings, trials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create game session: %w", err)
 	}
 
-	s.currentState = &CatChaserGameState{
-		Settings: settings,
-		Problems: problems,
-		ID:       sessionID,
+	state := &CatChaserGameState{
+		Settings:  settings,
+		Problems:  problems,
+		ID:        sessionID,
+		Answered:  make([][]string, len(problems)),
+		NextRound: 1,
 	}
+	if err := database.SaveSessionState(s.db, sessionID, state, 1); err != nil {
+		return nil, fmt.Errorf("failed to save game state: %w", err)
+	}
+	s.games.Add(sessionID, state)
 
-	return s.currentState, nil
+	return state, nil
 }
 
-// SubmitAnswer processes a user's answer.
-func (s *Service) SubmitAnswer(round int, targetColor string, playerChoice string, confidence int, responseTimeMs int) (*types.CatChaserResult, error) {
-	if s.currentState == nil {
-		return nil, fmt.Errorf("game not started")
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first round with a target left to answer.
+func (s *Service) ResumeSession(sessionID int64) (*CatChaserGameState, error) {
+	var state CatChaserGameState
+	next, err := database.LoadSessionState(s.db, sessionID, &state)
+	if err != nil {
+		return nil, err
 	}
+	state.NextRound = next
+	s.games.Add(sessionID, &state)
+	return &state, nil
+}
 
-	if round < 1 || round > len(s.currentState.Problems) {
+// nextRound returns the first round with a target left to answer, or one past the last
+// round when every target is answered.
+func (gs *CatChaserGameState) nextRound() int {
+	for i, p := range gs.Problems {
+		if len(gs.Answered[i]) < len(p.Targets) {
+			return i + 1
+		}
+	}
+	return len(gs.Problems) + 1
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitAnswer processes a user's answer.
+func (s *Service) SubmitAnswer(sessionID int64, round int, targetColor string, playerChoice string, confidence int, responseTimeMs int) (*types.CatChaserResult, error) {
+	var result *types.CatChaserResult
+	err := s.games.With(sessionID, func(gs *CatChaserGameState) error {
+		var err error
+		result, err = gs.submitAnswer(s.db, round, targetColor, playerChoice, confidence, responseTimeMs)
+		return err
+	})
+	return result, err
+}
+
+func (gs *CatChaserGameState) submitAnswer(db *sql.DB, round int, targetColor string, playerChoice string, confidence int, responseTimeMs int) (*types.CatChaserResult, error) {
+	if round < 1 || round > len(gs.Problems) {
 		return nil, fmt.Errorf("invalid round number")
 	}
 
-	problem := s.currentState.Problems[round-1]
+	problem := gs.Problems[round-1]
 	
 	var correctChoice string
 	var isCorrect bool
-	var caughtStatus types.CaughtStatus
 
-	if targetColor == "RED" {
-		caughtStatus = problem.RedCat
-	} else if targetColor == "BLUE" {
-		caughtStatus = problem.BlueCat
-	} else {
+	caughtStatus, ok := targetStatus(problem, targetColor)
+	if !ok {
 		return nil, fmt.Errorf("invalid target color")
 	}
 
@@ -99,8 +168,13 @@ func (s *Service) SubmitAnswer(round int, targetColor string, playerChoice strin
 		}
 	}
 
+	frameScheduleJSON, err := json.Marshal(problem.Frames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frame schedule: %w", err)
+	}
+
 	result := types.CatChaserResult{
-		SessionID:      s.currentState.ID,
+		SessionID:      gs.ID,
 		Round:          round,
 		TargetColor:    targetColor,
 		PlayerChoice:   playerChoice,
@@ -109,100 +183,320 @@ func (s *Service) SubmitAnswer(round int, targetColor string, playerChoice strin
 		IsCorrect:      isCorrect,
 		Score:          score,
 		ResponseTimeMs: responseTimeMs,
+		FrameSchedule:  string(frameScheduleJSON),
 	}
 
-	if err := database.SaveCatChaserResult(s.db, result); err != nil {
+	if err := database.SaveCatChaserResult(db, result); err != nil {
 		return nil, fmt.Errorf("failed to save result: %w", err)
 	}
+	gs.Answered[round-1] = append(gs.Answered[round-1], targetColor)
+	gs.NextRound = gs.nextRound()
+	if err := database.SaveSessionState(db, gs.ID, gs, gs.NextRound); err != nil {
+		return nil, fmt.Errorf("failed to save game state: %w", err)
+	}
 
 	return &result, nil
 }
 
 // --- Helper Functions ---
 
-func generateProblems(numTrials int, difficulty string) []types.CatChaserProblem {
-	problems := make([]types.CatChaserProblem, numTrials)
-	rand.Seed(time.Now().UnixNano())
-
-	// Determine mouse counts per round
-	mouseCounts := make([]int, numTrials)
-	if difficulty == "auto" {
-		// 6 levels: 4, 6, 8, 10, 12, 16
-		levels := []int{4, 6, 8, 10, 12, 16}
-		// Distribute trials across levels
-		// Example: 10 trials -> 1,1,2,2,2,2 (roughly)
-		// Simple linear distribution
+const (
+	defaultGridSize    = 6
+	defaultNumTargets  = 2
+	defaultCaughtRatio = 0.5
+	referenceGridCells = 36 // The auto levels below are tuned for a 6x6 grid
+	defaultFrameMs     = 1000
+)
+
+// autoMouseLevels are the mouse counts "auto" difficulty steps through on a 6x6 grid.
+var autoMouseLevels = []int{4, 6, 8, 10, 12, 16}
+
+// targetColors are assigned to target cats in order.
+var targetColors = []string{"RED", "BLUE", "GREEN", "YELLOW", "PURPLE", "ORANGE"}
+
+// targetStatus returns whether the target cat of the given color caught a mouse.
+// The status is worked out from the final frame: the target is CAUGHT when its last
+// position held a mouse in any of the mice frames.
+func targetStatus(problem types.CatChaserProblem, color string) (types.CaughtStatus, bool) {
+	for _, t := range problem.Targets {
+		if t.Color != color {
+			continue
+		}
+		if len(problem.Frames) == 0 {
+			return t.Status, true
+		}
+
+		final := problem.Frames[len(problem.Frames)-1]
+		for _, frame := range problem.Frames {
+			if contains(frame.MicePositions, final.CatPositions[t.CatIndex]) {
+				return types.StatusCaught, true
+			}
+		}
+		return types.StatusMissed, true
+	}
+	return "", false
+}
+
+func contains(slice []int, val int) bool {
+	for _, item := range slice {
+		if item == val {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaults fills in unset grid, target and base-rate settings.
+func withDefaults(settings types.CatChaserSettings) types.CatChaserSettings {
+	if settings.GridRows <= 0 {
+		settings.GridRows = defaultGridSize
+	}
+	if settings.GridCols <= 0 {
+		settings.GridCols = defaultGridSize
+	}
+	if settings.NumTargets <= 0 {
+		settings.NumTargets = defaultNumTargets
+	}
+	if settings.CaughtRatio == nil {
+		ratio := defaultCaughtRatio
+		settings.CaughtRatio = &ratio
+	}
+	if settings.Difficulty.Mode == "" {
+		settings.Difficulty.Mode = types.CatChaserDifficultyAuto
+	}
+	if settings.MouseSteps <= 0 {
+		settings.MouseSteps = 1
+	}
+	if settings.CatSteps <= 0 {
+		settings.CatSteps = 1
+	}
+	if settings.FrameMs <= 0 {
+		settings.FrameMs = int(settings.ShowTime * 1000)
+		if settings.FrameMs <= 0 {
+			settings.FrameMs = defaultFrameMs
+		}
+	}
+	return settings
+}
+
+// mouseCounts returns the number of mice for each round.
+func mouseCounts(settings types.CatChaserSettings) ([]int, error) {
+	numTrials := settings.NumTrials
+	counts := make([]int, numTrials)
+
+	switch settings.Difficulty.Mode {
+	case types.CatChaserDifficultyAuto:
+		// Scale the levels to the grid, then spread the trials across them in order.
+		cells := settings.GridRows * settings.GridCols
 		for i := 0; i < numTrials; i++ {
-			levelIdx := (i * len(levels)) / numTrials
-			if levelIdx >= len(levels) {
-				levelIdx = len(levels) - 1
+			levelIdx := (i * len(autoMouseLevels)) / numTrials
+			if levelIdx >= len(autoMouseLevels) {
+				levelIdx = len(autoMouseLevels) - 1
 			}
-			mouseCounts[i] = levels[levelIdx]
+			count := autoMouseLevels[levelIdx] * cells / referenceGridCells
+			if count < settings.NumTargets {
+				count = settings.NumTargets
+			}
+			counts[i] = count
 		}
-	} else {
-		count, _ := strconv.Atoi(difficulty)
-		if count < 4 { count = 4 }
+	case types.CatChaserDifficultyFixed:
 		for i := 0; i < numTrials; i++ {
-			mouseCounts[i] = count
+			counts[i] = settings.Difficulty.MiceCount
 		}
+	default:
+		return nil, fmt.Errorf("unknown difficulty mode: %q", settings.Difficulty.Mode)
 	}
+	return counts, nil
+}
+
+// generateProblems creates the problems for a session. Whether each target cat is CAUGHT
+// or MISSED is decided up front so that the share of CAUGHT answers over the session
+// matches settings.CaughtRatio; the cats are then placed to fit.
+func generateProblems(settings types.CatChaserSettings, rng *rand.Rand) ([]types.CatChaserProblem, error) {
+	settings = withDefaults(settings)
+	cells := settings.GridRows * settings.GridCols
 
-	for i := 0; i < numTrials; i++ {
-		problems[i] = generateSingleProblem(i+1, mouseCounts[i])
+	if settings.NumTargets > len(targetColors) {
+		return nil, fmt.Errorf("at most %d target cats are supported", len(targetColors))
+	}
+	if ratio := *settings.CaughtRatio; ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("caught ratio must be between 0 and 1, got %v", ratio)
 	}
 
-	return problems
+	counts, err := mouseCounts(settings)
+	if err != nil {
+		return nil, err
+	}
+	for _, numMice := range counts {
+		// Every target needs a mouse to land on, or an empty cell to miss on.
+		if numMice < settings.NumTargets || numMice+settings.NumTargets > cells {
+			return nil, fmt.Errorf("%d mice with %d target cats do not fit a %dx%d grid", numMice, settings.NumTargets, settings.GridRows, settings.GridCols)
+		}
+	}
+
+	totalTargets := settings.NumTrials * settings.NumTargets
+	numCaught := int(math.Round(float64(totalTargets) * *settings.CaughtRatio))
+	statuses := make([]types.CaughtStatus, totalTargets)
+	for i := range statuses {
+		if i < numCaught {
+			statuses[i] = types.StatusCaught
+		} else {
+			statuses[i] = types.StatusMissed
+		}
+	}
+	rng.Shuffle(len(statuses), func(i, j int) { statuses[i], statuses[j] = statuses[j], statuses[i] })
+
+	problems := make([]types.CatChaserProblem, settings.NumTrials)
+	for i := 0; i < settings.NumTrials; i++ {
+		roundStatuses := statuses[i*settings.NumTargets : (i+1)*settings.NumTargets]
+		problems[i] = generateSingleProblem(rng, i+1, settings.GridRows, settings.GridCols, counts[i], roundStatuses)
+		problems[i].Frames = buildFrames(rng, problems[i], settings)
+	}
+
+	return problems, nil
 }
 
-func generateSingleProblem(round int, numMice int) types.CatChaserProblem {
-	gridSize := 36
-	// Generate random unique positions for mice
-	perm := rand.Perm(gridSize)
+// generateSingleProblem places the mice at random, then puts each target cat on a mouse
+// (CAUGHT) or on an empty cell (MISSED) as requested. The remaining cats go anywhere
+// that is still free; cats never share a cell.
+func generateSingleProblem(rng *rand.Rand, round, rows, cols, numMice int, statuses []types.CaughtStatus) types.CatChaserProblem {
+	gridSize := rows * cols
+	perm := rng.Perm(gridSize)
 	micePositions := perm[:numMice]
-	
-	// Generate random unique positions for cats (same count as mice)
-	// Cats can be anywhere, overlapping or not? 
-	// "생쥐와 같은 칸에 나타난 고양이는 생쥐를 '잡았다'" -> Implies overlap allowed.
-	// Are cats unique among themselves? "임의의 칸에 생쥐와 같은 수의 고양이" -> Usually implies unique positions for the group of cats.
-	permCats := rand.Perm(gridSize)
-	catPositions := permCats[:numMice]
+	emptyCells := perm[numMice:]
 
-	// Select 2 target cats
-	// We need 2 distinct indices from the *catPositions array* (0 to numMice-1)
-	catIndices := rand.Perm(numMice)
-	redCatIdx := catIndices[0]
-	blueCatIdx := catIndices[1]
+	mouseCells := append([]int(nil), micePositions...)
+	rng.Shuffle(len(mouseCells), func(i, j int) { mouseCells[i], mouseCells[j] = mouseCells[j], mouseCells[i] })
+	freeEmpty := append([]int(nil), emptyCells...)
+	rng.Shuffle(len(freeEmpty), func(i, j int) { freeEmpty[i], freeEmpty[j] = freeEmpty[j], freeEmpty[i] })
+
+	// Place the target cats first.
+	taken := make(map[int]bool)
+	targetPositions := make([]int, len(statuses))
+	for t, status := range statuses {
+		if status == types.StatusCaught {
+			targetPositions[t], mouseCells = mouseCells[0], mouseCells[1:]
+		} else {
+			targetPositions[t], freeEmpty = freeEmpty[0], freeEmpty[1:]
+		}
+		taken[targetPositions[t]] = true
+	}
 
-	// Determine status
-	redCatPos := catPositions[redCatIdx]
-	blueCatPos := catPositions[blueCatIdx]
+	// The other cats (same count as mice in total) go on any free cell.
+	catPositions := append([]int(nil), targetPositions...)
+	for _, cell := range rng.Perm(gridSize) {
+		if len(catPositions) == numMice {
+			break
+		}
+		if !taken[cell] {
+			taken[cell] = true
+			catPositions = append(catPositions, cell)
+		}
+	}
 
-	redStatus := types.StatusMissed
-	if contains(micePositions, redCatPos) {
-		redStatus = types.StatusCaught
+	// Shuffle the cats so the targets are not always first, tracking where they end up.
+	order := rng.Perm(len(catPositions))
+	shuffled := make([]int, len(catPositions))
+	newIndex := make([]int, len(catPositions))
+	for newIdx, oldIdx := range order {
+		shuffled[newIdx] = catPositions[oldIdx]
+		newIndex[oldIdx] = newIdx
 	}
 
-	blueStatus := types.StatusMissed
-	if contains(micePositions, blueCatPos) {
-		blueStatus = types.StatusCaught
+	targets := make([]types.CatTarget, len(statuses))
+	for t, status := range statuses {
+		targets[t] = types.CatTarget{Color: targetColors[t], CatIndex: newIndex[t], Status: status}
 	}
 
-	return types.CatChaserProblem{
+	problem := types.CatChaserProblem{
 		Round:         round,
+		GridRows:      rows,
+		GridCols:      cols,
 		MicePositions: micePositions,
-		CatPositions:  catPositions,
-		RedCatIndex:   redCatIdx,
-		BlueCatIndex:  blueCatIdx,
-		RedCat:        redStatus,
-		BlueCat:       blueStatus,
+		CatPositions:  shuffled,
+		Targets:       targets,
+		RedCatIndex:   targets[0].CatIndex,
+		RedCat:        targets[0].Status,
+		BlueCatIndex:  -1,
 	}
+	if len(targets) > 1 {
+		problem.BlueCatIndex = targets[1].CatIndex
+		problem.BlueCat = targets[1].Status
+	}
+	return problem
 }
 
-func contains(slice []int, val int) bool {
-	for _, item := range slice {
-		if item == val {
-			return true
+// buildFrames splits a round into timed frames. The mice are revealed in MouseSteps
+// groups, then the cats appear over CatSteps frames. Going backwards from the final
+// cat positions, DistractorMoves non-target cats are moved to another free cell for
+// each earlier cat frame, so the cats seem to wander before settling. Target cats
+// stay put.
+func buildFrames(rng *rand.Rand, problem types.CatChaserProblem, settings types.CatChaserSettings) []types.CatChaserFrame {
+	var frames []types.CatChaserFrame
+
+	mice := append([]int(nil), problem.MicePositions...)
+	rng.Shuffle(len(mice), func(i, j int) { mice[i], mice[j] = mice[j], mice[i] })
+	mouseSteps := settings.MouseSteps
+	if mouseSteps > len(mice) {
+		mouseSteps = len(mice)
+	}
+	for step := 0; step < mouseSteps; step++ {
+		from, to := step*len(mice)/mouseSteps, (step+1)*len(mice)/mouseSteps
+		frames = append(frames, types.CatChaserFrame{
+			MicePositions: append([]int(nil), mice[from:to]...),
+			CatPositions:  []int{},
+		})
+	}
+
+	isTarget := make(map[int]bool)
+	for _, t := range problem.Targets {
+		isTarget[t.CatIndex] = true
+	}
+
+	catFrames := make([][]int, settings.CatSteps)
+	catFrames[len(catFrames)-1] = append([]int(nil), problem.CatPositions...)
+	gridSize := problem.GridRows * problem.GridCols
+	for k := len(catFrames) - 2; k >= 0; k-- {
+		cats := append([]int(nil), catFrames[k+1]...)
+		occupied := make(map[int]bool)
+		for _, c := range cats {
+			occupied[c] = true
+		}
+
+		var distractors []int
+		for idx := range cats {
+			if !isTarget[idx] {
+				distractors = append(distractors, idx)
+			}
 		}
+		rng.Shuffle(len(distractors), func(i, j int) { distractors[i], distractors[j] = distractors[j], distractors[i] })
+		if len(distractors) > settings.DistractorMoves {
+			distractors = distractors[:settings.DistractorMoves]
+		}
+
+		for _, idx := range distractors {
+			for _, cell := range rng.Perm(gridSize) {
+				if !occupied[cell] {
+					delete(occupied, cats[idx])
+					occupied[cell] = true
+					cats[idx] = cell
+					break
+				}
+			}
+		}
+		catFrames[k] = cats
 	}
-	return false
+
+	for _, cats := range catFrames {
+		frames = append(frames, types.CatChaserFrame{
+			MicePositions: []int{},
+			CatPositions:  cats,
+		})
+	}
+
+	for i := range frames {
+		frames[i].StartMs = i * settings.FrameMs
+		frames[i].DurationMs = settings.FrameMs
+	}
+	return frames
 }
@@ -0,0 +1,240 @@
+package cat_chaser
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"acca-games/types"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupTestDB creates an in-memory SQLite database and applies the schema.
+func setupTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+
+	schemaPath := filepath.Join("..", "..", "database", "schema.sql")
+	schema, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatalf("Failed to read schema.sql: %v", err)
+	}
+
+	_, err = db.Exec(string(schema))
+	if err != nil {
+		t.Fatalf("Failed to execute schema.sql: %v", err)
+	}
+
+	return db
+}
+
+func ratio(v float64) *float64 { return &v }
+
+func TestDifficultyUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want types.CatChaserDifficulty
+	}{
+		{"Legacy auto", `"auto"`, types.CatChaserDifficulty{Mode: types.CatChaserDifficultyAuto}},
+		{"Legacy count", `"8"`, types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 8}},
+		{"Structured", `{"mode":"fixed","miceCount":12}`, types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d types.CatChaserDifficulty
+			assert.NoError(t, json.Unmarshal([]byte(tt.json), &d))
+			assert.Equal(t, tt.want, d)
+		})
+	}
+
+	var d types.CatChaserDifficulty
+	assert.Error(t, json.Unmarshal([]byte(`"hard"`), &d))
+}
+
+func TestGenerateProblems_Defaults(t *testing.T) {
+	settings := types.CatChaserSettings{NumTrials: 12, Difficulty: types.CatChaserDifficulty{Mode: types.CatChaserDifficultyAuto}}
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(1)))
+	assert.NoError(t, err)
+	assert.Len(t, problems, 12)
+
+	// Auto difficulty ramps 4 -> 16 mice on the default 6x6 grid.
+	assert.Len(t, problems[0].MicePositions, 4)
+	assert.Len(t, problems[11].MicePositions, 16)
+
+	caught := 0
+	for _, p := range problems {
+		assert.Equal(t, 6, p.GridRows)
+		assert.Equal(t, 6, p.GridCols)
+		assert.Len(t, p.Targets, 2)
+		assert.Equal(t, "RED", p.Targets[0].Color)
+		assert.Equal(t, p.Targets[0].CatIndex, p.RedCatIndex)
+		assert.Equal(t, p.Targets[1].CatIndex, p.BlueCatIndex)
+		for _, target := range p.Targets {
+			if target.Status == types.StatusCaught {
+				caught++
+			}
+		}
+	}
+	assert.Equal(t, 12, caught, "Half of the 24 targets should be CAUGHT by default")
+}
+
+func TestGenerateProblems_ConfiguredGridAndRatio(t *testing.T) {
+	settings := types.CatChaserSettings{
+		NumTrials:   20,
+		Difficulty:  types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 5},
+		GridRows:    4,
+		GridCols:    5,
+		NumTargets:  3,
+		CaughtRatio: ratio(0.25),
+	}
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(2)))
+	assert.NoError(t, err)
+
+	caught := 0
+	for _, p := range problems {
+		assert.Len(t, p.MicePositions, 5)
+		assert.Len(t, p.CatPositions, 5)
+		assert.Len(t, p.Targets, 3)
+
+		mice := make(map[int]bool)
+		for _, m := range p.MicePositions {
+			assert.True(t, m >= 0 && m < 20)
+			mice[m] = true
+		}
+		cats := make(map[int]bool)
+		for _, c := range p.CatPositions {
+			assert.True(t, c >= 0 && c < 20)
+			assert.False(t, cats[c], "Cats should not share a cell")
+			cats[c] = true
+		}
+
+		seen := make(map[int]bool)
+		for _, target := range p.Targets {
+			assert.False(t, seen[target.CatIndex], "Targets should be different cats")
+			seen[target.CatIndex] = true
+
+			onMouse := mice[p.CatPositions[target.CatIndex]]
+			assert.Equal(t, onMouse, target.Status == types.StatusCaught, "Status should match the board")
+			if onMouse {
+				caught++
+			}
+		}
+	}
+	assert.Equal(t, 15, caught, "A quarter of the 60 targets should be CAUGHT")
+}
+
+func TestGenerateProblems_InvalidSettings(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	_, err := generateProblems(types.CatChaserSettings{NumTrials: 1, Difficulty: types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 35}}, rng)
+	assert.Error(t, err, "35 mice leave no empty cell for a MISSED target")
+
+	_, err = generateProblems(types.CatChaserSettings{NumTrials: 1, NumTargets: 3, Difficulty: types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 2}}, rng)
+	assert.Error(t, err, "Three targets need at least three cats")
+
+	_, err = generateProblems(types.CatChaserSettings{NumTrials: 1, CaughtRatio: ratio(1.5)}, rng)
+	assert.Error(t, err)
+
+	_, err = generateProblems(types.CatChaserSettings{NumTrials: 1, Difficulty: types.CatChaserDifficulty{Mode: "hard"}}, rng)
+	assert.Error(t, err)
+}
+
+func TestService_SubmitAnswer(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err := service.SubmitAnswer(1, 1, "RED", "CAUGHT", 3, 500)
+	assert.Error(t, err, "Submitting before the game starts should fail")
+
+	state, err := service.StartGame(types.CatChaserSettings{NumTrials: 2, NumTargets: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, state.Settings.NumTargets)
+	assert.NotNil(t, state.Settings.CaughtRatio, "Defaults should be stored with the session")
+
+	green := state.Problems[0].Targets[2]
+	result, err := service.SubmitAnswer(state.ID, 1, "GREEN", string(green.Status), 4, 500)
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.Equal(t, 2.0, result.Score)
+
+	_, err = service.SubmitAnswer(state.ID, 1, "PURPLE", "CAUGHT", 4, 500)
+	assert.Error(t, err, "There is no fourth target")
+}
+
+func TestBuildFrames(t *testing.T) {
+	settings := types.CatChaserSettings{
+		NumTrials:       10,
+		Difficulty:      types.CatChaserDifficulty{Mode: types.CatChaserDifficultyFixed, MiceCount: 10},
+		MouseSteps:      3,
+		CatSteps:        3,
+		DistractorMoves: 2,
+		FrameMs:         400,
+	}
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(4)))
+	assert.NoError(t, err)
+
+	for _, p := range problems {
+		assert.Len(t, p.Frames, 6)
+
+		var mice []int
+		for i, f := range p.Frames {
+			assert.Equal(t, i*400, f.StartMs)
+			assert.Equal(t, 400, f.DurationMs)
+			if i < 3 {
+				assert.Empty(t, f.CatPositions, "Mice frames come first")
+				mice = append(mice, f.MicePositions...)
+			} else {
+				assert.Empty(t, f.MicePositions)
+				assert.Len(t, f.CatPositions, 10)
+			}
+		}
+		assert.ElementsMatch(t, p.MicePositions, mice, "The mice frames together show every mouse once")
+		assert.Equal(t, p.CatPositions, p.Frames[5].CatPositions, "The final frame holds the answer positions")
+
+		for k := 3; k < 5; k++ {
+			moved := 0
+			for idx := range p.CatPositions {
+				if p.Frames[k].CatPositions[idx] != p.Frames[k+1].CatPositions[idx] {
+					moved++
+				}
+			}
+			assert.LessOrEqual(t, moved, 2)
+			for _, target := range p.Targets {
+				assert.Equal(t, p.Frames[k].CatPositions[target.CatIndex], p.Frames[k+1].CatPositions[target.CatIndex], "Targets stay put")
+			}
+		}
+
+		for _, target := range p.Targets {
+			status, ok := targetStatus(p, target.Color)
+			assert.True(t, ok)
+			assert.Equal(t, target.Status, status)
+		}
+	}
+}
+
+func TestService_SubmitAnswer_StoresFrameSchedule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewService(db)
+	state, err := service.StartGame(types.CatChaserSettings{NumTrials: 1, ShowTime: 1.5, CatSteps: 2, DistractorMoves: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, state.Settings.FrameMs, "Frame duration defaults to the show time")
+
+	_, err = service.SubmitAnswer(state.ID, 1, "RED", "CAUGHT", 2, 700)
+	assert.NoError(t, err)
+
+	var scheduleJSON string
+	assert.NoError(t, db.QueryRow("SELECT frame_schedule FROM cat_chaser_results WHERE session_id = ?", state.ID).Scan(&scheduleJSON))
+	var frames []types.CatChaserFrame
+	assert.NoError(t, json.Unmarshal([]byte(scheduleJSON), &frames))
+	assert.Equal(t, state.Problems[0].Frames, frames)
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"acca-games/database"
+	"acca-games/games/session"
 	"acca-games/types"
 )
 
@@ -18,8 +20,8 @@ var wordListJSON []byte
 
 // Service for the Count Comparison game.
 type Service struct {
-	db          *sql.DB
-	currentGame *Game
+	db    *sql.DB
+	games *session.Manager[Game]
 }
 
 // Game holds the state of a single Count Comparison game.
@@ -27,64 +29,232 @@ type Game struct {
 	Settings       types.CountComparisonSettings
 	Problems       []types.CountComparisonProblem
 	StartTime      time.Time
-	WordPairs      [][]string
+	WordPairs      []types.WordPair
+	WordList       string // Name of the word list the pairs came from
+	wordListID     int64
 	rng            *rand.Rand
 	currentProblem int
 	SessionID      int64
 }
 
+// savedGame is the part of a game stored so that its session can be resumed.
+type savedGame struct {
+	Settings   types.CountComparisonSettings  `json:"settings"`
+	Problems   []types.CountComparisonProblem `json:"problems"`
+	WordList   string                         `json:"wordList"`
+	WordListID int64                          `json:"wordListId"`
+}
+
 const (
 	minCount = 5
 	maxCount = 30 // Changed from 40 to 30 as per user request
+
+	// ratioTolerance is the allowed relative error between a target ratio and the
+	// ratio that integer counts can actually produce.
+	ratioTolerance = 0.03
 )
 
+// defaultRatioLevels is the ratio schedule used in "ratio" mode when none is configured.
+var defaultRatioLevels = []float64{1.5, 1.33, 1.25, 1.2, 1.15, 1.1}
+
 // NewService creates a new Count Comparison game service.
 func NewService(db *sql.DB) *Service {
-	return &Service{db: db}
+	return &Service{db: db, games: session.NewManager[Game](session.DefaultIdleTimeout)}
 }
 
 // StartGame initializes a new Count Comparison game session.
 func (s *Service) StartGame(settings types.CountComparisonSettings) (int64, error) {
-	game, err := NewGame(settings)
+	if settings.GenerationMode == types.CountGenerationRatio {
+		if err := validateRatioLevels(settings.RatioLevels); err != nil {
+			return 0, fmt.Errorf("invalid settings: %w", err)
+		}
+	}
+
+	list, err := s.loadWordList(settings.WordListID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start count comparison game: %w", err)
+	}
+
+	game, err := NewGameWithWordList(settings, list)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start count comparison game: %w", err)
 	}
 
-	sessionID, err := database.CreateGameSession(s.db, types.GameCodeCountComparison, settings)
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeCountComparison, settings, len(game.Problems))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create game session for count comparison: %w", err)
 	}
 	game.SessionID = sessionID
-	s.currentGame = game
+	saved := savedGame{
+		Settings:   game.Settings,
+		Problems:   game.Problems,
+		WordList:   game.WordList,
+		WordListID: game.wordListID,
+	}
+	if err := database.SaveSessionState(s.db, sessionID, saved, 1); err != nil {
+		return 0, fmt.Errorf("failed to save game state for count comparison: %w", err)
+	}
+	s.games.Add(sessionID, game)
 
 	return sessionID, nil
 }
 
-// NextProblem returns the next problem in the game.
-func (s *Service) NextProblem() *types.CountComparisonProblem {
-	if s.currentGame == nil {
+// ResumeSession restores a session left in progress from its saved state and returns
+// its next unanswered problem, or nil when every problem is answered.
+func (s *Service) ResumeSession(sessionID int64) (*types.CountComparisonProblem, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, &Game{
+		Settings:       saved.Settings,
+		Problems:       saved.Problems,
+		StartTime:      time.Now(),
+		WordList:       saved.WordList,
+		wordListID:     saved.WordListID,
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		currentProblem: next - 1,
+		SessionID:      sessionID,
+	})
+	problem, _ := s.NextProblem(sessionID)
+	return problem, nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// loadWordList returns the built-in list for ID 0 and a stored list otherwise.
+func (s *Service) loadWordList(id int64) (types.WordList, error) {
+	if id == types.BuiltinWordListID {
+		return BuiltinWordList()
+	}
+	list, err := database.GetWordList(s.db, id)
+	if err != nil {
+		return types.WordList{}, fmt.Errorf("failed to load word list %d: %w", id, err)
+	}
+	return *list, nil
+}
+
+// ImportWordList parses, validates and stores a new word list.
+func (s *Service) ImportWordList(name string, language string, format string, content string) (*types.WordList, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("word list name is required")
+	}
+	if name == types.BuiltinWordListName {
+		return nil, fmt.Errorf("word list name %q is reserved", name)
+	}
+
+	pairs, err := ParseWordList(format, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateWordPairs(pairs); err != nil {
+		return nil, err
+	}
+
+	list := types.WordList{
+		Name:     name,
+		Language: strings.TrimSpace(language),
+		Pairs:    pairs,
+	}
+	id, err := database.SaveWordList(s.db, list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save word list: %w", err)
+	}
+	return database.GetWordList(s.db, id)
+}
+
+// GetWordLists returns the built-in list followed by all stored lists, optionally
+// limited to one language.
+func (s *Service) GetWordLists(language string) ([]types.WordListSummary, error) {
+	var summaries []types.WordListSummary
+
+	builtin, err := BuiltinWordList()
+	if err != nil {
+		return nil, err
+	}
+	if language == "" || language == builtin.Language {
+		summaries = append(summaries, types.WordListSummary{
+			ID:         builtin.ID,
+			Name:       builtin.Name,
+			Language:   builtin.Language,
+			PairCount:  len(builtin.Pairs),
+			Categories: []string{},
+		})
+	}
+
+	stored, err := database.GetWordLists(s.db, language)
+	if err != nil {
+		return nil, err
+	}
+	return append(summaries, stored...), nil
+}
+
+// DeleteWordList removes a stored word list. The built-in list cannot be deleted.
+func (s *Service) DeleteWordList(id int64) error {
+	if id == types.BuiltinWordListID {
+		return fmt.Errorf("the built-in word list cannot be deleted")
+	}
+	return database.DeleteWordList(s.db, id)
+}
+
+// NextProblem returns the next problem of a session, or nil once the game is over or
+// when the session is not in play. over reports that the session has run out of problems.
+func (s *Service) NextProblem(sessionID int64) (problem *types.CountComparisonProblem, over bool) {
+	err := s.games.With(sessionID, func(g *Game) error {
+		problem = g.NextProblem()
+		if problem == nil {
+			// Game is over
+			g.EndGame()
+		}
 		return nil
+	})
+	if err != nil {
+		return nil, false
 	}
-	problem := s.currentGame.NextProblem()
 	if problem == nil {
-		// Game is over
-		s.currentGame.EndGame()
-		s.currentGame = nil
+		s.games.Remove(sessionID)
+		return nil, true
 	}
-	return problem
+	return problem, false
 }
 
 // SubmitAnswer handles the player's submission and saves the result.
-func (s *Service) SubmitAnswer(submission types.CountComparisonSubmission) error {
-	if s.currentGame == nil {
-		return fmt.Errorf("game not started")
-	}
+func (s *Service) SubmitAnswer(sessionID int64, submission types.CountComparisonSubmission) error {
+	return s.games.With(sessionID, func(g *Game) error {
+		return g.submitAnswer(s.db, submission)
+	})
+}
 
-	if submission.ProblemNumber <= 0 || submission.ProblemNumber > len(s.currentGame.Problems) {
+func (g *Game) submitAnswer(db *sql.DB, submission types.CountComparisonSubmission) error {
+	if submission.ProblemNumber <= 0 || submission.ProblemNumber > len(g.Problems) {
 		return fmt.Errorf("invalid problem number in submission: %d", submission.ProblemNumber)
 	}
 
-	problem := s.currentGame.Problems[submission.ProblemNumber-1]
+	problem := g.Problems[submission.ProblemNumber-1]
 	isCorrect := submission.PlayerChoice == problem.CorrectSide
 
 	appliedTrapsJSON, err := json.Marshal(problem.AppliedTraps)
@@ -92,11 +262,21 @@ func (s *Service) SubmitAnswer(submission types.CountComparisonSubmission) error
 		return fmt.Errorf("failed to marshal applied traps: %w", err)
 	}
 
+	layoutJSON, err := json.Marshal(types.CountComparisonLayout{
+		CanvasWidth:  problem.CanvasWidth,
+		CanvasHeight: problem.CanvasHeight,
+		LeftWords:    problem.LeftWords,
+		RightWords:   problem.RightWords,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal layout: %w", err)
+	}
+
 	leftWordCount := countWords(problem.LeftWords)
 	rightWordCount := countWords(problem.RightWords)
 
 	result := types.CountComparisonResult{
-		SessionID:      s.currentGame.SessionID,
+		SessionID:      g.SessionID,
 		ProblemNumber:  submission.ProblemNumber,
 		IsCorrect:      isCorrect,
 		ResponseTimeMs: submission.ResponseTimeMs,
@@ -107,11 +287,18 @@ func (s *Service) SubmitAnswer(submission types.CountComparisonSubmission) error
 		LeftWordCount:  leftWordCount,
 		RightWordCount: rightWordCount,
 		AppliedTraps:   string(appliedTrapsJSON),
+		Ratio:          problem.Ratio,
+		WordListID:     g.wordListID,
+		WordListName:   g.WordList,
+		Layout:         string(layoutJSON),
 	}
 
-	if err := database.SaveCountComparisonResult(s.db, result); err != nil {
+	if err := database.SaveCountComparisonResult(db, result); err != nil {
 		return fmt.Errorf("failed to save count comparison result: %w", err)
 	}
+	if err := database.SetSessionProgress(db, result.SessionID, submission.ProblemNumber+1); err != nil {
+		return fmt.Errorf("failed to save count comparison progress: %w", err)
+	}
 
 	return nil
 }
@@ -126,19 +313,38 @@ func countWords(details []types.WordDetail) int {
 	return count
 }
 
-// NewGame creates a new Count Comparison game instance.
+// NewGame creates a new Count Comparison game instance using the built-in word list.
 func NewGame(settings types.CountComparisonSettings) (*Game, error) {
+	list, err := BuiltinWordList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load words: %w", err)
+	}
+	return NewGameWithWordList(settings, list)
+}
+
+// NewGameWithWordList creates a new Count Comparison game instance using the given word list.
+// Only pairs from settings.Categories are used when categories are set, and pairs with a
+// word too long to lay out are skipped.
+func NewGameWithWordList(settings types.CountComparisonSettings, list types.WordList) (*Game, error) {
+	pairs := filterByCategory(list.Pairs, settings.Categories)
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("word list %q has no pairs in categories %v", list.Name, settings.Categories)
+	}
+	pairs = filterFitting(pairs)
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("word list %q has no pairs short enough to lay out", list.Name)
+	}
+
 	game := &Game{
 		Settings:       settings,
 		StartTime:      time.Now(),
+		WordPairs:      append([]types.WordPair(nil), pairs...),
+		WordList:       list.Name,
+		wordListID:     list.ID,
 		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
 		currentProblem: 0,
 	}
 
-	if err := game.loadWords(); err != nil {
-		return nil, fmt.Errorf("failed to load words: %w", err)
-	}
-
 	if err := game.generateProblems(); err != nil {
 		return nil, fmt.Errorf("failed to generate problems: %w", err)
 	}
@@ -146,19 +352,6 @@ func NewGame(settings types.CountComparisonSettings) (*Game, error) {
 	return game, nil
 }
 
-// loadWords reads the word list from the JSON file.
-func (g *Game) loadWords() error {
-	var data struct {
-		Words [][]string `json:"words"`
-	}
-	if err := json.Unmarshal(wordListJSON, &data); err != nil {
-		return fmt.Errorf("failed to unmarshal word list: %w", err)
-	}
-
-	g.WordPairs = data.Words
-	return nil
-}
-
 // generateProblems creates the full set of problems for the game.
 func (g *Game) generateProblems() error {
 	g.rng.Shuffle(len(g.WordPairs), func(i, j int) {
@@ -168,26 +361,16 @@ func (g *Game) generateProblems() error {
 	problems := make([]types.CountComparisonProblem, g.Settings.NumProblems)
 	for i := 0; i < g.Settings.NumProblems; i++ {
 		wordPair := g.WordPairs[i%len(g.WordPairs)]
-		leftWord, rightWord := wordPair[0], wordPair[1]
+		leftWord, rightWord := wordPair.Left, wordPair.Right
 		if g.rng.Float32() > 0.5 {
 			leftWord, rightWord = rightWord, leftWord
 		}
 
-		// Determine counts using normal distribution
-		difficulty := float64(i) / float64(g.Settings.NumProblems) // 0.0 to ~1.0
-		mean := g.rng.Float64()*25 + 5                         // Mean count between 5 and 30 (adjusted for new maxCount)
-		stdDevFactor := 0.5 - (0.4 * difficulty)                // StdDev factor from 50% down to 10%
-		stdDev := mean * stdDevFactor
-
-		count1 := int(math.Round(g.rng.NormFloat64()*stdDev + mean))
-		count2 := int(math.Round(g.rng.NormFloat64()*stdDev + mean))
-
-		// Clamp counts and ensure they are not equal
-		count1 = clamp(count1, minCount, maxCount)
-		count2 = clamp(count2, minCount, maxCount)
-		if count1 == count2 {
-			count1++
-			count1 = clamp(count1, minCount, maxCount)
+		var count1, count2 int
+		if g.Settings.GenerationMode == types.CountGenerationRatio {
+			count1, count2 = g.ratioCounts(g.targetRatio(i))
+		} else {
+			count1, count2 = g.normalCounts(i)
 		}
 
 		leftCount, rightCount := count1, count2
@@ -230,6 +413,13 @@ func (g *Game) generateProblems() error {
 		leftWords := g.generateWordDetails(leftCount, leftWord, trapSide == "left" && useFontSizeTrap, trapSide == "left" && useFontWeightTrap, leftDensityParams.GapProbability)
 		rightWords := g.generateWordDetails(rightCount, rightWord, trapSide == "right" && useFontSizeTrap, trapSide == "right" && useFontWeightTrap, rightDensityParams.GapProbability)
 
+		if err := g.layoutWords(leftWords, leftDensityParams); err != nil {
+			return fmt.Errorf("failed to lay out left words of problem %d: %w", i+1, err)
+		}
+		if err := g.layoutWords(rightWords, rightDensityParams); err != nil {
+			return fmt.Errorf("failed to lay out right words of problem %d: %w", i+1, err)
+		}
+
 		problems[i] = types.CountComparisonProblem{
 			ProblemNumber: i + 1,
 			LeftWords:     leftWords,
@@ -241,12 +431,119 @@ func (g *Game) generateProblems() error {
 			InputTime:        g.Settings.InputTime,
 			CorrectSide:      correctSide,
 			AppliedTraps:     appliedTraps,
+			Ratio:            countRatio(leftCount, rightCount),
+			CanvasWidth:      canvasWidth,
+			CanvasHeight:     canvasHeight,
 		}
 	}
 	g.Problems = problems
 	return nil
 }
 
+// normalCounts draws both counts around a random mean. The spread shrinks as the
+// problem index grows, so later problems tend to be harder.
+func (g *Game) normalCounts(i int) (int, int) {
+	difficulty := float64(i) / float64(g.Settings.NumProblems) // 0.0 to ~1.0
+	mean := g.rng.Float64()*25 + 5                              // Mean count between 5 and 30 (adjusted for new maxCount)
+	stdDevFactor := 0.5 - (0.4 * difficulty)                    // StdDev factor from 50% down to 10%
+	stdDev := mean * stdDevFactor
+
+	count1 := int(math.Round(g.rng.NormFloat64()*stdDev + mean))
+	count2 := int(math.Round(g.rng.NormFloat64()*stdDev + mean))
+
+	// Clamp counts and ensure they are not equal
+	count1 = clamp(count1, minCount, maxCount)
+	count2 = clamp(count2, minCount, maxCount)
+	if count1 == count2 {
+		count1++
+		count1 = clamp(count1, minCount, maxCount)
+	}
+	return count1, count2
+}
+
+// targetRatio returns the ratio level for the i-th problem. Problems are spread
+// evenly across the configured levels in order, so the session gets harder as it goes.
+func (g *Game) targetRatio(i int) float64 {
+	levels := g.Settings.RatioLevels
+	if len(levels) == 0 {
+		levels = defaultRatioLevels
+	}
+	levelIdx := (i * len(levels)) / g.Settings.NumProblems
+	if levelIdx >= len(levels) {
+		levelIdx = len(levels) - 1
+	}
+	return levels[levelIdx]
+}
+
+// ratioPair is a smaller and a larger count.
+type ratioPair struct{ small, large int }
+
+// ratioCandidates returns the count pairs whose ratio is within ratioTolerance of the
+// target, and the pair closest to it, which may be outside the tolerance.
+func ratioCandidates(target float64) ([]ratioPair, ratioPair) {
+	var candidates []ratioPair
+	best := ratioPair{minCount, minCount + 1}
+	bestErr := math.Inf(1)
+
+	for small := minCount; small < maxCount; small++ {
+		large := int(math.Round(float64(small) * target))
+		if large <= small {
+			large = small + 1
+		}
+		if large > maxCount {
+			break
+		}
+		diff := math.Abs(float64(large)/float64(small) - target)
+		if diff <= ratioTolerance*target {
+			candidates = append(candidates, ratioPair{small, large})
+		}
+		if diff < bestErr {
+			best, bestErr = ratioPair{small, large}, diff
+		}
+	}
+	return candidates, best
+}
+
+// ratioCounts picks a smaller and a larger count whose ratio is as close as possible
+// to the target. Among the pairs within ratioTolerance of the target one is chosen
+// at random, and the larger count is placed on a random side.
+func (g *Game) ratioCounts(target float64) (int, int) {
+	candidates, chosen := ratioCandidates(target)
+	if len(candidates) > 0 {
+		chosen = candidates[g.rng.Intn(len(candidates))]
+	}
+	if g.rng.Float32() < 0.5 {
+		return chosen.large, chosen.small
+	}
+	return chosen.small, chosen.large
+}
+
+// validateRatioLevels checks that every ratio level is above 1 and can be shown with
+// counts between minCount and maxCount, so that no problem silently gets another ratio.
+func validateRatioLevels(levels []float64) error {
+	for _, level := range levels {
+		if level <= 1 {
+			return fmt.Errorf("ratio level %g must be greater than 1", level)
+		}
+		if candidates, _ := ratioCandidates(level); len(candidates) == 0 {
+			return fmt.Errorf("ratio level %g cannot be shown with %d to %d words a side", level, minCount, maxCount)
+		}
+	}
+	return nil
+}
+
+// countRatio returns the larger count divided by the smaller one.
+func countRatio(a, b int) float64 {
+	small, large := a, b
+	if small > large {
+		small, large = large, small
+	}
+	if small <= 0 {
+		return 0
+	}
+	return math.Round(float64(large)/float64(small)*1000) / 1000
+}
+
 // generateWordDetails creates the slice of WordDetail for one side of a problem.
 func (g *Game) generateWordDetails(count int, text string, hasFontSizeTrap, hasFontWeightTrap bool, gapProbability float64) []types.WordDetail {
 	details := make([]types.WordDetail, 0)
@@ -225,9 +225,12 @@ func TestService_StartGame(t *testing.T) {
 		t.Fatal("StartGame returned nil sessionID")
 	}
 
-	if len(service.currentGame.Problems) != settings.NumProblems {
-		t.Errorf("Expected %d problems, got %d", settings.NumProblems, len(service.currentGame.Problems))
-	}
+	service.games.With(sessionID, func(g *Game) error {
+		if len(g.Problems) != settings.NumProblems {
+			t.Errorf("Expected %d problems, got %d", settings.NumProblems, len(g.Problems))
+		}
+		return nil
+	})
 
 	// Verify that a session was created in the database
 	var gameCode string
@@ -252,7 +255,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 
 	// --- Test Case 1: Game not started ---
 	t.Run("Game not started", func(t *testing.T) {
-		err := service.SubmitAnswer(types.CountComparisonSubmission{PlayerChoice: "LEFT", ResponseTimeMs: 500, ProblemNumber: 0})
+		err := service.SubmitAnswer(1, types.CountComparisonSubmission{PlayerChoice: "LEFT", ResponseTimeMs: 500, ProblemNumber: 0})
 		if err == nil {
 			t.Errorf("Expected an error when submitting answer before game start, but got nil")
 		}
@@ -273,9 +276,12 @@ func TestService_SubmitAnswer(t *testing.T) {
 	// Manually set predictable problems for testing
 	// We need to ensure that problem.CorrectSide is set correctly for testing purposes
 	// Let's assume Problem 1 is "LEFT" and Problem 2 is "RIGHT"
-	service.currentGame.Problems[0].CorrectSide = "LEFT"
-	service.currentGame.Problems[1].CorrectSide = "RIGHT"
-	service.currentGame.Problems[2].CorrectSide = "LEFT"
+	service.games.With(sessionID, func(g *Game) error {
+		g.Problems[0].CorrectSide = "LEFT"
+		g.Problems[1].CorrectSide = "RIGHT"
+		g.Problems[2].CorrectSide = "LEFT"
+		return nil
+	})
 
 
 	tests := []struct {
@@ -312,7 +318,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 				PlayerChoice:   tt.playerChoice,
 				ResponseTimeMs: 500,
 			}
-			err := service.SubmitAnswer(submission)
+			err := service.SubmitAnswer(sessionID, submission)
 			if err != nil {
 				t.Fatalf("SubmitAnswer failed: %v", err)
 			}
@@ -340,9 +346,99 @@ func TestService_SubmitAnswer(t *testing.T) {
 			PlayerChoice:   "LEFT",
 			ResponseTimeMs: 500,
 		}
-		err := service.SubmitAnswer(submission)
+		err := service.SubmitAnswer(sessionID, submission)
 		if err == nil {
 			t.Error("Expected an error for invalid problem number, but got nil")
 		}
 	})
-}
\ No newline at end of file
+}
+func TestRatioMode(t *testing.T) {
+	settings := types.CountComparisonSettings{
+		NumProblems:    12,
+		GenerationMode: types.CountGenerationRatio,
+		RatioLevels:    []float64{1.5, 1.2, 1.1},
+	}
+	game, err := NewGame(settings)
+	assert.NoError(t, err)
+
+	for i, p := range game.Problems {
+		target := settings.RatioLevels[i*len(settings.RatioLevels)/settings.NumProblems]
+		left := countActualWords(p.LeftWords)
+		right := countActualWords(p.RightWords)
+
+		assert.NotEqual(t, left, right)
+		assert.True(t, left >= minCount && left <= maxCount)
+		assert.True(t, right >= minCount && right <= maxCount)
+		assert.Equal(t, countRatio(left, right), p.Ratio)
+		assert.InDelta(t, target, p.Ratio, target*ratioTolerance+0.001, "problem %d", p.ProblemNumber)
+	}
+}
+
+func TestCountRatio(t *testing.T) {
+	assert.Equal(t, 1.5, countRatio(10, 15))
+	assert.Equal(t, 1.5, countRatio(15, 10))
+	assert.Equal(t, 0.0, countRatio(0, 10))
+}
+
+func TestService_ResumeSession(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	settings := types.CountComparisonSettings{NumProblems: 3, PresentationTime: 1000, InputTime: 3000}
+	sessionID, err := NewService(db).StartGame(settings)
+	if err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	service := NewService(db)
+	problem, err := service.ResumeSession(sessionID)
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+	if problem == nil || problem.ProblemNumber != 1 {
+		t.Fatalf("Expected to resume at problem 1, got %+v", problem)
+	}
+	if err := service.SubmitAnswer(sessionID, types.CountComparisonSubmission{PlayerChoice: "left", ResponseTimeMs: 500, ProblemNumber: 1}); err != nil {
+		t.Fatalf("SubmitAnswer failed: %v", err)
+	}
+
+	restarted := NewService(db)
+	problem, err = restarted.ResumeSession(sessionID)
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+	if problem == nil || problem.ProblemNumber != 2 {
+		t.Fatalf("Expected to resume at problem 2, got %+v", problem)
+	}
+	if err := restarted.SubmitAnswer(sessionID, types.CountComparisonSubmission{PlayerChoice: "left", ResponseTimeMs: 500, ProblemNumber: 2}); err != nil {
+		t.Errorf("Expected answers to be accepted after resuming, got %v", err)
+	}
+
+	if problem, over := restarted.NextProblem(sessionID); problem == nil || over {
+		t.Fatalf("Expected problem 3, got %+v, %v", problem, over)
+	}
+	if problem, over := restarted.NextProblem(sessionID); problem != nil || !over {
+		t.Errorf("Expected the session to run out of problems, got %+v, %v", problem, over)
+	}
+	if problem, over := restarted.NextProblem(sessionID); problem != nil || over {
+		t.Errorf("Expected a session out of play not to count as run out, got %+v, %v", problem, over)
+	}
+}
+
+func TestService_StartGame_ValidatesRatioLevels(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	service := NewService(db)
+
+	assert.NoError(t, validateRatioLevels(defaultRatioLevels))
+	for _, levels := range [][]float64{{1.5, 1}, {0.8}, {-2}, {7}, {1.001}} {
+		_, err := service.StartGame(types.CountComparisonSettings{NumProblems: 4, GenerationMode: types.CountGenerationRatio, RatioLevels: levels})
+		assert.Error(t, err, "levels %v", levels)
+	}
+
+	// Levels only matter in ratio mode.
+	_, err := service.StartGame(types.CountComparisonSettings{NumProblems: 4, RatioLevels: []float64{0.5}})
+	assert.NoError(t, err)
+	_, err = service.StartGame(types.CountComparisonSettings{NumProblems: 4, GenerationMode: types.CountGenerationRatio, RatioLevels: []float64{2, 1.25}})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,151 @@
+package count_comparison
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"unicode"
+
+	"acca-games/types"
+)
+
+// The word cloud of each side is laid out on a fixed virtual canvas measured in rem,
+// so a problem looks the same on every screen. The frontend scales the canvas to fit.
+const (
+	canvasWidth  = 32.0
+	canvasHeight = 24.0
+
+	lineHeight        = 1.2  // Box height as a multiple of font size
+	wideGlyphWidth    = 1.0  // Hangul and other full-width letters, in em
+	narrowGlyphWidth  = 0.6  // Latin letters and digits, in em
+	boldWidthFactor   = 1.05 // Bold text runs slightly wider
+	minAreaMultiplier = 0.25 // Smallest fraction of the canvas a cloud may be squeezed into
+
+	// Words keep at least basePadding rem apart. A higher gap probability spreads them
+	// further, by up to gapPadding rem, so sparse-looking clouds stay sparse on screen.
+	basePadding = 0.2
+	gapPadding  = 0.6
+
+	placementAttempts = 300
+	scanStep          = 0.25
+
+	// Words are shown at up to largestWordSize rem. A word longer than maxWordWidth rem at
+	// that size, in bold, leaves too little room for a full cloud of it to be laid out.
+	largestWordSize = 1.5
+	maxWordWidth    = 8.5
+)
+
+// wordBox is the space a placed word takes on the canvas.
+type wordBox struct {
+	x, y, w, h float64
+}
+
+func (a wordBox) overlaps(b wordBox, padding float64) bool {
+	return a.x < b.x+b.w+padding && b.x < a.x+a.w+padding &&
+		a.y < b.y+b.h+padding && b.y < a.y+a.h+padding
+}
+
+// layoutWords assigns a canvas position to every word in details. Gaps are left
+// unplaced. Words are kept inside a centred region whose area is AreaMultiplier times
+// the canvas, and never overlap.
+func (g *Game) layoutWords(details []types.WordDetail, density types.DensityParams) error {
+	scale := math.Sqrt(math.Min(math.Max(density.AreaMultiplier, minAreaMultiplier), 1))
+	region := wordBox{w: canvasWidth * scale, h: canvasHeight * scale}
+	region.x = (canvasWidth - region.w) / 2
+	region.y = (canvasHeight - region.h) / 2
+	padding := basePadding + density.GapProbability*gapPadding
+
+	// Place the biggest words first; small ones fit into what is left.
+	var order []int
+	for i := range details {
+		if !details[i].IsGap {
+			details[i].Width = round2(textWidth(details[i].Text, details[i].Size, details[i].Weight))
+			details[i].Height = round2(details[i].Size * lineHeight)
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return details[order[a]].Width*details[order[a]].Height > details[order[b]].Width*details[order[b]].Height
+	})
+
+	placed := make([]wordBox, 0, len(order))
+	for _, i := range order {
+		box := wordBox{w: details[i].Width, h: details[i].Height}
+		fullCanvas := wordBox{w: canvasWidth, h: canvasHeight}
+
+		ok := g.placeRandomly(&box, region, placed, padding) ||
+			placeByScan(&box, region, placed, padding) ||
+			placeByScan(&box, fullCanvas, placed, basePadding)
+		if !ok {
+			return fmt.Errorf("no room on the canvas for word %d of %d", len(placed)+1, len(order))
+		}
+
+		details[i].X = box.x
+		details[i].Y = box.y
+		placed = append(placed, box)
+	}
+	return nil
+}
+
+// placeRandomly tries random spots inside the region.
+func (g *Game) placeRandomly(box *wordBox, region wordBox, placed []wordBox, padding float64) bool {
+	if box.w > region.w || box.h > region.h {
+		return false
+	}
+	for attempt := 0; attempt < placementAttempts; attempt++ {
+		box.x = round2(region.x + g.rng.Float64()*(region.w-box.w))
+		box.y = round2(region.y + g.rng.Float64()*(region.h-box.h))
+		if fits(*box, placed, padding) {
+			return true
+		}
+	}
+	return false
+}
+
+// placeByScan walks the region row by row and takes the first free spot.
+func placeByScan(box *wordBox, region wordBox, placed []wordBox, padding float64) bool {
+	for y := region.y; y+box.h <= region.y+region.h; y += scanStep {
+		for x := region.x; x+box.w <= region.x+region.w; x += scanStep {
+			box.x, box.y = round2(x), round2(y)
+			if fits(*box, placed, padding) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fits(box wordBox, placed []wordBox, padding float64) bool {
+	for _, other := range placed {
+		if box.overlaps(other, padding) {
+			return false
+		}
+	}
+	return true
+}
+
+// textWidth estimates the rendered width of text in rem.
+func textWidth(text string, size float64, weight int) float64 {
+	width := 0.0
+	for _, r := range text {
+		if unicode.Is(unicode.Hangul, r) || unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) {
+			width += wideGlyphWidth
+		} else {
+			width += narrowGlyphWidth
+		}
+	}
+	width *= size
+	if weight >= 700 {
+		width *= boldWidthFactor
+	}
+	return width
+}
+
+// wordFits reports whether a word is short enough for a cloud of maxCount copies of it.
+func wordFits(word string) bool {
+	return textWidth(word, largestWordSize, 700) <= maxWordWidth
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
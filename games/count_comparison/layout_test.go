@@ -0,0 +1,114 @@
+package count_comparison
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const layoutEpsilon = 0.01
+
+// placedBoxes returns the boxes of all words in details.
+func placedBoxes(details []types.WordDetail) []wordBox {
+	var boxes []wordBox
+	for _, d := range details {
+		if !d.IsGap {
+			boxes = append(boxes, wordBox{x: d.X, y: d.Y, w: d.Width, h: d.Height})
+		}
+	}
+	return boxes
+}
+
+func assertValidLayout(t *testing.T, details []types.WordDetail) {
+	t.Helper()
+	boxes := placedBoxes(details)
+	for i, a := range boxes {
+		assert.True(t, a.x >= -layoutEpsilon && a.x+a.w <= canvasWidth+layoutEpsilon, "word %d should be inside the canvas horizontally", i)
+		assert.True(t, a.y >= -layoutEpsilon && a.y+a.h <= canvasHeight+layoutEpsilon, "word %d should be inside the canvas vertically", i)
+		assert.True(t, a.w > 0 && a.h > 0, "word %d should have a size", i)
+		for j := i + 1; j < len(boxes); j++ {
+			shrunk := wordBox{x: a.x + layoutEpsilon, y: a.y + layoutEpsilon, w: a.w - 2*layoutEpsilon, h: a.h - 2*layoutEpsilon}
+			assert.False(t, shrunk.overlaps(boxes[j], 0), "words %d and %d should not overlap", i, j)
+		}
+	}
+}
+
+func TestLayoutWords(t *testing.T) {
+	game := &Game{rng: rand.New(rand.NewSource(1))}
+
+	t.Run("Maximum count with traps", func(t *testing.T) {
+		details := game.generateWordDetails(maxCount, "건강하다", true, true, 0.8)
+		err := game.layoutWords(details, types.DensityParams{AreaMultiplier: 1.0, GapProbability: 0.8})
+		assert.NoError(t, err)
+		assertValidLayout(t, details)
+	})
+
+	t.Run("Long Latin words", func(t *testing.T) {
+		details := game.generateWordDetails(maxCount, "temperature", true, true, 0.4)
+		err := game.layoutWords(details, types.DensityParams{AreaMultiplier: 1.0, GapProbability: 0.4})
+		assert.NoError(t, err)
+		assertValidLayout(t, details)
+	})
+
+	t.Run("Longest word that fits", func(t *testing.T) {
+		assert.True(t, wordFits("가나다라마"))
+		assert.False(t, wordFits("가나다라마바"))
+		details := game.generateWordDetails(maxCount, "가나다라마", true, true, 0.8)
+		err := game.layoutWords(details, types.DensityParams{AreaMultiplier: minAreaMultiplier, GapProbability: 0.8})
+		assert.NoError(t, err)
+		assertValidLayout(t, details)
+	})
+
+	t.Run("Area multiplier shrinks the cloud", func(t *testing.T) {
+		details := game.generateWordDetails(minCount, "낮", false, false, 0.0)
+		err := game.layoutWords(details, types.DensityParams{AreaMultiplier: 0.25, GapProbability: 0.0})
+		assert.NoError(t, err)
+		assertValidLayout(t, details)
+
+		// A quarter of the area is half the width and height, centred.
+		for _, b := range placedBoxes(details) {
+			assert.True(t, b.x >= canvasWidth/4-layoutEpsilon && b.x+b.w <= canvasWidth*3/4+layoutEpsilon)
+			assert.True(t, b.y >= canvasHeight/4-layoutEpsilon && b.y+b.h <= canvasHeight*3/4+layoutEpsilon)
+		}
+	})
+
+	t.Run("Gaps are not placed", func(t *testing.T) {
+		details := game.generateWordDetails(minCount, "낮", false, false, 1.0)
+		assert.NoError(t, game.layoutWords(details, types.DensityParams{AreaMultiplier: 1.0, GapProbability: 1.0}))
+		for _, d := range details {
+			if d.IsGap {
+				assert.Zero(t, d.X)
+				assert.Zero(t, d.Width)
+			}
+		}
+	})
+
+	t.Run("Same seed gives the same layout", func(t *testing.T) {
+		a := &Game{rng: rand.New(rand.NewSource(42))}
+		b := &Game{rng: rand.New(rand.NewSource(42))}
+		da := a.generateWordDetails(20, "word", false, false, 0.4)
+		db := b.generateWordDetails(20, "word", false, false, 0.4)
+		assert.NoError(t, a.layoutWords(da, types.DensityParams{AreaMultiplier: 1.0, GapProbability: 0.4}))
+		assert.NoError(t, b.layoutWords(db, types.DensityParams{AreaMultiplier: 1.0, GapProbability: 0.4}))
+		assert.Equal(t, da, db)
+	})
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.InDelta(t, 3.0, textWidth("가깝다", 1.0, 400), 0.001)
+	assert.InDelta(t, 1.8, textWidth("hot", 1.0, 400), 0.001)
+	assert.InDelta(t, 1.8*1.5*boldWidthFactor, textWidth("hot", 1.5, 700), 0.001)
+}
+
+func TestNewGame_ProblemsAreLaidOut(t *testing.T) {
+	game, err := NewGame(types.CountComparisonSettings{NumProblems: 5})
+	assert.NoError(t, err)
+	for _, p := range game.Problems {
+		assert.Equal(t, canvasWidth, p.CanvasWidth)
+		assert.Equal(t, canvasHeight, p.CanvasHeight)
+		assertValidLayout(t, p.LeftWords)
+		assertValidLayout(t, p.RightWords)
+	}
+}
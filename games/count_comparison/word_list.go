@@ -0,0 +1,235 @@
+package count_comparison
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"acca-games/types"
+)
+
+const (
+	WordListFormatJSON = "json"
+	WordListFormatCSV  = "csv"
+
+	// minPairLengthRatio is the smallest allowed ratio between the shorter and the longer
+	// word of a pair. A much longer word takes up more space and gives its side away.
+	minPairLengthRatio = 0.5
+)
+
+var (
+	builtinOnce  sync.Once
+	builtinPairs []types.WordPair
+	builtinErr   error
+)
+
+// BuiltinWordList returns the embedded Korean word list. It is parsed only once.
+func BuiltinWordList() (types.WordList, error) {
+	builtinOnce.Do(func() {
+		builtinPairs, builtinErr = ParseWordList(WordListFormatJSON, string(wordListJSON))
+	})
+	if builtinErr != nil {
+		return types.WordList{}, fmt.Errorf("failed to load built-in word list: %w", builtinErr)
+	}
+
+	pairs := make([]types.WordPair, len(builtinPairs))
+	copy(pairs, builtinPairs)
+	return types.WordList{
+		ID:       types.BuiltinWordListID,
+		Name:     types.BuiltinWordListName,
+		Language: "ko",
+		Pairs:    pairs,
+	}, nil
+}
+
+// ParseWordList reads word pairs in the given format.
+//
+// JSON may be either the embedded layout, {"words": [["left", "right", "category"], ...]}
+// with an optional third element, or an array of {"left", "right", "category"} objects.
+// CSV has one pair per line as left,right[,category]; a leading "left,right" header is skipped.
+func ParseWordList(format string, content string) ([]types.WordPair, error) {
+	switch strings.ToLower(format) {
+	case WordListFormatJSON:
+		return parseWordListJSON(content)
+	case WordListFormatCSV:
+		return parseWordListCSV(content)
+	default:
+		return nil, fmt.Errorf("unsupported word list format: %s", format)
+	}
+}
+
+func parseWordListJSON(content string) ([]types.WordPair, error) {
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "[") {
+		var pairs []types.WordPair
+		if err := json.Unmarshal([]byte(trimmed), &pairs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal word pairs: %w", err)
+		}
+		for i := range pairs {
+			pairs[i] = normalizePair(pairs[i])
+		}
+		return pairs, nil
+	}
+
+	var data struct {
+		Words [][]string `json:"words"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal word list: %w", err)
+	}
+
+	pairs := make([]types.WordPair, 0, len(data.Words))
+	for i, words := range data.Words {
+		if len(words) < 2 || len(words) > 3 {
+			return nil, fmt.Errorf("entry %d: expected 2 or 3 values, got %d", i+1, len(words))
+		}
+		pair := types.WordPair{Left: words[0], Right: words[1]}
+		if len(words) == 3 {
+			pair.Category = words[2]
+		}
+		pairs = append(pairs, normalizePair(pair))
+	}
+	return pairs, nil
+}
+
+func parseWordListCSV(content string) ([]types.WordPair, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var pairs []types.WordPair
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		line++
+
+		if line == 1 && len(record) >= 2 && strings.EqualFold(strings.TrimSpace(record[0]), "left") {
+			continue // Header row
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("line %d: expected 2 or 3 columns, got %d", line, len(record))
+		}
+
+		pair := types.WordPair{Left: record[0], Right: record[1]}
+		if len(record) == 3 {
+			pair.Category = record[2]
+		}
+		pairs = append(pairs, normalizePair(pair))
+	}
+	return pairs, nil
+}
+
+func normalizePair(pair types.WordPair) types.WordPair {
+	return types.WordPair{
+		Left:     strings.TrimSpace(pair.Left),
+		Right:    strings.TrimSpace(pair.Right),
+		Category: strings.TrimSpace(pair.Category),
+	}
+}
+
+// WordListValidationError lists every problem found in an imported word list.
+type WordListValidationError struct {
+	Issues []string
+}
+
+func (e *WordListValidationError) Error() string {
+	return fmt.Sprintf("invalid word list: %s", strings.Join(e.Issues, "; "))
+}
+
+// ValidateWordPairs checks that a list is usable for the game: no empty words, no word
+// too long to lay out, no pair that repeats (in either order), both words of a pair
+// differ, and neither word is more than twice as long as the other.
+func ValidateWordPairs(pairs []types.WordPair) error {
+	var issues []string
+	if len(pairs) == 0 {
+		issues = append(issues, "the list has no word pairs")
+	}
+
+	seen := make(map[string]int)
+	for i, pair := range pairs {
+		n := i + 1
+		if pair.Left == "" || pair.Right == "" {
+			issues = append(issues, fmt.Sprintf("pair %d: empty word", n))
+			continue
+		}
+		if pair.Left == pair.Right {
+			issues = append(issues, fmt.Sprintf("pair %d: both words are %q", n, pair.Left))
+		}
+		for _, word := range []string{pair.Left, pair.Right} {
+			if !wordFits(word) {
+				issues = append(issues, fmt.Sprintf("pair %d: %q is too long to lay out", n, word))
+			}
+		}
+
+		key := pairKey(pair)
+		if first, ok := seen[key]; ok {
+			issues = append(issues, fmt.Sprintf("pair %d: duplicate of pair %d (%s/%s)", n, first, pair.Left, pair.Right))
+		} else {
+			seen[key] = n
+		}
+
+		shorter, longer := utf8.RuneCountInString(pair.Left), utf8.RuneCountInString(pair.Right)
+		if shorter > longer {
+			shorter, longer = longer, shorter
+		}
+		if float64(shorter)/float64(longer) < minPairLengthRatio {
+			issues = append(issues, fmt.Sprintf("pair %d: %q and %q differ too much in length", n, pair.Left, pair.Right))
+		}
+	}
+
+	if len(issues) > 0 {
+		return &WordListValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// pairKey identifies a pair regardless of word order.
+func pairKey(pair types.WordPair) string {
+	a, b := strings.ToLower(pair.Left), strings.ToLower(pair.Right)
+	if a > b {
+		a, b = b, a
+	}
+	return a + "\x00" + b
+}
+
+// filterByCategory keeps the pairs whose category is one of the given ones.
+// An empty category list keeps everything.
+func filterByCategory(pairs []types.WordPair, categories []string) []types.WordPair {
+	if len(categories) == 0 {
+		return pairs
+	}
+	wanted := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		wanted[c] = true
+	}
+
+	var filtered []types.WordPair
+	for _, pair := range pairs {
+		if wanted[pair.Category] {
+			filtered = append(filtered, pair)
+		}
+	}
+	return filtered
+}
+
+// filterFitting keeps the pairs whose words both fit on the canvas. Lists imported before
+// long words were rejected may still hold some.
+func filterFitting(pairs []types.WordPair) []types.WordPair {
+	var filtered []types.WordPair
+	for _, pair := range pairs {
+		if wordFits(pair.Left) && wordFits(pair.Right) {
+			filtered = append(filtered, pair)
+		}
+	}
+	return filtered
+}
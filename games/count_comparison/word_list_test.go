@@ -0,0 +1,171 @@
+package count_comparison
+
+import (
+	"encoding/json"
+	"testing"
+
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinWordList(t *testing.T) {
+	list, err := BuiltinWordList()
+	assert.NoError(t, err)
+	assert.Equal(t, types.BuiltinWordListID, list.ID)
+	assert.NotEmpty(t, list.Pairs)
+
+	// Callers get their own copy.
+	list.Pairs[0].Left = "changed"
+	again, _ := BuiltinWordList()
+	assert.NotEqual(t, "changed", again.Pairs[0].Left)
+}
+
+func TestParseWordList(t *testing.T) {
+	t.Run("JSON words layout", func(t *testing.T) {
+		pairs, err := ParseWordList("json", `{"words": [["hot", "cold"], ["up", "down", "direction"]]}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WordPair{
+			{Left: "hot", Right: "cold"},
+			{Left: "up", Right: "down", Category: "direction"},
+		}, pairs)
+	})
+
+	t.Run("JSON object array", func(t *testing.T) {
+		pairs, err := ParseWordList("JSON", `[{"left": " 낮 ", "right": "밤", "category": "시간"}]`)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WordPair{{Left: "낮", Right: "밤", Category: "시간"}}, pairs)
+	})
+
+	t.Run("CSV with header", func(t *testing.T) {
+		pairs, err := ParseWordList("csv", "left,right,category\nhot,cold,temperature\nup, down\n")
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WordPair{
+			{Left: "hot", Right: "cold", Category: "temperature"},
+			{Left: "up", Right: "down"},
+		}, pairs)
+	})
+
+	t.Run("CSV with wrong column count", func(t *testing.T) {
+		_, err := ParseWordList("csv", "hot\n")
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		_, err := ParseWordList("xml", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateWordPairs(t *testing.T) {
+	assert.NoError(t, ValidateWordPairs([]types.WordPair{{Left: "hot", Right: "cold"}, {Left: "up", Right: "down"}}))
+
+	err := ValidateWordPairs([]types.WordPair{
+		{Left: "hot", Right: "cold"},
+		{Left: "Cold", Right: "hot"}, // Duplicate in reverse order
+		{Left: "same", Right: "same"},
+		{Left: "up", Right: "downward"}, // Lengths too different
+		{Left: "", Right: "x"},
+		{Left: "가나다라마바", Right: "가나다라마"}, // Left is too long to lay out
+	})
+	var validationErr *WordListValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Issues, 5)
+
+	assert.Error(t, ValidateWordPairs(nil))
+}
+
+func TestNewGameWithWordList_Categories(t *testing.T) {
+	list := types.WordList{
+		ID:   7,
+		Name: "colors",
+		Pairs: []types.WordPair{
+			{Left: "red", Right: "blue", Category: "color"},
+			{Left: "hot", Right: "cold", Category: "temperature"},
+		},
+	}
+
+	game, err := NewGameWithWordList(types.CountComparisonSettings{NumProblems: 4, Categories: []string{"color"}}, list)
+	assert.NoError(t, err)
+	for _, p := range game.Problems {
+		assert.ElementsMatch(t, []string{"red", "blue"}, []string{p.LeftWordText, p.RightWordText})
+	}
+
+	_, err = NewGameWithWordList(types.CountComparisonSettings{NumProblems: 4, Categories: []string{"missing"}}, list)
+	assert.Error(t, err)
+}
+
+func TestNewGameWithWordList_SkipsWordsTooLong(t *testing.T) {
+	list := types.WordList{
+		Name: "long",
+		Pairs: []types.WordPair{
+			{Left: "낮", Right: "밤"},
+			{Left: "extraordinary", Right: "unremarkable"},
+		},
+	}
+
+	game, err := NewGameWithWordList(types.CountComparisonSettings{NumProblems: 10}, list)
+	assert.NoError(t, err)
+	for _, p := range game.Problems {
+		assert.ElementsMatch(t, []string{"낮", "밤"}, []string{p.LeftWordText, p.RightWordText})
+	}
+
+	list.Pairs = list.Pairs[1:]
+	_, err = NewGameWithWordList(types.CountComparisonSettings{NumProblems: 10}, list)
+	assert.Error(t, err)
+}
+
+func TestService_WordLists(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewService(db)
+
+	_, err := service.ImportWordList("bad", "en", "csv", "hot,cold\nhot,cold\n")
+	assert.Error(t, err, "Duplicate pairs should be rejected")
+
+	list, err := service.ImportWordList("english", "en", "csv", "hot,cold,temperature\nup,down,direction\n")
+	assert.NoError(t, err)
+	assert.Len(t, list.Pairs, 2)
+
+	assert.NoError(t, err)
+	if !assert.NotNil(t, list) {
+		return
+	}
+	assert.Len(t, list.Pairs, 2)
+
+	lists, err := service.GetWordLists("en")
+	assert.NoError(t, err)
+	assert.Len(t, lists, 1)
+	assert.Equal(t, []string{"direction", "temperature"}, lists[0].Categories)
+
+	lists, err = service.GetWordLists("")
+	assert.NoError(t, err)
+	assert.Len(t, lists, 2, "Built-in list plus the imported one")
+
+	sessionID, err := service.StartGame(types.CountComparisonSettings{NumProblems: 2, WordListID: list.ID})
+	assert.NoError(t, err)
+	assert.NoError(t, service.SubmitAnswer(sessionID, types.CountComparisonSubmission{ProblemNumber: 1, PlayerChoice: "left", ResponseTimeMs: 500}))
+
+	var listID int64
+	var listName string
+	err = db.QueryRow("SELECT word_list_id, word_list_name FROM count_comparison_results WHERE session_id = ?", sessionID).Scan(&listID, &listName)
+	assert.NoError(t, err)
+	assert.Equal(t, list.ID, listID)
+	assert.Equal(t, "english", listName)
+
+	var layoutJSON string
+	err = db.QueryRow("SELECT layout FROM count_comparison_results WHERE session_id = ?", sessionID).Scan(&layoutJSON)
+	assert.NoError(t, err)
+	var layout types.CountComparisonLayout
+	assert.NoError(t, json.Unmarshal([]byte(layoutJSON), &layout))
+	assert.Equal(t, canvasWidth, layout.CanvasWidth)
+	service.games.With(sessionID, func(g *Game) error {
+		assert.Equal(t, g.Problems[0].LeftWords, layout.LeftWords)
+		return nil
+	})
+
+	assert.Error(t, service.DeleteWordList(types.BuiltinWordListID))
+	assert.NoError(t, service.DeleteWordList(list.ID))
+	_, err = service.StartGame(types.CountComparisonSettings{NumProblems: 2, WordListID: list.ID})
+	assert.Error(t, err)
+}
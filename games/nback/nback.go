@@ -2,6 +2,7 @@ package nback
 
 import (
 	"acca-games/database"
+	"acca-games/games/session"
 	"acca-games/types"
 	"database/sql"
 	"fmt"
@@ -13,44 +14,92 @@ type NBackGameState struct {
 	Settings      types.NBackSettings `json:"settings"`
 	ShapeSequence []string            `json:"shapeSequence"`
 	ID            int64               `json:"id"`
+	NextQuestion  int                 `json:"nextQuestion"` // Number of the next unanswered trial
 }
 
 // Service for the N-Back game.
 type Service struct {
-	db           *sql.DB
-	currentState *NBackGameState
+	db    *sql.DB
+	games *session.Manager[NBackGameState]
 }
 
 // NewService creates a new N-Back game service.
 func NewService(db *sql.DB) *Service {
-	return &Service{db: db}
+	return &Service{db: db, games: session.NewManager[NBackGameState](session.DefaultIdleTimeout)}
 }
 
 // StartGame initializes a new N-Back game session.
 func (s *Service) StartGame(settings types.NBackSettings) (*NBackGameState, error) {
 	shapeSequence := generateShapeSequence(settings.NumTrials, settings.ShapeGroup, settings.NBackLevel)
 
-	sessionID, err := database.CreateGameSession(s.db, types.GameCodeNBack, settings)
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeNBack, settings, settings.NumTrials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create game session: %w", err)
 	}
 
-	s.currentState = &NBackGameState{
+	state := &NBackGameState{
 		Settings:      settings,
 		ShapeSequence: shapeSequence,
 		ID:            sessionID,
+		NextQuestion:  1,
 	}
+	if err := database.SaveSessionState(s.db, sessionID, state, 1); err != nil {
+		return nil, fmt.Errorf("failed to save game state: %w", err)
+	}
+	s.games.Add(sessionID, state)
 
-	return s.currentState, nil
+	return state, nil
 }
 
-// SubmitAnswer processes a user's answer for a single trial.
-func (s *Service) SubmitAnswer(playerChoice string, responseTimeMs int, questionNum int) (*types.NBackResult, error) {
-	if s.currentState == nil {
-		return nil, fmt.Errorf("game not started")
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the next unanswered trial.
+func (s *Service) ResumeSession(sessionID int64) (*NBackGameState, error) {
+	var state NBackGameState
+	next, err := database.LoadSessionState(s.db, sessionID, &state)
+	if err != nil {
+		return nil, err
 	}
+	state.NextQuestion = next
+	s.games.Add(sessionID, &state)
+	return &state, nil
+}
 
-	gs := s.currentState
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitAnswer processes a user's answer for a single trial.
+func (s *Service) SubmitAnswer(sessionID int64, playerChoice string, responseTimeMs int, questionNum int) (*types.NBackResult, error) {
+	var result *types.NBackResult
+	err := s.games.With(sessionID, func(gs *NBackGameState) error {
+		var err error
+		result, err = gs.submitAnswer(s.db, playerChoice, responseTimeMs, questionNum)
+		return err
+	})
+	return result, err
+}
+
+func (gs *NBackGameState) submitAnswer(db *sql.DB, playerChoice string, responseTimeMs int, questionNum int) (*types.NBackResult, error) {
 	correctChoice := determineCorrectChoice(gs.ShapeSequence, questionNum, gs.Settings.NBackLevel)
 	isCorrect := playerChoice == correctChoice
 
@@ -64,9 +113,13 @@ func (s *Service) SubmitAnswer(playerChoice string, responseTimeMs int, question
 		CorrectChoice:  correctChoice,
 	}
 
-	if err := database.SaveNBackResult(s.db, result); err != nil {
+	if err := database.SaveNBackResult(db, result); err != nil {
 		return nil, fmt.Errorf("failed to save result: %w", err)
 	}
+	gs.NextQuestion = questionNum + 1
+	if err := database.SetSessionProgress(db, gs.ID, gs.NextQuestion); err != nil {
+		return nil, fmt.Errorf("failed to save progress: %w", err)
+	}
 
 	return &result, nil
 }
@@ -81,7 +81,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 
 	// --- Test Case 1: Game not started ---
 	t.Run("Game not started", func(t *testing.T) {
-		_, err := service.SubmitAnswer("LEFT", 500, 0)
+		_, err := service.SubmitAnswer(1, "LEFT", 500, 0)
 		if err == nil {
 			t.Errorf("Expected an error when submitting answer before game start, but got nil")
 		}
@@ -95,7 +95,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 	}
 
 	// Manually set a predictable shape sequence for testing
-	service.currentState.ShapeSequence = []string{"A", "B", "A", "C", "D"}
+	gameState.ShapeSequence = []string{"A", "B", "A", "C", "D"}
 
 	// --- Test Case 2: Correct Answer ---
 	t.Run("Correct answer", func(t *testing.T) {
@@ -103,7 +103,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 		playerChoice := "LEFT"
 		correctChoice := "LEFT"
 		
-		result, err := service.SubmitAnswer(playerChoice, 500, trialNum)
+		result, err := service.SubmitAnswer(gameState.ID, playerChoice, 500, trialNum)
 		if err != nil {
 			t.Fatalf("SubmitAnswer failed: %v", err)
 		}
@@ -122,7 +122,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 		playerChoice := "LEFT" // Player incorrectly thinks there's a match
 		correctChoice := "SPACE"
 
-		result, err := service.SubmitAnswer(playerChoice, 600, trialNum)
+		result, err := service.SubmitAnswer(gameState.ID, playerChoice, 600, trialNum)
 		if err != nil {
 			t.Fatalf("SubmitAnswer failed: %v", err)
 		}
@@ -0,0 +1,144 @@
+package number_pressing
+
+import "acca-games/types"
+
+// alignment operations, in the order they are preferred when tracing back.
+const (
+	opMatch = iota
+	opTranspose
+	opInsert     // The player pressed something that is not in the correct sequence
+	opDelete     // The player left out a press from the correct sequence
+	opSubstitute // The player pressed one number where another was expected
+)
+
+// ClassifyClickErrors aligns the player's presses against the correct sequence with an
+// edit distance (adjacent swaps included) and sorts every edit into an error type.
+//
+// The game stops at the first wrong press, so presses the player never got to are not
+// counted: the correct sequence may end anywhere in the alignment at no cost.
+func ClassifyClickErrors(problem types.NumberPressingProblemR2, playerClicks []int, correctClicks []int) types.NumberPressingErrorCounts {
+	n, m := len(playerClicks), len(correctClicks)
+
+	d := make([][]int, n+1)
+	for i := range d {
+		d[i] = make([]int, m+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if playerClicks[i-1] == correctClicks[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if isTransposition(playerClicks, correctClicks, i, j) {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	// Take the cheapest end point, preferring the one that covers most of the sequence.
+	end := m
+	for j := m; j >= 0; j-- {
+		if d[n][j] < d[n][end] {
+			end = j
+		}
+	}
+
+	isSkipped := make(map[int]bool)
+	for _, s := range problem.Skip {
+		isSkipped[s] = true
+	}
+	isDoubleClick := make(map[int]bool)
+	for _, dc := range problem.DoubleClick {
+		isDoubleClick[dc] = true
+	}
+	for _, r := range problem.Repeat {
+		isDoubleClick[r] = true
+	}
+	// missedRepeat reports whether the correct press at index k is one of a run of repeated presses.
+	missedRepeat := func(k int) bool {
+		c := correctClicks[k]
+		return isDoubleClick[c] &&
+			((k > 0 && correctClicks[k-1] == c) || (k+1 < m && correctClicks[k+1] == c))
+	}
+
+	var counts types.NumberPressingErrorCounts
+	i, j := n, end
+	for i > 0 || j > 0 {
+		switch traceStep(d, playerClicks, correctClicks, i, j) {
+		case opMatch:
+			i, j = i-1, j-1
+		case opTranspose:
+			counts.OrderSlip++
+			i, j = i-2, j-2
+		case opInsert:
+			if isSkipped[playerClicks[i-1]] {
+				counts.PressedSkipped++
+			} else {
+				counts.ExtraPress++
+			}
+			i--
+		case opDelete:
+			if missedRepeat(j - 1) {
+				counts.MissingDoubleClick++
+			} else {
+				counts.OrderSlip++
+			}
+			j--
+		case opSubstitute:
+			switch {
+			case isSkipped[playerClicks[i-1]]:
+				counts.PressedSkipped++
+			case j > 1 && playerClicks[i-1] == correctClicks[j-2]:
+				counts.ExtraPress++ // Pressed the previous number again
+			case missedRepeat(j - 1):
+				counts.MissingDoubleClick++
+			default:
+				counts.OrderSlip++
+			}
+			i, j = i-1, j-1
+		}
+	}
+	return counts
+}
+
+// traceStep picks the alignment operation that produced d[i][j].
+func traceStep(d [][]int, playerClicks []int, correctClicks []int, i, j int) int {
+	switch {
+	case i > 0 && j > 0 && playerClicks[i-1] == correctClicks[j-1] && d[i][j] == d[i-1][j-1]:
+		return opMatch
+	case isTransposition(playerClicks, correctClicks, i, j) && d[i][j] == d[i-2][j-2]+1:
+		return opTranspose
+	case i > 0 && d[i][j] == d[i-1][j]+1:
+		return opInsert
+	case j > 0 && d[i][j] == d[i][j-1]+1:
+		return opDelete
+	default:
+		return opSubstitute
+	}
+}
+
+// isTransposition reports whether the last two presses are the last two expected ones, swapped.
+func isTransposition(playerClicks []int, correctClicks []int, i, j int) bool {
+	return i > 1 && j > 1 &&
+		playerClicks[i-1] == correctClicks[j-2] &&
+		playerClicks[i-2] == correctClicks[j-1] &&
+		playerClicks[i-1] != playerClicks[i-2]
+}
+
+// sameClicks reports whether two press sequences are identical.
+func sameClicks(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
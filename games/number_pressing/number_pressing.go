@@ -2,9 +2,7 @@ package number_pressing
 
 import (
 	"database/sql"
-	"math/rand"
-	"sort"
-	"time"
+	"fmt"
 
 	"acca-games/database"
 	"acca-games/types"
@@ -19,136 +17,131 @@ func NewService(db *sql.DB) *Service {
 }
 
 func (s *Service) StartGame(setup types.NumberPressingSetup) (*types.NumberPressingGameState, error) {
-	sessionID, err := database.CreateGameSession(s.db, types.GameCodeNumberPressing, setup)
-	if err != nil {
+	if err := validateSetup(setup); err != nil {
 		return nil, err
 	}
 
-	problemsR1 := generateProblemsR1(setup.ProblemsPerRound)
-	problemsR2 := generateProblemsR2(setup.ProblemsPerRound)
+	problemsR1, problemsR2, layout := GenerateProblems(setup)
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeNumberPressing, setup, len(problemsR1)+len(problemsR2))
+	if err != nil {
+		return nil, err
+	}
 
 	gameState := &types.NumberPressingGameState{
-		Setup:      setup,
-		ProblemsR1: problemsR1,
-		ProblemsR2: problemsR2,
-		ID:         sessionID,
+		Setup:       setup,
+		ProblemsR1:  problemsR1,
+		ProblemsR2:  problemsR2,
+		Layout:      layout,
+		ID:          sessionID,
+		NextProblem: 1,
+	}
+	if err := database.SaveSessionState(s.db, sessionID, gameState, 1); err != nil {
+		return nil, err
 	}
 
 	return gameState, nil
 }
 
-func generateProblemsR1(count int) []types.NumberPressingProblemR1 {
-	problems := make([]types.NumberPressingProblemR1, count)
-	for i := 0; i < count; i++ {
-		problems[i] = types.NumberPressingProblemR1{
-			TargetNumber: rand.Intn(9) + 1,
-		}
+// ResumeSession returns the saved state of a session left in progress, pointing at its
+// next unanswered problem.
+func (s *Service) ResumeSession(sessionID int64) (*types.NumberPressingGameState, error) {
+	var gameState types.NumberPressingGameState
+	next, err := database.LoadSessionState(s.db, sessionID, &gameState)
+	if err != nil {
+		return nil, err
 	}
-	return problems
+	gameState.NextProblem = next
+	return &gameState, nil
 }
 
-func generateProblemsR2(count int) []types.NumberPressingProblemR2 {
-	problems := make([]types.NumberPressingProblemR2, count)
-	for i := 0; i < count; i++ {
-		// For simplicity, generate one of each type. A real implementation might have more complex rules.
-		doubleClick := []int{rand.Intn(9) + 1}
-		skip := []int{rand.Intn(9) + 1}
-		// Ensure doubleClick and skip numbers are different
-		for doubleClick[0] == skip[0] {
-			skip[0] = rand.Intn(9) + 1
-		}
-		problems[i] = types.NumberPressingProblemR2{
-			DoubleClick: doubleClick,
-			Skip:        skip,
-		}
-	}
-	return problems
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	return database.EndGameSession(s.db, sessionID)
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	return database.AbandonGameSession(s.db, sessionID)
 }
 
+// SubmitResultR1 scores a Round 1 answer from the number the player pressed and saves it.
+// The problem is the one generated for the session at the answer's problem number;
+// whatever problem the caller sent is ignored.
 func (s *Service) SubmitResultR1(result types.NumberPressingResultR1) error {
-	return database.SaveNumberPressingResultR1(s.db, result)
+	state, err := s.loadState(result.SessionID)
+	if err != nil {
+		return err
+	}
+	if result.ProblemNumber < 1 || result.ProblemNumber > len(state.ProblemsR1) {
+		return fmt.Errorf("invalid Round 1 problem number: %d", result.ProblemNumber)
+	}
+	result.Problem = state.ProblemsR1[result.ProblemNumber-1]
+	result.IsCorrect = result.PlayerNumber == result.Problem.TargetNumber
+	if err := database.SaveNumberPressingResultR1(s.db, result); err != nil {
+		return err
+	}
+	return database.AdvanceSessionProgress(s.db, result.SessionID)
 }
 
+// SubmitResultR2 scores a Round 2 attempt against the correct click sequence, classifies
+// its click errors and saves it. As in Round 1, the problem is taken from the session and
+// whatever problem and correctness the caller sent are ignored.
 func (s *Service) SubmitResultR2(result types.NumberPressingResultR2) error {
-	return database.SaveNumberPressingResultR2(s.db, result)
+	state, err := s.loadState(result.SessionID)
+	if err != nil {
+		return err
+	}
+	if result.ProblemNumber < 1 || result.ProblemNumber > len(state.ProblemsR2) {
+		return fmt.Errorf("invalid Round 2 problem number: %d", result.ProblemNumber)
+	}
+	result.Problem = state.ProblemsR2[result.ProblemNumber-1]
+	evaluateResultR2(&result)
+	if err := database.SaveNumberPressingResultR2(s.db, result); err != nil {
+		return err
+	}
+	return database.AdvanceSessionProgress(s.db, result.SessionID)
 }
 
-
-// GenerateProblems creates a list of problems for both rounds based on the setup.
-func GenerateProblems(setup types.NumberPressingSetup) ([]types.NumberPressingProblemR1, []types.NumberPressingProblemR2) {
-	var problemsR1 []types.NumberPressingProblemR1
-	var problemsR2 []types.NumberPressingProblemR2
-
-	rand.Seed(time.Now().UnixNano())
-
-	// Generate for Round 1 if it's in the setup
-	for _, round := range setup.Rounds {
-		if round == 1 {
-			for i := 0; i < setup.ProblemsPerRound; i++ {
-				problemsR1 = append(problemsR1, types.NumberPressingProblemR1{
-					TargetNumber: rand.Intn(9) + 1,
-				})
-			}
-		}
-		if round == 2 {
-			for i := 0; i < setup.ProblemsPerRound; i++ {
-				doubleClickCount := rand.Intn(3) // 0, 1, or 2
-				skipCount := rand.Intn(3)        // 0, 1, or 2
-
-				// Ensure not too many skips
-				if skipCount == 2 && doubleClickCount > 0 {
-					skipCount = 1
-				}
-
-				var doubleClick []int
-				var skip []int
-				
-				nums := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
-				rand.Shuffle(len(nums), func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
-
-				doubleClick = nums[:doubleClickCount]
-				
-				// Make sure skip numbers are not the same as double-click numbers
-				remainingNums := nums[doubleClickCount:]
-				if len(remainingNums) < skipCount {
-					skipCount = len(remainingNums)
-				}
-				skip = remainingNums[:skipCount]
-
-				sort.Ints(doubleClick)
-				sort.Ints(skip)
-
-				problemsR2 = append(problemsR2, types.NumberPressingProblemR2{
-					DoubleClick: doubleClick,
-					Skip:        skip,
-				})
-			}
-		}
+// loadState reads the problems generated for a session in progress.
+func (s *Service) loadState(sessionID int64) (*types.NumberPressingGameState, error) {
+	var state types.NumberPressingGameState
+	if _, err := database.LoadSessionState(s.db, sessionID, &state); err != nil {
+		return nil, err
 	}
+	return &state, nil
+}
 
-	return problemsR1, problemsR2
+func evaluateResultR2(result *types.NumberPressingResultR2) {
+	result.CorrectClicks = CalculateCorrectClicksR2(result.Problem)
+	result.IsCorrect = sameClicks(result.PlayerClicks, result.CorrectClicks)
+	result.Errors = ClassifyClickErrors(result.Problem, result.PlayerClicks, result.CorrectClicks)
 }
 
 // CalculateCorrectClicksR2 determines the correct sequence of clicks for a Round 2 problem.
 func CalculateCorrectClicksR2(problem types.NumberPressingProblemR2) []int {
 	var correctClicks []int
-	
-	isSkipped := make(map[int]bool)
-	for _, s := range problem.Skip {
-		isSkipped[s] = true
-	}
 
-	isDoubleClick := make(map[int]bool)
+	presses := make(map[int]int)
+	for i := 1; i <= 9; i++ {
+		presses[i] = 1
+	}
 	for _, d := range problem.DoubleClick {
-		isDoubleClick[d] = true
+		presses[d] = 2
+	}
+	for _, r := range problem.Repeat {
+		presses[r] = problem.RepeatCount
+	}
+	for _, s := range problem.Skip {
+		presses[s] = 0
 	}
 
-	for i := 1; i <= 9; i++ {
-		if isSkipped[i] {
-			continue
+	for k := 1; k <= 9; k++ {
+		i := k
+		if problem.Reverse {
+			i = 10 - k
 		}
-		correctClicks = append(correctClicks, i)
-		if isDoubleClick[i] {
+		for n := 0; n < presses[i]; n++ {
 			correctClicks = append(correctClicks, i)
 		}
 	}
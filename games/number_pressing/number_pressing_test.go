@@ -1,19 +1,42 @@
 package number_pressing
 
 import (
+	"acca-games/database"
 	"acca-games/types"
+	"database/sql"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
 )
 
+// setupTestDB creates an in-memory SQLite database and applies the schema.
+func setupTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	schema, err := os.ReadFile(filepath.Join("..", "..", "database", "schema.sql"))
+	if err != nil {
+		t.Fatalf("Failed to read schema.sql: %v", err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatalf("Failed to execute schema.sql: %v", err)
+	}
+	return db
+}
+
 func TestGenerateProblems(t *testing.T) {
 	t.Run("Generates correct number of problems for both rounds", func(t *testing.T) {
 		setup := types.NumberPressingSetup{
 			Rounds:           []int{1, 2},
 			ProblemsPerRound: 5,
 		}
-		r1, r2 := GenerateProblems(setup)
+		r1, r2, _ := GenerateProblems(setup)
 		if len(r1) != 5 {
 			t.Errorf("Expected 5 problems for round 1, got %d", len(r1))
 		}
@@ -27,7 +50,7 @@ func TestGenerateProblems(t *testing.T) {
 			Rounds:           []int{2},
 			ProblemsPerRound: 10,
 		}
-		r1, r2 := GenerateProblems(setup)
+		r1, r2, _ := GenerateProblems(setup)
 		if len(r1) != 0 {
 			t.Errorf("Expected 0 problems for round 1, got %d", len(r1))
 		}
@@ -41,7 +64,7 @@ func TestGenerateProblems(t *testing.T) {
 			Rounds:           []int{2},
 			ProblemsPerRound: 20, // Generate a good number to be sure
 		}
-		_, r2 := GenerateProblems(setup)
+		_, r2, _ := GenerateProblems(setup)
 		for i, p := range r2 {
 			seen := make(map[int]bool)
 			for _, d := range p.DoubleClick {
@@ -102,6 +125,25 @@ func TestCalculateCorrectClicksR2(t *testing.T) {
 			},
 			expected: []int{2, 3, 4, 5, 5, 6, 7, 8},
 		},
+		{
+			name: "Reverse order with a double click",
+			problem: types.NumberPressingProblemR2{
+				DoubleClick: []int{4},
+				Skip:        []int{7},
+				Reverse:     true,
+			},
+			expected: []int{9, 8, 6, 5, 4, 4, 3, 2, 1},
+		},
+		{
+			name: "Press one number three times",
+			problem: types.NumberPressingProblemR2{
+				DoubleClick: []int{},
+				Skip:        []int{},
+				Repeat:      []int{2},
+				RepeatCount: 3,
+			},
+			expected: []int{1, 2, 2, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
 		{
 			name: "Edge cases: double click 1 and 9",
 			problem: types.NumberPressingProblemR2{
@@ -125,3 +167,253 @@ func TestCalculateCorrectClicksR2(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyClickErrors(t *testing.T) {
+	problem := types.NumberPressingProblemR2{DoubleClick: []int{3}, Skip: []int{6}}
+	correct := CalculateCorrectClicksR2(problem) // 1 2 3 3 4 5 7 8 9
+
+	testCases := []struct {
+		name     string
+		clicks   []int
+		expected types.NumberPressingErrorCounts
+	}{
+		{
+			name:   "Correct sequence",
+			clicks: []int{1, 2, 3, 3, 4, 5, 7, 8, 9},
+		},
+		{
+			name:   "Stopped early without errors",
+			clicks: []int{1, 2, 3},
+		},
+		{
+			name:     "Missing double click",
+			clicks:   []int{1, 2, 3, 4},
+			expected: types.NumberPressingErrorCounts{MissingDoubleClick: 1},
+		},
+		{
+			name:     "Pressed skipped number",
+			clicks:   []int{1, 2, 3, 3, 4, 5, 6},
+			expected: types.NumberPressingErrorCounts{PressedSkipped: 1},
+		},
+		{
+			name:     "Swapped two numbers",
+			clicks:   []int{2, 1},
+			expected: types.NumberPressingErrorCounts{OrderSlip: 1},
+		},
+		{
+			name:     "Jumped ahead",
+			clicks:   []int{1, 2, 3, 3, 5},
+			expected: types.NumberPressingErrorCounts{OrderSlip: 1},
+		},
+		{
+			name:     "Pressed a number again",
+			clicks:   []int{1, 2, 2},
+			expected: types.NumberPressingErrorCounts{ExtraPress: 1},
+		},
+		{
+			name:     "Full sequence with several errors",
+			clicks:   []int{1, 2, 3, 4, 5, 6, 7, 8, 8, 9},
+			expected: types.NumberPressingErrorCounts{MissingDoubleClick: 1, PressedSkipped: 1, ExtraPress: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := ClassifyClickErrors(problem, tc.clicks, correct)
+			if actual != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEvaluateResultR2IgnoresClientScoring(t *testing.T) {
+	result := types.NumberPressingResultR2{
+		Problem:       types.NumberPressingProblemR2{DoubleClick: []int{2}, Skip: []int{5}},
+		PlayerClicks:  []int{1, 2, 3},
+		CorrectClicks: []int{1, 2, 3},
+		IsCorrect:     true,
+	}
+	evaluateResultR2(&result)
+
+	if result.IsCorrect {
+		t.Errorf("Expected the attempt to be scored incorrect")
+	}
+	if !reflect.DeepEqual(result.CorrectClicks, []int{1, 2, 2, 3, 4, 6, 7, 8, 9}) {
+		t.Errorf("Unexpected correct clicks %v", result.CorrectClicks)
+	}
+	if result.Errors.MissingDoubleClick != 1 {
+		t.Errorf("Expected one missing double click, got %+v", result.Errors)
+	}
+}
+
+func TestService_SubmitScoresAgainstSessionProblems(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	service := NewService(db)
+
+	state, err := service.StartGame(types.NumberPressingSetup{Rounds: []int{1, 2}, ProblemsPerRound: 2})
+	if err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	target := state.ProblemsR1[1].TargetNumber
+	wrong := target%9 + 1
+
+	// The client claims the target was the number it pressed; the session's problem decides.
+	err = service.SubmitResultR1(types.NumberPressingResultR1{
+		SessionID:     state.ID,
+		ProblemNumber: 2,
+		Problem:       types.NumberPressingProblemR1{TargetNumber: wrong},
+		PlayerNumber:  wrong,
+		IsCorrect:     true,
+	})
+	if err != nil {
+		t.Fatalf("SubmitResultR1 failed: %v", err)
+	}
+	// A Round 2 attempt with a made-up problem whose clicks it matches.
+	err = service.SubmitResultR2(types.NumberPressingResultR2{
+		SessionID:     state.ID,
+		ProblemNumber: 1,
+		Problem:       types.NumberPressingProblemR2{Skip: []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		PlayerClicks:  []int{9},
+		IsCorrect:     true,
+	})
+	if err != nil {
+		t.Fatalf("SubmitResultR2 failed: %v", err)
+	}
+
+	results, err := database.GetNumberPressingResultsForSession(db, state.ID)
+	if err != nil {
+		t.Fatalf("GetNumberPressingResultsForSession failed: %v", err)
+	}
+	if len(results.ResultsR1) != 1 || len(results.ResultsR2) != 1 {
+		t.Fatalf("Expected one result per round, got %+v", results)
+	}
+	if r := results.ResultsR1[0]; r.IsCorrect || r.Problem.TargetNumber != target {
+		t.Errorf("Expected an incorrect answer to target %d, got %+v", target, r)
+	}
+	if r := results.ResultsR2[0]; r.IsCorrect || !reflect.DeepEqual(r.CorrectClicks, CalculateCorrectClicksR2(state.ProblemsR2[0])) {
+		t.Errorf("Expected an incorrect attempt at the session's problem, got %+v", r)
+	}
+
+	for _, n := range []int{0, 3} {
+		if err := service.SubmitResultR1(types.NumberPressingResultR1{SessionID: state.ID, ProblemNumber: n, PlayerNumber: 1}); err == nil {
+			t.Errorf("Expected problem number %d to be rejected", n)
+		}
+	}
+}
+
+func TestGenerateProblemsRules(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Deals every rule family out evenly", func(t *testing.T) {
+		setup := types.NumberPressingSetup{Rounds: []int{2}, ProblemsPerRound: 10}
+		_, r2, _ := generateProblems(setup, rng)
+
+		counts := make(map[string]int)
+		for _, p := range r2 {
+			counts[p.Rule]++
+		}
+		for _, rule := range allRules {
+			if counts[rule] != 2 {
+				t.Errorf("Expected 2 problems for rule %s, got %d", rule, counts[rule])
+			}
+		}
+	})
+
+	t.Run("Uses only the requested rules", func(t *testing.T) {
+		setup := types.NumberPressingSetup{
+			Rounds:           []int{2},
+			ProblemsPerRound: 6,
+			RuleTypes:        []string{types.NumberPressingRuleSkipEven, types.NumberPressingRulePressN},
+		}
+		_, r2, _ := generateProblems(setup, rng)
+		for i, p := range r2 {
+			switch p.Rule {
+			case types.NumberPressingRuleSkipEven:
+				if !reflect.DeepEqual(p.Skip, []int{2, 4, 6, 8}) {
+					t.Errorf("Problem %d: expected even numbers skipped, got %v", i, p.Skip)
+				}
+			case types.NumberPressingRulePressN:
+				if len(p.Repeat) != 1 || p.RepeatCount < 3 {
+					t.Errorf("Problem %d: expected one number pressed 3+ times, got %v x%d", i, p.Repeat, p.RepeatCount)
+				}
+			default:
+				t.Errorf("Problem %d: unexpected rule %s", i, p.Rule)
+			}
+		}
+	})
+
+	t.Run("Every problem has a non-empty answer", func(t *testing.T) {
+		setup := types.NumberPressingSetup{Rounds: []int{2}, ProblemsPerRound: 50}
+		_, r2, _ := generateProblems(setup, rng)
+		for i, p := range r2 {
+			if len(CalculateCorrectClicksR2(p)) == 0 {
+				t.Errorf("Problem %d (%s) has no clicks to make", i, p.Rule)
+			}
+		}
+	})
+
+	t.Run("Rejects unknown rules", func(t *testing.T) {
+		if err := validateRules([]string{"sideways"}); err == nil {
+			t.Errorf("Expected an error for an unknown rule")
+		}
+	})
+}
+
+func TestGenerateProblemsLayouts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inOrder := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	t.Run("Default keeps round 1 in order and scrambles round 2", func(t *testing.T) {
+		setup := types.NumberPressingSetup{Rounds: []int{1, 2}, ProblemsPerRound: 20}
+		r1, r2, sessionLayout := generateProblems(setup, rng)
+		if sessionLayout != nil {
+			t.Errorf("Expected no session layout, got %v", sessionLayout)
+		}
+		for i, p := range r1 {
+			if !reflect.DeepEqual(p.Layout, inOrder) {
+				t.Errorf("R1 problem %d: expected keys in order, got %v", i, p.Layout)
+			}
+		}
+		scrambled := 0
+		for i, p := range r2 {
+			if !validLayout(p.Layout) || len(p.Layout) != 9 {
+				t.Errorf("R2 problem %d: invalid layout %v", i, p.Layout)
+			}
+			if !reflect.DeepEqual(p.Layout, inOrder) {
+				scrambled++
+			}
+		}
+		if scrambled == 0 {
+			t.Errorf("Expected round 2 layouts to be scrambled")
+		}
+	})
+
+	t.Run("Per-session mode shares one scramble", func(t *testing.T) {
+		setup := types.NumberPressingSetup{Rounds: []int{1, 2}, ProblemsPerRound: 5, LayoutMode: types.NumberPressingLayoutPerSession}
+		r1, r2, sessionLayout := generateProblems(setup, rng)
+		if !validLayout(sessionLayout) || len(sessionLayout) != 9 {
+			t.Fatalf("Expected a session layout, got %v", sessionLayout)
+		}
+		for _, p := range r1 {
+			if !reflect.DeepEqual(p.Layout, sessionLayout) {
+				t.Errorf("R1 layout %v differs from session layout %v", p.Layout, sessionLayout)
+			}
+		}
+		for _, p := range r2 {
+			if !reflect.DeepEqual(p.Layout, sessionLayout) {
+				t.Errorf("R2 layout %v differs from session layout %v", p.Layout, sessionLayout)
+			}
+		}
+	})
+
+	t.Run("Rejects bad layouts and modes", func(t *testing.T) {
+		if validLayout([]int{1, 1, 2, 3, 4, 5, 6, 7, 8}) {
+			t.Errorf("Expected a repeated key to be rejected")
+		}
+		if err := validateSetup(types.NumberPressingSetup{LayoutMode: "spiral"}); err == nil {
+			t.Errorf("Expected an error for an unknown layout mode")
+		}
+	})
+}
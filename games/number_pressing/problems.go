@@ -0,0 +1,202 @@
+package number_pressing
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"acca-games/types"
+)
+
+// allRules are the Round 2 rule families used when the setup does not pick any.
+var allRules = []string{
+	types.NumberPressingRuleDoubleSkip,
+	types.NumberPressingRuleReverse,
+	types.NumberPressingRuleSkipEven,
+	types.NumberPressingRuleSkipOdd,
+	types.NumberPressingRulePressN,
+}
+
+// validateSetup checks the rule families and the keypad layout mode of a setup.
+func validateSetup(setup types.NumberPressingSetup) error {
+	if err := validateRules(setup.RuleTypes); err != nil {
+		return err
+	}
+	switch setup.LayoutMode {
+	case types.NumberPressingLayoutDefault, types.NumberPressingLayoutFixed,
+		types.NumberPressingLayoutPerProblem, types.NumberPressingLayoutPerSession:
+		return nil
+	default:
+		return fmt.Errorf("unknown keypad layout mode: %s", setup.LayoutMode)
+	}
+}
+
+// validateRules checks that every requested rule family is known.
+func validateRules(rules []string) error {
+	for _, rule := range rules {
+		known := false
+		for _, r := range allRules {
+			if rule == r {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown number pressing rule: %s", rule)
+		}
+	}
+	return nil
+}
+
+// GenerateProblems creates the problems of every round listed in setup.Rounds, each with
+// its keypad layout. In perSession mode the shared layout is returned as well.
+func GenerateProblems(setup types.NumberPressingSetup) ([]types.NumberPressingProblemR1, []types.NumberPressingProblemR2, []int) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return generateProblems(setup, rng)
+}
+
+func generateProblems(setup types.NumberPressingSetup, rng *rand.Rand) ([]types.NumberPressingProblemR1, []types.NumberPressingProblemR2, []int) {
+	problemsR1 := []types.NumberPressingProblemR1{}
+	problemsR2 := []types.NumberPressingProblemR2{}
+
+	rules := setup.RuleTypes
+	if len(rules) == 0 {
+		rules = allRules
+	}
+
+	var sessionLayout []int
+	if setup.LayoutMode == types.NumberPressingLayoutPerSession {
+		sessionLayout = newLayout(rng, true)
+	}
+	layoutFor := func(round int) []int {
+		switch setup.LayoutMode {
+		case types.NumberPressingLayoutPerSession:
+			return sessionLayout
+		case types.NumberPressingLayoutPerProblem:
+			return newLayout(rng, true)
+		case types.NumberPressingLayoutFixed:
+			return newLayout(rng, false)
+		default:
+			return newLayout(rng, round == 2)
+		}
+	}
+
+	for _, round := range setup.Rounds {
+		switch round {
+		case 1:
+			for i := 0; i < setup.ProblemsPerRound; i++ {
+				problemsR1 = append(problemsR1, types.NumberPressingProblemR1{
+					TargetNumber: rng.Intn(9) + 1,
+					Layout:       layoutFor(1),
+				})
+			}
+		case 2:
+			// Deal the rules out evenly, then shuffle so they come in no fixed order.
+			var problems []types.NumberPressingProblemR2
+			for i := 0; i < setup.ProblemsPerRound; i++ {
+				problems = append(problems, generateProblemR2(rng, rules[i%len(rules)]))
+			}
+			rng.Shuffle(len(problems), func(i, j int) { problems[i], problems[j] = problems[j], problems[i] })
+			for i := range problems {
+				problems[i].Layout = layoutFor(2)
+			}
+			problemsR2 = append(problemsR2, problems...)
+		}
+	}
+
+	return problemsR1, problemsR2, sessionLayout
+}
+
+// newLayout returns the numbers 1-9 in keypad order, scrambled if asked.
+func newLayout(rng *rand.Rand, shuffled bool) []int {
+	layout := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if shuffled {
+		rng.Shuffle(len(layout), func(i, j int) { layout[i], layout[j] = layout[j], layout[i] })
+	}
+	return layout
+}
+
+// validLayout reports whether a layout is a permutation of 1-9. An empty layout
+// (from a client that does not send one) is accepted.
+func validLayout(layout []int) bool {
+	if len(layout) == 0 {
+		return true
+	}
+	if len(layout) != 9 {
+		return false
+	}
+	seen := make(map[int]bool)
+	for _, n := range layout {
+		if n < 1 || n > 9 || seen[n] {
+			return false
+		}
+		seen[n] = true
+	}
+	return true
+}
+
+// generateProblemR2 creates a single Round 2 problem of the given rule family.
+func generateProblemR2(rng *rand.Rand, rule string) types.NumberPressingProblemR2 {
+	problem := types.NumberPressingProblemR2{
+		Rule:        rule,
+		DoubleClick: []int{},
+		Skip:        []int{},
+		Repeat:      []int{},
+	}
+
+	// A shuffled 1..9 to draw distinct numbers from.
+	nums := rng.Perm(9)
+	for i := range nums {
+		nums[i]++
+	}
+
+	switch rule {
+	case types.NumberPressingRuleDoubleSkip:
+		doubleClickCount := rng.Intn(3) // 0, 1, or 2
+		skipCount := rng.Intn(3)        // 0, 1, or 2
+		// Ensure not too many skips
+		if skipCount == 2 && doubleClickCount > 0 {
+			skipCount = 1
+		}
+		problem.DoubleClick = append(problem.DoubleClick, nums[:doubleClickCount]...)
+		problem.Skip = append(problem.Skip, nums[doubleClickCount:doubleClickCount+skipCount]...)
+
+	case types.NumberPressingRuleReverse:
+		problem.Reverse = true
+		doubleClickCount := rng.Intn(2)
+		skipCount := rng.Intn(2)
+		problem.DoubleClick = append(problem.DoubleClick, nums[:doubleClickCount]...)
+		problem.Skip = append(problem.Skip, nums[doubleClickCount:doubleClickCount+skipCount]...)
+
+	case types.NumberPressingRuleSkipEven, types.NumberPressingRuleSkipOdd:
+		skipParity := 0
+		if rule == types.NumberPressingRuleSkipOdd {
+			skipParity = 1
+		}
+		for i := 1; i <= 9; i++ {
+			if i%2 == skipParity {
+				problem.Skip = append(problem.Skip, i)
+			}
+		}
+		// Half the time, one of the remaining numbers is pressed twice.
+		if rng.Intn(2) == 0 {
+			for _, n := range nums {
+				if n%2 != skipParity {
+					problem.DoubleClick = append(problem.DoubleClick, n)
+					break
+				}
+			}
+		}
+
+	case types.NumberPressingRulePressN:
+		problem.Repeat = append(problem.Repeat, nums[0])
+		problem.RepeatCount = 3 + rng.Intn(2) // 3 or 4
+		skipCount := rng.Intn(2)
+		problem.Skip = append(problem.Skip, nums[1:1+skipCount]...)
+	}
+
+	sort.Ints(problem.DoubleClick)
+	sort.Ints(problem.Skip)
+	return problem
+}
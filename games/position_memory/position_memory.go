@@ -0,0 +1,314 @@
+package position_memory
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// PositionMemoryGameState holds the current state of the game.
+type PositionMemoryGameState struct {
+	Settings types.PositionMemorySettings  `json:"settings"`
+	Blocks   []types.PositionMemoryBlock   `json:"blocks"`
+	Current  *types.PositionMemorySequence `json:"current"` // The first sequence; later ones come with each answer
+	ID       int64                         `json:"id"`
+}
+
+// savedGame is what is stored so that a session can be resumed. The sequences are made
+// as the test goes, so the span so far is kept along with the current sequence.
+type savedGame struct {
+	State    PositionMemoryGameState `json:"state"`
+	Span     int                     `json:"span"`
+	Finished bool                    `json:"finished"`
+}
+
+// game is one Position Memory session in play.
+type game struct {
+	state    *PositionMemoryGameState
+	rng      *rand.Rand
+	span     int
+	finished bool
+}
+
+// Service for the Position Memory game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.PositionMemorySettings) (*PositionMemoryGameState, error) {
+	settings = withDefaults(settings)
+	if err := validateSettings(settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	blocks := generateLayout(settings.NumBlocks, rng)
+
+	sessionID, err := database.CreateGameSession(s.db, types.GameCodePositionMemory, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &PositionMemoryGameState{
+			Settings: settings,
+			Blocks:   blocks,
+			Current:  generateSequence(settings.NumBlocks, 1, settings.StartLength, 1, rng),
+			ID:       sessionID,
+		},
+		rng: rng,
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state. The current
+// sequence is the next one to answer, or nil when the test is already finished.
+func (s *Service) ResumeSession(sessionID int64) (*PositionMemoryGameState, error) {
+	var saved savedGame
+	if _, err := database.LoadSessionState(s.db, sessionID, &saved); err != nil {
+		return nil, err
+	}
+	g := &game{
+		state:    &saved.State,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		span:     saved.Span,
+		finished: saved.Finished,
+	}
+	if g.finished {
+		g.state.Current = nil
+	}
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// saveState stores the game, pointing at the sequence to answer next.
+func (g *game) saveState(db *sql.DB) error {
+	next := g.state.Current.SequenceNum
+	if g.finished {
+		next++
+	}
+	saved := savedGame{State: *g.state, Span: g.span, Finished: g.finished}
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitSequence checks the blocks the player tapped against the current sequence, saves
+// the result and moves on by the span rule: a correct answer goes up one length, a first
+// failure gives a second sequence of the same length, and a second failure ends the test.
+func (s *Service) SubmitSequence(sessionID int64, sequenceNum int, response []int, responseTimeMs int) (*types.PositionMemorySubmitResult, error) {
+	var result *types.PositionMemorySubmitResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitSequence(s.db, sequenceNum, response, responseTimeMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitSequence(db *sql.DB, sequenceNum int, response []int, responseTimeMs int) (*types.PositionMemorySubmitResult, error) {
+	if g.finished {
+		return nil, fmt.Errorf("the test is already finished")
+	}
+	current := g.state.Current
+	if sequenceNum != current.SequenceNum {
+		return nil, fmt.Errorf("invalid sequence number")
+	}
+	for _, b := range response {
+		if b < 0 || b >= len(g.state.Blocks) {
+			return nil, fmt.Errorf("unknown block: %d", b)
+		}
+	}
+	if response == nil {
+		response = []int{}
+	}
+
+	settings := g.state.Settings
+	expected := expectedResponse(current.Blocks, settings.Mode)
+	result := types.PositionMemoryResult{
+		SessionID:        g.state.ID,
+		SequenceNum:      current.SequenceNum,
+		Mode:             settings.Mode,
+		Length:           current.Length,
+		Attempt:          current.Attempt,
+		Sequence:         current.Blocks,
+		Response:         response,
+		IsCorrect:        equal(response, expected),
+		CorrectPositions: correctPositions(response, expected),
+		ResponseTimeMs:   responseTimeMs,
+	}
+
+	if err := database.SavePositionMemoryResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+
+	length, attempt := current.Length, 1
+	switch {
+	case result.IsCorrect:
+		g.span = max(g.span, current.Length)
+		length++
+	case current.Attempt == 1:
+		attempt = 2
+	default:
+		g.finished = true
+	}
+	if length > settings.MaxLength {
+		g.finished = true
+	}
+
+	res := &types.PositionMemorySubmitResult{Result: result, Span: g.span, Finished: g.finished}
+	if !g.finished {
+		g.state.Current = generateSequence(settings.NumBlocks, current.SequenceNum+1, length, attempt, g.rng)
+		res.Next = g.state.Current
+	}
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// --- Helper Functions ---
+
+const (
+	minBlocks          = 4
+	maxBlocks          = 16
+	defaultNumBlocks   = 9
+	defaultStartLength = 2
+	defaultFlashMs     = 1000
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.PositionMemorySettings) types.PositionMemorySettings {
+	if settings.NumBlocks == 0 {
+		settings.NumBlocks = defaultNumBlocks
+	}
+	if settings.StartLength == 0 {
+		settings.StartLength = defaultStartLength
+	}
+	if settings.MaxLength == 0 {
+		settings.MaxLength = settings.NumBlocks
+	}
+	if settings.Mode == "" {
+		settings.Mode = types.PositionMemoryForward
+	}
+	if settings.FlashMs == 0 {
+		settings.FlashMs = defaultFlashMs
+	}
+	return settings
+}
+
+func validateSettings(settings types.PositionMemorySettings) error {
+	if settings.NumBlocks < minBlocks || settings.NumBlocks > maxBlocks {
+		return fmt.Errorf("block count must be between %d and %d, got %d", minBlocks, maxBlocks, settings.NumBlocks)
+	}
+	// A sequence never lights the same block twice, so it cannot be longer than the board.
+	if settings.StartLength < 1 || settings.StartLength > settings.MaxLength || settings.MaxLength > settings.NumBlocks {
+		return fmt.Errorf("invalid length range %d to %d for %d blocks", settings.StartLength, settings.MaxLength, settings.NumBlocks)
+	}
+	if settings.Mode != types.PositionMemoryForward && settings.Mode != types.PositionMemoryBackward {
+		return fmt.Errorf("unknown mode: %s", settings.Mode)
+	}
+	if settings.FlashMs < 0 {
+		return fmt.Errorf("flash time must not be negative")
+	}
+	return nil
+}
+
+// generateLayout scatters the blocks over distinct cells of the board.
+func generateLayout(numBlocks int, rng *rand.Rand) []types.PositionMemoryBlock {
+	cells := rng.Perm(types.PositionMemoryBoardSize * types.PositionMemoryBoardSize)
+	blocks := make([]types.PositionMemoryBlock, numBlocks)
+	for i := range blocks {
+		blocks[i] = types.PositionMemoryBlock{
+			Row: cells[i] / types.PositionMemoryBoardSize,
+			Col: cells[i] % types.PositionMemoryBoardSize,
+		}
+	}
+	return blocks
+}
+
+// generateSequence picks length distinct blocks in random order.
+func generateSequence(numBlocks, sequenceNum, length, attempt int, rng *rand.Rand) *types.PositionMemorySequence {
+	return &types.PositionMemorySequence{
+		SequenceNum: sequenceNum,
+		Length:      length,
+		Attempt:     attempt,
+		Blocks:      rng.Perm(numBlocks)[:length],
+	}
+}
+
+// expectedResponse is the order the player should tap the blocks in.
+func expectedResponse(sequence []int, mode string) []int {
+	if mode != types.PositionMemoryBackward {
+		return sequence
+	}
+	reversed := make([]int, len(sequence))
+	for i, b := range sequence {
+		reversed[len(sequence)-1-i] = b
+	}
+	return reversed
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func correctPositions(response, expected []int) int {
+	n := 0
+	for i := 0; i < len(response) && i < len(expected); i++ {
+		if response[i] == expected[i] {
+			n++
+		}
+	}
+	return n
+}
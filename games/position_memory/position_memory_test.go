@@ -0,0 +1,129 @@
+package position_memory
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateLayoutAndSequence(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	blocks := generateLayout(9, rng)
+	assert.Len(t, blocks, 9)
+	seen := make(map[types.PositionMemoryBlock]bool)
+	for _, b := range blocks {
+		assert.False(t, seen[b], "Blocks must not share a cell")
+		seen[b] = true
+	}
+
+	seq := generateSequence(9, 3, 6, 2, rng)
+	assert.Equal(t, 3, seq.SequenceNum)
+	assert.Equal(t, 2, seq.Attempt)
+	assert.Len(t, seq.Blocks, 6)
+	lit := make(map[int]bool)
+	for _, b := range seq.Blocks {
+		assert.False(t, lit[b], "A block lights up only once per sequence")
+		lit[b] = true
+	}
+}
+
+func TestExpectedResponse(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 4}, expectedResponse([]int{3, 1, 4}, types.PositionMemoryForward))
+	assert.Equal(t, []int{4, 1, 3}, expectedResponse([]int{3, 1, 4}, types.PositionMemoryBackward))
+	assert.Equal(t, 2, correctPositions([]int{4, 3, 3}, []int{4, 1, 3}))
+}
+
+func TestValidateSettings(t *testing.T) {
+	assert.NoError(t, validateSettings(withDefaults(types.PositionMemorySettings{})))
+	assert.Error(t, validateSettings(withDefaults(types.PositionMemorySettings{NumBlocks: 3})))
+	assert.Error(t, validateSettings(withDefaults(types.PositionMemorySettings{NumBlocks: 5, MaxLength: 6})))
+	assert.Error(t, validateSettings(withDefaults(types.PositionMemorySettings{Mode: "sideways"})))
+}
+
+func TestService_SpanRule(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.SubmitSequence(1, 1, []int{0}, 100)
+	assert.Error(t, err, "Submitting before the game starts should fail")
+
+	state, err := service.StartGame(types.PositionMemorySettings{Mode: types.PositionMemoryBackward, StartLength: 2})
+	assert.NoError(t, err)
+	assert.Len(t, state.Blocks, 9)
+	seq := state.Current
+
+	// Length 2: correct, straight on to length 3.
+	res, err := service.SubmitSequence(state.ID, seq.SequenceNum, expectedResponse(seq.Blocks, types.PositionMemoryBackward), 1200)
+	assert.NoError(t, err)
+	assert.True(t, res.Result.IsCorrect)
+	assert.Equal(t, 2, res.Span)
+	assert.Equal(t, 3, res.Next.Length)
+	assert.Equal(t, 1, res.Next.Attempt)
+	seq = res.Next
+
+	_, err = service.SubmitSequence(state.ID, seq.SequenceNum+1, seq.Blocks, 100)
+	assert.Error(t, err, "Only the current sequence can be answered")
+	_, err = service.SubmitSequence(state.ID, seq.SequenceNum, []int{9}, 100)
+	assert.Error(t, err, "Block 9 does not exist")
+
+	// Length 3: the forward order is wrong in backward mode, so a second try follows.
+	res, err = service.SubmitSequence(state.ID, seq.SequenceNum, seq.Blocks, 1500)
+	assert.NoError(t, err)
+	assert.False(t, res.Result.IsCorrect)
+	assert.False(t, res.Finished)
+	assert.Equal(t, 3, res.Next.Length)
+	assert.Equal(t, 2, res.Next.Attempt)
+	seq = res.Next
+
+	// Second try correct: on to length 4.
+	res, err = service.SubmitSequence(state.ID, seq.SequenceNum, expectedResponse(seq.Blocks, types.PositionMemoryBackward), 1800)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Span)
+	assert.Equal(t, 4, res.Next.Length)
+	seq = res.Next
+
+	// Two failures at length 4 end the test.
+	res, err = service.SubmitSequence(state.ID, seq.SequenceNum, nil, 2000)
+	assert.NoError(t, err)
+	assert.False(t, res.Finished)
+	seq = res.Next
+	res, err = service.SubmitSequence(state.ID, seq.SequenceNum, seq.Blocks[:2], 2100)
+	assert.NoError(t, err)
+	assert.True(t, res.Finished)
+	assert.Nil(t, res.Next)
+	assert.Equal(t, 3, res.Span)
+
+	_, err = service.SubmitSequence(state.ID, seq.SequenceNum+1, []int{0}, 100)
+	assert.Error(t, err, "The test is over")
+
+	stats, err := database.GetPositionMemorySessionStats(db, state.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.MaxSpan)
+	assert.Equal(t, 5, stats.TotalSequences)
+	assert.Equal(t, types.PositionMemoryBackward, stats.Mode)
+}
+
+func TestService_MaxLengthEndsTest(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	state, err := service.StartGame(types.PositionMemorySettings{NumBlocks: 4, StartLength: 3})
+	assert.NoError(t, err)
+
+	seq := state.Current
+	res, err := service.SubmitSequence(state.ID, seq.SequenceNum, seq.Blocks, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, res.Next.Length)
+	seq = res.Next
+	res, err = service.SubmitSequence(state.ID, seq.SequenceNum, seq.Blocks, 1000)
+	assert.NoError(t, err)
+	assert.True(t, res.Finished, "Every block has been used")
+	assert.Equal(t, 4, res.Span)
+}
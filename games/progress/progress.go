@@ -0,0 +1,72 @@
+package progress
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+// RecordBrokenEvent is the name of the event fired for each personal record a session breaks.
+const RecordBrokenEvent = "personalRecordBroken"
+
+// Service tracks goals, streaks and personal records.
+type Service struct {
+	db *sql.DB
+	// onRecordBroken is called for every record a completed session breaks. Records set
+	// for the first time are not reported, as there was nothing to break.
+	onRecordBroken func(types.PersonalRecord)
+}
+
+// NewService creates a new service that calls onRecordBroken, if set, whenever a
+// personal record is broken.
+func NewService(db *sql.DB, onRecordBroken func(types.PersonalRecord)) *Service {
+	return &Service{db: db, onRecordBroken: onRecordBroken}
+}
+
+// CompleteSession updates the personal records with a finished session and returns the
+// records it set.
+func (s *Service) CompleteSession(sessionID int64) ([]types.PersonalRecord, error) {
+	records, err := database.UpdatePersonalRecords(s.db, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update personal records: %w", err)
+	}
+	if s.onRecordBroken != nil {
+		for _, r := range records {
+			if r.PreviousValue != nil {
+				s.onRecordBroken(r)
+			}
+		}
+	}
+	return records, nil
+}
+
+// CreateGoal stores a new goal.
+func (s *Service) CreateGoal(goal types.Goal) (*types.Goal, error) {
+	id, err := database.CreateGoal(s.db, goal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create goal: %w", err)
+	}
+	goals, err := database.GetGoals(s.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range goals {
+		if g.ID == id {
+			return &g, nil
+		}
+	}
+	return nil, fmt.Errorf("goal %d not found", id)
+}
+
+// GoalProgress judges every goal over the last seven days.
+func (s *Service) GoalProgress() ([]types.GoalProgress, error) {
+	return database.EvaluateGoals(s.db, time.Now())
+}
+
+// Streak returns the current and longest daily practice streaks.
+func (s *Service) Streak() (*types.PracticeStreak, error) {
+	return database.GetPracticeStreak(s.db, time.Now())
+}
@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteSessionFiresRecordBroken(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var broken []types.PersonalRecord
+	service := NewService(db, func(r types.PersonalRecord) { broken = append(broken, r) })
+
+	play := func(solved ...bool) int64 {
+		sessionID, err := database.CreateGameSession(db, types.GameCodeTower, types.TowerSettings{})
+		assert.NoError(t, err)
+		for i, s := range solved {
+			assert.NoError(t, database.SaveTowerResult(db, types.TowerResult{
+				SessionID: sessionID, ProblemNum: i + 1, Discs: 3,
+				Start: [][]int{{3, 2, 1}, {}, {}}, Goal: [][]int{{}, {}, {3, 2, 1}}, Moves: []types.TowerMove{},
+				IsSolved: s,
+			}))
+		}
+		return sessionID
+	}
+
+	records, err := service.CompleteSession(play(true, false))
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Empty(t, broken, "A first record breaks nothing")
+
+	records, err = service.CompleteSession(play(true, true))
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	if assert.Len(t, broken, 1) {
+		assert.Equal(t, 100.0, broken[0].Value)
+		assert.Equal(t, 50.0, *broken[0].PreviousValue)
+	}
+
+	_, err = service.CompleteSession(999)
+	assert.Error(t, err)
+}
+
+func TestCreateGoal(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	service := NewService(db, nil)
+
+	goal, err := service.CreateGoal(types.Goal{GameCode: types.GameCodeStroop, Kind: types.GoalSessionsPerWeek, Target: 3})
+	assert.NoError(t, err)
+	assert.NotZero(t, goal.ID)
+	assert.False(t, goal.CreatedAt.IsZero())
+
+	_, err = service.CreateGoal(types.Goal{GameCode: types.GameCodeStroop, Kind: types.GoalAccuracy})
+	assert.Error(t, err)
+
+	progress, err := service.GoalProgress()
+	assert.NoError(t, err)
+	if assert.Len(t, progress, 1) {
+		assert.Equal(t, 0.0, *progress[0].Current)
+		assert.False(t, progress[0].Met)
+	}
+}
@@ -0,0 +1,524 @@
+package recommendation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+const (
+	// recencyHalfLifeDays is how many days it takes for a session to count half as much.
+	recencyHalfLifeDays = 14.0
+	// trendSessions is how many of a spot's latest sessions its trend is fitted over.
+	trendSessions = 5
+	// minTrendSessions is the fewest sessions a trend is fitted on.
+	minTrendSessions = 3
+	// trendWeight is the priority added per percentage point lost each session.
+	trendWeight = 2.0
+	// minSpotQuestions is the fewest answers a spot needs before it is ranked.
+	minSpotQuestions = 4
+	// unplayedPriority ranks a game without enough results as if it were half right:
+	// behind clear weak spots but ahead of games that are going well.
+	unplayedPriority = 50.0
+	// planSize is the number of games in a daily plan.
+	planSize = 4
+)
+
+// observation is how the player did on one spot in one session.
+type observation struct {
+	Round     int
+	Condition string
+	Level     int
+	Questions int
+	Correct   int
+}
+
+// game knows how to break a game's sessions into spots and how to practice one.
+type game struct {
+	code      string
+	levelUnit string // Names the level in labels, e.g. "discs"
+	observe   func(db *sql.DB, sessionID int64) ([]observation, error)
+	// settings builds practice settings from the settings of the game's last session,
+	// "" if it has not been played, aimed at spot when it is not nil.
+	settings func(last string, spot *types.WeakSpot) (interface{}, error)
+}
+
+// practice returns a settings builder that starts from the last session's settings, or
+// def, and lets target switch real mode off and aim the settings at the spot.
+func practice[S any](def S, target func(s *S, spot *types.WeakSpot)) func(string, *types.WeakSpot) (interface{}, error) {
+	return func(last string, spot *types.WeakSpot) (interface{}, error) {
+		s := def
+		if last != "" {
+			var settings S
+			if err := json.Unmarshal([]byte(last), &settings); err != nil {
+				return nil, fmt.Errorf("invalid settings of the last session: %w", err)
+			}
+			s = settings
+		}
+		target(&s, spot)
+		return s, nil
+	}
+}
+
+var games = []game{
+	{
+		code: types.GameCodeShapeRotation,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetShapeRotationSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, rs := range stats.RoundStats {
+				obs = append(obs, observation{Round: rs.Round, Questions: rs.TotalQuestions, Correct: rs.TotalCorrect})
+			}
+			return obs, nil
+		},
+		settings: practice(types.ShapeRotationSettings{NumProblems: 10, TimeLimit: 180, Round: 1},
+			func(s *types.ShapeRotationSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil {
+					s.Round = spot.Round
+				}
+			}),
+	},
+	{
+		code: types.GameCodeRPS,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetRpsSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, rs := range stats.RoundStats {
+				if len(rs.ProblemCardHolderStats) == 0 {
+					obs = append(obs, observation{Round: rs.Round, Questions: rs.TotalQuestions, Correct: rs.TotalCorrect})
+				}
+				for _, h := range rs.ProblemCardHolderStats {
+					obs = append(obs, observation{Round: rs.Round, Condition: h.ProblemCardHolder, Questions: h.TotalQuestions, Correct: h.TotalCorrect})
+				}
+			}
+			return obs, nil
+		},
+		settings: practice(types.RpsSettings{Rounds: []int{1, 2, 3}, QuestionsPerRound: 10, TimeLimitMs: 3000},
+			func(s *types.RpsSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil {
+					s.Rounds = []int{spot.Round}
+				}
+			}),
+	},
+	{
+		code: types.GameCodeNBack,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetNBackSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, rs := range stats.RoundStats {
+				if len(rs.NBackLevelStats) == 0 {
+					obs = append(obs, observation{Round: rs.Round, Questions: rs.TotalQuestions, Correct: rs.TotalCorrect})
+				}
+				for _, ls := range rs.NBackLevelStats {
+					obs = append(obs, observation{Round: rs.Round, Condition: fmt.Sprintf("%d-back", ls.NBackLevel), Questions: ls.TotalQuestions, Correct: ls.TotalCorrect})
+				}
+			}
+			return obs, nil
+		},
+		settings: practice(types.NBackSettings{NumTrials: 25, PresentationTime: 3000, NBackLevel: 1, ShapeGroup: "group1"},
+			func(s *types.NBackSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil && spot.Condition == "3-back" {
+					s.NBackLevel = 2 // 3-back only comes in the mix with 2-back
+				}
+			}),
+	},
+	{
+		code: types.GameCodeNumberPressing,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetNumberPressingSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, rs := range stats.RoundStats {
+				if len(rs.ConditionStats) == 0 {
+					obs = append(obs, observation{Round: rs.Round, Questions: rs.TotalQuestions, Correct: rs.TotalCorrect})
+				}
+				for _, cs := range rs.ConditionStats {
+					obs = append(obs, observation{Round: rs.Round, Condition: cs.ConditionType, Questions: cs.TotalQuestions, Correct: cs.TotalCorrect})
+				}
+			}
+			return obs, nil
+		},
+		settings: practice(types.NumberPressingSetup{Rounds: []int{1, 2}, ProblemsPerRound: 10, TimeLimitR1: 30, TimeLimitR2: 60},
+			func(s *types.NumberPressingSetup, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot == nil {
+					return
+				}
+				s.Rounds = []int{spot.Round}
+				if spot.Round == 2 && spot.Condition != "" {
+					s.RuleTypes = []string{spot.Condition}
+				}
+			}),
+	},
+	{
+		code: types.GameCodeCountComparison,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetCountComparisonSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, ts := range stats.TrapStats {
+				obs = append(obs, observation{Condition: ts.TrapType, Questions: ts.TotalQuestions, Correct: ts.TotalCorrect})
+			}
+			return obs, nil
+		},
+		// Traps cannot be picked, so a trap spot is practiced with the usual mix.
+		settings: practice(types.CountComparisonSettings{NumProblems: 10, PresentationTime: 1000, InputTime: 3000},
+			func(s *types.CountComparisonSettings, spot *types.WeakSpot) { s.IsRealMode = false }),
+	},
+	{
+		code: types.GameCodeCatChaser,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetCatChaserSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			// Cat Chaser rounds are single trials, so the session is one spot.
+			return []observation{{Questions: stats.TotalQuestions, Correct: stats.TotalCorrect}}, nil
+		},
+		settings: practice(types.CatChaserSettings{NumTrials: 6, Difficulty: types.CatChaserDifficulty{Mode: types.CatChaserDifficultyAuto}, ShowTime: 1.0, ResponseTimeLimit: 3.0},
+			func(s *types.CatChaserSettings, spot *types.WeakSpot) { s.IsRealMode = false }),
+	},
+	{
+		code:      types.GameCodeTower,
+		levelUnit: "discs",
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetTowerSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, ds := range stats.DiscStats {
+				obs = append(obs, observation{Level: ds.Discs, Questions: ds.TotalProblems, Correct: ds.TotalSolved})
+			}
+			return obs, nil
+		},
+		settings: practice(types.TowerSettings{NumProblems: 5},
+			func(s *types.TowerSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil {
+					s.MinDiscs, s.MaxDiscs = spot.Level, spot.Level
+				}
+			}),
+	},
+	{
+		code:      types.GameCodeWeight,
+		levelUnit: "objects",
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetWeightSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, os := range stats.ObjectStats {
+				obs = append(obs, observation{Level: os.NumObjects, Questions: os.TotalProblems, Correct: os.TotalCorrect})
+			}
+			return obs, nil
+		},
+		settings: practice(types.WeightSettings{NumProblems: 5},
+			func(s *types.WeightSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil {
+					s.NumObjects = spot.Level
+				}
+			}),
+	},
+	{
+		code: types.GameCodeRoad,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetRoadSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			return []observation{{Questions: stats.TotalProblems, Correct: stats.TotalSolved}}, nil
+		},
+		settings: practice(types.RoadSettings{NumProblems: 5},
+			func(s *types.RoadSettings, spot *types.WeakSpot) { s.IsRealMode = false }),
+	},
+	{
+		code: types.GameCodePositionMemory,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetPositionMemorySessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			return []observation{{Condition: stats.Mode, Questions: stats.TotalSequences, Correct: stats.TotalCorrect}}, nil
+		},
+		settings: practice(types.PositionMemorySettings{},
+			func(s *types.PositionMemorySettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot != nil {
+					s.Mode = spot.Condition
+				}
+			}),
+	},
+	{
+		code: types.GameCodeStroop,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetStroopSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			var obs []observation
+			for _, cs := range stats.ConditionStats {
+				obs = append(obs, observation{Condition: cs.Condition, Questions: cs.TotalTrials, Correct: cs.TotalCorrect})
+			}
+			return obs, nil
+		},
+		settings: practice(types.StroopSettings{},
+			func(s *types.StroopSettings, spot *types.WeakSpot) {
+				s.IsRealMode = false
+				if spot == nil {
+					return
+				}
+				// Deal twice as many trials of the weak condition.
+				s.Mix = types.StroopTrialMix{Congruent: 1, Incongruent: 1, Neutral: 1}
+				switch spot.Condition {
+				case types.StroopCongruent:
+					s.Mix.Congruent = 2
+				case types.StroopIncongruent:
+					s.Mix.Incongruent = 2
+				case types.StroopNeutral:
+					s.Mix.Neutral = 2
+				}
+			}),
+	},
+	{
+		code: types.GameCodeScheduling,
+		observe: func(db *sql.DB, sessionID int64) ([]observation, error) {
+			stats, err := database.GetSchedulingSessionStats(db, sessionID)
+			if err != nil {
+				return nil, err
+			}
+			// A constraint kind is gotten right each time it is shown and kept.
+			var obs []observation
+			for _, ks := range stats.KindStats {
+				obs = append(obs, observation{Condition: ks.Kind, Questions: ks.TotalShown, Correct: ks.TotalShown - ks.TotalViolated})
+			}
+			return obs, nil
+		},
+		settings: practice(types.SchedulingSettings{NumProblems: 5},
+			func(s *types.SchedulingSettings, spot *types.WeakSpot) { s.IsRealMode = false }),
+	},
+}
+
+// Service builds practice plans from the player's results.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// DailyPlan ranks the player's weak spots and returns today's practice plan.
+func (s *Service) DailyPlan() (*types.DailyPlan, error) {
+	return s.dailyPlan(time.Now())
+}
+
+// candidate is a game competing for a place in the plan.
+type candidate struct {
+	game     game
+	spot     *types.WeakSpot
+	last     string // Settings of the last session
+	priority float64
+}
+
+func (s *Service) dailyPlan(now time.Time) (*types.DailyPlan, error) {
+	plan := &types.DailyPlan{Date: now.Format("2006-01-02"), Items: []types.PracticeItem{}, WeakSpots: []types.WeakSpot{}}
+
+	var candidates []candidate
+	for _, g := range games {
+		spots, last, err := s.weakSpots(g, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find weak spots for %s: %w", g.code, err)
+		}
+		plan.WeakSpots = append(plan.WeakSpots, spots...)
+
+		c := candidate{game: g, last: last, priority: unplayedPriority}
+		if len(spots) > 0 {
+			c.spot = &spots[0]
+			c.priority = spots[0].Priority
+		}
+		candidates = append(candidates, c)
+	}
+	sortSpots(plan.WeakSpots)
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].priority > candidates[j].priority })
+
+	for i, c := range candidates {
+		if i == planSize {
+			break
+		}
+		settings, err := c.game.settings(c.last, c.spot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s settings: %w", c.game.code, err)
+		}
+		plan.Items = append(plan.Items, types.PracticeItem{
+			Rank:     i + 1,
+			GameCode: c.game.code,
+			Spot:     c.spot,
+			Reason:   reason(c),
+			Settings: settings,
+		})
+	}
+	return plan, nil
+}
+
+// spotTally gathers one spot's observations across sessions.
+type spotTally struct {
+	spot              types.WeakSpot
+	weightedQuestions float64
+	weightedCorrect   float64
+	sessionAccuracies []float64
+}
+
+// weakSpots returns a game's spots that have enough answers, weakest first, and the
+// settings of its last session.
+func (s *Service) weakSpots(g game, now time.Time) ([]types.WeakSpot, string, error) {
+	sessions, err := database.GetSessionsWithResults(s.db, g.code)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(sessions) == 0 {
+		return nil, "", nil
+	}
+
+	tallies := make(map[observation]*spotTally) // Keyed by round, condition and level only
+	var order []observation
+	for _, session := range sessions {
+		obs, err := g.observe(s.db, session.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		ageDays := math.Max(now.Sub(session.PlayDatetime.Time).Hours()/24, 0)
+		weight := math.Pow(0.5, ageDays/recencyHalfLifeDays)
+
+		for _, o := range obs {
+			if o.Questions == 0 {
+				continue
+			}
+			key := observation{Round: o.Round, Condition: o.Condition, Level: o.Level}
+			t, ok := tallies[key]
+			if !ok {
+				t = &spotTally{spot: types.WeakSpot{
+					GameCode:  g.code,
+					Round:     o.Round,
+					Condition: o.Condition,
+					Level:     o.Level,
+					Label:     label(g, o),
+				}}
+				tallies[key] = t
+				order = append(order, key)
+			}
+			t.spot.Sessions++
+			t.spot.TotalQuestions += o.Questions
+			t.spot.LastPlayed = session.PlayDatetime
+			t.weightedQuestions += weight * float64(o.Questions)
+			t.weightedCorrect += weight * float64(o.Correct)
+			t.sessionAccuracies = append(t.sessionAccuracies, float64(o.Correct)/float64(o.Questions)*100)
+		}
+	}
+
+	var spots []types.WeakSpot
+	for _, key := range order {
+		t := tallies[key]
+		if t.spot.TotalQuestions < minSpotQuestions {
+			continue
+		}
+		t.spot.Accuracy = t.weightedCorrect / t.weightedQuestions * 100
+		t.spot.Trend = trend(t.sessionAccuracies)
+		t.spot.Priority = (100 - t.spot.Accuracy) - trendWeight*t.spot.Trend
+		spots = append(spots, t.spot)
+	}
+	sortSpots(spots)
+	return spots, sessions[len(sessions)-1].Settings, nil
+}
+
+// trend fits a line through the latest accuracies and returns its slope in percentage
+// points per session, or 0 with too few sessions.
+func trend(accuracies []float64) float64 {
+	if len(accuracies) > trendSessions {
+		accuracies = accuracies[len(accuracies)-trendSessions:]
+	}
+	n := float64(len(accuracies))
+	if len(accuracies) < minTrendSessions {
+		return 0
+	}
+
+	meanX, meanY := (n-1)/2, 0.0
+	for _, y := range accuracies {
+		meanY += y
+	}
+	meanY /= n
+	var sxy, sxx float64
+	for i, y := range accuracies {
+		dx := float64(i) - meanX
+		sxy += dx * (y - meanY)
+		sxx += dx * dx
+	}
+	return sxy / sxx
+}
+
+// sortSpots orders spots by priority, highest first.
+func sortSpots(spots []types.WeakSpot) {
+	sort.SliceStable(spots, func(i, j int) bool { return spots[i].Priority > spots[j].Priority })
+}
+
+// label names a spot, e.g. "Round 2, opponent" or "4 discs".
+func label(g game, o observation) string {
+	var parts []string
+	if o.Round > 0 {
+		parts = append(parts, fmt.Sprintf("Round %d", o.Round))
+	}
+	if o.Condition != "" {
+		parts = append(parts, o.Condition)
+	}
+	if o.Level > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s", o.Level, g.levelUnit))
+	}
+	if len(parts) == 0 {
+		return "Overall"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// reason explains why a game made the plan.
+func reason(c candidate) string {
+	if c.spot == nil {
+		if c.last == "" {
+			return "Not played yet"
+		}
+		return "Too few results to judge yet"
+	}
+	r := fmt.Sprintf("%.0f%% accuracy on %s", c.spot.Accuracy, c.spot.Label)
+	switch {
+	case c.spot.Trend <= -1:
+		r += fmt.Sprintf(", falling %.1f points per session", -c.spot.Trend)
+	case c.spot.Trend >= 1:
+		r += fmt.Sprintf(", rising %.1f points per session", c.spot.Trend)
+	}
+	return r
+}
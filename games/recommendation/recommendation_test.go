@@ -0,0 +1,97 @@
+package recommendation
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// playedAt moves a session's play time, which is otherwise the moment it was created.
+func playedAt(t *testing.T, db *sql.DB, sessionID int64, at time.Time) {
+	_, err := db.Exec("UPDATE game_sessions SET play_datetime = ? WHERE id = ?", types.CustomTime{Time: at}, sessionID)
+	assert.NoError(t, err)
+}
+
+func TestTrend(t *testing.T) {
+	assert.Equal(t, 0.0, trend([]float64{100, 0}), "Two sessions are too few for a trend")
+	assert.InDelta(t, -10, trend([]float64{80, 70, 60}), 1e-9)
+	assert.InDelta(t, 10, trend([]float64{90, 0, 10, 20, 30, 40, 50}), 1e-9, "Only the latest sessions count")
+}
+
+func TestDailyPlan(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	now := time.Now()
+
+	// Every Tower session solves both 3-disc problems and neither 4-disc problem.
+	for day := 3; day >= 1; day-- {
+		settings := types.TowerSettings{NumProblems: 4, MinDiscs: 3, MaxDiscs: 4, IsRealMode: true}
+		sessionID, err := database.CreateGameSession(db, types.GameCodeTower, settings)
+		assert.NoError(t, err)
+		playedAt(t, db, sessionID, now.AddDate(0, 0, -day))
+		for p := 1; p <= 4; p++ {
+			discs := 3
+			if p > 2 {
+				discs = 4
+			}
+			assert.NoError(t, database.SaveTowerResult(db, types.TowerResult{
+				SessionID: sessionID, ProblemNum: p, Discs: discs,
+				Start: [][]int{{}, {}, {}}, Goal: [][]int{{}, {}, {}}, Moves: []types.TowerMove{},
+				IsSolved: discs == 3, TimeTakenMs: 1000,
+			}))
+		}
+	}
+
+	// Road went badly two months ago and well today: the recent session dominates.
+	for i, solved := range []bool{false, true} {
+		sessionID, err := database.CreateGameSession(db, types.GameCodeRoad, types.RoadSettings{NumProblems: 4})
+		assert.NoError(t, err)
+		if i == 0 {
+			playedAt(t, db, sessionID, now.AddDate(0, -2, 0))
+		}
+		for p := 1; p <= 4; p++ {
+			assert.NoError(t, database.SaveRoadResult(db, types.RoadResult{
+				SessionID: sessionID, ProblemNum: p, Layout: []types.RoadTile{}, IsSolved: solved,
+			}))
+		}
+	}
+
+	plan, err := NewService(db).dailyPlan(now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Format("2006-01-02"), plan.Date)
+	assert.Len(t, plan.Items, planSize)
+	assert.Len(t, plan.WeakSpots, 3)
+
+	first := plan.Items[0]
+	assert.Equal(t, types.GameCodeTower, first.GameCode)
+	if assert.NotNil(t, first.Spot) {
+		assert.Equal(t, 4, first.Spot.Level)
+		assert.Equal(t, "4 discs", first.Spot.Label)
+		assert.Equal(t, 0.0, first.Spot.Accuracy)
+		assert.Equal(t, 3, first.Spot.Sessions)
+	}
+	assert.Equal(t, types.TowerSettings{NumProblems: 4, MinDiscs: 4, MaxDiscs: 4}, first.Settings,
+		"Practice keeps the last settings, aimed at the weak spot and out of real mode")
+
+	// The unplayed games follow, Road is ranked last of all.
+	for _, item := range plan.Items[1:] {
+		assert.Nil(t, item.Spot)
+		assert.Equal(t, "Not played yet", item.Reason)
+		assert.NotEqual(t, types.GameCodeRoad, item.GameCode)
+	}
+	assert.Equal(t, types.GameCodeShapeRotation, plan.Items[1].GameCode)
+	assert.Equal(t, types.ShapeRotationSettings{NumProblems: 10, TimeLimit: 180, Round: 1}, plan.Items[1].Settings)
+
+	for _, spot := range plan.WeakSpots {
+		if spot.GameCode == types.GameCodeRoad {
+			assert.Greater(t, spot.Accuracy, 90.0)
+			assert.Equal(t, "Overall", spot.Label)
+		}
+	}
+	assert.Equal(t, "3 discs", plan.WeakSpots[len(plan.WeakSpots)-1].Label)
+}
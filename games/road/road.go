@@ -0,0 +1,310 @@
+package road
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// RoadGameState holds the current state of the game.
+type RoadGameState struct {
+	Settings types.RoadSettings  `json:"settings"`
+	Problems []types.RoadProblem `json:"problems"`
+	ID       int64               `json:"id"`
+	// NextProblem is the first problem not yet finished, or one past the last when all are.
+	NextProblem int `json:"nextProblem"`
+}
+
+// savedGame is what is stored so that a session can be resumed.
+type savedGame struct {
+	State     RoadGameState `json:"state"`
+	Submitted []bool        `json:"submitted"`
+}
+
+// game is one Road session in play.
+type game struct {
+	state     *RoadGameState
+	submitted []bool
+}
+
+// Service for the Road game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.RoadSettings) (*RoadGameState, error) {
+	settings = withDefaults(settings)
+
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate problems: %w", err)
+	}
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeRoad, settings, len(problems))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &RoadGameState{
+			Settings: settings,
+			Problems: problems,
+			ID:       sessionID,
+		},
+		submitted: make([]bool, len(problems)),
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first problem not yet finished.
+func (s *Service) ResumeSession(sessionID int64) (*RoadGameState, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved.Submitted) != len(saved.State.Problems) {
+		return nil, fmt.Errorf("saved state of session %d is inconsistent", sessionID)
+	}
+	g := &game{state: &saved.State, submitted: saved.Submitted}
+	g.state.NextProblem = next
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// saveState stores the game, pointing at the first problem not yet finished.
+func (g *game) saveState(db *sql.DB) error {
+	next := len(g.submitted) + 1
+	for i, done := range g.submitted {
+		if !done {
+			next = i + 1
+			break
+		}
+	}
+	g.state.NextProblem = next
+	saved := savedGame{State: *g.state, Submitted: g.submitted}
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitLayout checks the tiles the player placed, traces the road from the start and
+// saves the result. A layout that breaks the rules of the board is rejected and not saved;
+// one that simply does not reach the goal is saved as unsolved.
+func (s *Service) SubmitLayout(sessionID int64, problemNum int, layout []types.RoadTile, timeTakenMs int) (*types.RoadResult, error) {
+	var result *types.RoadResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitLayout(s.db, problemNum, layout, timeTakenMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitLayout(db *sql.DB, problemNum int, layout []types.RoadTile, timeTakenMs int) (*types.RoadResult, error) {
+	if problemNum < 1 || problemNum > len(g.state.Problems) {
+		return nil, fmt.Errorf("invalid problem number")
+	}
+	if g.submitted[problemNum-1] {
+		return nil, fmt.Errorf("problem %d is already finished", problemNum)
+	}
+	problem := g.state.Problems[problemNum-1]
+
+	b, err := newBoard(problem)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		layout = []types.RoadTile{}
+	}
+	if err := b.checkLayout(layout); err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+
+	result := types.RoadResult{
+		SessionID:   g.state.ID,
+		ProblemNum:  problemNum,
+		Problem:     problem,
+		Layout:      layout,
+		TilesUsed:   len(layout),
+		MinTiles:    problem.MinTiles,
+		IsSolved:    b.trace(layout),
+		TimeTakenMs: timeTakenMs,
+	}
+
+	if err := database.SaveRoadResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+	g.submitted[problemNum-1] = true
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Helper Functions ---
+
+const (
+	defaultNumProblems = 10
+	minGridSize        = 3
+	maxGridSize        = 6
+	defaultGridSize    = 5
+	defaultObstacles   = 4
+	defaultSpareTiles  = 1
+	defaultMinTiles    = 4
+	problemAttempts    = 1000
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.RoadSettings) types.RoadSettings {
+	if settings.NumProblems <= 0 {
+		settings.NumProblems = defaultNumProblems
+	}
+	if settings.GridRows == 0 {
+		settings.GridRows = defaultGridSize
+	}
+	if settings.GridCols == 0 {
+		settings.GridCols = defaultGridSize
+	}
+	if settings.Obstacles == nil {
+		obstacles := defaultObstacles
+		settings.Obstacles = &obstacles
+	}
+	if settings.SpareTiles == nil {
+		spare := defaultSpareTiles
+		settings.SpareTiles = &spare
+	}
+	if settings.MinTiles == 0 {
+		settings.MinTiles = defaultMinTiles
+	}
+	return settings
+}
+
+// generateProblems creates the problems of a session.
+func generateProblems(settings types.RoadSettings, rng *rand.Rand) ([]types.RoadProblem, error) {
+	if settings.GridRows < minGridSize || settings.GridRows > maxGridSize ||
+		settings.GridCols < minGridSize || settings.GridCols > maxGridSize {
+		return nil, fmt.Errorf("grid must be between %d and %d cells a side, got %dx%d",
+			minGridSize, maxGridSize, settings.GridRows, settings.GridCols)
+	}
+	if *settings.Obstacles < 0 || *settings.SpareTiles < 0 || settings.MinTiles < 1 {
+		return nil, fmt.Errorf("invalid obstacle, spare tile or minimum tile count")
+	}
+	// The start, the goal and the shortest road all need free cells.
+	if *settings.Obstacles+2+settings.MinTiles > settings.GridRows*settings.GridCols {
+		return nil, fmt.Errorf("a %dx%d grid has no room for %d obstacles and a road of %d tiles",
+			settings.GridRows, settings.GridCols, *settings.Obstacles, settings.MinTiles)
+	}
+
+	problems := make([]types.RoadProblem, settings.NumProblems)
+	for i := range problems {
+		p, err := generateProblem(settings, rng)
+		if err != nil {
+			return nil, err
+		}
+		p.ProblemNum = i + 1
+		problems[i] = p
+	}
+	return problems, nil
+}
+
+// generateProblem lays out random obstacles, a start and a goal until the shortest road
+// between them needs at least settings.MinTiles tiles. The tiles on hand are those of
+// that road plus the spares, so the problem can always be solved.
+func generateProblem(settings types.RoadSettings, rng *rand.Rand) (types.RoadProblem, error) {
+	rows, cols := settings.GridRows, settings.GridCols
+	for attempt := 0; attempt < problemAttempts; attempt++ {
+		cells := rng.Perm(rows * cols)
+		cellAt := func(i int) types.RoadCell {
+			return types.RoadCell{Row: cells[i] / cols, Col: cells[i] % cols}
+		}
+
+		problem := types.RoadProblem{
+			GridRows:  rows,
+			GridCols:  cols,
+			Obstacles: make([]types.RoadCell, *settings.Obstacles),
+		}
+		for j := range problem.Obstacles {
+			problem.Obstacles[j] = cellAt(j + 2)
+		}
+		start, goal := cellAt(0), cellAt(1)
+		problem.Start = types.RoadEndpoint{Row: start.Row, Col: start.Col, Side: sideNames[openSide(rng, start, rows, cols)]}
+		problem.Goal = types.RoadEndpoint{Row: goal.Row, Col: goal.Col, Side: sideNames[openSide(rng, goal, rows, cols)]}
+
+		b, err := newBoard(problem)
+		if err != nil {
+			return types.RoadProblem{}, err
+		}
+		path := b.solve(unlimited, unlimited)
+		if path == nil || len(path) < settings.MinTiles {
+			continue
+		}
+
+		for _, t := range path {
+			if isStraight(t.Kind) {
+				problem.Straights++
+			} else {
+				problem.Curves++
+			}
+		}
+		problem.Straights += *settings.SpareTiles
+		problem.Curves += *settings.SpareTiles
+		problem.MinTiles = len(path)
+		return problem, nil
+	}
+	return types.RoadProblem{}, fmt.Errorf("no solvable problem found after %d attempts", problemAttempts)
+}
+
+// openSide picks a side of a cell that faces into the grid.
+func openSide(rng *rand.Rand, c types.RoadCell, rows, cols int) int {
+	for {
+		side := rng.Intn(4)
+		next := move(c, side)
+		if next.Row >= 0 && next.Row < rows && next.Col >= 0 && next.Col < cols {
+			return side
+		}
+	}
+}
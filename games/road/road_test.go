@@ -0,0 +1,179 @@
+package road
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func count(v int) *int { return &v }
+
+// A 3x3 board with the start in the top left corner, leaving downwards, and the goal on
+// the right of the middle row, open at the bottom. The centre is blocked:
+//
+//	S . .
+//	. # G
+//	. . .
+func detourProblem() types.RoadProblem {
+	return types.RoadProblem{
+		GridRows:  3,
+		GridCols:  3,
+		Obstacles: []types.RoadCell{{Row: 1, Col: 1}},
+		Start:     types.RoadEndpoint{Row: 0, Col: 0, Side: "S"},
+		Goal:      types.RoadEndpoint{Row: 1, Col: 2, Side: "S"},
+		Straights: 5,
+		Curves:    5,
+	}
+}
+
+func TestSolve(t *testing.T) {
+	problem := detourProblem()
+	path, err := Solve(problem)
+	assert.NoError(t, err)
+	// Down the left column, along the bottom and up into the goal from below.
+	assert.Equal(t, []types.RoadTile{
+		{Row: 1, Col: 0, Kind: types.RoadTileNS},
+		{Row: 2, Col: 0, Kind: types.RoadTileNE},
+		{Row: 2, Col: 1, Kind: types.RoadTileEW},
+		{Row: 2, Col: 2, Kind: types.RoadTileNW},
+	}, path)
+
+	b, _ := newBoard(problem)
+	assert.True(t, b.trace(path))
+
+	// The same road needs two curves.
+	problem.Curves = 1
+	_, err = Solve(problem)
+	assert.Error(t, err, "One curve cannot turn the road around the obstacle")
+
+	problem.Goal.Side = "N"
+	_, err = Solve(problem)
+	assert.Error(t, err, "Leaving downwards, the road cannot reach the top of the goal")
+
+	problem.Start.Side = "E"
+	path, err = Solve(problem)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RoadTile{
+		{Row: 0, Col: 1, Kind: types.RoadTileEW},
+		{Row: 0, Col: 2, Kind: types.RoadTileSW},
+	}, path)
+}
+
+func TestTileKinds(t *testing.T) {
+	for _, kind := range []string{types.RoadTileNS, types.RoadTileEW, types.RoadTileNE, types.RoadTileNW, types.RoadTileSE, types.RoadTileSW} {
+		a, b, ok := tileSides(kind)
+		assert.True(t, ok, kind)
+		assert.Equal(t, kind, tileKind(a, b))
+		assert.Equal(t, kind, tileKind(b, a))
+	}
+	_, _, ok := tileSides("SN")
+	assert.False(t, ok, "Kinds have one spelling")
+}
+
+func TestTrace(t *testing.T) {
+	b, _ := newBoard(detourProblem())
+
+	assert.False(t, b.trace(nil), "No tiles, no road")
+	assert.False(t, b.trace([]types.RoadTile{
+		{Row: 1, Col: 0, Kind: types.RoadTileNS},
+		{Row: 2, Col: 0, Kind: types.RoadTileNW}, // Turns off the grid
+		{Row: 2, Col: 1, Kind: types.RoadTileEW},
+		{Row: 2, Col: 2, Kind: types.RoadTileNW},
+	}))
+	assert.False(t, b.trace([]types.RoadTile{
+		{Row: 1, Col: 0, Kind: types.RoadTileEW}, // Does not open towards the start
+	}))
+
+	assert.Error(t, b.checkLayout([]types.RoadTile{{Row: 1, Col: 1, Kind: types.RoadTileNS}}), "Obstacle")
+	assert.Error(t, b.checkLayout([]types.RoadTile{{Row: 3, Col: 0, Kind: types.RoadTileNS}}), "Outside")
+	assert.Error(t, b.checkLayout([]types.RoadTile{{Row: 0, Col: 0, Kind: types.RoadTileNS}}), "Start cell")
+	assert.Error(t, b.checkLayout([]types.RoadTile{{Row: 1, Col: 0, Kind: "XY"}}), "Unknown kind")
+	assert.Error(t, b.checkLayout([]types.RoadTile{
+		{Row: 1, Col: 0, Kind: types.RoadTileNS}, {Row: 1, Col: 0, Kind: types.RoadTileEW},
+	}), "Two tiles on one cell")
+}
+
+func TestGenerateProblems(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	settings := withDefaults(types.RoadSettings{NumProblems: 20, GridRows: 6, GridCols: 6, Obstacles: count(8), MinTiles: 6})
+
+	problems, err := generateProblems(settings, rng)
+	assert.NoError(t, err)
+	assert.Len(t, problems, 20)
+
+	for _, p := range problems {
+		assert.GreaterOrEqual(t, p.MinTiles, 6)
+		assert.Len(t, p.Obstacles, 8)
+
+		// The shortest road fits the tiles on hand and has the stated length.
+		path, err := Solve(p)
+		assert.NoError(t, err, "Problem %d is not solvable", p.ProblemNum)
+		assert.Len(t, path, p.MinTiles)
+		assert.Equal(t, p.MinTiles+2, p.Straights+p.Curves, "One spare of each kind")
+	}
+
+	_, err = generateProblems(withDefaults(types.RoadSettings{GridRows: 7}), rng)
+	assert.Error(t, err, "Grids are at most six cells a side")
+
+	_, err = generateProblems(withDefaults(types.RoadSettings{GridRows: 3, GridCols: 3, Obstacles: count(5)}), rng)
+	assert.Error(t, err, "No room for the road")
+}
+
+func TestService_SubmitLayout(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.SubmitLayout(1, 1, nil, 100)
+	assert.Error(t, err, "Submitting before the game starts should fail")
+
+	state, err := service.StartGame(types.RoadSettings{NumProblems: 2, SpareTiles: count(0)})
+	assert.NoError(t, err)
+	assert.Len(t, state.Problems, 2)
+
+	// Lay the shortest road on problem 1.
+	path, err := Solve(state.Problems[0])
+	assert.NoError(t, err)
+	res, err := service.SubmitLayout(state.ID, 1, path, 4000)
+	assert.NoError(t, err)
+	assert.True(t, res.IsSolved)
+	assert.Equal(t, res.MinTiles, res.TilesUsed)
+
+	_, err = service.SubmitLayout(state.ID, 1, path, 4000)
+	assert.Error(t, err, "A finished problem cannot be submitted again")
+
+	// With no spares, one tile more than the shortest road is over the tiles on hand.
+	problem := state.Problems[1]
+	path, _ = Solve(problem)
+	over := append([]types.RoadTile{}, path...)
+	b, _ := newBoard(problem)
+	for r := 0; r < problem.GridRows && len(over) == len(path); r++ {
+		for c := 0; c < problem.GridCols; c++ {
+			cell := types.RoadCell{Row: r, Col: c}
+			onPath := false
+			for _, t := range path {
+				onPath = onPath || (t.Row == r && t.Col == c)
+			}
+			if !b.blocked[cell] && !onPath {
+				over = append(over, types.RoadTile{Row: r, Col: c, Kind: path[0].Kind})
+				break
+			}
+		}
+	}
+	_, err = service.SubmitLayout(state.ID, 2, over, 1000)
+	assert.Error(t, err, "Layouts may only use the tiles on hand")
+
+	// An incomplete road is saved as unsolved.
+	res, err = service.SubmitLayout(state.ID, 2, path[:len(path)-1], 5000)
+	assert.NoError(t, err)
+	assert.False(t, res.IsSolved)
+
+	results, err := database.GetRoadResultsForSession(db, state.ID)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, state.Problems[1], results[1].Problem)
+}
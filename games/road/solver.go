@@ -0,0 +1,286 @@
+package road
+
+import (
+	"fmt"
+
+	"acca-games/types"
+)
+
+// Sides of a cell, clockwise from the top.
+const (
+	north = iota
+	east
+	south
+	west
+)
+
+var sideNames = []string{"N", "E", "S", "W"}
+
+// step is the row and column offset of moving out through each side.
+var step = [4][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+func opposite(side int) int {
+	return (side + 2) % 4
+}
+
+func sideIndex(name string) (int, bool) {
+	for i, n := range sideNames {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// tileKind names the tile that connects two sides of a cell.
+func tileKind(a, b int) string {
+	name := sideNames[a] + sideNames[b]
+	switch name {
+	case "SN", "WE", "EN", "WN", "ES", "WS":
+		name = sideNames[b] + sideNames[a]
+	}
+	return name
+}
+
+// tileSides returns the two sides a tile connects.
+func tileSides(kind string) (int, int, bool) {
+	if len(kind) != 2 {
+		return 0, 0, false
+	}
+	a, okA := sideIndex(kind[:1])
+	b, okB := sideIndex(kind[1:])
+	if !okA || !okB || tileKind(a, b) != kind {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+func isStraight(kind string) bool {
+	return kind == types.RoadTileNS || kind == types.RoadTileEW
+}
+
+// board is a problem laid out for lookups.
+type board struct {
+	problem   types.RoadProblem
+	blocked   map[types.RoadCell]bool // Obstacles, the start and the goal
+	startSide int
+	goalSide  int
+	need      map[types.RoadCell]int // Fewest tiles from a cell to the goal, ignoring the tiles on hand
+}
+
+func newBoard(problem types.RoadProblem) (*board, error) {
+	startSide, ok := sideIndex(problem.Start.Side)
+	if !ok {
+		return nil, fmt.Errorf("invalid start side: %s", problem.Start.Side)
+	}
+	goalSide, ok := sideIndex(problem.Goal.Side)
+	if !ok {
+		return nil, fmt.Errorf("invalid goal side: %s", problem.Goal.Side)
+	}
+	b := &board{
+		problem:   problem,
+		blocked:   make(map[types.RoadCell]bool),
+		startSide: startSide,
+		goalSide:  goalSide,
+	}
+	for _, c := range problem.Obstacles {
+		b.blocked[c] = true
+	}
+	b.blocked[types.RoadCell{Row: problem.Start.Row, Col: problem.Start.Col}] = true
+	b.blocked[types.RoadCell{Row: problem.Goal.Row, Col: problem.Goal.Col}] = true
+	b.need = b.tilesNeeded()
+	return b, nil
+}
+
+// tilesNeeded runs a breadth-first search back from the cell in front of the goal's open
+// side. A cell it cannot reach can never lead to the goal.
+func (b *board) tilesNeeded() map[types.RoadCell]int {
+	need := make(map[types.RoadCell]int)
+	last := move(types.RoadCell{Row: b.problem.Goal.Row, Col: b.problem.Goal.Col}, b.goalSide)
+	if !b.inside(last) || b.blocked[last] {
+		return need
+	}
+	need[last] = 1
+	queue := []types.RoadCell{last}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for side := 0; side < 4; side++ {
+			next := move(c, side)
+			if _, seen := need[next]; seen || !b.inside(next) || b.blocked[next] {
+				continue
+			}
+			need[next] = need[c] + 1
+			queue = append(queue, next)
+		}
+	}
+	return need
+}
+
+func (b *board) inside(c types.RoadCell) bool {
+	return c.Row >= 0 && c.Row < b.problem.GridRows && c.Col >= 0 && c.Col < b.problem.GridCols
+}
+
+func (b *board) isGoal(c types.RoadCell) bool {
+	return c.Row == b.problem.Goal.Row && c.Col == b.problem.Goal.Col
+}
+
+func move(c types.RoadCell, side int) types.RoadCell {
+	return types.RoadCell{Row: c.Row + step[side][0], Col: c.Col + step[side][1]}
+}
+
+// checkLayout makes sure every tile is a known kind on a free cell, no cell holds two
+// tiles and the tiles on hand are not exceeded.
+func (b *board) checkLayout(layout []types.RoadTile) error {
+	seen := make(map[types.RoadCell]bool)
+	straights, curves := 0, 0
+	for _, t := range layout {
+		c := types.RoadCell{Row: t.Row, Col: t.Col}
+		if _, _, ok := tileSides(t.Kind); !ok {
+			return fmt.Errorf("unknown tile kind %q at (%d, %d)", t.Kind, t.Row, t.Col)
+		}
+		if !b.inside(c) {
+			return fmt.Errorf("tile at (%d, %d) is outside the grid", t.Row, t.Col)
+		}
+		if b.blocked[c] {
+			return fmt.Errorf("cell (%d, %d) cannot hold a tile", t.Row, t.Col)
+		}
+		if seen[c] {
+			return fmt.Errorf("cell (%d, %d) holds more than one tile", t.Row, t.Col)
+		}
+		seen[c] = true
+		if isStraight(t.Kind) {
+			straights++
+		} else {
+			curves++
+		}
+	}
+	if straights > b.problem.Straights || curves > b.problem.Curves {
+		return fmt.Errorf("layout uses %d straight and %d curved tiles, only %d and %d are on hand",
+			straights, curves, b.problem.Straights, b.problem.Curves)
+	}
+	return nil
+}
+
+// trace follows the road from the start through the placed tiles and reports whether
+// it reaches the goal through the goal's open side.
+func (b *board) trace(layout []types.RoadTile) bool {
+	tiles := make(map[types.RoadCell]string, len(layout))
+	for _, t := range layout {
+		tiles[types.RoadCell{Row: t.Row, Col: t.Col}] = t.Kind
+	}
+
+	cell := move(types.RoadCell{Row: b.problem.Start.Row, Col: b.problem.Start.Col}, b.startSide)
+	in := opposite(b.startSide)
+	visited := make(map[types.RoadCell]bool)
+	for {
+		if b.isGoal(cell) {
+			return in == b.goalSide
+		}
+		kind, ok := tiles[cell]
+		if !ok || visited[cell] {
+			return false
+		}
+		visited[cell] = true
+
+		x, y, _ := tileSides(kind)
+		var out int
+		switch in {
+		case x:
+			out = y
+		case y:
+			out = x
+		default:
+			return false // The tile does not open towards the road
+		}
+		cell = move(cell, out)
+		in = opposite(out)
+	}
+}
+
+// unlimited is the tile budget used when the tiles on hand should not matter.
+const unlimited = 1 << 20
+
+// solve finds a road with the fewest tiles that fits the tiles on hand, or nil if there
+// is none. It deepens the search one tile at a time, so the first road found is shortest.
+func (b *board) solve(straights, curves int) []types.RoadTile {
+	start := move(types.RoadCell{Row: b.problem.Start.Row, Col: b.problem.Start.Col}, b.startSide)
+	in := opposite(b.startSide)
+	if b.isGoal(start) {
+		if in == b.goalSide {
+			return []types.RoadTile{}
+		}
+		return nil
+	}
+	first, ok := b.need[start]
+	if !ok {
+		return nil
+	}
+	maxTiles := min(b.problem.GridRows*b.problem.GridCols-len(b.blocked), straights+curves)
+
+	for limit := first; limit <= maxTiles; limit++ {
+		s := &search{board: b, visited: make(map[types.RoadCell]bool)}
+		if s.dfs(start, in, limit, straights, curves) {
+			return s.path
+		}
+	}
+	return nil
+}
+
+// search is one depth-limited walk of the solver.
+type search struct {
+	*board
+	visited map[types.RoadCell]bool
+	path    []types.RoadTile
+}
+
+func (s *search) dfs(cell types.RoadCell, in int, budget int, straights, curves int) bool {
+	if s.isGoal(cell) {
+		return in == s.goalSide
+	}
+	if s.visited[cell] {
+		return false
+	}
+	// Blocked and outside cells have no entry, so they are cut off here too.
+	need, ok := s.need[cell]
+	if !ok || budget < need {
+		return false
+	}
+
+	s.visited[cell] = true
+	for out := 0; out < 4; out++ {
+		if out == in {
+			continue
+		}
+		kind := tileKind(in, out)
+		ns, nc := straights, curves
+		if isStraight(kind) {
+			ns--
+		} else {
+			nc--
+		}
+		if ns < 0 || nc < 0 {
+			continue
+		}
+		s.path = append(s.path, types.RoadTile{Row: cell.Row, Col: cell.Col, Kind: kind})
+		if s.dfs(move(cell, out), opposite(out), budget-1, ns, nc) {
+			return true
+		}
+		s.path = s.path[:len(s.path)-1]
+	}
+	s.visited[cell] = false
+	return false
+}
+
+// Solve returns a road with the fewest tiles for a problem, using only the tiles on hand.
+func Solve(problem types.RoadProblem) ([]types.RoadTile, error) {
+	b, err := newBoard(problem)
+	if err != nil {
+		return nil, err
+	}
+	path := b.solve(problem.Straights, problem.Curves)
+	if path == nil {
+		return nil, fmt.Errorf("problem has no solution")
+	}
+	return path, nil
+}
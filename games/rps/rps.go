@@ -6,6 +6,7 @@ import (
 	"math/rand"
 
 	"acca-games/database"
+	"acca-games/games/session"
 	"acca-games/types"
 )
 
@@ -15,17 +16,19 @@ type GameState struct {
 	Problems   []Problem         `json:"problems"`
 	ID         int64             `json:"id"`
 	GameCode   string            `json:"gameCode"`
+	// NextQuestion is the number of the next unanswered question.
+	NextQuestion int `json:"nextQuestion"`
 }
 
-// Service holds the database connection and the current game state.
+// Service holds the database connection and the games in play.
 type Service struct {
-	db           *sql.DB
-	currentState *GameState
+	db    *sql.DB
+	games *session.Manager[GameState]
 }
 
 // NewService creates a new RPS service.
 func NewService(db *sql.DB) *Service {
-	return &Service{db: db}
+	return &Service{db: db, games: session.NewManager[GameState](session.DefaultIdleTimeout)}
 }
 
 // Problem defines a single question in the RPS game.
@@ -49,32 +52,84 @@ func (s *Service) StartGame(settings types.RpsSettings) (*GameState, error) {
 		}
 	}
 
-	sessionID, err := database.CreateGameSession(s.db, types.GameCodeRPS, settings)
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeRPS, settings, len(problems))
 	if err != nil {
 		return nil, err
 	}
 
-	s.currentState = &GameState{
+	state := &GameState{
 		Settings:   settings,
 		Problems:   problems,
 		ID:         sessionID,
 		GameCode:   types.GameCodeRPS,
+		NextQuestion: 1,
 	}
+	if err := database.SaveSessionState(s.db, sessionID, state, 1); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, state)
+
+	return state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the next unanswered question.
+func (s *Service) ResumeSession(sessionID int64) (*GameState, error) {
+	var state GameState
+	next, err := database.LoadSessionState(s.db, sessionID, &state)
+	if err != nil {
+		return nil, err
+	}
+	state.NextQuestion = next
+	s.games.Add(sessionID, &state)
+	return &state, nil
+}
 
-	return s.currentState, nil
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
 }
 
 // SubmitAnswer checks the answer, saves it, and returns the result.
-func (s *Service) SubmitAnswer(playerChoice string, responseTimeMs int, questionNum int) (*types.RpsResult, error) {
-	if s.currentState == nil || questionNum < 1 || questionNum > len(s.currentState.Problems) {
-		return nil, fmt.Errorf("invalid game state or question number")
+func (s *Service) SubmitAnswer(sessionID int64, playerChoice string, responseTimeMs int, questionNum int) (*types.RpsResult, error) {
+	var result *types.RpsResult
+	err := s.games.With(sessionID, func(gs *GameState) error {
+		var err error
+		result, err = gs.submitAnswer(s.db, playerChoice, responseTimeMs, questionNum)
+		return err
+	})
+	return result, err
+}
+
+func (gs *GameState) submitAnswer(db *sql.DB, playerChoice string, responseTimeMs int, questionNum int) (*types.RpsResult, error) {
+	if questionNum < 1 || questionNum > len(gs.Problems) {
+		return nil, fmt.Errorf("invalid question number")
 	}
 
-	problem := s.currentState.Problems[questionNum-1]
+	problem := gs.Problems[questionNum-1]
 	isCorrect := playerChoice == problem.CorrectChoice
 
 	result := types.RpsResult{
-		SessionID:         s.currentState.ID,
+		SessionID:         gs.ID,
 		Round:             problem.Round,
 		QuestionNum:       problem.QuestionNum,
 		ProblemCardHolder: problem.ProblemCardHolder,
@@ -85,7 +140,11 @@ func (s *Service) SubmitAnswer(playerChoice string, responseTimeMs int, question
 		CorrectChoice:     problem.CorrectChoice,
 	}
 
-	if err := database.SaveRpsResult(s.db, result); err != nil {
+	if err := database.SaveRpsResult(db, result); err != nil {
+		return nil, err
+	}
+	gs.NextQuestion = questionNum + 1
+	if err := database.SetSessionProgress(db, gs.ID, questionNum+1); err != nil {
 		return nil, err
 	}
 
@@ -87,7 +87,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 	}
 
 	// Manually set predictable problems for testing
-	service.currentState.Problems = []Problem{
+	gameState.Problems = []Problem{
 		{ProblemCardHolder: "me", GivenCard: "ROCK", CorrectChoice: "PAPER", Round: 1, QuestionNum: 1},
 		{ProblemCardHolder: "me", GivenCard: "PAPER", CorrectChoice: "SCISSORS", Round: 1, QuestionNum: 2},
 		{ProblemCardHolder: "opponent", GivenCard: "PAPER", CorrectChoice: "ROCK", Round: 2, QuestionNum: 3},
@@ -128,8 +128,8 @@ func TestService_SubmitAnswer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			problem := service.currentState.Problems[tt.questionNum-1]
-			result, err := service.SubmitAnswer(tt.playerChoice, 500, tt.questionNum)
+			problem := gameState.Problems[tt.questionNum-1]
+			result, err := service.SubmitAnswer(gameState.ID, tt.playerChoice, 500, tt.questionNum)
 			if err != nil {
 				t.Fatalf("SubmitAnswer failed: %v", err)
 			}
@@ -159,7 +159,7 @@ func TestService_SubmitAnswer(t *testing.T) {
 	}
 
 	t.Run("Invalid question number", func(t *testing.T) {
-		_, err := service.SubmitAnswer("ROCK", 500, 99) // 99 is out of bounds
+		_, err := service.SubmitAnswer(gameState.ID, "ROCK", 500, 99) // 99 is out of bounds
 		if err == nil {
 			t.Error("Expected an error for invalid question number, but got nil")
 		}
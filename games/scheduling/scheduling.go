@@ -0,0 +1,384 @@
+package scheduling
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+	"unicode/utf8"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// SchedulingGameState holds the current state of the game.
+type SchedulingGameState struct {
+	Settings types.SchedulingSettings  `json:"settings"`
+	Problems []types.SchedulingProblem `json:"problems"`
+	ID       int64                     `json:"id"`
+	// NextProblem is the first problem not yet finished, or one past the last when all are.
+	NextProblem int `json:"nextProblem"`
+}
+
+// savedGame is what is stored so that a session can be resumed. The solutions are kept
+// apart from the state, which leaves them out of its JSON.
+type savedGame struct {
+	State     SchedulingGameState      `json:"state"`
+	Solutions [][]types.SchedulingSlot `json:"solutions"`
+	Submitted []bool                   `json:"submitted"`
+}
+
+// game is one Scheduling session in play.
+type game struct {
+	state     *SchedulingGameState
+	submitted []bool
+}
+
+// Service for the Scheduling game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.SchedulingSettings) (*SchedulingGameState, error) {
+	settings = withDefaults(settings)
+
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate problems: %w", err)
+	}
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeScheduling, settings, len(problems))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &SchedulingGameState{
+			Settings: settings,
+			Problems: problems,
+			ID:       sessionID,
+		},
+		submitted: make([]bool, len(problems)),
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first problem not yet finished.
+func (s *Service) ResumeSession(sessionID int64) (*SchedulingGameState, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved.Submitted) != len(saved.State.Problems) || len(saved.Solutions) != len(saved.State.Problems) {
+		return nil, fmt.Errorf("saved state of session %d is inconsistent", sessionID)
+	}
+	for i := range saved.State.Problems {
+		saved.State.Problems[i].Solution = saved.Solutions[i]
+	}
+	g := &game{state: &saved.State, submitted: saved.Submitted}
+	g.state.NextProblem = next
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// saveState stores the game, pointing at the first problem not yet finished.
+func (g *game) saveState(db *sql.DB) error {
+	next := len(g.submitted) + 1
+	for i, done := range g.submitted {
+		if !done {
+			next = i + 1
+			break
+		}
+	}
+	g.state.NextProblem = next
+	saved := savedGame{State: *g.state, Submitted: g.submitted}
+	saved.Solutions = make([][]types.SchedulingSlot, len(g.state.Problems))
+	for i, p := range g.state.Problems {
+		saved.Solutions[i] = p.Solution
+	}
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitPlacement checks the slot the player gave each person against every constraint
+// and saves the result. A placement that leaves someone out, goes off the grid or puts
+// two people in one slot is rejected and not saved.
+func (s *Service) SubmitPlacement(sessionID int64, problemNum int, placement []types.SchedulingSlot, responseTimeMs int) (*types.SchedulingResult, error) {
+	var result *types.SchedulingResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitPlacement(s.db, problemNum, placement, responseTimeMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitPlacement(db *sql.DB, problemNum int, placement []types.SchedulingSlot, responseTimeMs int) (*types.SchedulingResult, error) {
+	if problemNum < 1 || problemNum > len(g.state.Problems) {
+		return nil, fmt.Errorf("invalid problem number")
+	}
+	if g.submitted[problemNum-1] {
+		return nil, fmt.Errorf("problem %d is already finished", problemNum)
+	}
+	problem := g.state.Problems[problemNum-1]
+
+	violated, err := violations(problem, placement)
+	if err != nil {
+		return nil, fmt.Errorf("invalid placement: %w", err)
+	}
+
+	result := types.SchedulingResult{
+		SessionID:      g.state.ID,
+		ProblemNum:     problemNum,
+		NumPeople:      len(problem.People),
+		Constraints:    problem.Constraints,
+		Placement:      placement,
+		Solution:       problem.Solution,
+		IsCorrect:      len(violated) == 0, // The solution is unique, so meeting every constraint means matching it
+		Violated:       violated,
+		NumViolated:    len(violated),
+		ResponseTimeMs: responseTimeMs,
+	}
+
+	if err := database.SaveSchedulingResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+	g.submitted[problemNum-1] = true
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// violations returns the indexes of the constraints a placement breaks.
+func violations(problem types.SchedulingProblem, placement []types.SchedulingSlot) ([]int, error) {
+	g := grid{days: problem.NumDays, periods: problem.NumPeriods}
+	if len(placement) != len(problem.People) {
+		return nil, fmt.Errorf("expected a slot for each of %d people, got %d", len(problem.People), len(placement))
+	}
+	slots := make([]int, len(placement))
+	taken := make(map[int]int)
+	for i, p := range placement {
+		if !g.contains(p) {
+			return nil, fmt.Errorf("%s is placed outside the grid", problem.People[i])
+		}
+		slots[i] = g.slot(p)
+		if other, ok := taken[slots[i]]; ok {
+			return nil, fmt.Errorf("%s and %s share a slot", problem.People[other], problem.People[i])
+		}
+		taken[slots[i]] = i
+	}
+
+	violated := []int{}
+	for i, c := range problem.Constraints {
+		if !g.holds(c, slots) {
+			violated = append(violated, i)
+		}
+	}
+	return violated, nil
+}
+
+// --- Helper Functions ---
+
+var names = []string{"민준", "서연", "지호", "하은", "도윤", "수아"}
+
+const (
+	defaultNumProblems = 5
+	minPeople          = 3
+	defaultNumPeople   = 4
+	maxDays            = 5
+	defaultNumDays     = 3
+	maxPeriods         = 4
+	defaultNumPeriods  = 3
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.SchedulingSettings) types.SchedulingSettings {
+	if settings.NumProblems <= 0 {
+		settings.NumProblems = defaultNumProblems
+	}
+	if settings.NumPeople == 0 {
+		settings.NumPeople = defaultNumPeople
+	}
+	if settings.NumDays == 0 {
+		settings.NumDays = defaultNumDays
+	}
+	if settings.NumPeriods == 0 {
+		settings.NumPeriods = defaultNumPeriods
+	}
+	return settings
+}
+
+// generateProblems creates the problems of a session.
+func generateProblems(settings types.SchedulingSettings, rng *rand.Rand) ([]types.SchedulingProblem, error) {
+	if settings.NumPeople < minPeople || settings.NumPeople > len(names) {
+		return nil, fmt.Errorf("people must be between %d and %d, got %d", minPeople, len(names), settings.NumPeople)
+	}
+	if settings.NumDays < 1 || settings.NumDays > maxDays || settings.NumPeriods < 1 || settings.NumPeriods > maxPeriods {
+		return nil, fmt.Errorf("invalid grid of %d days and %d periods", settings.NumDays, settings.NumPeriods)
+	}
+	if settings.NumPeople > settings.NumDays*settings.NumPeriods {
+		return nil, fmt.Errorf("%d people do not fit in %d slots", settings.NumPeople, settings.NumDays*settings.NumPeriods)
+	}
+
+	problems := make([]types.SchedulingProblem, settings.NumProblems)
+	for i := range problems {
+		problems[i] = generateProblem(settings, rng)
+		problems[i].ProblemNum = i + 1
+	}
+	return problems, nil
+}
+
+// generateProblem hides a random placement, then adds constraints that it meets, in
+// random order, until the solver finds no other placement. Constraints that turn out not
+// to be needed are dropped again, so every one left is part of the reasoning.
+func generateProblem(settings types.SchedulingSettings, rng *rand.Rand) types.SchedulingProblem {
+	g := grid{days: settings.NumDays, periods: settings.NumPeriods}
+	people := make([]string, settings.NumPeople)
+	for i, idx := range rng.Perm(len(names))[:settings.NumPeople] {
+		people[i] = names[idx]
+	}
+	hidden := rng.Perm(g.size())[:settings.NumPeople]
+
+	candidates := candidateConstraints(g, hidden)
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	var constraints []types.SchedulingConstraint
+	for _, c := range candidates {
+		constraints = append(constraints, c)
+		if newSolver(g, len(people), constraints).count(2) == 1 {
+			break
+		}
+	}
+	// Every placement but the hidden one breaks some candidate, so the loop always ends unique.
+
+	for i := len(constraints) - 1; i >= 0; i-- {
+		without := append(append([]types.SchedulingConstraint{}, constraints[:i]...), constraints[i+1:]...)
+		if newSolver(g, len(people), without).count(2) == 1 {
+			constraints = without
+		}
+	}
+
+	for i := range constraints {
+		constraints[i].Description = describe(constraints[i], people)
+	}
+	solution := make([]types.SchedulingSlot, len(hidden))
+	for i, slot := range hidden {
+		solution[i] = g.at(slot)
+	}
+	return types.SchedulingProblem{
+		People:      people,
+		NumDays:     g.days,
+		NumPeriods:  g.periods,
+		Constraints: constraints,
+		Solution:    solution,
+	}
+}
+
+// candidateConstraints lists every constraint the hidden placement meets.
+func candidateConstraints(g grid, hidden []int) []types.SchedulingConstraint {
+	var candidates []types.SchedulingConstraint
+	add := func(c types.SchedulingConstraint) {
+		if g.holds(c, hidden) {
+			candidates = append(candidates, c)
+		}
+	}
+	for p := range hidden {
+		for d := 0; d < g.days; d++ {
+			add(types.SchedulingConstraint{Kind: types.SchedulingNotDay, Person: p, Day: d})
+			add(types.SchedulingConstraint{Kind: types.SchedulingOnDay, Person: p, Day: d})
+		}
+		for t := 0; t < g.periods; t++ {
+			add(types.SchedulingConstraint{Kind: types.SchedulingNotPeriod, Person: p, Period: t})
+		}
+		for o := range hidden {
+			if o == p {
+				continue
+			}
+			add(types.SchedulingConstraint{Kind: types.SchedulingBefore, Person: p, Other: o})
+			add(types.SchedulingConstraint{Kind: types.SchedulingRightAfter, Person: p, Other: o})
+			if p < o { // Symmetric, so one of each pair is enough
+				add(types.SchedulingConstraint{Kind: types.SchedulingSameDay, Person: p, Other: o})
+				add(types.SchedulingConstraint{Kind: types.SchedulingDifferentDay, Person: p, Other: o})
+			}
+		}
+	}
+	return candidates
+}
+
+// describe words a constraint for the player. Days and periods are shown from 1.
+func describe(c types.SchedulingConstraint, people []string) string {
+	a := people[c.Person]
+	switch c.Kind {
+	case types.SchedulingNotDay:
+		return fmt.Sprintf("%s %d일차에는 올 수 없습니다.", withParticle(a, "은", "는"), c.Day+1)
+	case types.SchedulingOnDay:
+		return fmt.Sprintf("%s %d일차에만 올 수 있습니다.", withParticle(a, "은", "는"), c.Day+1)
+	case types.SchedulingNotPeriod:
+		return fmt.Sprintf("%s %d교시에는 항상 올 수 없습니다.", withParticle(a, "은", "는"), c.Period+1)
+	}
+	b := people[c.Other]
+	switch c.Kind {
+	case types.SchedulingBefore:
+		return fmt.Sprintf("%s %s보다 먼저 옵니다.", withParticle(a, "은", "는"), b)
+	case types.SchedulingRightAfter:
+		return fmt.Sprintf("%s 같은 날 %s 바로 다음에 옵니다.", withParticle(b, "은", "는"), a)
+	case types.SchedulingSameDay:
+		return fmt.Sprintf("%s %s 같은 날 옵니다.", withParticle(a, "과", "와"), withParticle(b, "은", "는"))
+	case types.SchedulingDifferentDay:
+		return fmt.Sprintf("%s %s 서로 다른 날 옵니다.", withParticle(a, "과", "와"), withParticle(b, "은", "는"))
+	}
+	return ""
+}
+
+// withParticle adds a Korean particle to a word, in the form that fits how the word ends:
+// afterConsonant when its last syllable closes on a consonant, as in 민준은, and
+// afterVowel otherwise, as in 지호는.
+func withParticle(word, afterConsonant, afterVowel string) string {
+	r, _ := utf8.DecodeLastRuneInString(word)
+	if r >= '가' && r <= '힣' && (r-'가')%28 != 0 {
+		return word + afterConsonant
+	}
+	return word + afterVowel
+}
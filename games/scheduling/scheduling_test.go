@@ -0,0 +1,150 @@
+package scheduling
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolve(t *testing.T) {
+	// Two days of two periods. 민준 comes on day 1 with 서연 right after them, which fills
+	// day 1 and leaves 지호 a choice of both periods on day 2.
+	problem := types.SchedulingProblem{
+		People:     []string{"민준", "서연", "지호"},
+		NumDays:    2,
+		NumPeriods: 2,
+		Constraints: []types.SchedulingConstraint{
+			{Kind: types.SchedulingOnDay, Person: 0, Day: 0},
+			{Kind: types.SchedulingRightAfter, Person: 0, Other: 1},
+		},
+	}
+	_, unique, err := Solve(problem)
+	assert.NoError(t, err)
+	assert.False(t, unique)
+
+	problem.Constraints = append(problem.Constraints, types.SchedulingConstraint{Kind: types.SchedulingNotPeriod, Person: 2, Period: 0})
+	placement, unique, err := Solve(problem)
+	assert.NoError(t, err)
+	assert.True(t, unique)
+	assert.Equal(t, []types.SchedulingSlot{{Day: 0, Period: 0}, {Day: 0, Period: 1}, {Day: 1, Period: 1}}, placement)
+
+	problem.Constraints = append(problem.Constraints, types.SchedulingConstraint{Kind: types.SchedulingBefore, Person: 2, Other: 0})
+	_, _, err = Solve(problem)
+	assert.Error(t, err, "Nobody can come before 민준")
+
+	problem.Constraints[0].Person = 5
+	_, _, err = Solve(problem)
+	assert.Error(t, err, "Unknown people are rejected")
+}
+
+func TestDescribe(t *testing.T) {
+	people := []string{"민준", "지호", "서연"}
+	assert.Equal(t, "민준은 2일차에만 올 수 있습니다.", describe(types.SchedulingConstraint{Kind: types.SchedulingOnDay, Person: 0, Day: 1}, people))
+	assert.Equal(t, "지호는 1교시에는 항상 올 수 없습니다.", describe(types.SchedulingConstraint{Kind: types.SchedulingNotPeriod, Person: 1, Period: 0}, people))
+	assert.Equal(t, "서연은 같은 날 지호 바로 다음에 옵니다.", describe(types.SchedulingConstraint{Kind: types.SchedulingRightAfter, Person: 1, Other: 2}, people))
+	assert.Equal(t, "지호와 민준은 같은 날 옵니다.", describe(types.SchedulingConstraint{Kind: types.SchedulingSameDay, Person: 1, Other: 0}, people))
+	assert.Equal(t, "민준과 지호는 서로 다른 날 옵니다.", describe(types.SchedulingConstraint{Kind: types.SchedulingDifferentDay, Person: 0, Other: 1}, people))
+}
+
+func TestGenerateProblems(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	settings := withDefaults(types.SchedulingSettings{NumProblems: 10, NumPeople: 6, NumDays: 5, NumPeriods: 4})
+
+	problems, err := generateProblems(settings, rng)
+	assert.NoError(t, err)
+	assert.Len(t, problems, 10)
+
+	for _, p := range problems {
+		assert.Len(t, p.People, 6)
+		placement, unique, err := Solve(p)
+		assert.NoError(t, err)
+		assert.True(t, unique, "Problem %d has more than one answer", p.ProblemNum)
+		assert.Equal(t, p.Solution, placement)
+
+		for i, c := range p.Constraints {
+			assert.NotEmpty(t, c.Description)
+			// Every constraint is needed: without it the answer is no longer unique.
+			without := p
+			without.Constraints = append(append([]types.SchedulingConstraint{}, p.Constraints[:i]...), p.Constraints[i+1:]...)
+			_, unique, _ := Solve(without)
+			assert.False(t, unique, "Constraint %q of problem %d is redundant", c.Description, p.ProblemNum)
+		}
+	}
+
+	_, err = generateProblems(withDefaults(types.SchedulingSettings{NumPeople: 5, NumDays: 2, NumPeriods: 2}), rng)
+	assert.Error(t, err, "Five people do not fit in four slots")
+}
+
+func TestService_SubmitPlacement(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.SubmitPlacement(1, 1, nil, 100)
+	assert.Error(t, err, "Submitting before the game starts should fail")
+
+	state, err := service.StartGame(types.SchedulingSettings{NumProblems: 2})
+	assert.NoError(t, err)
+	assert.Len(t, state.Problems, 2)
+
+	res, err := service.SubmitPlacement(state.ID, 1, state.Problems[0].Solution, 30000)
+	assert.NoError(t, err)
+	assert.True(t, res.IsCorrect)
+	assert.Empty(t, res.Violated)
+
+	_, err = service.SubmitPlacement(state.ID, 1, state.Problems[0].Solution, 30000)
+	assert.Error(t, err, "A finished problem cannot be submitted again")
+
+	// Rotate everyone one slot along the solution: every slot is still used once, but
+	// the unique answer is gone, so some constraint breaks.
+	solution := state.Problems[1].Solution
+	wrong := append(append([]types.SchedulingSlot{}, solution[1:]...), solution[0])
+	shared := append([]types.SchedulingSlot{}, solution...)
+	shared[1] = shared[0]
+	_, err = service.SubmitPlacement(state.ID, 2, shared, 1000)
+	assert.Error(t, err, "Two people cannot share a slot")
+	_, err = service.SubmitPlacement(state.ID, 2, solution[:1], 1000)
+	assert.Error(t, err, "Everyone needs a slot")
+
+	res, err = service.SubmitPlacement(state.ID, 2, wrong, 45000)
+	assert.NoError(t, err)
+	assert.False(t, res.IsCorrect)
+	assert.Greater(t, res.NumViolated, 0)
+	assert.Len(t, res.Violated, res.NumViolated)
+
+	results, err := database.GetSchedulingResultsForSession(db, state.ID)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestService_ResumeSession(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	state, err := NewService(db).StartGame(types.SchedulingSettings{NumProblems: 2})
+	assert.NoError(t, err)
+
+	// A fresh service, as after a restart, still knows the solutions.
+	service := NewService(db)
+	resumed, err := service.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed.NextProblem)
+	result, err := service.SubmitPlacement(state.ID, 1, state.Problems[0].Solution, 20000)
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.Equal(t, state.Problems[0].Solution, result.Solution)
+
+	restarted := NewService(db)
+	resumed, err = restarted.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resumed.NextProblem)
+	result, err = restarted.SubmitPlacement(state.ID, 2, state.Problems[1].Solution, 20000)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Solution)
+	assert.Equal(t, state.Problems[1].Solution, result.Solution)
+}
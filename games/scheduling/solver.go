@@ -0,0 +1,179 @@
+package scheduling
+
+import (
+	"fmt"
+
+	"acca-games/types"
+)
+
+// grid is the shape of the time grid. Slots are numbered day by day, so a lower slot
+// number is always an earlier appointment.
+type grid struct {
+	days    int
+	periods int
+}
+
+func (g grid) size() int {
+	return g.days * g.periods
+}
+
+func (g grid) slot(s types.SchedulingSlot) int {
+	return s.Day*g.periods + s.Period
+}
+
+func (g grid) at(slot int) types.SchedulingSlot {
+	return types.SchedulingSlot{Day: slot / g.periods, Period: slot % g.periods}
+}
+
+func (g grid) contains(s types.SchedulingSlot) bool {
+	return s.Day >= 0 && s.Day < g.days && s.Period >= 0 && s.Period < g.periods
+}
+
+// holds reports whether a constraint is met, given the slot number of every person it
+// mentions.
+func (g grid) holds(c types.SchedulingConstraint, slots []int) bool {
+	a := g.at(slots[c.Person])
+	switch c.Kind {
+	case types.SchedulingNotDay:
+		return a.Day != c.Day
+	case types.SchedulingOnDay:
+		return a.Day == c.Day
+	case types.SchedulingNotPeriod:
+		return a.Period != c.Period
+	}
+
+	b := g.at(slots[c.Other])
+	switch c.Kind {
+	case types.SchedulingBefore:
+		return slots[c.Person] < slots[c.Other]
+	case types.SchedulingRightAfter:
+		return a.Day == b.Day && b.Period == a.Period+1
+	case types.SchedulingSameDay:
+		return a.Day == b.Day
+	case types.SchedulingDifferentDay:
+		return a.Day != b.Day
+	}
+	return false
+}
+
+// lastPerson is the highest-numbered person a constraint mentions. The solver checks a
+// constraint as soon as that person has a slot.
+func lastPerson(c types.SchedulingConstraint) int {
+	switch c.Kind {
+	case types.SchedulingNotDay, types.SchedulingOnDay, types.SchedulingNotPeriod:
+		return c.Person
+	}
+	return max(c.Person, c.Other)
+}
+
+// solver counts placements by backtracking over the people in order.
+type solver struct {
+	grid
+	numPeople int
+	checks    [][]types.SchedulingConstraint // Per person, the constraints to check on placing them
+	slots     []int
+	taken     []bool
+	limit     int
+	found     int
+	first     []int
+}
+
+func newSolver(g grid, numPeople int, constraints []types.SchedulingConstraint) *solver {
+	s := &solver{
+		grid:      g,
+		numPeople: numPeople,
+		checks:    make([][]types.SchedulingConstraint, numPeople),
+		slots:     make([]int, numPeople),
+		taken:     make([]bool, g.size()),
+	}
+	for _, c := range constraints {
+		p := lastPerson(c)
+		s.checks[p] = append(s.checks[p], c)
+	}
+	return s
+}
+
+// count returns the number of placements that meet every constraint, stopping at limit.
+func (s *solver) count(limit int) int {
+	s.limit = limit
+	s.found = 0
+	s.first = nil
+	s.place(0)
+	return s.found
+}
+
+func (s *solver) place(person int) {
+	if person == s.numPeople {
+		if s.found == 0 {
+			s.first = append([]int{}, s.slots...)
+		}
+		s.found++
+		return
+	}
+	for slot := 0; slot < s.size(); slot++ {
+		if s.taken[slot] {
+			continue
+		}
+		s.slots[person] = slot
+		ok := true
+		for _, c := range s.checks[person] {
+			if !s.holds(c, s.slots) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		s.taken[slot] = true
+		s.place(person + 1)
+		s.taken[slot] = false
+		if s.found >= s.limit {
+			return
+		}
+	}
+}
+
+// Solve returns the placement of a problem and whether it is the only one.
+func Solve(problem types.SchedulingProblem) ([]types.SchedulingSlot, bool, error) {
+	g := grid{days: problem.NumDays, periods: problem.NumPeriods}
+	if err := checkConstraints(g, len(problem.People), problem.Constraints); err != nil {
+		return nil, false, err
+	}
+	s := newSolver(g, len(problem.People), problem.Constraints)
+	n := s.count(2)
+	if n == 0 {
+		return nil, false, fmt.Errorf("problem has no solution")
+	}
+	placement := make([]types.SchedulingSlot, len(s.first))
+	for i, slot := range s.first {
+		placement[i] = g.at(slot)
+	}
+	return placement, n == 1, nil
+}
+
+// checkConstraints makes sure every constraint refers to people and slots that exist.
+func checkConstraints(g grid, numPeople int, constraints []types.SchedulingConstraint) error {
+	for i, c := range constraints {
+		if c.Person < 0 || c.Person >= numPeople {
+			return fmt.Errorf("constraint %d: unknown person %d", i, c.Person)
+		}
+		switch c.Kind {
+		case types.SchedulingNotDay, types.SchedulingOnDay:
+			if c.Day < 0 || c.Day >= g.days {
+				return fmt.Errorf("constraint %d: unknown day %d", i, c.Day)
+			}
+		case types.SchedulingNotPeriod:
+			if c.Period < 0 || c.Period >= g.periods {
+				return fmt.Errorf("constraint %d: unknown period %d", i, c.Period)
+			}
+		case types.SchedulingBefore, types.SchedulingRightAfter, types.SchedulingSameDay, types.SchedulingDifferentDay:
+			if c.Other < 0 || c.Other >= numPeople || c.Other == c.Person {
+				return fmt.Errorf("constraint %d: invalid second person %d", i, c.Other)
+			}
+		default:
+			return fmt.Errorf("constraint %d: unknown kind %q", i, c.Kind)
+		}
+	}
+	return nil
+}
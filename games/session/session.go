@@ -0,0 +1,110 @@
+// Package session keeps the games a service has in play, keyed by session ID, so that
+// several sessions can run side by side and be played from concurrent calls.
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultIdleTimeout is how long a game may go unused before it is evicted. An evicted
+// session is not lost: its saved state lets it be resumed.
+const DefaultIdleTimeout = 2 * time.Hour
+
+// Manager holds the games in play. Each game has its own lock, so calls on different
+// sessions run in parallel while calls on the same session run one at a time.
+type Manager[T any] struct {
+	mu          sync.Mutex
+	games       map[int64]*entry[T]
+	idleTimeout time.Duration
+	now         func() time.Time
+}
+
+// entry is one game in play.
+type entry[T any] struct {
+	mu       sync.Mutex
+	game     *T
+	lastUsed time.Time
+}
+
+// NewManager creates a manager that evicts games left unused for longer than idleTimeout.
+// A timeout of zero or less keeps games until they are removed.
+func NewManager[T any](idleTimeout time.Duration) *Manager[T] {
+	return &Manager[T]{
+		games:       make(map[int64]*entry[T]),
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// Add puts a game in play under its session ID, replacing any game already held for that
+// session, and evicts the games that have been idle too long.
+func (m *Manager[T]) Add(sessionID int64, game *T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictIdle()
+	m.games[sessionID] = &entry[T]{game: game, lastUsed: m.now()}
+}
+
+// With runs fn on the game of a session while holding that game's lock, and fails when
+// the session is not in play.
+func (m *Manager[T]) With(sessionID int64, fn func(game *T) error) error {
+	m.mu.Lock()
+	e, ok := m.games[sessionID]
+	if ok {
+		e.lastUsed = m.now()
+	}
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("session %d is not in play", sessionID)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return fn(e.game)
+}
+
+// Remove takes a session out of play. Removing a session that is not in play does nothing.
+func (m *Manager[T]) Remove(sessionID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.games, sessionID)
+}
+
+// Clear takes every session out of play.
+func (m *Manager[T]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.games)
+}
+
+// EvictIdle takes the games that have been idle too long out of play and returns how
+// many there were.
+func (m *Manager[T]) EvictIdle() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.evictIdle()
+}
+
+func (m *Manager[T]) evictIdle() int {
+	if m.idleTimeout <= 0 {
+		return 0
+	}
+	cutoff := m.now().Add(-m.idleTimeout)
+	evicted := 0
+	for id, e := range m.games {
+		if e.lastUsed.Before(cutoff) {
+			delete(m.games, id)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// Len returns the number of games in play.
+func (m *Manager[T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.games)
+}
@@ -0,0 +1,107 @@
+package session
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type counter struct {
+	n int
+}
+
+func TestManager_With(t *testing.T) {
+	m := NewManager[counter](time.Hour)
+	m.Add(1, &counter{})
+	m.Add(2, &counter{n: 10})
+
+	assert.NoError(t, m.With(2, func(c *counter) error {
+		c.n++
+		return nil
+	}))
+	assert.Error(t, m.With(3, func(c *counter) error { return nil }), "A session never added is not in play")
+
+	m.Add(2, &counter{})
+	assert.NoError(t, m.With(2, func(c *counter) error {
+		assert.Equal(t, 0, c.n, "Adding a session again replaces its game")
+		return nil
+	}))
+
+	m.Remove(1)
+	assert.Error(t, m.With(1, func(c *counter) error { return nil }), "A removed session is not in play")
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestManager_EvictIdle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewManager[counter](time.Hour)
+	m.now = func() time.Time { return now }
+
+	m.Add(1, &counter{})
+	now = now.Add(30 * time.Minute)
+	m.Add(2, &counter{})
+	now = now.Add(45 * time.Minute)
+	assert.NoError(t, m.With(2, func(c *counter) error { return nil }))
+
+	assert.Equal(t, 1, m.EvictIdle(), "Only the session unused for over an hour is evicted")
+	assert.Error(t, m.With(1, func(c *counter) error { return nil }))
+	assert.NoError(t, m.With(2, func(c *counter) error { return nil }), "Using a session keeps it in play")
+
+	now = now.Add(2 * time.Hour)
+	m.Add(3, &counter{})
+	assert.Equal(t, 1, m.Len(), "Adding a session sweeps out idle ones")
+
+	keep := NewManager[counter](0)
+	keep.now = func() time.Time { return now }
+	keep.Add(1, &counter{})
+	now = now.Add(1000 * time.Hour)
+	assert.Equal(t, 0, keep.EvictIdle(), "Without a timeout nothing is evicted")
+}
+
+// TestManager_Concurrent is meant for the race detector: sessions are added, played and
+// removed from many goroutines at once.
+func TestManager_Concurrent(t *testing.T) {
+	m := NewManager[counter](time.Hour)
+	const sessions, calls = 8, 50
+	for id := int64(1); id <= sessions; id++ {
+		m.Add(id, &counter{})
+	}
+
+	var wg sync.WaitGroup
+	for id := int64(1); id <= sessions; id++ {
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+			go func(id int64) {
+				defer wg.Done()
+				assert.NoError(t, m.With(id, func(c *counter) error {
+					c.n++
+					return nil
+				}))
+			}(id)
+		}
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < calls; i++ {
+			m.EvictIdle()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < calls; i++ {
+			m.Add(sessions+1, &counter{})
+			m.Remove(sessions + 1)
+		}
+	}()
+	wg.Wait()
+
+	for id := int64(1); id <= sessions; id++ {
+		assert.NoError(t, m.With(id, func(c *counter) error {
+			assert.Equal(t, calls, c.n)
+			return nil
+		}))
+	}
+}
@@ -0,0 +1,88 @@
+package shape_rotation
+
+import (
+	"database/sql"
+	"sync"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+// Saver checks and saves answers in the background, so the player can move on to the
+// next problem without waiting for the database.
+type Saver struct {
+	db      *sql.DB
+	onSaved func(sessionID int64)
+	onError func(error)
+
+	mu      sync.Mutex
+	done    *sync.Cond    // Signalled whenever an answer has been saved or has failed
+	pending map[int64]int // Answers still being saved, per session
+}
+
+// NewSaver creates a saver. onSaved, if set, is called after every answer that was saved
+// and onError for every answer that could not be, both from the saving goroutine and
+// before the answer stops counting as pending.
+func NewSaver(db *sql.DB, onSaved func(sessionID int64), onError func(error)) *Saver {
+	s := &Saver{db: db, onSaved: onSaved, onError: onError, pending: make(map[int64]int)}
+	s.done = sync.NewCond(&s.mu)
+	return s
+}
+
+// SubmitAsync verifies an answer and saves the result without waiting for it.
+func (s *Saver) SubmitAsync(sessionID int64, problem ShapeRotationProblemWithFinalShape, userSolution []string, solveTime int, clickCount int) {
+	s.mu.Lock()
+	s.pending[sessionID]++
+	s.mu.Unlock()
+	go func() {
+		defer s.finish(sessionID)
+		result := types.ShapeRotationResult{
+			SessionID:    sessionID,
+			ProblemID:    problem.ID,
+			UserSolution: userSolution,
+			IsCorrect:    VerifySolution(problem, userSolution),
+			SolveTime:    solveTime,
+			ClickCount:   clickCount,
+		}
+
+		if err := database.SaveShapeRotationResult(s.db, result); err != nil {
+			s.onError(err)
+			return
+		}
+		if err := database.AdvanceSessionProgress(s.db, sessionID); err != nil {
+			s.onError(err)
+			return
+		}
+		if s.onSaved != nil {
+			s.onSaved(sessionID)
+		}
+	}()
+}
+
+func (s *Saver) finish(sessionID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending[sessionID]--; s.pending[sessionID] == 0 {
+		delete(s.pending, sessionID)
+	}
+	s.done.Broadcast()
+}
+
+// WaitSession blocks until every answer submitted so far for the session has been saved
+// or has failed.
+func (s *Saver) WaitSession(sessionID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.pending[sessionID] > 0 {
+		s.done.Wait()
+	}
+}
+
+// Wait blocks until every answer submitted so far has been saved or has failed.
+func (s *Saver) Wait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.pending) > 0 {
+		s.done.Wait()
+	}
+}
@@ -0,0 +1,136 @@
+package shape_rotation
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+)
+
+func TestSaver_ConcurrentWrites(t *testing.T) {
+	// The single-connection pool the app uses, and an unbounded one where writers race for
+	// the file lock and rely on the busy timeout.
+	unbounded := database.DefaultOptions()
+	unbounded.MaxOpenConns = 0
+
+	for name, opts := range map[string]database.Options{"single writer": database.DefaultOptions(), "unbounded pool": unbounded} {
+		t.Run(name, func(t *testing.T) {
+			db, err := database.OpenDatabase(filepath.Join(t.TempDir(), "games.db"), opts)
+			if err != nil {
+				t.Fatalf("OpenDatabase failed: %v", err)
+			}
+			defer db.Close()
+
+			const numSessions, numAnswers = 4, 25
+			problems, err := GetProblems(1, 1)
+			if err != nil {
+				t.Fatalf("GetProblems failed: %v", err)
+			}
+
+			var mu sync.Mutex
+			var errs []error
+			saver := NewSaver(db, nil, func(err error) {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			})
+
+			sessionIDs := make([]int64, numSessions)
+			for i := range sessionIDs {
+				sessionIDs[i], err = database.SaveShapeRotationSession(db, types.ShapeRotationSettings{Round: 1, NumProblems: numAnswers})
+				if err != nil {
+					t.Fatalf("SaveShapeRotationSession failed: %v", err)
+				}
+				if err := database.SaveSessionState(db, sessionIDs[i], problems, 1); err != nil {
+					t.Fatalf("SaveSessionState failed: %v", err)
+				}
+			}
+
+			// Answer every session at once while other sessions are being started.
+			var wg sync.WaitGroup
+			for _, id := range sessionIDs {
+				wg.Add(1)
+				go func(id int64) {
+					defer wg.Done()
+					for j := 0; j < numAnswers; j++ {
+						saver.SubmitAsync(id, problems[0], []string{"rotate_right_45"}, 1000+j, 1)
+					}
+				}(id)
+			}
+			for i := 0; i < numSessions; i++ {
+				if _, err := database.SaveShapeRotationSession(db, types.ShapeRotationSettings{Round: 1}); err != nil {
+					t.Errorf("SaveShapeRotationSession during saving failed: %v", err)
+				}
+			}
+			wg.Wait()
+			saver.Wait()
+
+			if len(errs) > 0 {
+				t.Fatalf("Expected every answer to be saved, got %d errors, first: %v", len(errs), errs[0])
+			}
+			for _, id := range sessionIDs {
+				results, err := database.GetShapeRotationResultsForSession(db, id)
+				if err != nil {
+					t.Fatalf("GetShapeRotationResultsForSession failed: %v", err)
+				}
+				if len(results) != numAnswers {
+					t.Errorf("Session %d: expected %d results, got %d", id, numAnswers, len(results))
+				}
+				var next int
+				if err := db.QueryRow("SELECT next_trial FROM session_states WHERE session_id = ?", id).Scan(&next); err != nil {
+					t.Fatalf("Failed to read progress: %v", err)
+				}
+				if next != numAnswers+1 {
+					t.Errorf("Session %d: expected progress at %d, got %d", id, numAnswers+1, next)
+				}
+			}
+		})
+	}
+}
+
+func TestSaver_WaitSession(t *testing.T) {
+	db, err := database.OpenDatabase(filepath.Join(t.TempDir(), "games.db"), database.DefaultOptions())
+	if err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	defer db.Close()
+
+	problems, err := GetProblems(1, 1)
+	if err != nil {
+		t.Fatalf("GetProblems failed: %v", err)
+	}
+	sessionID, err := database.SaveShapeRotationSession(db, types.ShapeRotationSettings{Round: 1, NumProblems: 10})
+	if err != nil {
+		t.Fatalf("SaveShapeRotationSession failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	saved := 0
+	saver := NewSaver(db, func(id int64) {
+		mu.Lock()
+		saved++
+		mu.Unlock()
+	}, func(err error) { t.Errorf("Saving failed: %v", err) })
+	for i := 0; i < 10; i++ {
+		saver.SubmitAsync(sessionID, problems[0], []string{"rotate_right_45"}, 1000, 1)
+	}
+	saver.WaitSession(sessionID)
+
+	// Once the wait returns, every answer of the session can be read back.
+	results, err := database.GetShapeRotationResultsForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetShapeRotationResultsForSession failed: %v", err)
+	}
+	if len(results) != 10 {
+		t.Errorf("Expected 10 results after waiting, got %d", len(results))
+	}
+	mu.Lock()
+	if saved != 10 {
+		t.Errorf("Expected every saved answer to be reported before the wait returns, got %d", saved)
+	}
+	mu.Unlock()
+	saver.WaitSession(sessionID + 1) // A session with nothing pending does not block
+	saver.Wait()
+}
@@ -0,0 +1,375 @@
+package stroop
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// StroopGameState holds the current state of the game.
+type StroopGameState struct {
+	Settings types.StroopSettings `json:"settings"`
+	Trials   []types.StroopTrial  `json:"trials"`
+	ID       int64                `json:"id"`
+	// NextTrial is the first trial not yet answered, or one past the last when all are.
+	NextTrial int `json:"nextTrial"`
+}
+
+// savedGame is what is stored so that a session can be resumed.
+type savedGame struct {
+	State    StroopGameState `json:"state"`
+	Answered []bool          `json:"answered"`
+}
+
+// game is one Stroop session in play.
+type game struct {
+	state    *StroopGameState
+	answered []bool
+}
+
+// Service for the Stroop game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.StroopSettings) (*StroopGameState, error) {
+	settings = withDefaults(settings)
+	if err := validateSettings(settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+
+	trials := generateTrials(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeStroop, settings, len(trials))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &StroopGameState{
+			Settings: settings,
+			Trials:   trials,
+			ID:       sessionID,
+		},
+		answered: make([]bool, len(trials)),
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first trial not yet answered.
+func (s *Service) ResumeSession(sessionID int64) (*StroopGameState, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved.Answered) != len(saved.State.Trials) {
+		return nil, fmt.Errorf("saved state of session %d is inconsistent", sessionID)
+	}
+	g := &game{state: &saved.State, answered: saved.Answered}
+	g.state.NextTrial = next
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// saveState stores the game, pointing at the first trial not yet answered.
+func (g *game) saveState(db *sql.DB) error {
+	next := len(g.answered) + 1
+	for i, done := range g.answered {
+		if !done {
+			next = i + 1
+			break
+		}
+	}
+	g.state.NextTrial = next
+	saved := savedGame{State: *g.state, Answered: g.answered}
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitAnswer scores the key pressed for a trial and saves the result. An empty key, or
+// an answer slower than the response time limit, counts as a timeout.
+func (s *Service) SubmitAnswer(sessionID int64, trialNum int, responseKey string, responseTimeMs int) (*types.StroopResult, error) {
+	var result *types.StroopResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitAnswer(s.db, trialNum, responseKey, responseTimeMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitAnswer(db *sql.DB, trialNum int, responseKey string, responseTimeMs int) (*types.StroopResult, error) {
+	if trialNum < 1 || trialNum > len(g.state.Trials) {
+		return nil, fmt.Errorf("invalid trial number")
+	}
+	if g.answered[trialNum-1] {
+		return nil, fmt.Errorf("trial %d is already answered", trialNum)
+	}
+
+	settings := g.state.Settings
+	responseColor := ""
+	if responseKey != "" {
+		var ok bool
+		responseColor, ok = colorForKey(settings.ResponseKeys, responseKey)
+		if !ok {
+			return nil, fmt.Errorf("key %q is not a response key", responseKey)
+		}
+	}
+
+	trial := g.state.Trials[trialNum-1]
+	result := scoreTrial(trial, responseKey, responseColor, responseTimeMs, settings.ResponseTimeLimitMs)
+	result.SessionID = g.state.ID
+
+	if err := database.SaveStroopResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+	g.answered[trialNum-1] = true
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Helper Functions ---
+
+// allColors are the ink colors the game can show. Their first letters differ, which the
+// default response keys rely on.
+var allColors = []string{"red", "green", "blue", "yellow", "purple", "orange"}
+
+// colorWords are the words that name each color on screen.
+var colorWords = map[string]string{
+	"red":    "빨강",
+	"green":  "초록",
+	"blue":   "파랑",
+	"yellow": "노랑",
+	"purple": "보라",
+	"orange": "주황",
+}
+
+// neutralWords are shown on neutral trials; none of them names a color, and they are as
+// long as the color words.
+var neutralWords = []string{"의자", "책상", "시계", "우산", "연필", "창문"}
+
+const (
+	defaultNumTrials           = 48
+	defaultResponseTimeLimitMs = 2000
+	defaultNumColors           = 4
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.StroopSettings) types.StroopSettings {
+	if settings.NumTrials <= 0 {
+		settings.NumTrials = defaultNumTrials
+	}
+	if settings.Mix == (types.StroopTrialMix{}) {
+		settings.Mix = types.StroopTrialMix{Congruent: 1, Incongruent: 1, Neutral: 1}
+	}
+	if len(settings.Colors) == 0 {
+		settings.Colors = append([]string{}, allColors[:defaultNumColors]...)
+	}
+	if len(settings.ResponseKeys) == 0 {
+		settings.ResponseKeys = make(map[string]string, len(settings.Colors))
+		for _, c := range settings.Colors {
+			settings.ResponseKeys[c] = c[:1]
+		}
+	}
+	if settings.ResponseTimeLimitMs == 0 {
+		settings.ResponseTimeLimitMs = defaultResponseTimeLimitMs
+	}
+	return settings
+}
+
+func validateSettings(settings types.StroopSettings) error {
+	mix := settings.Mix
+	if mix.Congruent < 0 || mix.Incongruent < 0 || mix.Neutral < 0 {
+		return fmt.Errorf("trial mix must not be negative")
+	}
+	// An incongruent trial needs a second color for the word.
+	if len(settings.Colors) < 2 {
+		return fmt.Errorf("at least 2 colors are needed, got %d", len(settings.Colors))
+	}
+	seen := make(map[string]bool)
+	for _, c := range settings.Colors {
+		if !isKnownColor(c) {
+			return fmt.Errorf("unknown color: %s", c)
+		}
+		if seen[c] {
+			return fmt.Errorf("color %s is listed twice", c)
+		}
+		seen[c] = true
+	}
+
+	keys := make(map[string]string)
+	for _, c := range settings.Colors {
+		key, ok := settings.ResponseKeys[c]
+		if !ok || key == "" {
+			return fmt.Errorf("no response key for %s", c)
+		}
+		if other, taken := keys[key]; taken {
+			return fmt.Errorf("key %q is used for both %s and %s", key, other, c)
+		}
+		keys[key] = c
+	}
+	if len(settings.ResponseKeys) != len(settings.Colors) {
+		return fmt.Errorf("response keys must cover exactly the colors in play")
+	}
+	if settings.ResponseTimeLimitMs < 0 {
+		return fmt.Errorf("response time limit must not be negative")
+	}
+	return nil
+}
+
+func isKnownColor(color string) bool {
+	for _, c := range allColors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+func colorForKey(keys map[string]string, key string) (string, bool) {
+	for color, k := range keys {
+		if k == key {
+			return color, true
+		}
+	}
+	return "", false
+}
+
+// dealConditions splits the trials between the conditions in proportion to the mix,
+// giving leftover trials to the largest remainders, then shuffles the order.
+func dealConditions(mix types.StroopTrialMix, numTrials int, rng *rand.Rand) []string {
+	conditions := []string{types.StroopCongruent, types.StroopIncongruent, types.StroopNeutral}
+	weights := []int{mix.Congruent, mix.Incongruent, mix.Neutral}
+	total := mix.Congruent + mix.Incongruent + mix.Neutral
+
+	counts := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	dealt := 0
+	for i, w := range weights {
+		counts[i] = numTrials * w / total
+		remainders[i] = numTrials * w % total
+		dealt += counts[i]
+	}
+	for ; dealt < numTrials; dealt++ {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		counts[best]++
+		remainders[best] = -1
+	}
+
+	deck := make([]string, 0, numTrials)
+	for i, c := range conditions {
+		for j := 0; j < counts[i]; j++ {
+			deck = append(deck, c)
+		}
+	}
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	return deck
+}
+
+// generateTrials creates the trials of a session.
+func generateTrials(settings types.StroopSettings, rng *rand.Rand) []types.StroopTrial {
+	colors := settings.Colors
+	conditions := dealConditions(settings.Mix, settings.NumTrials, rng)
+
+	trials := make([]types.StroopTrial, len(conditions))
+	for i, condition := range conditions {
+		ink := colors[rng.Intn(len(colors))]
+		trial := types.StroopTrial{TrialNum: i + 1, Condition: condition, InkColor: ink}
+		switch condition {
+		case types.StroopCongruent:
+			trial.Word = colorWords[ink]
+		case types.StroopIncongruent:
+			other := colors[rng.Intn(len(colors)-1)]
+			if other == ink {
+				other = colors[len(colors)-1]
+			}
+			trial.Word = colorWords[other]
+		default:
+			trial.Word = neutralWords[rng.Intn(len(neutralWords))]
+		}
+		trials[i] = trial
+	}
+	return trials
+}
+
+// wordColor returns the color a word names, or "" for a neutral word.
+func wordColor(word string) string {
+	for color, w := range colorWords {
+		if w == word {
+			return color
+		}
+	}
+	return ""
+}
+
+// scoreTrial works out the result of one answer.
+func scoreTrial(trial types.StroopTrial, responseKey, responseColor string, responseTimeMs, limitMs int) types.StroopResult {
+	result := types.StroopResult{
+		TrialNum:       trial.TrialNum,
+		Condition:      trial.Condition,
+		Word:           trial.Word,
+		WordColor:      wordColor(trial.Word),
+		InkColor:       trial.InkColor,
+		ResponseKey:    responseKey,
+		ResponseColor:  responseColor,
+		TimedOut:       responseKey == "" || (limitMs > 0 && responseTimeMs > limitMs),
+		ResponseTimeMs: responseTimeMs,
+	}
+	if !result.TimedOut {
+		result.IsCorrect = responseColor == trial.InkColor
+		result.IsWordError = trial.Condition == types.StroopIncongruent && responseColor == result.WordColor
+	}
+	return result
+}
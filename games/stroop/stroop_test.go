@@ -0,0 +1,162 @@
+package stroop
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDealConditions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	count := func(deck []string) map[string]int {
+		counts := make(map[string]int)
+		for _, c := range deck {
+			counts[c]++
+		}
+		return counts
+	}
+
+	counts := count(dealConditions(types.StroopTrialMix{Congruent: 1, Incongruent: 1, Neutral: 1}, 48, rng))
+	assert.Equal(t, map[string]int{types.StroopCongruent: 16, types.StroopIncongruent: 16, types.StroopNeutral: 16}, counts)
+
+	counts = count(dealConditions(types.StroopTrialMix{Congruent: 1, Incongruent: 2}, 10, rng))
+	assert.Equal(t, 3, counts[types.StroopCongruent])
+	assert.Equal(t, 7, counts[types.StroopIncongruent], "The leftover trial goes to the largest remainder")
+	assert.Zero(t, counts[types.StroopNeutral])
+}
+
+func TestGenerateTrials(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	settings := withDefaults(types.StroopSettings{NumTrials: 60})
+	assert.NoError(t, validateSettings(settings))
+
+	for _, trial := range generateTrials(settings, rng) {
+		word := wordColor(trial.Word)
+		switch trial.Condition {
+		case types.StroopCongruent:
+			assert.Equal(t, trial.InkColor, word)
+		case types.StroopIncongruent:
+			assert.NotEqual(t, trial.InkColor, word)
+			assert.Contains(t, settings.Colors, word)
+		default:
+			assert.Empty(t, word)
+		}
+		assert.Contains(t, settings.Colors, trial.InkColor)
+	}
+
+	for _, color := range allColors {
+		assert.Equal(t, color, wordColor(colorWords[color]), "Every color is named by a word")
+	}
+	assert.Equal(t, "red", wordColor("빨강"))
+}
+
+func TestValidateSettings(t *testing.T) {
+	settings := withDefaults(types.StroopSettings{})
+	assert.Equal(t, "r", settings.ResponseKeys["red"])
+
+	settings = withDefaults(types.StroopSettings{Colors: []string{"red"}})
+	assert.Error(t, validateSettings(settings), "Incongruent trials need two colors")
+
+	settings = withDefaults(types.StroopSettings{Colors: []string{"red", "pink"}})
+	assert.Error(t, validateSettings(settings))
+
+	settings = withDefaults(types.StroopSettings{
+		Colors:       []string{"red", "blue"},
+		ResponseKeys: map[string]string{"red": "j", "blue": "j"},
+	})
+	assert.Error(t, validateSettings(settings), "Two colors on one key")
+
+	settings = withDefaults(types.StroopSettings{Mix: types.StroopTrialMix{Congruent: -1, Incongruent: 2}})
+	assert.Error(t, validateSettings(settings))
+}
+
+func TestService_SubmitAnswer(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.SubmitAnswer(1, 1, "r", 500)
+	assert.Error(t, err, "Answering before the game starts should fail")
+
+	keys := map[string]string{"red": "1", "green": "2", "blue": "3", "yellow": "4"}
+	state, err := service.StartGame(types.StroopSettings{
+		NumTrials:    3,
+		Mix:          types.StroopTrialMix{Incongruent: 1},
+		ResponseKeys: keys,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, state.Trials, 3)
+
+	// Trial 1: name the ink.
+	trial := state.Trials[0]
+	res, err := service.SubmitAnswer(state.ID, 1, keys[trial.InkColor], 700)
+	assert.NoError(t, err)
+	assert.True(t, res.IsCorrect)
+	assert.False(t, res.IsWordError)
+
+	_, err = service.SubmitAnswer(state.ID, 1, keys[trial.InkColor], 700)
+	assert.Error(t, err, "A trial is answered once")
+
+	// Trial 2: read the word instead.
+	trial = state.Trials[1]
+	res, err = service.SubmitAnswer(state.ID, 2, keys[wordColor(trial.Word)], 900)
+	assert.NoError(t, err)
+	assert.False(t, res.IsCorrect)
+	assert.True(t, res.IsWordError)
+
+	_, err = service.SubmitAnswer(state.ID, 3, "x", 500)
+	assert.Error(t, err, "Unknown keys are rejected")
+
+	// Trial 3: too slow.
+	res, err = service.SubmitAnswer(state.ID, 3, keys[state.Trials[2].InkColor], 2500)
+	assert.NoError(t, err)
+	assert.True(t, res.TimedOut)
+	assert.False(t, res.IsCorrect)
+
+	results, err := database.GetStroopResultsForSession(db, state.ID)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+}
+
+// TestService_ConcurrentSessions is meant for the race detector: two sessions are played
+// at once, each from several goroutines.
+func TestService_ConcurrentSessions(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1) // Every connection to :memory: opens a database of its own
+
+	service := NewService(db)
+	const numTrials = 20
+	settings := types.StroopSettings{NumTrials: numTrials}
+	first, err := service.StartGame(settings)
+	assert.NoError(t, err)
+	second, err := service.StartGame(settings)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for _, state := range []*StroopGameState{first, second} {
+		for trial := 1; trial <= numTrials; trial++ {
+			wg.Add(1)
+			go func(sessionID int64, trial int) {
+				defer wg.Done()
+				_, err := service.SubmitAnswer(sessionID, trial, "", 0)
+				assert.NoError(t, err)
+			}(state.ID, trial)
+		}
+	}
+	wg.Wait()
+
+	for _, state := range []*StroopGameState{first, second} {
+		results, err := database.GetStroopResultsForSession(db, state.ID)
+		assert.NoError(t, err)
+		assert.Len(t, results, numTrials, "Starting a second session leaves the first in play")
+	}
+	_, err = service.SubmitAnswer(first.ID, 1, "", 0)
+	assert.Error(t, err, "Each trial is still answered once")
+}
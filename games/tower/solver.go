@@ -0,0 +1,173 @@
+package tower
+
+import (
+	"fmt"
+
+	"acca-games/types"
+)
+
+const numPegs = 3
+
+// config records the peg each disc sits on; config[0] is the smallest disc. Every
+// assignment is a legal position, because discs on a peg are always stacked by size.
+type config []int
+
+// key packs a configuration into a base-3 number.
+func (c config) key() int {
+	k := 0
+	for d := len(c) - 1; d >= 0; d-- {
+		k = k*numPegs + c[d]
+	}
+	return k
+}
+
+// configFromKey is the inverse of key.
+func configFromKey(k, discs int) config {
+	c := make(config, discs)
+	for d := 0; d < discs; d++ {
+		c[d] = k % numPegs
+		k /= numPegs
+	}
+	return c
+}
+
+// top returns the smallest disc on a peg, or -1 if the peg is empty.
+func (c config) top(peg int) int {
+	for d, p := range c {
+		if p == peg {
+			return d
+		}
+	}
+	return -1
+}
+
+// canMove reports whether the top disc of m.From may go onto m.To.
+func (c config) canMove(m types.TowerMove) bool {
+	if m.From < 0 || m.From >= numPegs || m.To < 0 || m.To >= numPegs || m.From == m.To {
+		return false
+	}
+	disc := c.top(m.From)
+	if disc < 0 {
+		return false
+	}
+	onto := c.top(m.To)
+	return onto < 0 || onto > disc
+}
+
+// apply returns the configuration after a legal move.
+func (c config) apply(m types.TowerMove) config {
+	next := make(config, len(c))
+	copy(next, c)
+	next[c.top(m.From)] = m.To
+	return next
+}
+
+// pegs lists the discs on each peg from bottom to top, numbered from 1.
+func (c config) pegs() [][]int {
+	pegs := make([][]int, numPegs)
+	for i := range pegs {
+		pegs[i] = []int{}
+	}
+	for d := len(c) - 1; d >= 0; d-- {
+		pegs[c[d]] = append(pegs[c[d]], d+1)
+	}
+	return pegs
+}
+
+// configFromPegs reads a configuration in the form pegs returns.
+func configFromPegs(pegs [][]int) (config, error) {
+	if len(pegs) != numPegs {
+		return nil, fmt.Errorf("expected %d pegs, got %d", numPegs, len(pegs))
+	}
+	discs := 0
+	for _, peg := range pegs {
+		discs += len(peg)
+	}
+
+	c := make(config, discs)
+	seen := make([]bool, discs)
+	for p, peg := range pegs {
+		for i, disc := range peg {
+			if disc < 1 || disc > discs || seen[disc-1] {
+				return nil, fmt.Errorf("peg %d: invalid or repeated disc %d", p, disc)
+			}
+			if i > 0 && disc > peg[i-1] {
+				return nil, fmt.Errorf("peg %d: disc %d sits on smaller disc %d", p, disc, peg[i-1])
+			}
+			seen[disc-1] = true
+			c[disc-1] = p
+		}
+	}
+	return c, nil
+}
+
+var allMoves = []types.TowerMove{{From: 0, To: 1}, {From: 0, To: 2}, {From: 1, To: 0}, {From: 1, To: 2}, {From: 2, To: 0}, {From: 2, To: 1}}
+
+// distances runs a breadth-first search from start and returns the fewest moves needed
+// to reach every configuration, indexed by key, together with the move that reached it
+// first so a path can be traced back.
+func distances(start config) ([]int, []types.TowerMove) {
+	size := 1
+	for range start {
+		size *= numPegs
+	}
+	dist := make([]int, size)
+	for i := range dist {
+		dist[i] = -1
+	}
+	via := make([]types.TowerMove, size)
+
+	dist[start.key()] = 0
+	queue := []config{start}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, m := range allMoves {
+			if !c.canMove(m) {
+				continue
+			}
+			next := c.apply(m)
+			k := next.key()
+			if dist[k] >= 0 {
+				continue
+			}
+			dist[k] = dist[c.key()] + 1
+			via[k] = m
+			queue = append(queue, next)
+		}
+	}
+	return dist, via
+}
+
+// Solve returns a shortest sequence of moves from start to goal, both given as pegs of
+// discs from bottom to top.
+func Solve(start, goal [][]int) ([]types.TowerMove, error) {
+	from, err := configFromPegs(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	to, err := configFromPegs(goal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid goal: %w", err)
+	}
+	if len(from) != len(to) {
+		return nil, fmt.Errorf("start has %d discs but goal has %d", len(from), len(to))
+	}
+	return solve(from, to), nil
+}
+
+func solve(start, goal config) []types.TowerMove {
+	_, via := distances(start)
+
+	// Walk back from the goal, undoing the move that first reached each configuration.
+	moves := []types.TowerMove{}
+	for c := goal; c.key() != start.key(); {
+		m := via[c.key()]
+		moves = append(moves, m)
+		c = c.apply(types.TowerMove{From: m.To, To: m.From})
+	}
+	for i, j := 0, len(moves)-1; i < j; i, j = i+1, j-1 {
+		moves[i], moves[j] = moves[j], moves[i]
+	}
+	return moves
+}
@@ -0,0 +1,362 @@
+package tower
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// TowerGameState holds the current state of the game.
+type TowerGameState struct {
+	Settings types.TowerSettings  `json:"settings"`
+	Problems []types.TowerProblem `json:"problems"`
+	ID       int64                `json:"id"`
+	// NextProblem is the first problem not yet finished, or one past the last when all are.
+	NextProblem int `json:"nextProblem"`
+}
+
+// savedGame is what is stored so that a session can be resumed. A problem left
+// unfinished starts again from its start position.
+type savedGame struct {
+	State    TowerGameState `json:"state"`
+	Finished []bool         `json:"finished"`
+}
+
+// progress tracks the moves made on one problem.
+type progress struct {
+	current  config
+	goal     config
+	moves    []types.TowerMove
+	finished bool
+}
+
+// game is one Tower session in play.
+type game struct {
+	state    *TowerGameState
+	progress []*progress
+}
+
+// Service for the Tower game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.TowerSettings) (*TowerGameState, error) {
+	settings = withDefaults(settings)
+
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate problems: %w", err)
+	}
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeTower, settings, len(problems))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &TowerGameState{
+			Settings:    settings,
+			Problems:    problems,
+			ID:          sessionID,
+			NextProblem: 1,
+		},
+		progress: newProgress(problems, make([]bool, len(problems))),
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first problem not yet finished.
+func (s *Service) ResumeSession(sessionID int64) (*TowerGameState, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved.Finished) != len(saved.State.Problems) {
+		return nil, fmt.Errorf("saved state of session %d is inconsistent", sessionID)
+	}
+	g := &game{state: &saved.State, progress: newProgress(saved.State.Problems, saved.Finished)}
+	g.state.NextProblem = next
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// newProgress sets every problem at its start position, marking the finished ones.
+func newProgress(problems []types.TowerProblem, finished []bool) []*progress {
+	progresses := make([]*progress, len(problems))
+	for i, p := range problems {
+		start, _ := configFromPegs(p.Start)
+		goal, _ := configFromPegs(p.Goal)
+		progresses[i] = &progress{current: start, goal: goal, moves: []types.TowerMove{}, finished: finished[i]}
+	}
+	return progresses
+}
+
+// saveState stores the game, pointing at the first problem not yet finished.
+func (g *game) saveState(db *sql.DB) error {
+	saved := savedGame{State: *g.state, Finished: make([]bool, len(g.progress))}
+	next := 0
+	for i, p := range g.progress {
+		saved.Finished[i] = p.finished
+		if !p.finished && next == 0 {
+			next = i + 1
+		}
+	}
+	if next == 0 {
+		next = len(g.progress) + 1
+	}
+	g.state.NextProblem = next
+	saved.State.NextProblem = next
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// SubmitMove checks and applies one move. An illegal move is rejected and does not count.
+// Once the goal is reached or the move limit is used up, the result is saved.
+func (s *Service) SubmitMove(sessionID int64, problemNum int, from int, to int, elapsedMs int) (*types.TowerMoveResult, error) {
+	var result *types.TowerMoveResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitMove(s.db, problemNum, from, to, elapsedMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitMove(db *sql.DB, problemNum int, from int, to int, elapsedMs int) (*types.TowerMoveResult, error) {
+	p, err := g.problemProgress(problemNum)
+	if err != nil {
+		return nil, err
+	}
+
+	move := types.TowerMove{From: from, To: to}
+	if !p.current.canMove(move) {
+		return nil, fmt.Errorf("illegal move from peg %d to peg %d", from, to)
+	}
+	p.current = p.current.apply(move)
+	p.moves = append(p.moves, move)
+
+	res := &types.TowerMoveResult{
+		Pegs:      p.current.pegs(),
+		MoveCount: len(p.moves),
+		Solved:    p.current.key() == p.goal.key(),
+	}
+	if res.Solved || len(p.moves) >= g.state.Problems[problemNum-1].MoveLimit {
+		result, err := g.finish(db, problemNum, elapsedMs)
+		if err != nil {
+			return nil, err
+		}
+		res.Finished = true
+		res.Result = result
+	}
+	return res, nil
+}
+
+// FinishProblem ends a problem the player gave up on or ran out of time for, and saves it.
+func (s *Service) FinishProblem(sessionID int64, problemNum int, elapsedMs int) (*types.TowerResult, error) {
+	var result *types.TowerResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.finishProblem(s.db, problemNum, elapsedMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) finishProblem(db *sql.DB, problemNum int, elapsedMs int) (*types.TowerResult, error) {
+	if _, err := g.problemProgress(problemNum); err != nil {
+		return nil, err
+	}
+	return g.finish(db, problemNum, elapsedMs)
+}
+
+func (g *game) problemProgress(problemNum int) (*progress, error) {
+	if problemNum < 1 || problemNum > len(g.state.Problems) {
+		return nil, fmt.Errorf("invalid problem number")
+	}
+	p := g.progress[problemNum-1]
+	if p.finished {
+		return nil, fmt.Errorf("problem %d is already finished", problemNum)
+	}
+	return p, nil
+}
+
+func (g *game) finish(db *sql.DB, problemNum int, elapsedMs int) (*types.TowerResult, error) {
+	problem := g.state.Problems[problemNum-1]
+	p := g.progress[problemNum-1]
+	p.finished = true
+
+	result := types.TowerResult{
+		SessionID:    g.state.ID,
+		ProblemNum:   problemNum,
+		Discs:        problem.Discs,
+		Start:        problem.Start,
+		Goal:         problem.Goal,
+		Moves:        p.moves,
+		MoveCount:    len(p.moves),
+		OptimalMoves: problem.OptimalMoves,
+		MoveLimit:    problem.MoveLimit,
+		IsSolved:     p.current.key() == p.goal.key(),
+		TimeTakenMs:  elapsedMs,
+	}
+
+	if err := database.SaveTowerResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Helper Functions ---
+
+const (
+	defaultNumProblems = 10
+	minDiscs           = 3
+	maxDiscs           = 5
+	defaultMinMoves    = 3
+	defaultMaxMoves    = 7
+	goalAttempts       = 100
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.TowerSettings) types.TowerSettings {
+	if settings.NumProblems <= 0 {
+		settings.NumProblems = defaultNumProblems
+	}
+	if settings.MinDiscs == 0 {
+		settings.MinDiscs = minDiscs
+	}
+	if settings.MaxDiscs == 0 {
+		settings.MaxDiscs = settings.MinDiscs
+	}
+	if settings.MinMoves == 0 {
+		settings.MinMoves = defaultMinMoves
+	}
+	if settings.MaxMoves == 0 {
+		settings.MaxMoves = max(settings.MinMoves, defaultMaxMoves)
+	}
+	return settings
+}
+
+// maxOptimalMoves is the most moves any problem with the given disc count can need.
+func maxOptimalMoves(discs int) int {
+	return 1<<discs - 1
+}
+
+// generateProblems creates the problems of a session.
+func generateProblems(settings types.TowerSettings, rng *rand.Rand) ([]types.TowerProblem, error) {
+	if settings.MinDiscs < minDiscs || settings.MaxDiscs > maxDiscs || settings.MinDiscs > settings.MaxDiscs {
+		return nil, fmt.Errorf("disc count must be between %d and %d, got %d to %d", minDiscs, maxDiscs, settings.MinDiscs, settings.MaxDiscs)
+	}
+	if settings.MinMoves < 1 || settings.MinMoves > settings.MaxMoves {
+		return nil, fmt.Errorf("invalid move range %d to %d", settings.MinMoves, settings.MaxMoves)
+	}
+	if settings.MinMoves > maxOptimalMoves(settings.MaxDiscs) {
+		return nil, fmt.Errorf("no problem with %d discs needs %d moves", settings.MaxDiscs, settings.MinMoves)
+	}
+	if settings.ExtraMoves != nil && *settings.ExtraMoves < 0 {
+		return nil, fmt.Errorf("extra moves must not be negative")
+	}
+
+	problems := make([]types.TowerProblem, settings.NumProblems)
+	for i := range problems {
+		discs := settings.MinDiscs + rng.Intn(settings.MaxDiscs-settings.MinDiscs+1)
+		// Fewer discs cannot always reach the requested move count; use more discs then.
+		for discs < settings.MaxDiscs && maxOptimalMoves(discs) < settings.MinMoves {
+			discs++
+		}
+		target := settings.MinMoves + rng.Intn(settings.MaxMoves-settings.MinMoves+1)
+		target = min(target, maxOptimalMoves(discs))
+
+		problems[i] = generateProblem(rng, discs, target)
+		problems[i].ProblemNum = i + 1
+
+		extra := problems[i].OptimalMoves
+		if settings.ExtraMoves != nil {
+			extra = *settings.ExtraMoves
+		}
+		problems[i].MoveLimit = problems[i].OptimalMoves + extra
+	}
+	return problems, nil
+}
+
+// generateProblem picks a random start and a goal exactly target moves away. Some starts
+// have no position that far; after a number of tries the farthest goal found is used.
+func generateProblem(rng *rand.Rand, discs int, target int) types.TowerProblem {
+	var best types.TowerProblem
+	for attempt := 0; attempt < goalAttempts; attempt++ {
+		start := make(config, discs)
+		for d := range start {
+			start[d] = rng.Intn(numPegs)
+		}
+
+		dist, _ := distances(start)
+		var candidates []int
+		farthest := 0
+		for k, d := range dist {
+			if d == target {
+				candidates = append(candidates, k)
+			}
+			if d > dist[farthest] {
+				farthest = k
+			}
+		}
+
+		if len(candidates) > 0 {
+			goal := configFromKey(candidates[rng.Intn(len(candidates))], discs)
+			return types.TowerProblem{Discs: discs, Start: start.pegs(), Goal: goal.pegs(), OptimalMoves: target}
+		}
+		if dist[farthest] > best.OptimalMoves {
+			goal := configFromKey(farthest, discs)
+			best = types.TowerProblem{Discs: discs, Start: start.pegs(), Goal: goal.pegs(), OptimalMoves: dist[farthest]}
+		}
+	}
+	return best
+}
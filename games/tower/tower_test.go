@@ -0,0 +1,199 @@
+package tower
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func extra(v int) *int { return &v }
+
+func TestSolve(t *testing.T) {
+	// The classic puzzle: move the whole tower from the left peg to the right peg.
+	moves, err := Solve([][]int{{3, 2, 1}, {}, {}}, [][]int{{}, {}, {3, 2, 1}})
+	assert.NoError(t, err)
+	assert.Len(t, moves, 7)
+
+	c, _ := configFromPegs([][]int{{3, 2, 1}, {}, {}})
+	for _, m := range moves {
+		assert.True(t, c.canMove(m), "Solver produced an illegal move %+v", m)
+		c = c.apply(m)
+	}
+	assert.Equal(t, [][]int{{}, {}, {3, 2, 1}}, c.pegs())
+
+	moves, err = Solve([][]int{{2}, {1}, {}}, [][]int{{2}, {1}, {}})
+	assert.NoError(t, err)
+	assert.Empty(t, moves, "Start and goal are the same")
+
+	_, err = Solve([][]int{{1, 2}, {}, {}}, [][]int{{}, {}, {2, 1}})
+	assert.Error(t, err, "A larger disc may not sit on a smaller one")
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	pegs := [][]int{{5, 2}, {4, 3}, {1}}
+	c, err := configFromPegs(pegs)
+	assert.NoError(t, err)
+	assert.Equal(t, pegs, c.pegs())
+	assert.Equal(t, c, configFromKey(c.key(), 5))
+}
+
+func TestGenerateProblems(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	settings := withDefaults(types.TowerSettings{NumProblems: 20, MinDiscs: 3, MaxDiscs: 5, MinMoves: 4, MaxMoves: 9})
+
+	problems, err := generateProblems(settings, rng)
+	assert.NoError(t, err)
+	assert.Len(t, problems, 20)
+
+	for _, p := range problems {
+		assert.GreaterOrEqual(t, p.Discs, 3)
+		assert.LessOrEqual(t, p.Discs, 5)
+		assert.GreaterOrEqual(t, p.OptimalMoves, 4)
+		assert.LessOrEqual(t, p.OptimalMoves, 9)
+		assert.Equal(t, 2*p.OptimalMoves, p.MoveLimit, "The default allowance is the optimum again")
+
+		moves, err := Solve(p.Start, p.Goal)
+		assert.NoError(t, err)
+		assert.Len(t, moves, p.OptimalMoves, "Problem %d does not need the stated number of moves", p.ProblemNum)
+	}
+
+	_, err = generateProblems(withDefaults(types.TowerSettings{MinDiscs: 2}), rng)
+	assert.Error(t, err, "Fewer than three discs is not allowed")
+
+	_, err = generateProblems(withDefaults(types.TowerSettings{MinDiscs: 3, MaxDiscs: 3, MinMoves: 8}), rng)
+	assert.Error(t, err, "Three discs never need eight moves")
+}
+
+func TestService_SubmitMove(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.SubmitMove(1, 1, 0, 1, 100)
+	assert.Error(t, err, "Moving before the game starts should fail")
+
+	state, err := service.StartGame(types.TowerSettings{NumProblems: 2, ExtraMoves: extra(0)})
+	assert.NoError(t, err)
+
+	// Solve problem 1 optimally.
+	problem := state.Problems[0]
+	moves, _ := Solve(problem.Start, problem.Goal)
+	var res *types.TowerMoveResult
+	for _, m := range moves {
+		res, err = service.SubmitMove(state.ID, 1, m.From, m.To, 1500)
+		assert.NoError(t, err)
+	}
+	assert.True(t, res.Solved)
+	assert.True(t, res.Finished)
+	assert.Equal(t, problem.Goal, res.Pegs)
+	assert.Equal(t, problem.OptimalMoves, res.Result.MoveCount)
+
+	_, err = service.SubmitMove(state.ID, 1, 0, 1, 1600)
+	assert.Error(t, err, "A finished problem takes no more moves")
+
+	// With no extra moves allowed, wandering around soon uses up the limit.
+	problem = state.Problems[1]
+	c, _ := configFromPegs(problem.Start)
+	var illegal types.TowerMove
+	for _, m := range allMoves {
+		if !c.canMove(m) {
+			illegal = m
+			break
+		}
+	}
+	_, err = service.SubmitMove(state.ID, 2, illegal.From, illegal.To, 100)
+	assert.Error(t, err, "Illegal moves are rejected")
+
+	for i := 0; i < problem.MoveLimit; i++ {
+		for _, m := range allMoves {
+			if c.canMove(m) {
+				res, err = service.SubmitMove(state.ID, 2, m.From, m.To, 2000)
+				assert.NoError(t, err)
+				c = c.apply(m)
+				break
+			}
+		}
+		if res.Finished {
+			break
+		}
+	}
+	assert.True(t, res.Finished)
+	if !res.Solved {
+		assert.Equal(t, problem.MoveLimit, res.MoveCount)
+		assert.False(t, res.Result.IsSolved)
+	}
+
+	results, err := database.GetTowerResultsForSession(db, state.ID)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestService_EndAndAbandonGame(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	state, err := service.StartGame(types.TowerSettings{NumProblems: 3})
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.EndGame(state.ID))
+	_, err = service.SubmitMove(state.ID, 1, 0, 1, 100)
+	assert.Error(t, err, "An ended game takes no more moves")
+	assert.Error(t, service.AbandonGame(state.ID), "A completed game cannot be abandoned")
+
+	abandoned, err := service.StartGame(types.TowerSettings{NumProblems: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, service.AbandonGame(abandoned.ID))
+
+	var status string
+	var expected int
+	assert.NoError(t, db.QueryRow("SELECT status, expected_trials FROM game_sessions WHERE id = ?", state.ID).Scan(&status, &expected))
+	assert.Equal(t, types.SessionStatusCompleted, status)
+	assert.Equal(t, 3, expected)
+	assert.NoError(t, db.QueryRow("SELECT status FROM game_sessions WHERE id = ?", abandoned.ID).Scan(&status))
+	assert.Equal(t, types.SessionStatusAbandoned, status)
+}
+
+func TestService_ResumeSession(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	state, err := NewService(db).StartGame(types.TowerSettings{NumProblems: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.NextProblem)
+
+	service := NewService(db)
+	_, err = service.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	_, err = service.FinishProblem(state.ID, 2, 1000)
+	assert.NoError(t, err)
+	_, err = service.FinishProblem(state.ID, 1, 1000)
+	assert.NoError(t, err)
+
+	// A fresh service, as after a restart, picks up where the last one stopped.
+	restarted := NewService(db)
+	resumed, err := restarted.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resumed.NextProblem)
+	assert.Equal(t, state.Problems, resumed.Problems)
+	_, err = restarted.FinishProblem(state.ID, 2, 1000)
+	assert.Error(t, err, "A problem finished before the restart stays finished")
+	c, _ := configFromPegs(resumed.Problems[2].Start)
+	for _, m := range allMoves {
+		if c.canMove(m) {
+			_, err = restarted.SubmitMove(state.ID, 3, m.From, m.To, 100)
+			assert.NoError(t, err)
+			break
+		}
+	}
+
+	assert.NoError(t, restarted.EndGame(state.ID))
+	_, err = NewService(db).ResumeSession(state.ID)
+	assert.Error(t, err, "A completed session cannot be resumed")
+}
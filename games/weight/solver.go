@@ -0,0 +1,143 @@
+package weight
+
+import (
+	"math/bits"
+	"strings"
+	"sync"
+)
+
+// orderSet is a set of candidate orderings, one bit per permutation of the objects.
+type orderSet []uint64
+
+func (s orderSet) count() int {
+	n := 0
+	for _, w := range s {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (s orderSet) key() string {
+	var b strings.Builder
+	for _, w := range s {
+		for i := 0; i < 8; i++ {
+			b.WriteByte(byte(w >> (8 * i)))
+		}
+	}
+	return b.String()
+}
+
+// solver finds the fewest weighings that sort n objects in the worst case. It searches
+// over the sets of orderings still possible after each weighing: a weighing splits the
+// set in two, and the opponent (the hidden order) always picks the worse half.
+type solver struct {
+	n     int
+	perms [][]int // perms[p][object] is the rank of the object in ordering p; 0 is heaviest
+	memo  map[string]int
+}
+
+func newSolver(n int) *solver {
+	s := &solver{n: n, memo: make(map[string]int)}
+	s.perms = permutations(n)
+	return s
+}
+
+// all returns the set of every ordering.
+func (s *solver) all() orderSet {
+	set := make(orderSet, (len(s.perms)+63)/64)
+	for p := range s.perms {
+		set[p/64] |= 1 << (p % 64)
+	}
+	return set
+}
+
+// split divides a set by the outcome of weighing a against b.
+func (s *solver) split(set orderSet, a, b int) (aHeavier, bHeavier orderSet) {
+	aHeavier = make(orderSet, len(set))
+	bHeavier = make(orderSet, len(set))
+	for p, rank := range s.perms {
+		if set[p/64]&(1<<(p%64)) == 0 {
+			continue
+		}
+		if rank[a] < rank[b] {
+			aHeavier[p/64] |= 1 << (p % 64)
+		} else {
+			bHeavier[p/64] |= 1 << (p % 64)
+		}
+	}
+	return aHeavier, bHeavier
+}
+
+// worstCase returns the fewest weighings that always narrow set down to one ordering.
+func (s *solver) worstCase(set orderSet) int {
+	count := set.count()
+	if count <= 1 {
+		return 0
+	}
+	key := set.key()
+	if v, ok := s.memo[key]; ok {
+		return v
+	}
+
+	// Each weighing at best halves the candidates, so no strategy beats log2(count).
+	lowerBound := bits.Len(uint(count - 1))
+	best := -1
+	for a := 0; a < s.n && best != lowerBound; a++ {
+		for b := a + 1; b < s.n && best != lowerBound; b++ {
+			left, right := s.split(set, a, b)
+			if left.count() == 0 || right.count() == 0 {
+				continue // The outcome is already known
+			}
+			v := 1 + max(s.worstCase(left), s.worstCase(right))
+			if best < 0 || v < best {
+				best = v
+			}
+		}
+	}
+
+	s.memo[key] = best
+	return best
+}
+
+var (
+	minWeighingsMu    sync.Mutex
+	minWeighingsCache = map[int]int{}
+)
+
+// MinWeighings returns the fewest pairwise weighings that are always enough to rank
+// n objects of distinct weight.
+func MinWeighings(n int) int {
+	minWeighingsMu.Lock()
+	defer minWeighingsMu.Unlock()
+	if v, ok := minWeighingsCache[n]; ok {
+		return v
+	}
+	s := newSolver(n)
+	v := s.worstCase(s.all())
+	minWeighingsCache[n] = v
+	return v
+}
+
+// permutations lists every ranking of n objects.
+func permutations(n int) [][]int {
+	var perms [][]int
+	rank := make([]int, n)
+	used := make([]bool, n)
+	var build func(obj int)
+	build = func(obj int) {
+		if obj == n {
+			perms = append(perms, append([]int(nil), rank...))
+			return
+		}
+		for r := 0; r < n; r++ {
+			if !used[r] {
+				used[r] = true
+				rank[obj] = r
+				build(obj + 1)
+				used[r] = false
+			}
+		}
+	}
+	build(0)
+	return perms
+}
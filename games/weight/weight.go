@@ -0,0 +1,365 @@
+package weight
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"acca-games/database"
+	"acca-games/games/session"
+	"acca-games/types"
+)
+
+// WeightGameState holds the current state of the game.
+type WeightGameState struct {
+	Settings types.WeightSettings  `json:"settings"`
+	Problems []types.WeightProblem `json:"problems"`
+	ID       int64                 `json:"id"`
+	// NextProblem is the first problem not yet answered, or one past the last when all are.
+	NextProblem int `json:"nextProblem"`
+}
+
+// savedGame is what is stored so that a session can be resumed. The hidden orders are
+// kept apart from the state, which leaves them out of its JSON.
+type savedGame struct {
+	State     WeightGameState          `json:"state"`
+	Orders    [][]string               `json:"orders"`
+	Finished  []bool                   `json:"finished"`
+	Weighings [][]types.WeightWeighing `json:"weighings"`
+}
+
+// game is one Weight Comparison session in play.
+type game struct {
+	state     *WeightGameState
+	finished  []bool
+	weighings [][]types.WeightWeighing // Per problem
+}
+
+// Service for the Weight Comparison game.
+type Service struct {
+	db    *sql.DB
+	games *session.Manager[game]
+}
+
+// NewService creates a new service.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db, games: session.NewManager[game](session.DefaultIdleTimeout)}
+}
+
+// StartGame initializes a new game session.
+func (s *Service) StartGame(settings types.WeightSettings) (*WeightGameState, error) {
+	settings = withDefaults(settings)
+
+	problems, err := generateProblems(settings, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate problems: %w", err)
+	}
+
+	sessionID, err := database.StartGameSession(s.db, types.GameCodeWeight, settings, len(problems))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game session: %w", err)
+	}
+
+	g := &game{
+		state: &WeightGameState{
+			Settings: settings,
+			Problems: problems,
+			ID:       sessionID,
+		},
+		finished:  make([]bool, len(problems)),
+		weighings: make([][]types.WeightWeighing, len(problems)),
+	}
+	if err := g.saveState(s.db); err != nil {
+		return nil, err
+	}
+	s.games.Add(sessionID, g)
+
+	return g.state, nil
+}
+
+// ResumeSession restores a session left in progress from its saved state, pointing at
+// the first problem not yet answered.
+func (s *Service) ResumeSession(sessionID int64) (*WeightGameState, error) {
+	var saved savedGame
+	next, err := database.LoadSessionState(s.db, sessionID, &saved)
+	if err != nil {
+		return nil, err
+	}
+	n := len(saved.State.Problems)
+	if len(saved.Finished) != n || len(saved.Orders) != n || len(saved.Weighings) != n {
+		return nil, fmt.Errorf("saved state of session %d is inconsistent", sessionID)
+	}
+	for i := range saved.State.Problems {
+		saved.State.Problems[i].Order = saved.Orders[i]
+	}
+	g := &game{state: &saved.State, finished: saved.Finished, weighings: saved.Weighings}
+	g.state.NextProblem = next
+	s.games.Add(sessionID, g)
+	return g.state, nil
+}
+
+// saveState stores the game, pointing at the first problem not yet answered.
+func (g *game) saveState(db *sql.DB) error {
+	next := len(g.finished) + 1
+	for i, done := range g.finished {
+		if !done {
+			next = i + 1
+			break
+		}
+	}
+	g.state.NextProblem = next
+	saved := savedGame{State: *g.state, Finished: g.finished, Weighings: g.weighings}
+	saved.Orders = make([][]string, len(g.state.Problems))
+	for i, p := range g.state.Problems {
+		saved.Orders[i] = p.Order
+	}
+	if err := database.SaveSessionState(db, g.state.ID, saved, next); err != nil {
+		return fmt.Errorf("failed to save game state: %w", err)
+	}
+	return nil
+}
+
+// EndGame marks the session as completed.
+func (s *Service) EndGame(sessionID int64) error {
+	if err := database.EndGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// AbandonGame marks the session as abandoned when the player leaves it unfinished.
+func (s *Service) AbandonGame(sessionID int64) error {
+	if err := database.AbandonGameSession(s.db, sessionID); err != nil {
+		return err
+	}
+	s.games.Remove(sessionID)
+	return nil
+}
+
+// ClearGames takes every session out of play, as their sessions may no longer exist once
+// the database has been restored from a backup.
+func (s *Service) ClearGames() {
+	s.games.Clear()
+}
+
+// Weigh puts two objects on the scale and returns which one is heavier. Weighings are
+// saved with the game, so a resumed session keeps those already made.
+func (s *Service) Weigh(sessionID int64, problemNum int, left string, right string) (*types.WeightWeighing, error) {
+	var result *types.WeightWeighing
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.weigh(s.db, problemNum, left, right)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) weigh(db *sql.DB, problemNum int, left string, right string) (*types.WeightWeighing, error) {
+	problem, err := g.openProblem(problemNum)
+	if err != nil {
+		return nil, err
+	}
+	if left == right {
+		return nil, fmt.Errorf("cannot weigh %s against itself", left)
+	}
+	rank := rankOf(problem.Order)
+	leftRank, ok := rank[left]
+	if !ok {
+		return nil, fmt.Errorf("unknown object: %s", left)
+	}
+	rightRank, ok := rank[right]
+	if !ok {
+		return nil, fmt.Errorf("unknown object: %s", right)
+	}
+	if limit := g.state.Settings.MaxWeighings; limit > 0 && len(g.weighings[problemNum-1]) >= limit {
+		return nil, fmt.Errorf("no weighings left (limit %d)", limit)
+	}
+
+	weighing := types.WeightWeighing{Left: left, Right: right, Heavier: right}
+	if leftRank < rightRank {
+		weighing.Heavier = left
+	}
+	g.weighings[problemNum-1] = append(g.weighings[problemNum-1], weighing)
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &weighing, nil
+}
+
+// SubmitRanking checks the player's ranking, heaviest first, and saves the result.
+func (s *Service) SubmitRanking(sessionID int64, problemNum int, ranking []string, responseTimeMs int) (*types.WeightResult, error) {
+	var result *types.WeightResult
+	err := s.games.With(sessionID, func(g *game) error {
+		var err error
+		result, err = g.submitRanking(s.db, problemNum, ranking, responseTimeMs)
+		return err
+	})
+	return result, err
+}
+
+func (g *game) submitRanking(db *sql.DB, problemNum int, ranking []string, responseTimeMs int) (*types.WeightResult, error) {
+	problem, err := g.openProblem(problemNum)
+	if err != nil {
+		return nil, err
+	}
+	if !samePermutation(ranking, problem.Objects) {
+		return nil, fmt.Errorf("ranking must list every object exactly once")
+	}
+
+	weighings := g.weighings[problemNum-1]
+	if weighings == nil {
+		weighings = []types.WeightWeighing{}
+	}
+	result := types.WeightResult{
+		SessionID:        g.state.ID,
+		ProblemNum:       problemNum,
+		NumObjects:       len(problem.Objects),
+		HiddenOrder:      problem.Order,
+		Weighings:        weighings,
+		Ranking:          ranking,
+		WeighingsUsed:    len(weighings),
+		OptimalWeighings: problem.OptimalWeighings,
+		IsCorrect:        sameOrder(ranking, problem.Order),
+		IsConsistent:     consistent(ranking, weighings),
+		IsDetermined:     possibleOrders(problem.Objects, weighings) == 1,
+		ResponseTimeMs:   responseTimeMs,
+	}
+
+	if err := database.SaveWeightResult(db, result); err != nil {
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+	g.finished[problemNum-1] = true
+	if err := g.saveState(db); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (g *game) openProblem(problemNum int) (types.WeightProblem, error) {
+	if problemNum < 1 || problemNum > len(g.state.Problems) {
+		return types.WeightProblem{}, fmt.Errorf("invalid problem number")
+	}
+	if g.finished[problemNum-1] {
+		return types.WeightProblem{}, fmt.Errorf("problem %d is already answered", problemNum)
+	}
+	return g.state.Problems[problemNum-1], nil
+}
+
+// --- Helper Functions ---
+
+const (
+	defaultNumProblems = 5
+	defaultNumObjects  = 4
+	minObjects         = 3
+	maxObjects         = 6
+)
+
+// withDefaults fills in the settings left at zero.
+func withDefaults(settings types.WeightSettings) types.WeightSettings {
+	if settings.NumProblems <= 0 {
+		settings.NumProblems = defaultNumProblems
+	}
+	if settings.NumObjects == 0 {
+		settings.NumObjects = defaultNumObjects
+	}
+	return settings
+}
+
+// generateProblems creates the problems of a session, each with a random hidden order.
+func generateProblems(settings types.WeightSettings, rng *rand.Rand) ([]types.WeightProblem, error) {
+	if settings.NumObjects < minObjects || settings.NumObjects > maxObjects {
+		return nil, fmt.Errorf("number of objects must be between %d and %d, got %d", minObjects, maxObjects, settings.NumObjects)
+	}
+	if settings.MaxWeighings < 0 {
+		return nil, fmt.Errorf("max weighings must not be negative")
+	}
+
+	objects := make([]string, settings.NumObjects)
+	for i := range objects {
+		objects[i] = string(rune('A' + i))
+	}
+	optimal := MinWeighings(settings.NumObjects)
+
+	problems := make([]types.WeightProblem, settings.NumProblems)
+	for i := range problems {
+		order := append([]string(nil), objects...)
+		rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+		problems[i] = types.WeightProblem{
+			ProblemNum:       i + 1,
+			Objects:          objects,
+			OptimalWeighings: optimal,
+			Order:            order,
+		}
+	}
+	return problems, nil
+}
+
+// rankOf maps each label to its position in an order.
+func rankOf(order []string) map[string]int {
+	rank := make(map[string]int, len(order))
+	for i, label := range order {
+		rank[label] = i
+	}
+	return rank
+}
+
+// consistent reports whether a ranking agrees with every weighing.
+func consistent(ranking []string, weighings []types.WeightWeighing) bool {
+	rank := rankOf(ranking)
+	for _, w := range weighings {
+		lighter := w.Left
+		if w.Heavier == w.Left {
+			lighter = w.Right
+		}
+		if rank[w.Heavier] > rank[lighter] {
+			return false
+		}
+	}
+	return true
+}
+
+// possibleOrders counts the orders of the objects that agree with every weighing.
+func possibleOrders(objects []string, weighings []types.WeightWeighing) int {
+	count := 0
+	for _, rank := range permutations(len(objects)) {
+		order := make([]string, len(objects))
+		for obj, r := range rank {
+			order[r] = objects[obj]
+		}
+		if consistent(order, weighings) {
+			count++
+		}
+	}
+	return count
+}
+
+func sameOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// samePermutation reports whether ranking lists exactly the given objects.
+func samePermutation(ranking, objects []string) bool {
+	if len(ranking) != len(objects) {
+		return false
+	}
+	rank := rankOf(ranking)
+	if len(rank) != len(objects) {
+		return false
+	}
+	for _, obj := range objects {
+		if _, ok := rank[obj]; !ok {
+			return false
+		}
+	}
+	return true
+}
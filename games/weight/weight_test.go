@@ -0,0 +1,133 @@
+package weight
+
+import (
+	"math/rand"
+	"testing"
+
+	"acca-games/database"
+	"acca-games/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinWeighings(t *testing.T) {
+	// The known minimum number of comparisons for sorting n items.
+	expected := map[int]int{1: 0, 2: 1, 3: 3, 4: 5, 5: 7}
+	for n, want := range expected {
+		assert.Equal(t, want, MinWeighings(n), "n = %d", n)
+	}
+}
+
+func TestPossibleOrders(t *testing.T) {
+	objects := []string{"A", "B", "C"}
+	assert.Equal(t, 6, possibleOrders(objects, nil))
+
+	weighings := []types.WeightWeighing{
+		{Left: "A", Right: "B", Heavier: "A"},
+		{Left: "B", Right: "C", Heavier: "B"},
+	}
+	assert.Equal(t, 1, possibleOrders(objects, weighings), "A > B > C is fixed")
+	assert.True(t, consistent([]string{"A", "B", "C"}, weighings))
+	assert.False(t, consistent([]string{"B", "A", "C"}, weighings))
+}
+
+func TestGenerateProblems(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	problems, err := generateProblems(withDefaults(types.WeightSettings{NumProblems: 3, NumObjects: 5}), rng)
+	assert.NoError(t, err)
+	assert.Len(t, problems, 3)
+	for _, p := range problems {
+		assert.Equal(t, []string{"A", "B", "C", "D", "E"}, p.Objects)
+		assert.True(t, samePermutation(p.Order, p.Objects))
+		assert.Equal(t, 7, p.OptimalWeighings)
+	}
+
+	_, err = generateProblems(withDefaults(types.WeightSettings{NumObjects: 7}), rng)
+	assert.Error(t, err)
+}
+
+func TestService_WeighAndSubmit(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	service := NewService(db)
+	_, err = service.Weigh(1, 1, "A", "B")
+	assert.Error(t, err, "Weighing before the game starts should fail")
+
+	state, err := service.StartGame(types.WeightSettings{NumProblems: 2, NumObjects: 3, MaxWeighings: 3})
+	assert.NoError(t, err)
+	order := state.Problems[0].Order
+
+	// Weigh neighbours in the hidden order, which pins the order down in two weighings.
+	w, err := service.Weigh(state.ID, 1, order[1], order[0])
+	assert.NoError(t, err)
+	assert.Equal(t, order[0], w.Heavier)
+	_, err = service.Weigh(state.ID, 1, order[1], order[2])
+	assert.NoError(t, err)
+
+	_, err = service.Weigh(state.ID, 1, "A", "A")
+	assert.Error(t, err)
+	_, err = service.Weigh(state.ID, 1, "A", "Z")
+	assert.Error(t, err)
+
+	_, err = service.SubmitRanking(state.ID, 1, []string{"A", "B"}, 1000)
+	assert.Error(t, err, "Every object must be ranked")
+
+	result, err := service.SubmitRanking(state.ID, 1, order, 4000)
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.True(t, result.IsConsistent)
+	assert.True(t, result.IsDetermined)
+	assert.Equal(t, 2, result.WeighingsUsed)
+	assert.Equal(t, state.Problems[0].OptimalWeighings, result.OptimalWeighings)
+
+	_, err = service.Weigh(state.ID, 1, "A", "B")
+	assert.Error(t, err, "An answered problem cannot be weighed again")
+
+	// Guess problem 2 without weighing anything.
+	result, err = service.SubmitRanking(state.ID, 2, []string{"C", "B", "A"}, 500)
+	assert.NoError(t, err)
+	assert.True(t, result.IsConsistent, "No weighings contradict any ranking")
+	assert.False(t, result.IsDetermined)
+
+	// The weighing limit is enforced.
+	limited, err := service.StartGame(types.WeightSettings{NumProblems: 1, NumObjects: 3, MaxWeighings: 1})
+	assert.NoError(t, err)
+	_, err = service.Weigh(limited.ID, 1, "A", "B")
+	assert.NoError(t, err)
+	_, err = service.Weigh(limited.ID, 1, "B", "C")
+	assert.Error(t, err)
+}
+
+func TestService_ResumeSession(t *testing.T) {
+	db, err := database.NewDatabase(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	state, err := NewService(db).StartGame(types.WeightSettings{NumProblems: 1, NumObjects: 3, MaxWeighings: 2})
+	assert.NoError(t, err)
+	order := state.Problems[0].Order
+
+	service := NewService(db)
+	_, err = service.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	_, err = service.Weigh(state.ID, 1, order[1], order[0])
+	assert.NoError(t, err)
+
+	// A fresh service, as after a restart, knows the hidden order and the weighing made.
+	restarted := NewService(db)
+	resumed, err := restarted.ResumeSession(state.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, order, resumed.Problems[0].Order)
+	w, err := restarted.Weigh(state.ID, 1, order[1], order[2])
+	assert.NoError(t, err)
+	assert.Equal(t, order[1], w.Heavier)
+	_, err = restarted.Weigh(state.ID, 1, order[0], order[2])
+	assert.Error(t, err, "The weighing made before the restart counts towards the limit")
+
+	result, err := restarted.SubmitRanking(state.ID, 1, order, 3000)
+	assert.NoError(t, err)
+	assert.True(t, result.IsCorrect)
+	assert.Equal(t, 2, result.WeighingsUsed)
+	assert.True(t, result.IsDetermined)
+}
@@ -0,0 +1,71 @@
+package types
+
+import "encoding/json"
+
+// Battery statuses.
+const (
+	BatteryStatusInProgress = "in_progress"
+	BatteryStatusCompleted  = "completed"
+)
+
+// Battery step statuses.
+const (
+	BatteryStepPending   = "pending"
+	BatteryStepStarted   = "started" // The game has a session but has not been finished
+	BatteryStepCompleted = "completed"
+)
+
+// BatteryStep is one game of a battery and the settings it is always played with. The
+// settings are the game's own settings type; real mode is turned on whatever they say.
+type BatteryStep struct {
+	GameCode string          `json:"gameCode"`
+	Settings json.RawMessage `json:"settings" ts_type:"any"`
+}
+
+// BatteryStepProgress is where one step of a battery stands.
+type BatteryStepProgress struct {
+	Step      int             `json:"step"` // From 0
+	GameCode  string          `json:"gameCode"`
+	Settings  json.RawMessage `json:"settings" ts_type:"any"`
+	SessionID int64           `json:"sessionId"` // The latest game session of the step, 0 if none
+	Status    string          `json:"status"`    // BatteryStep*
+}
+
+// BatterySession is a run through a sequence of games.
+type BatterySession struct {
+	ID          int64                 `json:"id"`
+	StartedAt   CustomTime            `json:"startedAt" ts_type:"string"`
+	CompletedAt CustomTime            `json:"completedAt" ts_type:"string"` // null until every step is done
+	Status      string                `json:"status"`
+	CurrentStep int                   `json:"currentStep"` // Equal to the number of steps once completed
+	Steps       []BatteryStepProgress `json:"steps"`
+}
+
+// BatteryStepStart is what starting a battery step returns: the battery and the state of
+// the game that was started, as the game's own Start*Game would have returned it.
+type BatteryStepStart struct {
+	Battery   *BatterySession `json:"battery"`
+	Step      int             `json:"step"`
+	GameCode  string          `json:"gameCode"`
+	SessionID int64           `json:"sessionId"`
+	State     interface{}     `json:"state" ts_type:"any"`
+}
+
+// BatteryStepReport holds the outcome of one finished step.
+type BatteryStepReport struct {
+	Step      int         `json:"step"`
+	GameCode  string      `json:"gameCode"`
+	SessionID int64       `json:"sessionId"`
+	Score     float64     `json:"score"`               // The game's accuracy or solve rate, in percent
+	Stats     interface{} `json:"stats" ts_type:"any"` // The game's own session stats
+}
+
+// BatteryReport sums up a battery.
+type BatteryReport struct {
+	BatteryID      int64               `json:"batteryId"`
+	Status         string              `json:"status"`
+	StartedAt      CustomTime          `json:"startedAt" ts_type:"string"`
+	CompletedAt    CustomTime          `json:"completedAt" ts_type:"string"`
+	CompositeScore float64             `json:"compositeScore"` // Mean score of the finished steps
+	Steps          []BatteryStepReport `json:"steps"`          // Finished steps only
+}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 type CaughtStatus string
 
 const (
@@ -7,24 +13,89 @@ const (
 	StatusMissed CaughtStatus = "MISSED"
 )
 
+const (
+	CatChaserDifficultyAuto  = "auto"
+	CatChaserDifficultyFixed = "fixed"
+)
+
+// CatChaserDifficulty controls how many mice (and cats) appear in each round.
+type CatChaserDifficulty struct {
+	Mode      string `json:"mode"`      // "auto" ramps up the count over the session, "fixed" uses MiceCount
+	MiceCount int    `json:"miceCount"` // Only used in "fixed" mode
+}
+
+// UnmarshalJSON accepts both the structured form and the legacy string form
+// ("auto", "4", "6", ...) stored with older sessions.
+func (d *CatChaserDifficulty) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		if legacy == CatChaserDifficultyAuto || legacy == "" {
+			*d = CatChaserDifficulty{Mode: CatChaserDifficultyAuto}
+			return nil
+		}
+		count, err := strconv.Atoi(legacy)
+		if err != nil {
+			return fmt.Errorf("invalid cat chaser difficulty %q", legacy)
+		}
+		*d = CatChaserDifficulty{Mode: CatChaserDifficultyFixed, MiceCount: count}
+		return nil
+	}
+
+	type plain CatChaserDifficulty
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*d = CatChaserDifficulty(p)
+	return nil
+}
+
 // CatChaserSettings defines the settings for the Cat Chaser game.
 type CatChaserSettings struct {
-	NumTrials         int     `json:"numTrials"`
-	Difficulty        string  `json:"difficulty"`        // "auto", "4", "6", "8", "10", "12", "14", "16"
-	ShowTime          float64 `json:"showTime"`          // Seconds (0.5 ~ 3.0)
-	ResponseTimeLimit float64 `json:"responseTimeLimit"` // Seconds (1.0 ~ 10.0)
-	IsRealMode        bool    `json:"isRealMode"`
+	NumTrials         int                 `json:"numTrials"`
+	Difficulty        CatChaserDifficulty `json:"difficulty"`
+	ShowTime          float64             `json:"showTime"`          // Seconds (0.5 ~ 3.0)
+	ResponseTimeLimit float64             `json:"responseTimeLimit"` // Seconds (1.0 ~ 10.0)
+	IsRealMode        bool                `json:"isRealMode"`
+	GridRows          int                 `json:"gridRows"`    // Defaults to 6
+	GridCols          int                 `json:"gridCols"`    // Defaults to 6
+	NumTargets        int                 `json:"numTargets"`  // Target cats per round, defaults to 2
+	CaughtRatio       *float64            `json:"caughtRatio"` // Share of target cats that are CAUGHT over the session, defaults to 0.5
+	MouseSteps        int                 `json:"mouseSteps"`      // Frames the mice are revealed over, defaults to 1
+	CatSteps          int                 `json:"catSteps"`        // Frames the cats are shown over, defaults to 1
+	DistractorMoves   int                 `json:"distractorMoves"` // Non-target cats that move between cat frames
+	FrameMs           int                 `json:"frameMs"`         // Duration of each frame, defaults to ShowTime
+}
+
+// CatChaserFrame is one timed step of a round. Mice frames have no cats and cat frames
+// have no mice. The answer is judged against the cats of the final frame and all mice.
+type CatChaserFrame struct {
+	StartMs       int   `json:"startMs"` // Offset from the start of the round
+	DurationMs    int   `json:"durationMs"`
+	MicePositions []int `json:"micePositions"`
+	CatPositions  []int `json:"catPositions"` // Indexed like CatChaserProblem.CatPositions
+}
+
+// CatTarget is one colored cat the player has to judge.
+type CatTarget struct {
+	Color    string       `json:"color"`    // "RED", "BLUE", "GREEN", ...
+	CatIndex int          `json:"catIndex"` // Index in CatPositions
+	Status   CaughtStatus `json:"-"`
 }
 
 // CatChaserProblem represents a single round's problem data.
 type CatChaserProblem struct {
 	Round         int          `json:"round"`
-	MicePositions []int        `json:"micePositions"` // Flat indices 0-35 (6x6)
-	CatPositions  []int        `json:"catPositions"`  // Flat indices 0-35
-	RedCatIndex   int          `json:"redCatIndex"`   // Index in CatPositions
-	BlueCatIndex  int          `json:"blueCatIndex"`  // Index in CatPositions
-	RedCat        CaughtStatus `json:"-"`             // Internal check
+	GridRows      int          `json:"gridRows"`
+	GridCols      int          `json:"gridCols"`
+	MicePositions []int        `json:"micePositions"` // Flat row-major indices into the grid
+	CatPositions  []int        `json:"catPositions"`  // Flat row-major indices into the grid
+	Targets       []CatTarget  `json:"targets"`
+	RedCatIndex   int          `json:"redCatIndex"`  // Index in CatPositions of the first target
+	BlueCatIndex  int          `json:"blueCatIndex"` // Index in CatPositions of the second target (-1 if there is none)
+	RedCat        CaughtStatus `json:"-"`            // Internal check
 	BlueCat       CaughtStatus `json:"-"`
+	Frames        []CatChaserFrame `json:"frames"` // Playback schedule; the last frame holds CatPositions
 }
 
 // CatChaserResult represents the result of a single user action/round.
@@ -38,6 +109,7 @@ type CatChaserResult struct {
 	IsCorrect      bool    `json:"isCorrect"`
 	Score          float64 `json:"score"`
 	ResponseTimeMs int     `json:"responseTimeMs"`
+	FrameSchedule  string  `json:"frameSchedule"` // JSON string of []CatChaserFrame
 }
 
 // CatChaserSessionWithResults holds a game session and all its results.
@@ -71,4 +143,37 @@ type CatChaserSessionStats struct {
 	OverallAccuracy       float64               `json:"overallAccuracy"`
 	AverageResponseTimeMs float64               `json:"averageResponseTimeMs"`
 	RoundStats            []CatChaserRoundStats `json:"roundStats"`
-}
\ No newline at end of file
+}
+
+// ConfidenceLevelStat holds the observed accuracy for one confidence rating.
+// It is one point of the calibration curve.
+type ConfidenceLevelStat struct {
+	Confidence        int     `json:"confidence"`        // 1 to 4
+	StatedProbability float64 `json:"statedProbability"` // Probability of being right the rating stands for (0.5 to 1.0)
+	TotalQuestions    int     `json:"totalQuestions"`
+	TotalCorrect      int     `json:"totalCorrect"`
+	Accuracy          float64 `json:"accuracy"` // Percent
+}
+
+// CatChaserCalibration holds confidence calibration statistics for a session.
+type CatChaserCalibration struct {
+	SessionID           int64                 `json:"sessionId"`
+	TotalAnswered       int                   `json:"totalAnswered"` // Timeouts are left out
+	Accuracy            float64               `json:"accuracy"`      // Percent
+	MeanConfidence      float64               `json:"meanConfidence"`
+	BrierScore          float64               `json:"brierScore"`          // 0 is perfect, 0.25 is always saying 50%
+	CalibrationError    float64               `json:"calibrationError"`    // Weighted mean squared gap between stated and observed accuracy
+	OverconfidenceIndex float64               `json:"overconfidenceIndex"` // Mean stated probability minus accuracy; positive means overconfident
+	Levels              []ConfidenceLevelStat `json:"levels"`
+}
+
+// CatChaserCalibrationTrendPoint holds the calibration of one session for trend display.
+type CatChaserCalibrationTrendPoint struct {
+	SessionID           int64      `json:"sessionId"`
+	PlayDatetime        CustomTime `json:"playDatetime" ts_type:"string"`
+	TotalAnswered       int        `json:"totalAnswered"`
+	Accuracy            float64    `json:"accuracy"`
+	BrierScore          float64    `json:"brierScore"`
+	CalibrationError    float64    `json:"calibrationError"`
+	OverconfidenceIndex float64    `json:"overconfidenceIndex"`
+}
@@ -1,9 +1,28 @@
 package types
 
+// Session statuses. A session is in progress from the moment it is created until its
+// game is ended, or abandoned when the player leaves it unfinished.
+const (
+	SessionStatusInProgress = "in_progress"
+	SessionStatusCompleted  = "completed"
+	SessionStatusAbandoned  = "abandoned"
+)
+
 // GameSession represents a single session of any game.
 type GameSession struct {
-	ID           int64      `json:"id"`
-	GameCode     string     `json:"gameCode"`
-	PlayDatetime CustomTime `json:"playDatetime" ts_type:"string"`
-	Settings     string     `json:"settings"` // Reverted to string
-}
\ No newline at end of file
+	ID             int64      `json:"id"`
+	GameCode       string     `json:"gameCode"`
+	PlayDatetime   CustomTime `json:"playDatetime" ts_type:"string"`
+	Settings       string     `json:"settings"` // Reverted to string
+	Status         string     `json:"status"`
+	EndedAt        CustomTime `json:"endedAt" ts_type:"string"` // null while in progress
+	ExpectedTrials int        `json:"expectedTrials"`           // 0 when the game decides as it goes
+	Notes          string     `json:"notes"`
+	Tags           []string   `json:"tags"`
+}
+
+// DeletedSession is a soft-deleted session that can still be restored or purged.
+type DeletedSession struct {
+	GameSession
+	DeletedAt CustomTime `json:"deletedAt" ts_type:"string"`
+}
@@ -2,10 +2,50 @@ package types
 
 // CountComparisonSettings holds the settings for a Count Comparison game.
 type CountComparisonSettings struct {
-	NumProblems      int  `json:"numProblems"`
-	PresentationTime int  `json:"presentationTime"` // in milliseconds
-	InputTime        int  `json:"inputTime"`        // in milliseconds
-	IsRealMode       bool `json:"isRealMode"`
+	NumProblems      int       `json:"numProblems"`
+	PresentationTime int       `json:"presentationTime"` // in milliseconds
+	InputTime        int       `json:"inputTime"`        // in milliseconds
+	IsRealMode       bool      `json:"isRealMode"`
+	GenerationMode   string    `json:"generationMode"` // "normal" (default) or "ratio"
+	RatioLevels      []float64 `json:"ratioLevels"`    // Target larger/smaller ratios, easiest first. Only used in "ratio" mode.
+	WordListID       int64     `json:"wordListId"`     // 0 uses the built-in Korean list
+	Categories       []string  `json:"categories"`     // Only use pairs from these categories (empty for all)
+}
+
+const (
+	CountGenerationNormal = "normal"
+	CountGenerationRatio  = "ratio"
+)
+
+const (
+	BuiltinWordListID   int64 = 0
+	BuiltinWordListName       = "기본"
+)
+
+// WordPair is a pair of words shown on the two sides of a problem.
+type WordPair struct {
+	Left     string `json:"left"`
+	Right    string `json:"right"`
+	Category string `json:"category"`
+}
+
+// WordList is a named set of word pairs in one language.
+type WordList struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Language  string     `json:"language"` // e.g., "ko", "en"
+	Pairs     []WordPair `json:"pairs"`
+	CreatedAt CustomTime `json:"createdAt" ts_type:"string"`
+}
+
+// WordListSummary describes a stored word list without its pairs.
+type WordListSummary struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Language   string     `json:"language"`
+	PairCount  int        `json:"pairCount"`
+	Categories []string   `json:"categories"`
+	CreatedAt  CustomTime `json:"createdAt" ts_type:"string"`
 }
 
 // WordDetail represents a single word instance or a gap in the word cloud for rendering.
@@ -15,6 +55,19 @@ type WordDetail struct {
 	Weight   int     `json:"weight"`   // Corresponds to font weight, e.g., 400, 700 (0 for gaps)
 	IsGap    bool    `json:"isGap"`    // True if this is a gap
 	GapWidth float64 `json:"gapWidth"` // Width of the gap in rem (only for gaps, 0 for words)
+	X        float64 `json:"x"`        // Left edge on the virtual canvas in rem (words only)
+	Y        float64 `json:"y"`        // Top edge on the virtual canvas in rem (words only)
+	Width    float64 `json:"width"`    // Estimated rendered width in rem (words only)
+	Height   float64 `json:"height"`   // Line box height in rem (words only)
+}
+
+// CountComparisonLayout is the placed word cloud of both sides of a problem.
+// It is stored with each result so the problem can be redrawn exactly.
+type CountComparisonLayout struct {
+	CanvasWidth  float64      `json:"canvasWidth"`
+	CanvasHeight float64      `json:"canvasHeight"`
+	LeftWords    []WordDetail `json:"leftWords"`
+	RightWords   []WordDetail `json:"rightWords"`
 }
 
 // AppliedTrap represents a trap used in a problem.
@@ -48,6 +101,9 @@ type CountComparisonProblem struct {
 	InputTime        int    `json:"inputTime"`
 	CorrectSide      string `json:"correctSide"` // "left" or "right".
 	AppliedTraps     []AppliedTrap `json:"appliedTraps"` // Traps applied to this problem
+	Ratio            float64       `json:"ratio"`        // Larger count / smaller count
+	CanvasWidth      float64       `json:"canvasWidth"`  // Size of the virtual canvas each side is laid out on, in rem
+	CanvasHeight     float64       `json:"canvasHeight"`
 }
 
 // CountComparisonSubmission holds the player's submission for a single problem.
@@ -70,7 +126,11 @@ type CountComparisonResult struct {
 	RightWord      string `json:"rightWord"`
 	LeftWordCount  int    `json:"leftWordCount"`
 	RightWordCount int    `json:"rightWordCount"`
-	AppliedTraps   string `json:"appliedTraps"` // JSON string of []AppliedTrap
+	AppliedTraps   string  `json:"appliedTraps"` // JSON string of []AppliedTrap
+	Ratio          float64 `json:"ratio"`        // Larger count / smaller count
+	WordListID     int64   `json:"wordListId"`
+	WordListName   string  `json:"wordListName"`
+	Layout         string  `json:"layout"` // JSON string of CountComparisonLayout
 }
 
 // CountComparisonSessionWithResults holds a game session and all its results.
@@ -94,6 +154,17 @@ type TrapStat struct {
 	AverageResponseTime float64 `json:"averageResponseTime"`
 }
 
+// WordListStat holds statistics for all problems played with one word list.
+type WordListStat struct {
+	WordListID            int64   `json:"wordListId"`
+	WordListName          string  `json:"wordListName"`
+	TotalSessions         int     `json:"totalSessions"`
+	TotalQuestions        int     `json:"totalQuestions"`
+	TotalCorrect          int     `json:"totalCorrect"`
+	Accuracy              float64 `json:"accuracy"`
+	AverageResponseTimeMs float64 `json:"averageResponseTimeMs"`
+}
+
 // CountComparisonSessionStats holds aggregated statistics for a session.
 type CountComparisonSessionStats struct {
 	SessionID             int64      `json:"sessionId"`
@@ -102,4 +173,43 @@ type CountComparisonSessionStats struct {
 	OverallAccuracy       float64    `json:"overallAccuracy"`
 	AverageResponseTimeMs float64    `json:"averageResponseTimeMs"`
 	TrapStats             []TrapStat `json:"trapStats"`
-}
\ No newline at end of file
+}
+
+// PsychometricPoint holds the observed accuracy at a single ratio level.
+type PsychometricPoint struct {
+	Ratio          float64 `json:"ratio"`
+	TotalQuestions int     `json:"totalQuestions"`
+	TotalCorrect   int     `json:"totalCorrect"`
+	Accuracy       float64 `json:"accuracy"`
+}
+
+// PsychometricFit holds a fitted psychometric curve for one group of trials.
+// The threshold is the Weber fraction (ratio - 1) at which accuracy reaches 75%.
+type PsychometricFit struct {
+	Condition      string              `json:"condition"` // "all", "noTrap" or "trap"
+	TotalQuestions int                 `json:"totalQuestions"`
+	Points         []PsychometricPoint `json:"points"`
+	Fitted         bool                `json:"fitted"` // False when there is too little data to fit
+	Threshold      float64             `json:"threshold"`
+	ThresholdRatio float64             `json:"thresholdRatio"`
+	Slope          float64             `json:"slope"`
+}
+
+// CountComparisonPsychometrics holds the psychometric fits for a session.
+type CountComparisonPsychometrics struct {
+	SessionID int64           `json:"sessionId"`
+	All       PsychometricFit `json:"all"`
+	NoTrap    PsychometricFit `json:"noTrap"`
+	Trap      PsychometricFit `json:"trap"`
+}
+
+// CountComparisonThresholdPoint holds the discrimination thresholds of one session for trend display.
+type CountComparisonThresholdPoint struct {
+	SessionID         int64      `json:"sessionId"`
+	PlayDatetime      CustomTime `json:"playDatetime" ts_type:"string"`
+	TotalQuestions    int        `json:"totalQuestions"`
+	Threshold         float64    `json:"threshold"`
+	ThresholdNoTrap   float64    `json:"thresholdNoTrap"`
+	ThresholdWithTrap float64    `json:"thresholdWithTrap"`
+	Fitted            bool       `json:"fitted"`
+}
@@ -0,0 +1,16 @@
+package types
+
+// DatabaseRecovery reports a corrupt database file that was moved aside at startup and
+// replaced with a new, empty one.
+type DatabaseRecovery struct {
+	MovedTo     string     `json:"movedTo"` // Where the corrupt file now is
+	Problem     string     `json:"problem"` // What the integrity check found
+	RecoveredAt CustomTime `json:"recoveredAt" ts_type:"string"`
+}
+
+// BackupInfo describes one backup of the database.
+type BackupInfo struct {
+	Name      string     `json:"name"` // File name within the backup directory
+	SizeBytes int64      `json:"sizeBytes"`
+	CreatedAt CustomTime `json:"createdAt" ts_type:"string"`
+}
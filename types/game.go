@@ -7,4 +7,10 @@ const (
 	GameCodeNumberPressing    = "NUMBER_PRESSING"
 	GameCodeCountComparison   = "COUNT_COMPARISON"
 	GameCodeCatChaser         = "CAT_CHASER"
+	GameCodeTower             = "TOWER"
+	GameCodeWeight            = "WEIGHT"
+	GameCodeRoad              = "ROAD"
+	GameCodePositionMemory    = "POSITION_MEMORY"
+	GameCodeStroop            = "STROOP"
+	GameCodeScheduling        = "SCHEDULING"
 )
\ No newline at end of file
@@ -7,17 +7,44 @@ type NumberPressingSetup struct {
 	ProblemsPerRound int   `json:"problemsPerRound"`
 	TimeLimitR1      int   `json:"timeLimitR1"` // in seconds
 	TimeLimitR2      int   `json:"timeLimitR2"` // in seconds
+	// RuleTypes limits Round 2 to these rule families (NumberPressingRule*). Empty uses all of them.
+	RuleTypes []string `json:"ruleTypes"`
+	// LayoutMode decides where the keys sit on the 3x3 keypad (NumberPressingLayout*).
+	LayoutMode string `json:"layoutMode"`
 }
 
+// Keypad layout modes.
+const (
+	NumberPressingLayoutDefault    = ""           // Round 1 in order, Round 2 shuffled for every problem
+	NumberPressingLayoutFixed      = "fixed"      // Always 1-9 in order
+	NumberPressingLayoutPerProblem = "perProblem" // A new scramble for every problem
+	NumberPressingLayoutPerSession = "perSession" // One scramble kept for the whole session
+)
+
+// Round 2 rule families.
+const (
+	NumberPressingRuleDoubleSkip = "doubleSkip" // Some numbers pressed twice, some skipped
+	NumberPressingRuleReverse    = "reverse"    // Press from 9 down to 1
+	NumberPressingRuleSkipEven   = "skipEven"   // Leave out every even number
+	NumberPressingRuleSkipOdd    = "skipOdd"    // Leave out every odd number
+	NumberPressingRulePressN     = "pressN"     // Press one number RepeatCount times
+)
+
 // NumberPressingProblemR1 defines a single problem for Round 1.
 type NumberPressingProblemR1 struct {
-	TargetNumber int `json:"targetNumber"`
+	TargetNumber int   `json:"targetNumber"`
+	Layout       []int `json:"layout"` // The number shown at each keypad position, row by row
 }
 
 // NumberPressingProblemR2 defines a single problem for Round 2.
 type NumberPressingProblemR2 struct {
-	DoubleClick []int `json:"doubleClick"`
-	Skip        []int `json:"skip"`
+	Rule        string `json:"rule"` // One of NumberPressingRule*
+	DoubleClick []int  `json:"doubleClick"`
+	Skip        []int  `json:"skip"`
+	Reverse     bool   `json:"reverse"`     // Numbers are pressed from 9 down to 1
+	Repeat      []int  `json:"repeat"`      // Numbers to press RepeatCount times
+	RepeatCount int    `json:"repeatCount"` // How often each Repeat number is pressed
+	Layout      []int  `json:"layout"`      // The number shown at each keypad position, row by row
 }
 
 // NumberPressingGameState holds the entire state for a game session.
@@ -25,27 +52,51 @@ type NumberPressingGameState struct {
 	Setup      NumberPressingSetup       `json:"setup"`
 	ProblemsR1 []NumberPressingProblemR1 `json:"problemsR1"`
 	ProblemsR2 []NumberPressingProblemR2 `json:"problemsR2"`
+	Layout     []int                     `json:"layout,omitempty"` // The session-wide scramble in perSession mode
 	ID         int64                     `json:"id"`
+	// NextProblem is the position of the next unanswered problem, counting the Round 1
+	// problems before the Round 2 ones, from 1.
+	NextProblem int `json:"nextProblem"`
 }
 
 // NumberPressingResultR1 holds the result for a single Round 1 problem.
 type NumberPressingResultR1 struct {
-	ID        int64                   `json:"id"`
-	SessionID int64                   `json:"sessionID"`
-	Problem   NumberPressingProblemR1 `json:"problem"`
-	TimeTaken float64                 `json:"timeTaken"` // in seconds
-	IsCorrect bool                    `json:"isCorrect"`
+	ID            int64                   `json:"id"`
+	SessionID     int64                   `json:"sessionID"`
+	ProblemNumber int                     `json:"problemNumber"` // The problem's position in Round 1, from 1
+	Problem       NumberPressingProblemR1 `json:"problem"`
+	PlayerNumber  int                     `json:"playerNumber"` // The number the player pressed
+	TimeTaken     float64                 `json:"timeTaken"`    // in seconds
+	IsCorrect     bool                    `json:"isCorrect"`
 }
 
 // NumberPressingResultR2 holds the result for a single Round 2 problem.
 type NumberPressingResultR2 struct {
-	ID            int64                   `json:"id"`
-	SessionID     int64                   `json:"sessionID"`
-	Problem       NumberPressingProblemR2 `json:"problem"`
-	PlayerClicks  []int                   `json:"playerClicks"`
-	CorrectClicks []int                   `json:"correctClicks"`
-	TimeTaken     float64                 `json:"timeTaken"` // in seconds
-	IsCorrect     bool                    `json:"isCorrect"`
+	ID            int64                     `json:"id"`
+	SessionID     int64                     `json:"sessionID"`
+	ProblemNumber int                       `json:"problemNumber"` // The problem's position in Round 2, from 1
+	Problem       NumberPressingProblemR2   `json:"problem"`
+	PlayerClicks  []int                     `json:"playerClicks"`
+	CorrectClicks []int                     `json:"correctClicks"`
+	TimeTaken     float64                   `json:"timeTaken"` // in seconds
+	IsCorrect     bool                      `json:"isCorrect"`
+	Errors        NumberPressingErrorCounts `json:"errors"`
+}
+
+// NumberPressingErrorCounts counts the click errors of a Round 2 attempt by type.
+type NumberPressingErrorCounts struct {
+	MissingDoubleClick int `json:"missingDoubleClick"` // Pressed a double-click or repeat number too few times
+	PressedSkipped     int `json:"pressedSkipped"`     // Pressed a number that should be skipped
+	OrderSlip          int `json:"orderSlip"`          // Pressed numbers out of order
+	ExtraPress         int `json:"extraPress"`         // Pressed a number that was not due, e.g. one time too many
+}
+
+// Add sums the counts of another attempt into c.
+func (c *NumberPressingErrorCounts) Add(other NumberPressingErrorCounts) {
+	c.MissingDoubleClick += other.MissingDoubleClick
+	c.PressedSkipped += other.PressedSkipped
+	c.OrderSlip += other.OrderSlip
+	c.ExtraPress += other.ExtraPress
 }
 
 // NumberPressingResultsBundle holds slices of results for both rounds.
@@ -61,143 +112,49 @@ type NumberPressingSessionWithResults struct {
 }
 
 // PaginatedNumberPressingSessions holds a page of sessions and the total count.
-
-
-
 type PaginatedNumberPressingSessions struct {
-
-
-
 	Sessions   []NumberPressingSessionWithResults `json:"sessions"`
-
-
-
 	TotalCount int                                `json:"totalCount"`
-
-
-
 }
 
-
-
-
-
-
-
 // NumberPressingConditionStat holds statistics for a specific condition type in Round 2.
-
-
-
 type NumberPressingConditionStat struct {
-
-
-
-	ConditionType     string  `json:"conditionType"` // e.g., "doubleClick: [1,2], skip: [3,4]"
-
-
-
-	TotalQuestions    int     `json:"totalQuestions"`
-
-
-
-	TotalCorrect      int     `json:"totalCorrect"`
-
-
-
-	Accuracy          float64 `json:"accuracy"`
-
-
-
-	AverageTimeTakenSec float64 `json:"averageTimeTakenSec"`
-
-
-
+	ConditionType       string                    `json:"conditionType"` // The rule family, e.g. "reverse"
+	TotalQuestions      int                       `json:"totalQuestions"`
+	TotalCorrect        int                       `json:"totalCorrect"`
+	Accuracy            float64                   `json:"accuracy"`
+	AverageTimeTakenSec float64                   `json:"averageTimeTakenSec"`
+	ErrorCounts         NumberPressingErrorCounts `json:"errorCounts"`
 }
 
-
-
-
-
-
+// NumberPressingSearchStat holds Round 1 search times for one target number or one keypad position.
+type NumberPressingSearchStat struct {
+	Key                  int     `json:"key"` // The target number (1-9) or the keypad position (0-8, row by row)
+	TotalQuestions       int     `json:"totalQuestions"`
+	TotalCorrect         int     `json:"totalCorrect"`
+	Accuracy             float64 `json:"accuracy"`
+	AverageSearchTimeSec float64 `json:"averageSearchTimeSec"` // Over correct answers only
+}
 
 // NumberPressingRoundStats holds statistics for a single round (R1 or R2) of a Number Pressing game session.
-
-
-
 type NumberPressingRoundStats struct {
-
-
-
-	Round             int                           `json:"round"`
-
-
-
-	TotalQuestions    int                           `json:"totalQuestions"`
-
-
-
-	TotalCorrect      int                           `json:"totalCorrect"`
-
-
-
-	Accuracy          float64                       `json:"accuracy"`
-
-
-
+	Round               int                           `json:"round"`
+	TotalQuestions      int                           `json:"totalQuestions"`
+	TotalCorrect        int                           `json:"totalCorrect"`
+	Accuracy            float64                       `json:"accuracy"`
 	AverageTimeTakenSec float64                       `json:"averageTimeTakenSec"`
-
-
-
-	ConditionStats    []NumberPressingConditionStat `json:"conditionStats,omitempty"` // Only for Round 2
-
-
-
+	ConditionStats      []NumberPressingConditionStat `json:"conditionStats,omitempty"` // Only for Round 2
+	TargetStats         []NumberPressingSearchStat    `json:"targetStats,omitempty"`    // Only for Round 1
+	PositionStats       []NumberPressingSearchStat    `json:"positionStats,omitempty"`  // Only for Round 1
+	ErrorCounts         *NumberPressingErrorCounts    `json:"errorCounts,omitempty"`    // Only for Round 2
 }
 
-
-
-
-
-
-
 // NumberPressingSessionStats holds aggregated statistics for an entire Number Pressing game session.
-
-
-
 type NumberPressingSessionStats struct {
-
-
-
 	SessionID           int64                      `json:"sessionId"`
-
-
-
 	TotalQuestions      int                        `json:"totalQuestions"`
-
-
-
 	TotalCorrect        int                        `json:"totalCorrect"`
-
-
-
 	OverallAccuracy     float64                    `json:"overallAccuracy"`
-
-
-
 	AverageTimeTakenSec float64                    `json:"averageTimeTakenSec"`
-
-
-
 	RoundStats          []NumberPressingRoundStats `json:"roundStats"`
-
-
-
 }
-
-
-
-
-
-
-
-
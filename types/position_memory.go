@@ -0,0 +1,104 @@
+package types
+
+// Position Memory modes: repeat the blocks in the order shown, or in reverse.
+const (
+	PositionMemoryForward  = "forward"
+	PositionMemoryBackward = "backward"
+)
+
+// PositionMemorySettings defines the settings for the Position Memory game.
+type PositionMemorySettings struct {
+	NumBlocks   int    `json:"numBlocks"`   // 4 to 16, defaults to 9
+	StartLength int    `json:"startLength"` // Length of the first sequence, defaults to 2
+	MaxLength   int    `json:"maxLength"`   // The test ends after this length, defaults to NumBlocks
+	Mode        string `json:"mode"`        // PositionMemoryForward or PositionMemoryBackward, defaults to forward
+	FlashMs     int    `json:"flashMs"`     // How long each block stays lit, defaults to 1000
+	IsRealMode  bool   `json:"isRealMode"`
+}
+
+// PositionMemoryBlock is where a block sits on the board, a square grid of
+// PositionMemoryBoardSize cells a side. Blocks are numbered by their index in the layout.
+type PositionMemoryBlock struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// PositionMemoryBoardSize is the number of grid cells on each side of the board.
+const PositionMemoryBoardSize = 5
+
+// PositionMemorySequence is one sequence of blocks to light up.
+type PositionMemorySequence struct {
+	SequenceNum int   `json:"sequenceNum"`
+	Length      int   `json:"length"`
+	Attempt     int   `json:"attempt"` // 1 or 2; a second attempt follows a failure at the same length
+	Blocks      []int `json:"blocks"`  // Block indexes in the order they light up
+}
+
+// PositionMemoryResult holds the result of one sequence.
+type PositionMemoryResult struct {
+	SessionID        int64  `json:"sessionId"`
+	SequenceNum      int    `json:"sequenceNum"`
+	Mode             string `json:"mode"`
+	Length           int    `json:"length"`
+	Attempt          int    `json:"attempt"`
+	Sequence         []int  `json:"sequence"` // As shown
+	Response         []int  `json:"response"` // As the player tapped them
+	IsCorrect        bool   `json:"isCorrect"`
+	CorrectPositions int    `json:"correctPositions"` // Taps that match the expected block at the same position
+	ResponseTimeMs   int    `json:"responseTimeMs"`
+}
+
+// PositionMemorySubmitResult tells the player how a sequence went and what comes next.
+type PositionMemorySubmitResult struct {
+	Result   PositionMemoryResult    `json:"result"`
+	Span     int                     `json:"span"`     // Longest length reproduced so far
+	Finished bool                    `json:"finished"` // Two failures at one length, or the maximum length passed
+	Next     *PositionMemorySequence `json:"next,omitempty"`
+}
+
+// PositionMemorySessionWithResults holds a game session and all its results.
+type PositionMemorySessionWithResults struct {
+	GameSession
+	Results []PositionMemoryResult `json:"results"`
+}
+
+// PaginatedPositionMemorySessions holds a page of sessions and the total count.
+type PaginatedPositionMemorySessions struct {
+	Sessions   []PositionMemorySessionWithResults `json:"sessions"`
+	TotalCount int                                `json:"totalCount"`
+}
+
+// PositionMemoryLengthStats holds statistics for the sequences of one length.
+type PositionMemoryLengthStats struct {
+	Length                int     `json:"length"`
+	TotalSequences        int     `json:"totalSequences"`
+	TotalCorrect          int     `json:"totalCorrect"`
+	AverageResponseTimeMs float64 `json:"averageResponseTimeMs"`
+}
+
+// PositionMemorySessionStats holds aggregated statistics for an entire Position Memory session.
+type PositionMemorySessionStats struct {
+	SessionID             int64                       `json:"sessionId"`
+	Mode                  string                      `json:"mode"`
+	MaxSpan               int                         `json:"maxSpan"` // Longest length reproduced correctly, 0 if none
+	TotalSequences        int                         `json:"totalSequences"`
+	TotalCorrect          int                         `json:"totalCorrect"`
+	Accuracy              float64                     `json:"accuracy"`
+	AverageResponseTimeMs float64                     `json:"averageResponseTimeMs"`
+	LengthStats           []PositionMemoryLengthStats `json:"lengthStats"`
+}
+
+// PositionMemorySpanPoint holds the span of one session for trend display.
+type PositionMemorySpanPoint struct {
+	SessionID    int64      `json:"sessionId"`
+	PlayDatetime CustomTime `json:"playDatetime" ts_type:"string"`
+	Mode         string     `json:"mode"`
+	MaxSpan      int        `json:"maxSpan"`
+}
+
+// PositionMemorySpanHistory holds the span of every session and the best in each mode.
+type PositionMemorySpanHistory struct {
+	BestForwardSpan  int                       `json:"bestForwardSpan"`
+	BestBackwardSpan int                       `json:"bestBackwardSpan"`
+	Sessions         []PositionMemorySpanPoint `json:"sessions"` // Oldest first
+}
@@ -0,0 +1,54 @@
+package types
+
+// Goal kinds.
+const (
+	GoalAccuracy        = "accuracy"        // Mean accuracy at or above the target, in percent
+	GoalResponseTime    = "responseTime"    // Mean response time at or below the target, in ms
+	GoalSessionsPerWeek = "sessionsPerWeek" // At least the target number of sessions
+)
+
+// Goal is a target the player set for one game.
+type Goal struct {
+	ID        int64      `json:"id"`
+	GameCode  string     `json:"gameCode"`
+	Kind      string     `json:"kind"` // Goal*
+	Target    float64    `json:"target"`
+	CreatedAt CustomTime `json:"createdAt" ts_type:"string"`
+}
+
+// GoalProgress is how a goal stands over the last seven days.
+type GoalProgress struct {
+	Goal     Goal     `json:"goal"`
+	Sessions int      `json:"sessions"` // Sessions of the game in the window
+	Current  *float64 `json:"current"`  // The window's value in the goal's unit, null without sessions
+	Progress float64  `json:"progress"` // Percent of the way to the target, capped at 100
+	Met      bool     `json:"met"`
+}
+
+// PracticeStreak counts consecutive days with at least one finished session.
+type PracticeStreak struct {
+	Current        int    `json:"current"` // Alive until a whole day is missed
+	Longest        int    `json:"longest"`
+	PracticedToday bool   `json:"practicedToday"`
+	LastPracticed  string `json:"lastPracticed"` // YYYY-MM-DD, "" if never
+	TotalDays      int    `json:"totalDays"`
+}
+
+// Personal record modes.
+const (
+	RecordModeReal     = "real"
+	RecordModePractice = "practice"
+)
+
+// PersonalRecord is the best value of one metric for a game, round and mode.
+type PersonalRecord struct {
+	GameCode       string     `json:"gameCode"`
+	Round          int        `json:"round"` // 0 for the session as a whole
+	Mode           string     `json:"mode"`  // RecordMode*
+	Metric         string     `json:"metric"`
+	HigherIsBetter bool       `json:"higherIsBetter"`
+	Value          float64    `json:"value"`
+	SessionID      int64      `json:"sessionId"`
+	AchievedAt     CustomTime `json:"achievedAt" ts_type:"string"`
+	PreviousValue  *float64   `json:"previousValue"` // The record this one broke, null if it is the first
+}
@@ -0,0 +1,32 @@
+package types
+
+// WeakSpot is one game, round and condition, and how the player has been doing on it.
+type WeakSpot struct {
+	GameCode       string     `json:"gameCode"`
+	Round          int        `json:"round"`     // 0 when the game has no rounds
+	Condition      string     `json:"condition"` // e.g. a trap type or rule family, "" for the round as a whole
+	Level          int        `json:"level"`     // Disc or object count for games graded that way, else 0
+	Label          string     `json:"label"`
+	Sessions       int        `json:"sessions"`
+	TotalQuestions int        `json:"totalQuestions"`
+	LastPlayed     CustomTime `json:"lastPlayed" ts_type:"string"`
+	Accuracy       float64    `json:"accuracy"` // Percent, recent sessions weigh more
+	Trend          float64    `json:"trend"`    // Accuracy change per session over the recent sessions, in percentage points
+	Priority       float64    `json:"priority"` // Higher needs practice more
+}
+
+// PracticeItem is one game of a daily practice plan, with the settings to start it with.
+type PracticeItem struct {
+	Rank     int         `json:"rank"` // From 1
+	GameCode string      `json:"gameCode"`
+	Spot     *WeakSpot   `json:"spot"` // null for a game that has not been played yet
+	Reason   string      `json:"reason"`
+	Settings interface{} `json:"settings" ts_type:"any"` // The game's settings type, ready for its Start*Game
+}
+
+// DailyPlan is the ranked practice plan for one day.
+type DailyPlan struct {
+	Date      string         `json:"date"` // YYYY-MM-DD
+	Items     []PracticeItem `json:"items"`
+	WeakSpots []WeakSpot     `json:"weakSpots"` // Every spot that was found, weakest first
+}
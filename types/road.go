@@ -0,0 +1,92 @@
+package types
+
+// Road tile kinds, named by the two sides of the cell they connect.
+const (
+	RoadTileNS = "NS" // Straight, top to bottom
+	RoadTileEW = "EW" // Straight, left to right
+	RoadTileNE = "NE" // Curve, top to right
+	RoadTileNW = "NW" // Curve, top to left
+	RoadTileSE = "SE" // Curve, bottom to right
+	RoadTileSW = "SW" // Curve, bottom to left
+)
+
+// RoadSettings defines the settings for the Road game.
+type RoadSettings struct {
+	NumProblems int  `json:"numProblems"`
+	GridRows    int  `json:"gridRows"`   // 3 to 6, defaults to 5
+	GridCols    int  `json:"gridCols"`   // 3 to 6, defaults to 5
+	Obstacles   *int `json:"obstacles"`  // Blocked cells, defaults to 4
+	SpareTiles  *int `json:"spareTiles"` // Tiles of each kind handed out beyond the shortest road, defaults to 1
+	MinTiles    int  `json:"minTiles"`   // Fewest tiles the shortest road may need, defaults to 4
+	IsRealMode  bool `json:"isRealMode"`
+}
+
+// RoadCell is a cell of the grid. Row 0 is the top row.
+type RoadCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// RoadEndpoint is the start or the goal of a road. Side is the side of the cell the road
+// leaves from ("N", "E", "S" or "W") at the start, or arrives through at the goal.
+type RoadEndpoint struct {
+	Row  int    `json:"row"`
+	Col  int    `json:"col"`
+	Side string `json:"side"`
+}
+
+// RoadTile is a tile placed on the grid.
+type RoadTile struct {
+	Row  int    `json:"row"`
+	Col  int    `json:"col"`
+	Kind string `json:"kind"` // One of RoadTile*
+}
+
+// RoadProblem is one puzzle: connect the start to the goal with the tiles on hand.
+type RoadProblem struct {
+	ProblemNum int          `json:"problemNum"`
+	GridRows   int          `json:"gridRows"`
+	GridCols   int          `json:"gridCols"`
+	Obstacles  []RoadCell   `json:"obstacles"`
+	Start      RoadEndpoint `json:"start"`
+	Goal       RoadEndpoint `json:"goal"`
+	Straights  int          `json:"straights"` // Straight tiles on hand
+	Curves     int          `json:"curves"`    // Curved tiles on hand
+	MinTiles   int          `json:"minTiles"`  // Tiles in the shortest road
+}
+
+// RoadResult holds the result of one Road problem.
+type RoadResult struct {
+	SessionID   int64       `json:"sessionId"`
+	ProblemNum  int         `json:"problemNum"`
+	Problem     RoadProblem `json:"problem"`
+	Layout      []RoadTile  `json:"layout"` // Every tile the player placed
+	TilesUsed   int         `json:"tilesUsed"`
+	MinTiles    int         `json:"minTiles"`
+	IsSolved    bool        `json:"isSolved"`
+	TimeTakenMs int         `json:"timeTakenMs"`
+}
+
+// RoadSessionWithResults holds a game session and all its results.
+type RoadSessionWithResults struct {
+	GameSession
+	Results []RoadResult `json:"results"`
+}
+
+// PaginatedRoadSessions holds a page of sessions and the total count.
+type PaginatedRoadSessions struct {
+	Sessions   []RoadSessionWithResults `json:"sessions"`
+	TotalCount int                      `json:"totalCount"`
+}
+
+// RoadSessionStats holds aggregated statistics for an entire Road session.
+type RoadSessionStats struct {
+	SessionID          int64   `json:"sessionId"`
+	TotalProblems      int     `json:"totalProblems"`
+	TotalSolved        int     `json:"totalSolved"`
+	TotalMinimal       int     `json:"totalMinimal"`      // Solved with the fewest possible tiles
+	SuccessRate        float64 `json:"successRate"`       // Percent
+	AverageExtraTiles  float64 `json:"averageExtraTiles"` // Tiles beyond the shortest road, over solved problems
+	AverageTimeMs      float64 `json:"averageTimeMs"`
+	AverageSolveTimeMs float64 `json:"averageSolveTimeMs"` // Over solved problems
+}
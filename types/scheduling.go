@@ -0,0 +1,94 @@
+package types
+
+// Scheduling constraint kinds. Person and Other index SchedulingProblem.People; Day and
+// Period are numbered from 0.
+const (
+	SchedulingNotDay       = "notDay"       // Person is not free on Day
+	SchedulingOnDay        = "onDay"        // Person comes on Day
+	SchedulingNotPeriod    = "notPeriod"    // Person is not free at Period on any day
+	SchedulingBefore       = "before"       // Person comes earlier than Other
+	SchedulingRightAfter   = "rightAfter"   // Other comes in the period right after Person, on the same day
+	SchedulingSameDay      = "sameDay"      // Person and Other come on the same day
+	SchedulingDifferentDay = "differentDay" // Person and Other come on different days
+)
+
+// SchedulingSettings defines the settings for the Scheduling game.
+type SchedulingSettings struct {
+	NumProblems int  `json:"numProblems"`
+	NumPeople   int  `json:"numPeople"`  // 3 to 6, defaults to 4
+	NumDays     int  `json:"numDays"`    // 1 to 5, defaults to 3
+	NumPeriods  int  `json:"numPeriods"` // Appointments per day, 1 to 4, defaults to 3
+	IsRealMode  bool `json:"isRealMode"`
+}
+
+// SchedulingSlot is one cell of the time grid.
+type SchedulingSlot struct {
+	Day    int `json:"day"`
+	Period int `json:"period"`
+}
+
+// SchedulingConstraint is one rule the appointments must follow.
+type SchedulingConstraint struct {
+	Kind        string `json:"kind"`
+	Person      int    `json:"person"`
+	Other       int    `json:"other"`  // Only for constraints between two people
+	Day         int    `json:"day"`    // Only for notDay and onDay
+	Period      int    `json:"period"` // Only for notPeriod
+	Description string `json:"description"`
+}
+
+// SchedulingProblem is one puzzle: give every person a slot of their own so that every
+// constraint holds. Exactly one placement does.
+type SchedulingProblem struct {
+	ProblemNum  int                    `json:"problemNum"`
+	People      []string               `json:"people"`
+	NumDays     int                    `json:"numDays"`
+	NumPeriods  int                    `json:"numPeriods"`
+	Constraints []SchedulingConstraint `json:"constraints"`
+	Solution    []SchedulingSlot       `json:"-"` // Slot of each person
+}
+
+// SchedulingResult holds the result of one Scheduling problem.
+type SchedulingResult struct {
+	SessionID      int64                  `json:"sessionId"`
+	ProblemNum     int                    `json:"problemNum"`
+	NumPeople      int                    `json:"numPeople"`
+	Constraints    []SchedulingConstraint `json:"constraints"`
+	Placement      []SchedulingSlot       `json:"placement"` // The player's slot for each person
+	Solution       []SchedulingSlot       `json:"solution"`
+	IsCorrect      bool                   `json:"isCorrect"`
+	Violated       []int                  `json:"violated"` // Indexes of the constraints the placement breaks
+	NumViolated    int                    `json:"numViolated"`
+	ResponseTimeMs int                    `json:"responseTimeMs"`
+}
+
+// SchedulingSessionWithResults holds a game session and all its results.
+type SchedulingSessionWithResults struct {
+	GameSession
+	Results []SchedulingResult `json:"results"`
+}
+
+// PaginatedSchedulingSessions holds a page of sessions and the total count.
+type PaginatedSchedulingSessions struct {
+	Sessions   []SchedulingSessionWithResults `json:"sessions"`
+	TotalCount int                            `json:"totalCount"`
+}
+
+// SchedulingKindStats holds how often one kind of constraint was shown and broken.
+type SchedulingKindStats struct {
+	Kind          string  `json:"kind"`
+	TotalShown    int     `json:"totalShown"`
+	TotalViolated int     `json:"totalViolated"`
+	ViolationRate float64 `json:"violationRate"` // Percent
+}
+
+// SchedulingSessionStats holds aggregated statistics for an entire Scheduling session.
+type SchedulingSessionStats struct {
+	SessionID             int64                 `json:"sessionId"`
+	TotalProblems         int                   `json:"totalProblems"`
+	TotalCorrect          int                   `json:"totalCorrect"`
+	Accuracy              float64               `json:"accuracy"`
+	AverageViolated       float64               `json:"averageViolated"` // Over wrong answers
+	AverageResponseTimeMs float64               `json:"averageResponseTimeMs"`
+	KindStats             []SchedulingKindStats `json:"kindStats"`
+}
@@ -0,0 +1,79 @@
+package types
+
+// Competency areas that games are grouped into.
+const (
+	CompetencyMemory    = "memory"
+	CompetencyAttention = "attention"
+	CompetencySpatial   = "spatial"
+	CompetencyReasoning = "reasoning"
+)
+
+// ScoreMetric is the single number a game's sessions are scored on.
+type ScoreMetric struct {
+	GameCode       string `json:"gameCode"`
+	Metric         string `json:"metric"`
+	Area           string `json:"area"` // Competency*
+	HigherIsBetter bool   `json:"higherIsBetter"`
+}
+
+// ScoreNorm is the distribution of one game's metric in a reference population.
+type ScoreNorm struct {
+	GameCode   string  `json:"gameCode"`
+	Metric     string  `json:"metric"` // Must be the metric the game is scored on
+	Mean       float64 `json:"mean"`
+	StdDev     float64 `json:"stdDev"`
+	SampleSize int     `json:"sampleSize,omitempty"`
+}
+
+// NormTable is a set of norms, as imported from a JSON file.
+type NormTable struct {
+	Name  string      `json:"name"`
+	Norms []ScoreNorm `json:"norms"`
+}
+
+// NormalizedScore places one session's metric against the player's earlier sessions of
+// the same game and against the norm table. Z-scores are signed so that positive is
+// always better, also for metrics where lower is better.
+type NormalizedScore struct {
+	SessionID         int64      `json:"sessionId"`
+	GameCode          string     `json:"gameCode"`
+	PlayDatetime      CustomTime `json:"playDatetime" ts_type:"string"`
+	Metric            string     `json:"metric"`
+	Value             float64    `json:"value"`
+	HistoryCount      int        `json:"historyCount"`      // Earlier sessions compared against
+	HistoryZ          *float64   `json:"historyZ"`          // null with fewer than two earlier sessions or no spread
+	HistoryPercentile *float64   `json:"historyPercentile"` // Share of earlier sessions beaten, null with none
+	NormZ             *float64   `json:"normZ"`             // null without a norm for the game
+	NormPercentile    *float64   `json:"normPercentile"`
+}
+
+// GameScore is where a player currently stands in one game: the mean of their most
+// recent sessions.
+type GameScore struct {
+	GameCode       string   `json:"gameCode"`
+	Metric         string   `json:"metric"`
+	Area           string   `json:"area"`
+	TotalSessions  int      `json:"totalSessions"`
+	RecentSessions int      `json:"recentSessions"` // Sessions averaged into RecentValue
+	RecentValue    float64  `json:"recentValue"`
+	HistoryZ       *float64 `json:"historyZ"` // Mean history z of the recent sessions
+	NormZ          *float64 `json:"normZ"`
+	NormPercentile *float64 `json:"normPercentile"`
+}
+
+// CompetencyScore averages the game scores of one competency area.
+type CompetencyScore struct {
+	Area           string      `json:"area"`
+	Games          []GameScore `json:"games"` // Only games that have been played
+	HistoryZ       *float64    `json:"historyZ"`
+	NormZ          *float64    `json:"normZ"`
+	NormPercentile *float64    `json:"normPercentile"`
+}
+
+// CompetencyProfile is a player's standing in every competency area.
+type CompetencyProfile struct {
+	Areas []CompetencyScore `json:"areas"` // Memory, attention, spatial, reasoning
+	// WeakestArea is the played area with the lowest norm z-score, or the lowest history
+	// z-score when no played game has a norm. Empty if nothing has been played.
+	WeakestArea string `json:"weakestArea"`
+}
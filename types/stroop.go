@@ -0,0 +1,94 @@
+package types
+
+// Stroop trial conditions.
+const (
+	StroopCongruent   = "congruent"   // The word names its own ink color
+	StroopIncongruent = "incongruent" // The word names a different color
+	StroopNeutral     = "neutral"     // The word is not a color
+)
+
+// StroopTrialMix sets the relative share of each condition. The trials are dealt in
+// these proportions, so 1:1:1 gives a third of each.
+type StroopTrialMix struct {
+	Congruent   int `json:"congruent"`
+	Incongruent int `json:"incongruent"`
+	Neutral     int `json:"neutral"`
+}
+
+// StroopSettings defines the settings for the Stroop game.
+type StroopSettings struct {
+	NumTrials           int               `json:"numTrials"`           // Defaults to 48
+	Mix                 StroopTrialMix    `json:"mix"`                 // Defaults to 1:1:1
+	Colors              []string          `json:"colors"`              // Ink colors in play, defaults to red, green, blue and yellow
+	ResponseKeys        map[string]string `json:"responseKeys"`        // Key for each color, defaults to the color's first letter
+	ResponseTimeLimitMs int               `json:"responseTimeLimitMs"` // Defaults to 2000
+	IsRealMode          bool              `json:"isRealMode"`
+}
+
+// StroopTrial is one word shown in one ink color.
+type StroopTrial struct {
+	TrialNum  int    `json:"trialNum"`
+	Condition string `json:"condition"`
+	Word      string `json:"word"`
+	InkColor  string `json:"inkColor"`
+}
+
+// StroopResult holds the result of one Stroop trial.
+type StroopResult struct {
+	SessionID      int64  `json:"sessionId"`
+	TrialNum       int    `json:"trialNum"`
+	Condition      string `json:"condition"`
+	Word           string `json:"word"`
+	WordColor      string `json:"wordColor"` // The color the word names, empty for neutral words
+	InkColor       string `json:"inkColor"`
+	ResponseKey    string `json:"responseKey"`   // Empty on a timeout
+	ResponseColor  string `json:"responseColor"` // The color the key stands for
+	IsCorrect      bool   `json:"isCorrect"`
+	IsWordError    bool   `json:"isWordError"` // Answered the word instead of the ink on an incongruent trial
+	TimedOut       bool   `json:"timedOut"`
+	ResponseTimeMs int    `json:"responseTimeMs"`
+}
+
+// StroopSessionWithResults holds a game session and all its results.
+type StroopSessionWithResults struct {
+	GameSession
+	Results []StroopResult `json:"results"`
+}
+
+// PaginatedStroopSessions holds a page of sessions and the total count.
+type PaginatedStroopSessions struct {
+	Sessions   []StroopSessionWithResults `json:"sessions"`
+	TotalCount int                        `json:"totalCount"`
+}
+
+// StroopConditionStats holds statistics for the trials of one condition.
+type StroopConditionStats struct {
+	Condition             string  `json:"condition"`
+	TotalTrials           int     `json:"totalTrials"`
+	TotalCorrect          int     `json:"totalCorrect"`
+	TotalTimedOut         int     `json:"totalTimedOut"`
+	Accuracy              float64 `json:"accuracy"`              // Percent
+	AverageResponseTimeMs float64 `json:"averageResponseTimeMs"` // Over correct trials
+}
+
+// StroopSessionStats holds aggregated statistics for an entire Stroop session.
+type StroopSessionStats struct {
+	SessionID                int64                  `json:"sessionId"`
+	TotalTrials              int                    `json:"totalTrials"`
+	TotalCorrect             int                    `json:"totalCorrect"`
+	TotalWordErrors          int                    `json:"totalWordErrors"`
+	OverallAccuracy          float64                `json:"overallAccuracy"`
+	AverageResponseTimeMs    float64                `json:"averageResponseTimeMs"`    // Over correct trials
+	InterferenceResponseTime float64                `json:"interferenceResponseTime"` // Incongruent minus congruent, in ms
+	InterferenceAccuracy     float64                `json:"interferenceAccuracy"`     // Incongruent minus congruent, in percentage points
+	ConditionStats           []StroopConditionStats `json:"conditionStats"`           // Congruent, incongruent, neutral
+}
+
+// StroopInterferencePoint holds the interference cost of one session for trend display.
+type StroopInterferencePoint struct {
+	SessionID                int64      `json:"sessionId"`
+	PlayDatetime             CustomTime `json:"playDatetime" ts_type:"string"`
+	TotalTrials              int        `json:"totalTrials"`
+	InterferenceResponseTime float64    `json:"interferenceResponseTime"`
+	InterferenceAccuracy     float64    `json:"interferenceAccuracy"`
+}
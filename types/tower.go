@@ -0,0 +1,87 @@
+package types
+
+// TowerSettings defines the settings for the Tower game.
+type TowerSettings struct {
+	NumProblems int  `json:"numProblems"`
+	MinDiscs    int  `json:"minDiscs"`   // 3 to 5, defaults to 3
+	MaxDiscs    int  `json:"maxDiscs"`   // 3 to 5, defaults to MinDiscs
+	MinMoves    int  `json:"minMoves"`   // Smallest optimal move count of a problem, defaults to 3
+	MaxMoves    int  `json:"maxMoves"`   // Largest optimal move count of a problem, defaults to 7 or MinMoves if higher
+	ExtraMoves  *int `json:"extraMoves"` // Moves allowed beyond the optimum, defaults to the optimum itself
+	IsRealMode  bool `json:"isRealMode"`
+}
+
+// TowerMove moves the top disc of one peg onto another. Pegs are numbered 0 to 2.
+type TowerMove struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// TowerProblem is one start and goal configuration. A configuration lists the discs on
+// each of the three pegs from bottom to top; disc 1 is the smallest.
+type TowerProblem struct {
+	ProblemNum   int     `json:"problemNum"`
+	Discs        int     `json:"discs"`
+	Start        [][]int `json:"start"`
+	Goal         [][]int `json:"goal"`
+	OptimalMoves int     `json:"optimalMoves"`
+	MoveLimit    int     `json:"moveLimit"`
+}
+
+// TowerMoveResult tells the player what a move did.
+type TowerMoveResult struct {
+	Pegs      [][]int      `json:"pegs"` // The configuration after the move
+	MoveCount int          `json:"moveCount"`
+	Solved    bool         `json:"solved"`
+	Finished  bool         `json:"finished"`         // Solved or out of moves; the result has been saved
+	Result    *TowerResult `json:"result,omitempty"` // Set once the problem is finished
+}
+
+// TowerResult holds the result of one Tower problem.
+type TowerResult struct {
+	SessionID    int64       `json:"sessionId"`
+	ProblemNum   int         `json:"problemNum"`
+	Discs        int         `json:"discs"`
+	Start        [][]int     `json:"start"`
+	Goal         [][]int     `json:"goal"`
+	Moves        []TowerMove `json:"moves"`
+	MoveCount    int         `json:"moveCount"`
+	OptimalMoves int         `json:"optimalMoves"`
+	MoveLimit    int         `json:"moveLimit"`
+	IsSolved     bool        `json:"isSolved"`
+	TimeTakenMs  int         `json:"timeTakenMs"`
+}
+
+// TowerSessionWithResults holds a game session and all its results.
+type TowerSessionWithResults struct {
+	GameSession
+	Results []TowerResult `json:"results"`
+}
+
+// PaginatedTowerSessions holds a page of sessions and the total count.
+type PaginatedTowerSessions struct {
+	Sessions   []TowerSessionWithResults `json:"sessions"`
+	TotalCount int                       `json:"totalCount"`
+}
+
+// TowerDiscStats holds statistics for the problems of one disc count.
+type TowerDiscStats struct {
+	Discs             int     `json:"discs"`
+	TotalProblems     int     `json:"totalProblems"`
+	TotalSolved       int     `json:"totalSolved"`
+	SolveRate         float64 `json:"solveRate"`         // Percent
+	AverageExtraMoves float64 `json:"averageExtraMoves"` // Moves beyond the optimum, over solved problems
+	AverageTimeMs     float64 `json:"averageTimeMs"`
+}
+
+// TowerSessionStats holds aggregated statistics for an entire Tower session.
+type TowerSessionStats struct {
+	SessionID         int64            `json:"sessionId"`
+	TotalProblems     int              `json:"totalProblems"`
+	TotalSolved       int              `json:"totalSolved"`
+	TotalOptimal      int              `json:"totalOptimal"` // Solved in the fewest possible moves
+	SolveRate         float64          `json:"solveRate"`    // Percent
+	AverageExtraMoves float64          `json:"averageExtraMoves"`
+	AverageTimeMs     float64          `json:"averageTimeMs"`
+	DiscStats         []TowerDiscStats `json:"discStats"`
+}
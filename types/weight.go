@@ -0,0 +1,76 @@
+package types
+
+// WeightSettings defines the settings for the Weight Comparison game.
+type WeightSettings struct {
+	NumProblems  int  `json:"numProblems"`
+	NumObjects   int  `json:"numObjects"`   // 3 to 6, defaults to 4
+	MaxWeighings int  `json:"maxWeighings"` // 0 means no limit
+	IsRealMode   bool `json:"isRealMode"`
+}
+
+// WeightProblem is one set of objects to rank from heaviest to lightest.
+type WeightProblem struct {
+	ProblemNum       int      `json:"problemNum"`
+	Objects          []string `json:"objects"`          // Labels shown to the player
+	OptimalWeighings int      `json:"optimalWeighings"` // Fewest weighings that always suffice
+	Order            []string `json:"-"`                // Hidden ranking, heaviest first
+}
+
+// WeightWeighing is one use of the scale and what it showed.
+type WeightWeighing struct {
+	Left    string `json:"left"`
+	Right   string `json:"right"`
+	Heavier string `json:"heavier"` // The label of the heavier object
+}
+
+// WeightResult holds the result of one Weight Comparison problem.
+type WeightResult struct {
+	SessionID        int64            `json:"sessionId"`
+	ProblemNum       int              `json:"problemNum"`
+	NumObjects       int              `json:"numObjects"`
+	HiddenOrder      []string         `json:"hiddenOrder"` // Heaviest first
+	Weighings        []WeightWeighing `json:"weighings"`
+	Ranking          []string         `json:"ranking"` // The player's answer, heaviest first
+	WeighingsUsed    int              `json:"weighingsUsed"`
+	OptimalWeighings int              `json:"optimalWeighings"`
+	IsCorrect        bool             `json:"isCorrect"`    // The ranking matches the hidden order
+	IsConsistent     bool             `json:"isConsistent"` // The ranking agrees with every weighing made
+	IsDetermined     bool             `json:"isDetermined"` // The weighings left only one possible order
+	ResponseTimeMs   int              `json:"responseTimeMs"`
+}
+
+// WeightSessionWithResults holds a game session and all its results.
+type WeightSessionWithResults struct {
+	GameSession
+	Results []WeightResult `json:"results"`
+}
+
+// PaginatedWeightSessions holds a page of sessions and the total count.
+type PaginatedWeightSessions struct {
+	Sessions   []WeightSessionWithResults `json:"sessions"`
+	TotalCount int                        `json:"totalCount"`
+}
+
+// WeightObjectStats holds statistics for the problems of one object count.
+type WeightObjectStats struct {
+	NumObjects            int     `json:"numObjects"`
+	TotalProblems         int     `json:"totalProblems"`
+	TotalCorrect          int     `json:"totalCorrect"`
+	Accuracy              float64 `json:"accuracy"`
+	AverageWeighings      float64 `json:"averageWeighings"`
+	OptimalWeighings      int     `json:"optimalWeighings"`
+	AverageResponseTimeMs float64 `json:"averageResponseTimeMs"`
+}
+
+// WeightSessionStats holds aggregated statistics for an entire game session.
+type WeightSessionStats struct {
+	SessionID             int64               `json:"sessionId"`
+	TotalProblems         int                 `json:"totalProblems"`
+	TotalCorrect          int                 `json:"totalCorrect"`
+	TotalGuessed          int                 `json:"totalGuessed"`   // Answered before the weighings pinned the order down
+	TotalEfficient        int                 `json:"totalEfficient"` // Correct within the optimal number of weighings
+	OverallAccuracy       float64             `json:"overallAccuracy"`
+	AverageExtraWeighings float64             `json:"averageExtraWeighings"` // Weighings beyond the optimum, may be negative
+	AverageResponseTimeMs float64             `json:"averageResponseTimeMs"`
+	ObjectStats           []WeightObjectStats `json:"objectStats"`
+}